@@ -0,0 +1,67 @@
+// Command reconcile runs one ledger reconciliation and prints the run as JSON.
+// It exits with status 2 when drift was found, so it can gate cron jobs.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"os"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/config"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	reconciliationRepository "github.com/aditwar-man/go-microservice-boilerplate/internal/reconciliation/repository"
+	reconciliationUseCase "github.com/aditwar-man/go-microservice-boilerplate/internal/reconciliation/usecase"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/db/postgres"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/logger"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/metric"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/utils"
+)
+
+const exitDrift = 2
+
+func main() {
+	configPath := utils.GetConfigPath(os.Getenv("config"))
+
+	cfgFile, err := config.LoadConfig(configPath)
+	if err != nil {
+		log.Fatalf("LoadConfig: %v", err)
+	}
+
+	cfg, err := config.ParseConfig(cfgFile)
+	if err != nil {
+		log.Fatalf("ParseConfig: %v", err)
+	}
+
+	appLogger := logger.NewApiLogger(cfg)
+	appLogger.InitLogger()
+
+	psqlDB, err := postgres.NewPsqlDB(cfg)
+	if err != nil {
+		appLogger.Fatalf("Postgresql init: %s", err)
+	}
+	defer psqlDB.Close()
+
+	driftGauge, err := metric.CreateDriftGauge(cfg.Metrics.ServiceName)
+	if err != nil {
+		appLogger.Fatalf("CreateDriftGauge: %s", err)
+	}
+
+	reconciliationUC := reconciliationUseCase.NewReconciliationUseCase(cfg, reconciliationRepository.NewReconciliationRepository(psqlDB), driftGauge, appLogger)
+
+	run, err := reconciliationUC.Run(context.Background(), models.ReconciliationTriggerCLI)
+	if err != nil {
+		appLogger.Fatalf("Reconciliation: %s", err)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(run); err != nil {
+		appLogger.Fatalf("Encode: %s", err)
+	}
+
+	if run.DriftCount > 0 {
+		psqlDB.Close()
+		os.Exit(exitDrift)
+	}
+}
@@ -0,0 +1,68 @@
+// Command verify-ledger walks the ledger hash chains and checks the latest
+// signed checkpoint, then prints the result as JSON. It exits with status 2
+// when a broken link was found. Pass -wallet to verify a single wallet.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/config"
+	ledgerRepository "github.com/aditwar-man/go-microservice-boilerplate/internal/ledger/repository"
+	ledgerUseCase "github.com/aditwar-man/go-microservice-boilerplate/internal/ledger/usecase"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/db/postgres"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/logger"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/utils"
+)
+
+const exitBroken = 2
+
+func main() {
+	walletID := flag.Int64("wallet", 0, "only verify this wallet")
+	flag.Parse()
+
+	configPath := utils.GetConfigPath(os.Getenv("config"))
+
+	cfgFile, err := config.LoadConfig(configPath)
+	if err != nil {
+		log.Fatalf("LoadConfig: %v", err)
+	}
+
+	cfg, err := config.ParseConfig(cfgFile)
+	if err != nil {
+		log.Fatalf("ParseConfig: %v", err)
+	}
+
+	appLogger := logger.NewApiLogger(cfg)
+	appLogger.InitLogger()
+
+	psqlDB, err := postgres.NewPsqlDB(cfg)
+	if err != nil {
+		appLogger.Fatalf("Postgresql init: %s", err)
+	}
+	defer psqlDB.Close()
+
+	ledgerUC, err := ledgerUseCase.NewLedgerUseCase(cfg, ledgerRepository.NewLedgerRepository(psqlDB), appLogger)
+	if err != nil {
+		appLogger.Fatalf("NewLedgerUseCase: %s", err)
+	}
+
+	verification, err := ledgerUC.Verify(context.Background(), *walletID)
+	if err != nil {
+		appLogger.Fatalf("Verify: %s", err)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(verification); err != nil {
+		appLogger.Fatalf("Encode: %s", err)
+	}
+
+	if !verification.Valid {
+		psqlDB.Close()
+		os.Exit(exitBroken)
+	}
+}
@@ -10,17 +10,29 @@ import (
 
 // App config struct
 type Config struct {
-	Server   ServerConfig
-	Postgres PostgresConfig
-	Redis    RedisConfig
-	MongoDB  MongoDB
-	Cookie   Cookie
-	Store    Store
-	Session  Session
-	Metrics  Metrics
-	Logger   Logger
-	AWS      AWS
-	Jaeger   Jaeger
+	Server          ServerConfig
+	Postgres        PostgresConfig
+	Redis           RedisConfig
+	MongoDB         MongoDB
+	Cookie          Cookie
+	Store           Store
+	Session         Session
+	Metrics         Metrics
+	Logger          Logger
+	AWS             AWS
+	Jaeger          Jaeger
+	FX              FX
+	Fees            Fees
+	Outbox          Outbox
+	Webhooks        Webhooks
+	Schedules       Schedules
+	Escrow          Escrow
+	Payouts         Payouts
+	PaymentRequests PaymentRequests
+	Statements      Statements
+	Reconciliation  Reconciliation
+	Ledger          Ledger
+	Snapshots       Snapshots
 }
 
 // Server config struct
@@ -120,6 +132,116 @@ type Jaeger struct {
 	LogSpans    bool
 }
 
+// FX rates config
+type FX struct {
+	Provider        string
+	RatesFile       string
+	URL             string
+	RequestTimeout  time.Duration
+	QuoteTTLSeconds int
+}
+
+// Fees config, collected fees are credited to HouseWalletID or the
+// system fee account when it is not set
+type Fees struct {
+	HouseWalletID int64
+}
+
+// Outbox relay config, Publisher is redis or memory
+type Outbox struct {
+	Publisher           string
+	Stream              string
+	StreamMaxLen        int64
+	BatchSize           int
+	MaxAttempts         int
+	PollIntervalSeconds int
+	RetryBackoffSeconds int
+	LeaseSeconds        int
+}
+
+// Webhook dispatcher config
+type Webhooks struct {
+	RequestTimeout      time.Duration
+	BatchSize           int
+	MaxAttempts         int
+	PollIntervalSeconds int
+	RetryBackoffSeconds int
+	LeaseSeconds        int
+}
+
+// Scheduled transfer runner config
+type Schedules struct {
+	BatchSize           int
+	MaxAttempts         int
+	PollIntervalSeconds int
+	RetryBackoffSeconds int
+	LeaseSeconds        int
+}
+
+// Escrow auto release worker config
+type Escrow struct {
+	BatchSize           int
+	PollIntervalSeconds int
+}
+
+// Batch payout upload limit and processor config
+type Payouts struct {
+	MaxRows             int
+	BatchSize           int
+	MaxAttempts         int
+	PollIntervalSeconds int
+	RetryBackoffSeconds int
+	LeaseSeconds        int
+}
+
+// Payment request expiry and sweep config
+type PaymentRequests struct {
+	DefaultExpiryHours  int
+	MaxExpiryHours      int
+	BatchSize           int
+	PollIntervalSeconds int
+}
+
+// Statement generation config, ranges up to SyncMaxDays are rendered in the
+// request and longer ones by the background generator
+type Statements struct {
+	Bucket              string
+	SyncMaxDays         int
+	MaxRangeDays        int
+	URLExpiryMinutes    int
+	BatchSize           int
+	MaxAttempts         int
+	PollIntervalSeconds int
+	RetryBackoffSeconds int
+	LeaseSeconds        int
+}
+
+// Ledger reconciliation config, repairs are only written when RepairEnabled
+type Reconciliation struct {
+	IntervalMinutes int
+	BatchSize       int
+	RepairEnabled   bool
+}
+
+// Environment variable the ledger checkpoint signing key is read from, set it
+// from a secret store rather than the config files
+const LedgerSigningKeyEnv = "LEDGER_CHECKPOINT_SIGNING_KEY"
+
+// Ledger hash chain config, CheckpointSigningKey is a base64 ed25519 seed and
+// is read from LedgerSigningKeyEnv, it may only be left empty in Development
+type Ledger struct {
+	CheckpointSigningKey      string
+	CheckpointIntervalMinutes int
+	VerifyBatchSize           int
+}
+
+// Daily balance snapshot config, a day is snapshotted SettleMinutes after it
+// ends so rows written in its last moments are committed
+type Snapshots struct {
+	PollIntervalMinutes int
+	SettleMinutes       int
+}
+
 // Load config file from given path
 func LoadConfig(filename string) (*viper.Viper, error) {
 	v := viper.New()
@@ -127,6 +249,9 @@ func LoadConfig(filename string) (*viper.Viper, error) {
 	v.SetConfigName(filename)
 	v.AddConfigPath(".")
 	v.AutomaticEnv()
+	if err := v.BindEnv("ledger.checkpointSigningKey", LedgerSigningKeyEnv); err != nil {
+		return nil, err
+	}
 	if err := v.ReadInConfig(); err != nil {
 		if _, ok := err.(viper.ConfigFileNotFoundError); ok {
 			return nil, errors.New("config file not found")
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"strconv"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/opentracing/opentracing-go"
@@ -12,6 +13,7 @@ import (
 
 	"github.com/aditwar-man/go-microservice-boilerplate/internal/auth"
 	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	outboxRepository "github.com/aditwar-man/go-microservice-boilerplate/internal/outbox/repository"
 	"github.com/aditwar-man/go-microservice-boilerplate/pkg/utils"
 )
 
@@ -33,23 +35,47 @@ func (r *authRepo) Register(ctx context.Context, user *models.User) (*models.Use
 	u := &models.User{}
 	sl, _ := json.Marshal(user)
 	fmt.Println(string(sl))
-	if err := r.db.QueryRowxContext(ctx, createUserQuery, &user.Username, &user.Email, &user.Password).StructScan(u); err != nil {
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "authRepo.Register.BeginTxx")
+	}
+	defer tx.Rollback()
+
+	if err := tx.QueryRowxContext(ctx, createUserQuery, &user.Username, &user.Email, &user.Password).StructScan(u); err != nil {
 		return nil, errors.Wrap(err, "authRepo.Register.StructScan")
 	}
 
 	role := &models.Role{}
-	if err := r.db.QueryRowxContext(ctx, `
+	if err := tx.QueryRowxContext(ctx, `
 		SELECT id, name, description, parent_role_id FROM roles WHERE name = $1 LIMIT 1
 	`, "employee").StructScan(role); err != nil {
 		return nil, errors.Wrap(err, "authRepo.Register.FetchRole.QueryRowContext")
 	}
 
-	if _, err := r.db.ExecContext(ctx, `
+	if _, err := tx.ExecContext(ctx, `
 		INSERT INTO user_roles (user_id, role_id) VALUES ($1, $2)
 	`, u.ID, role.ID); err != nil {
 		return nil, errors.Wrap(err, "authRepo.Register.SetUserRole.QueryRowContext")
 	}
 
+	event, err := models.NewOutboxEvent(models.AggregateUser, strconv.Itoa(u.ID), models.EventUserRegistered, map[string]interface{}{
+		"user_id":  u.ID,
+		"username": u.Username,
+		"email":    u.Email,
+		"role":     role.Name,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "authRepo.Register.NewOutboxEvent")
+	}
+	if err := outboxRepository.WriteEventsTx(ctx, tx, event); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "authRepo.Register.Commit")
+	}
+
 	userWithRole := models.UserWithRole{
 		User: *u,
 		Role: *role,
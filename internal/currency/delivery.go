@@ -0,0 +1,8 @@
+package currency
+
+import "github.com/labstack/echo/v4"
+
+// Currency HTTP Handlers interface
+type Handlers interface {
+	List() echo.HandlerFunc
+}
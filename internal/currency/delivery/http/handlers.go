@@ -0,0 +1,44 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/opentracing/opentracing-go"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/currency"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/httpErrors"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/logger"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/utils"
+)
+
+type currencyHandlers struct {
+	currencyUC currency.UseCase
+	logger     logger.Logger
+}
+
+func NewCurrencyHandlers(currencyUC currency.UseCase, log logger.Logger) currency.Handlers {
+	return &currencyHandlers{currencyUC: currencyUC, logger: log}
+}
+
+// List godoc
+// @Summary List currencies
+// @Description List registered currencies with their minor unit exponent and rounding mode
+// @Tags Currency
+// @Produce json
+// @Success 200 {array} models.Currency
+// @Router /currencies [get]
+func (h *currencyHandlers) List() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "currency.List")
+		defer span.Finish()
+
+		currencies, err := h.currencyUC.List(ctx)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, currencies)
+	}
+}
@@ -0,0 +1,12 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/currency"
+)
+
+// Map currency routes
+func MapCurrencyRoutes(currencyGroup *echo.Group, h currency.Handlers) {
+	currencyGroup.GET("", h.List())
+}
@@ -0,0 +1,14 @@
+package currency
+
+import (
+	"net/http"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/httpErrors"
+)
+
+// Currency domain errors
+var (
+	ErrUnknownCurrency  = httpErrors.NewRestError(http.StatusUnprocessableEntity, "Unknown currency", nil)
+	ErrCurrencyDisabled = httpErrors.NewRestError(http.StatusUnprocessableEntity, "Currency is disabled", nil)
+	ErrAmountPrecision  = httpErrors.NewRestError(http.StatusBadRequest, "Amount has more decimals than the currency allows", nil)
+)
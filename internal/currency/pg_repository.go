@@ -0,0 +1,14 @@
+//go:generate mockgen -source pg_repository.go -destination mock/pg_repository_mock.go -package mock
+package currency
+
+import (
+	"context"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+)
+
+// Currency repository interface
+type Repository interface {
+	GetByCode(ctx context.Context, code string) (*models.Currency, error)
+	List(ctx context.Context) ([]*models.Currency, error)
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/currency"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+)
+
+// Currency Repository
+type currencyRepo struct {
+	db *sqlx.DB
+}
+
+// Currency Repository constructor
+func NewCurrencyRepository(db *sqlx.DB) currency.Repository {
+	return &currencyRepo{db: db}
+}
+
+// Get currency by ISO code
+func (r *currencyRepo) GetByCode(ctx context.Context, code string) (*models.Currency, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "currencyRepo.GetByCode")
+	defer span.Finish()
+
+	c := &models.Currency{}
+	if err := r.db.GetContext(ctx, c, getCurrencyQuery, code); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, currency.ErrUnknownCurrency
+		}
+		return nil, errors.Wrap(err, "currencyRepo.GetByCode.GetContext")
+	}
+
+	return c, nil
+}
+
+// List all registered currencies
+func (r *currencyRepo) List(ctx context.Context) ([]*models.Currency, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "currencyRepo.List")
+	defer span.Finish()
+
+	currencies := make([]*models.Currency, 0)
+	if err := r.db.SelectContext(ctx, &currencies, listCurrenciesQuery); err != nil {
+		return nil, errors.Wrap(err, "currencyRepo.List.SelectContext")
+	}
+
+	return currencies, nil
+}
@@ -0,0 +1,9 @@
+package repository
+
+const (
+	getCurrencyQuery = `SELECT code, name, exponent, enabled, rounding_mode, created_at
+						FROM currencies WHERE code = $1`
+
+	listCurrenciesQuery = `SELECT code, name, exponent, enabled, rounding_mode, created_at
+						FROM currencies ORDER BY code`
+)
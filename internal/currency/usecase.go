@@ -0,0 +1,16 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase_mock.go -package mock
+package currency
+
+import (
+	"context"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+)
+
+// Currency UseCase interface
+type UseCase interface {
+	List(ctx context.Context) ([]*models.Currency, error)
+	Validate(ctx context.Context, code string, amount decimal.Decimal) (*models.Currency, error)
+}
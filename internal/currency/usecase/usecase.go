@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/shopspring/decimal"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/currency"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/logger"
+)
+
+// Currency UseCase
+type currencyUC struct {
+	currencyRepo currency.Repository
+	logger       logger.Logger
+}
+
+// Currency UseCase constructor
+func NewCurrencyUseCase(currencyRepo currency.Repository, log logger.Logger) currency.UseCase {
+	return &currencyUC{currencyRepo: currencyRepo, logger: log}
+}
+
+// List registered currencies
+func (u *currencyUC) List(ctx context.Context) ([]*models.Currency, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "currencyUC.List")
+	defer span.Finish()
+
+	return u.currencyRepo.List(ctx)
+}
+
+// Resolve an enabled currency and check amount fits its minor unit
+func (u *currencyUC) Validate(ctx context.Context, code string, amount decimal.Decimal) (*models.Currency, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "currencyUC.Validate")
+	defer span.Finish()
+
+	c, err := u.currencyRepo.GetByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	if !c.Enabled {
+		return nil, currency.ErrCurrencyDisabled
+	}
+
+	if !c.Fits(amount) {
+		return nil, currency.ErrAmountPrecision
+	}
+
+	return c, nil
+}
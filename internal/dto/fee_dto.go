@@ -0,0 +1,39 @@
+package dto
+
+import (
+	"github.com/shopspring/decimal"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+)
+
+type RequestFeeSchedule struct {
+	TxType       string           `json:"tx_type" validate:"required,oneof=transfer fx"`
+	FromCurrency string           `json:"from_currency" validate:"required"`
+	ToCurrency   *string          `json:"to_currency,omitempty"`
+	RoleID       *int64           `json:"role_id,omitempty"`
+	Flat         decimal.Decimal  `json:"flat"`
+	Percent      decimal.Decimal  `json:"percent"`
+	Tiers        models.FeeTiers  `json:"tiers,omitempty"`
+	MinFee       *decimal.Decimal `json:"min_fee,omitempty"`
+	MaxFee       *decimal.Decimal `json:"max_fee,omitempty"`
+}
+
+// Priced transfer before it is executed, TotalDebit is amount plus fee
+type TransferPreview struct {
+	FromCurrency    string          `json:"from_currency"`
+	ToCurrency      string          `json:"to_currency"`
+	Amount          decimal.Decimal `json:"amount"`
+	Fee             models.Fee      `json:"fee"`
+	TotalDebit      decimal.Decimal `json:"total_debit"`
+	Rate            decimal.Decimal `json:"rate"`
+	ConvertedAmount decimal.Decimal `json:"converted_amount"`
+	QuoteID         string          `json:"quote_id,omitempty"`
+}
+
+// Priced peer-to-peer transfer, the recipient name is masked so the sender
+// can confirm the person without learning their account details
+type P2PTransfer struct {
+	Recipient string `json:"recipient"`
+	Reference string `json:"reference,omitempty"`
+	TransferPreview
+}
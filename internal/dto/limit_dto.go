@@ -0,0 +1,13 @@
+package dto
+
+import "github.com/shopspring/decimal"
+
+type RequestLimitRule struct {
+	Currency  string          `json:"currency" validate:"required"`
+	Operation string          `json:"operation" validate:"required,oneof=deposit transfer withdrawal"`
+	Period    string          `json:"period" validate:"required,oneof=transaction day month"`
+	Aggregate string          `json:"aggregate" validate:"omitempty,oneof=wallet user"`
+	MaxAmount decimal.Decimal `json:"max_amount"`
+	UserID    *int64          `json:"user_id,omitempty"`
+	RoleID    *int64          `json:"role_id,omitempty"`
+}
@@ -0,0 +1,28 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Request money from a user found by username or email, paid into a wallet
+// of the requester. A zero expires_at uses the configured default.
+type RequestPaymentRequest struct {
+	RequesterWalletID int64           `json:"requester_wallet_id" validate:"required"`
+	Payer             string          `json:"payer" validate:"required"`
+	Currency          string          `json:"currency" validate:"required"`
+	Amount            decimal.Decimal `json:"amount"`
+	Memo              string          `json:"memo" validate:"max=280"`
+	ExpiresAt         *time.Time      `json:"expires_at"`
+}
+
+// Wallet of the payer the request is paid from
+type RequestPayPaymentRequest struct {
+	FromWalletID int64 `json:"from_wallet_id" validate:"required"`
+}
+
+// Optional reason given by the payer
+type RequestDeclinePaymentRequest struct {
+	Reason string `json:"reason" validate:"max=280"`
+}
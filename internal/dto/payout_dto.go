@@ -0,0 +1,28 @@
+package dto
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// Payout file formats
+const (
+	PayoutFormatCSV  = "csv"
+	PayoutFormatJSON = "json"
+)
+
+// Multipart payout upload, the rows come from the file part. Format is
+// taken from the file name.
+type RequestPayoutBatch struct {
+	BatchID      string `form:"batch_id" validate:"required,max=64"`
+	FromWalletID int64  `form:"from_wallet_id" validate:"required"`
+	Format       string `form:"-"`
+}
+
+// One row of a payout file, the target is a wallet or the primary wallet of a user
+type PayoutRow struct {
+	WalletID  int64           `json:"wallet_id"`
+	UserID    int64           `json:"user_id"`
+	Currency  string          `json:"currency"`
+	Amount    decimal.Decimal `json:"amount"`
+	Reference string          `json:"reference"`
+}
@@ -0,0 +1,6 @@
+package dto
+
+// Approval of a correcting entry for a reported balance drift
+type RequestDriftRepair struct {
+	Reason string `json:"reason" validate:"required"`
+}
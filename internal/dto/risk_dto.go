@@ -0,0 +1,18 @@
+package dto
+
+import "github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+
+// Declarative risk rule, params are checked against the kind
+type RequestRiskRule struct {
+	Name      string                `json:"name" validate:"required,lte=200"`
+	Kind      string                `json:"kind" validate:"required,oneof=amount_threshold velocity account_age structuring blocked_counterparty"`
+	Operation *string               `json:"operation,omitempty" validate:"omitempty,oneof=deposit transfer"`
+	Currency  *string               `json:"currency,omitempty"`
+	Action    string                `json:"action" validate:"required,oneof=review deny"`
+	Params    models.RiskRuleParams `json:"params"`
+}
+
+// Review of a held deposit or transfer, a rejection needs a note
+type RequestRiskReview struct {
+	Note string `json:"note" validate:"lte=1000"`
+}
@@ -0,0 +1,23 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Standing order, Rule is a cron expression or RRULE unless Recurrence is once
+type RequestScheduledTransfer struct {
+	FromWalletID   int64           `json:"from_wallet_id" validate:"required"`
+	ToWalletID     int64           `json:"to_wallet_id" validate:"required"`
+	FromCurrency   string          `json:"from_currency" validate:"required"`
+	ToCurrency     string          `json:"to_currency" validate:"required"`
+	Amount         decimal.Decimal `json:"amount"`
+	Description    string          `json:"description"`
+	Recurrence     string          `json:"recurrence" validate:"required,oneof=once cron rrule"`
+	Rule           string          `json:"rule"`
+	Timezone       string          `json:"timezone"`
+	StartAt        time.Time       `json:"start_at" validate:"required"`
+	EndAt          *time.Time      `json:"end_at"`
+	MaxOccurrences *int            `json:"max_occurrences" validate:"omitempty,min=1"`
+}
@@ -0,0 +1,12 @@
+package dto
+
+import "time"
+
+// Statement of a wallet in one currency over [from, to)
+type RequestStatement struct {
+	WalletID int64     `json:"wallet_id" validate:"required"`
+	Currency string    `json:"currency" validate:"required"`
+	From     time.Time `json:"from" validate:"required"`
+	To       time.Time `json:"to" validate:"required"`
+	Format   string    `json:"format" validate:"required,oneof=csv pdf"`
+}
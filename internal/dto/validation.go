@@ -0,0 +1,15 @@
+package dto
+
+import (
+	"github.com/go-playground/validator/v10"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/utils"
+)
+
+func init() {
+	// client references may not take one the service derives itself
+	utils.RegisterValidation("reference", func(fl validator.FieldLevel) bool {
+		return models.IsClientRef(fl.Field().String())
+	})
+}
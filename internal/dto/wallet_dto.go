@@ -1,22 +1,171 @@
 package dto
 
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+)
+
 type RequestCreateWallet struct {
 	Name   string `json:"name"`
 	UserId int    `json:"omitempty"`
 }
 
 type RequestDeposit struct {
-	WalletID  uint    `json:"wallet_id"`
-	Currency  string  `json:"currency"`
-	Amount    float64 `json:"amount"`
-	Reference string  `json:"reference"`
+	WalletID  uint            `json:"-"`
+	Currency  string          `json:"currency"`
+	Amount    decimal.Decimal `json:"amount"`
+	Reference string          `json:"reference" validate:"reference"`
 }
 
 type RequestTransfer struct {
-	FromWalletID uint    `json:"from_wallet_id"`
-	ToWalletID   uint    `json:"to_wallet_id"`
-	FromCurrency string  `json:"from_currency"`
-	ToCurrency   string  `json:"to_currency"`
-	Amount       float64 `json:"amount"`
-	Reference    string  `json:"reference"`
+	FromWalletID uint            `json:"from_wallet_id"`
+	ToWalletID   uint            `json:"to_wallet_id"`
+	FromCurrency string          `json:"from_currency"`
+	ToCurrency   string          `json:"to_currency"`
+	Amount       decimal.Decimal `json:"amount"`
+	Reference    string          `json:"reference" validate:"reference"`
+	QuoteID      string          `json:"quote_id,omitempty" validate:"omitempty,uuid"`
+}
+
+// Transfer to another user by username or email, the funds go to the
+// recipient's default wallet for the currency
+type RequestP2PTransfer struct {
+	FromWalletID uint            `json:"from_wallet_id" validate:"required"`
+	Recipient    string          `json:"recipient" validate:"required"`
+	Currency     string          `json:"currency" validate:"required"`
+	Amount       decimal.Decimal `json:"amount"`
+	Reference    string          `json:"reference" validate:"reference"`
+}
+
+type RequestWithdraw struct {
+	WalletID  uint            `json:"-"`
+	Currency  string          `json:"currency" validate:"required"`
+	Amount    decimal.Decimal `json:"amount"`
+	Reference string          `json:"reference" validate:"reference"`
+}
+
+// Reversal of a posted transfer, a zero amount reverses whatever is left
+type RequestReversal struct {
+	Amount    decimal.Decimal `json:"amount"`
+	RefundFee bool            `json:"refund_fee"`
+	Reason    string          `json:"reason" validate:"required"`
+	Reference string          `json:"reference" validate:"reference"`
+}
+
+// Escrow from a wallet of the caller to a seller wallet, a zero release_at
+// keeps the funds held until the buyer confirms
+type RequestEscrow struct {
+	BuyerWalletID  int64           `json:"buyer_wallet_id" validate:"required"`
+	SellerWalletID int64           `json:"seller_wallet_id" validate:"required"`
+	Currency       string          `json:"currency" validate:"required"`
+	Amount         decimal.Decimal `json:"amount"`
+	Description    string          `json:"description"`
+	ReleaseAt      *time.Time      `json:"release_at"`
+	Reference      string          `json:"reference" validate:"reference"`
+}
+
+// Reason given by a party cancelling or disputing an escrow
+type RequestEscrowAction struct {
+	Reason string `json:"reason" validate:"required"`
+}
+
+// Admin decision on a disputed escrow
+type RequestEscrowResolution struct {
+	Resolution string `json:"resolution" validate:"required,oneof=release refund"`
+	Reason     string `json:"reason" validate:"required"`
+}
+
+// Pocket under a wallet, the target date needs a target amount
+type RequestCreatePocket struct {
+	Name         string           `json:"name" validate:"required,lte=100"`
+	Currency     string           `json:"currency" validate:"required"`
+	TargetAmount *decimal.Decimal `json:"target_amount"`
+	TargetDate   *time.Time       `json:"target_date"`
+}
+
+// Savings goal of a pocket, omitted fields clear the goal
+type RequestPocketTarget struct {
+	TargetAmount *decimal.Decimal `json:"target_amount"`
+	TargetDate   *time.Time       `json:"target_date"`
+}
+
+// Move between a wallet and one of its pockets, or between two of its pockets
+type RequestPocketMove struct {
+	FromWalletID int64           `json:"from_wallet_id" validate:"required"`
+	ToWalletID   int64           `json:"to_wallet_id" validate:"required"`
+	Amount       decimal.Decimal `json:"amount"`
+	Reference    string          `json:"reference" validate:"reference"`
+}
+
+// Wallet balances at a point in time, replayed from SnapshotAt when a daily
+// snapshot precedes it
+type BalancesAt struct {
+	WalletID   int64                       `json:"wallet_id"`
+	At         time.Time                   `json:"at"`
+	SnapshotAt *time.Time                  `json:"snapshot_at,omitempty"`
+	Balances   []*models.HistoricalBalance `json:"balances"`
+}
+
+// Proposed manual balance correction, posted only once another user approves it
+type RequestAdjustment struct {
+	Currency    string                        `json:"currency" validate:"required"`
+	Direction   string                        `json:"direction" validate:"required,oneof=credit debit"`
+	Amount      decimal.Decimal               `json:"amount"`
+	Reason      string                        `json:"reason" validate:"required,lte=1000"`
+	Attachments []models.AdjustmentAttachment `json:"attachments" validate:"max=20,dive"`
+}
+
+// Review of a pending adjustment, a rejection needs a note
+type RequestAdjustmentReview struct {
+	Note string `json:"note" validate:"lte=1000"`
+}
+
+type RequestWalletStatus struct {
+	Reason string `json:"reason" validate:"required"`
+}
+
+type TransactionResponse struct {
+	ID        int64           `json:"id"`
+	WalletID  int64           `json:"wallet_id"`
+	Type      string          `json:"type"`
+	Currency  string          `json:"currency"`
+	Amount    decimal.Decimal `json:"amount"`
+	RefID     *string         `json:"ref_id,omitempty"`
+	Meta      json.RawMessage `json:"meta,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+type TransactionList struct {
+	Size         int                    `json:"size"`
+	HasMore      bool                   `json:"has_more"`
+	NextCursor   string                 `json:"next_cursor,omitempty"`
+	Transactions []*TransactionResponse `json:"transactions"`
+}
+
+// Map ledger row to response dto
+func NewTransactionResponse(t *models.Transaction) *TransactionResponse {
+	resp := &TransactionResponse{
+		ID:        t.ID,
+		WalletID:  t.WalletID,
+		Type:      t.Type,
+		Currency:  t.Currency,
+		Amount:    t.Amount,
+		RefID:     t.RefID,
+		CreatedAt: t.CreatedAt,
+	}
+	if len(t.Meta) > 0 {
+		resp.Meta = json.RawMessage(t.Meta)
+	}
+
+	return resp
+}
+
+type RequestFXQuote struct {
+	FromCurrency string          `json:"from_currency" validate:"required"`
+	ToCurrency   string          `json:"to_currency" validate:"required"`
+	Amount       decimal.Decimal `json:"amount"`
 }
@@ -0,0 +1,7 @@
+package dto
+
+type RequestWebhookEndpoint struct {
+	URL        string   `json:"url" validate:"required,url,startswith=https://"`
+	Secret     string   `json:"secret" validate:"omitempty,min=16"`
+	EventTypes []string `json:"event_types" validate:"required,min=1"`
+}
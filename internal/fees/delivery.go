@@ -0,0 +1,10 @@
+package fees
+
+import "github.com/labstack/echo/v4"
+
+// Fees HTTP Handlers interface
+type Handlers interface {
+	CreateSchedule() echo.HandlerFunc
+	ListSchedules() echo.HandlerFunc
+	DeleteSchedule() echo.HandlerFunc
+}
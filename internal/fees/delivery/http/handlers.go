@@ -0,0 +1,103 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/opentracing/opentracing-go"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/dto"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/fees"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/httpErrors"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/logger"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/utils"
+)
+
+type feesHandlers struct {
+	feesUC fees.UseCase
+	logger logger.Logger
+}
+
+func NewFeesHandlers(feesUC fees.UseCase, log logger.Logger) fees.Handlers {
+	return &feesHandlers{feesUC: feesUC, logger: log}
+}
+
+// CreateSchedule godoc
+// @Summary Create fee schedule
+// @Description Create a fee schedule for a transaction type and currency pair, optionally scoped to a role
+// @Tags Fees
+// @Accept json
+// @Produce json
+// @Param body body dto.RequestFeeSchedule true "schedule"
+// @Success 201 {object} models.FeeSchedule
+// @Router /fees [post]
+func (h *feesHandlers) CreateSchedule() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "fees.CreateSchedule")
+		defer span.Finish()
+
+		scheduleRequest := &dto.RequestFeeSchedule{}
+		if err := utils.ReadRequest(c, scheduleRequest); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		schedule, err := h.feesUC.CreateSchedule(ctx, scheduleRequest)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusCreated, schedule)
+	}
+}
+
+// ListSchedules godoc
+// @Summary List fee schedules
+// @Description List every fee schedule
+// @Tags Fees
+// @Produce json
+// @Success 200 {array} models.FeeSchedule
+// @Router /fees [get]
+func (h *feesHandlers) ListSchedules() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "fees.ListSchedules")
+		defer span.Finish()
+
+		schedules, err := h.feesUC.ListSchedules(ctx)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, schedules)
+	}
+}
+
+// DeleteSchedule godoc
+// @Summary Delete fee schedule
+// @Description Delete a fee schedule
+// @Tags Fees
+// @Param id path int true "schedule_id"
+// @Success 204
+// @Router /fees/{id} [delete]
+func (h *feesHandlers) DeleteSchedule() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "fees.DeleteSchedule")
+		defer span.Finish()
+
+		scheduleID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(httpErrors.NewBadRequestError(err.Error())))
+		}
+
+		if err = h.feesUC.DeleteSchedule(ctx, scheduleID); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,21 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/config"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/auth"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/fees"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/middleware"
+)
+
+// Map fees routes, schedules are managed by wallet administrators
+func MapFeesRoutes(feesGroup *echo.Group, h fees.Handlers, mw *middleware.MiddlewareManager, rbacMw *middleware.RBACMiddleware, authUc auth.UseCase, cfg *config.Config) {
+	feesGroup.Use(mw.AuthJWTMiddleware(authUc, cfg))
+	feesGroup.Use(mw.AuthSessionMiddleware)
+	feesGroup.Use(rbacMw.RequirePermission("manage", "wallets", nil))
+
+	feesGroup.GET("", h.ListSchedules())
+	feesGroup.POST("", h.CreateSchedule())
+	feesGroup.DELETE("/:id", h.DeleteSchedule())
+}
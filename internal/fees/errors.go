@@ -0,0 +1,13 @@
+package fees
+
+import (
+	"net/http"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/httpErrors"
+)
+
+// Fees domain errors
+var (
+	ErrScheduleNotFound = httpErrors.NewRestError(http.StatusNotFound, "Fee schedule not found", nil)
+	ErrInvalidSchedule  = httpErrors.NewRestError(http.StatusBadRequest, "Invalid fee schedule", nil)
+)
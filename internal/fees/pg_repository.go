@@ -0,0 +1,16 @@
+//go:generate mockgen -source pg_repository.go -destination mock/pg_repository_mock.go -package mock
+package fees
+
+import (
+	"context"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+)
+
+// Fees repository interface
+type Repository interface {
+	CreateSchedule(ctx context.Context, schedule *models.FeeSchedule) (*models.FeeSchedule, error)
+	ListSchedules(ctx context.Context) ([]*models.FeeSchedule, error)
+	DeleteSchedule(ctx context.Context, scheduleID int64) error
+	FindSchedules(ctx context.Context, txType, fromCurrency string) ([]models.FeeSchedule, error)
+}
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/fees"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+)
+
+// Fees Repository
+type feesRepo struct {
+	db *sqlx.DB
+}
+
+// Fees Repository constructor
+func NewFeesRepository(db *sqlx.DB) fees.Repository {
+	return &feesRepo{db: db}
+}
+
+// Create fee schedule
+func (r *feesRepo) CreateSchedule(ctx context.Context, schedule *models.FeeSchedule) (*models.FeeSchedule, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "feesRepo.CreateSchedule")
+	defer span.Finish()
+
+	created := &models.FeeSchedule{}
+	if err := r.db.QueryRowxContext(
+		ctx,
+		createScheduleQuery,
+		schedule.TxType,
+		schedule.FromCurrency,
+		schedule.ToCurrency,
+		schedule.RoleID,
+		schedule.Flat,
+		schedule.Percent,
+		schedule.Tiers,
+		schedule.MinFee,
+		schedule.MaxFee,
+	).StructScan(created); err != nil {
+		return nil, errors.Wrap(err, "feesRepo.CreateSchedule.StructScan")
+	}
+
+	return created, nil
+}
+
+// List all fee schedules
+func (r *feesRepo) ListSchedules(ctx context.Context) ([]*models.FeeSchedule, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "feesRepo.ListSchedules")
+	defer span.Finish()
+
+	schedules := make([]*models.FeeSchedule, 0)
+	if err := r.db.SelectContext(ctx, &schedules, listSchedulesQuery); err != nil {
+		return nil, errors.Wrap(err, "feesRepo.ListSchedules.SelectContext")
+	}
+
+	return schedules, nil
+}
+
+// Delete fee schedule
+func (r *feesRepo) DeleteSchedule(ctx context.Context, scheduleID int64) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "feesRepo.DeleteSchedule")
+	defer span.Finish()
+
+	result, err := r.db.ExecContext(ctx, deleteScheduleQuery, scheduleID)
+	if err != nil {
+		return errors.Wrap(err, "feesRepo.DeleteSchedule.ExecContext")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "feesRepo.DeleteSchedule.RowsAffected")
+	}
+	if rowsAffected == 0 {
+		return fees.ErrScheduleNotFound
+	}
+
+	return nil
+}
+
+// Find every schedule of a transaction type and source currency, across all scopes
+func (r *feesRepo) FindSchedules(ctx context.Context, txType, fromCurrency string) ([]models.FeeSchedule, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "feesRepo.FindSchedules")
+	defer span.Finish()
+
+	schedules := make([]models.FeeSchedule, 0)
+	if err := r.db.SelectContext(ctx, &schedules, findSchedulesQuery, txType, fromCurrency); err != nil {
+		return nil, errors.Wrap(err, "feesRepo.FindSchedules.SelectContext")
+	}
+
+	return schedules, nil
+}
@@ -0,0 +1,12 @@
+package repository
+
+const (
+	createScheduleQuery = `INSERT INTO fee_schedules (tx_type, from_currency, to_currency, role_id, flat, percent, tiers, min_fee, max_fee)
+						VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING *`
+
+	listSchedulesQuery = `SELECT * FROM fee_schedules ORDER BY tx_type, from_currency, id`
+
+	deleteScheduleQuery = `DELETE FROM fee_schedules WHERE id = $1`
+
+	findSchedulesQuery = `SELECT * FROM fee_schedules WHERE tx_type = $1 AND from_currency = $2 ORDER BY id`
+)
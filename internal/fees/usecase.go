@@ -0,0 +1,19 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase_mock.go -package mock
+package fees
+
+import (
+	"context"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/dto"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+)
+
+// Fees UseCase interface
+type UseCase interface {
+	CreateSchedule(ctx context.Context, dto *dto.RequestFeeSchedule) (*models.FeeSchedule, error)
+	ListSchedules(ctx context.Context) ([]*models.FeeSchedule, error)
+	DeleteSchedule(ctx context.Context, scheduleID int64) error
+	Calculate(ctx context.Context, userID int64, fromCurrency, toCurrency string, amount decimal.Decimal) (*models.Fee, error)
+}
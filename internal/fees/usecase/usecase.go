@@ -0,0 +1,141 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/dto"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/fees"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/rbac"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/logger"
+)
+
+// Fees UseCase
+type feesUC struct {
+	feesRepo fees.Repository
+	rbac     rbac.RBACServiceInterface
+	logger   logger.Logger
+}
+
+// Fees UseCase constructor
+func NewFeesUseCase(feesRepo fees.Repository, rbacService rbac.RBACServiceInterface, log logger.Logger) fees.UseCase {
+	return &feesUC{feesRepo: feesRepo, rbac: rbacService, logger: log}
+}
+
+// Create fee schedule
+func (u *feesUC) CreateSchedule(ctx context.Context, dto *dto.RequestFeeSchedule) (*models.FeeSchedule, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "feesUC.CreateSchedule")
+	defer span.Finish()
+
+	schedule := &models.FeeSchedule{
+		TxType:       dto.TxType,
+		FromCurrency: dto.FromCurrency,
+		ToCurrency:   dto.ToCurrency,
+		RoleID:       dto.RoleID,
+		Flat:         dto.Flat,
+		Percent:      dto.Percent,
+		Tiers:        dto.Tiers,
+		MinFee:       dto.MinFee,
+		MaxFee:       dto.MaxFee,
+	}
+
+	if err := validateSchedule(schedule); err != nil {
+		return nil, err
+	}
+
+	return u.feesRepo.CreateSchedule(ctx, schedule)
+}
+
+// List fee schedules
+func (u *feesUC) ListSchedules(ctx context.Context) ([]*models.FeeSchedule, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "feesUC.ListSchedules")
+	defer span.Finish()
+
+	return u.feesRepo.ListSchedules(ctx)
+}
+
+// Delete fee schedule
+func (u *feesUC) DeleteSchedule(ctx context.Context, scheduleID int64) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "feesUC.DeleteSchedule")
+	defer span.Finish()
+
+	return u.feesRepo.DeleteSchedule(ctx, scheduleID)
+}
+
+// Calculate the unrounded fee a user pays for moving amount of fromCurrency
+// into toCurrency, zero when no schedule applies
+func (u *feesUC) Calculate(ctx context.Context, userID int64, fromCurrency, toCurrency string, amount decimal.Decimal) (*models.Fee, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "feesUC.Calculate")
+	defer span.Finish()
+
+	txType := models.FeeTxTypeTransfer
+	if fromCurrency != toCurrency {
+		txType = models.FeeTxTypeFX
+	}
+
+	fee := &models.Fee{Currency: fromCurrency, Amount: decimal.Zero}
+
+	schedules, err := u.feesRepo.FindSchedules(ctx, txType, fromCurrency)
+	if err != nil {
+		return nil, err
+	}
+	if len(schedules) == 0 {
+		return fee, nil
+	}
+
+	roles, err := u.rbac.GetUserDirectRoles(int(userID))
+	if err != nil {
+		return nil, errors.Wrap(err, "feesUC.Calculate.GetUserDirectRoles")
+	}
+
+	roleIDs := make([]int64, 0, len(roles))
+	for _, role := range roles {
+		roleIDs = append(roleIDs, int64(role.ID))
+	}
+
+	schedule := models.SelectFeeSchedule(schedules, toCurrency, roleIDs)
+	if schedule == nil {
+		return fee, nil
+	}
+
+	id := schedule.ID
+	fee.ScheduleID = &id
+	fee.Amount = schedule.Compute(amount)
+	return fee, nil
+}
+
+// Amounts must be non negative, the bounds ordered and only the last tier open ended
+func validateSchedule(s *models.FeeSchedule) error {
+	if s.Flat.IsNegative() || s.Percent.IsNegative() {
+		return fees.ErrInvalidSchedule
+	}
+	if s.MinFee != nil && s.MinFee.IsNegative() || s.MaxFee != nil && s.MaxFee.IsNegative() {
+		return fees.ErrInvalidSchedule
+	}
+	if s.MinFee != nil && s.MaxFee != nil && s.MinFee.GreaterThan(*s.MaxFee) {
+		return fees.ErrInvalidSchedule
+	}
+
+	var prev *decimal.Decimal
+	for i, tier := range s.Tiers {
+		if tier.Flat.IsNegative() || tier.Percent.IsNegative() {
+			return fees.ErrInvalidSchedule
+		}
+		if tier.UpTo == nil {
+			if i != len(s.Tiers)-1 {
+				return fees.ErrInvalidSchedule
+			}
+			continue
+		}
+		if prev != nil && !tier.UpTo.GreaterThan(*prev) {
+			return fees.ErrInvalidSchedule
+		}
+		prev = tier.UpTo
+	}
+
+	return nil
+}
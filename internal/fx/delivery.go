@@ -0,0 +1,8 @@
+package fx
+
+import "github.com/labstack/echo/v4"
+
+// FX HTTP Handlers interface
+type Handlers interface {
+	CreateQuote() echo.HandlerFunc
+}
@@ -0,0 +1,55 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/opentracing/opentracing-go"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/config"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/dto"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/fx"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/httpErrors"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/logger"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/utils"
+)
+
+type fxHandlers struct {
+	cfg    *config.Config
+	fxUC   fx.UseCase
+	logger logger.Logger
+}
+
+func NewFXHandlers(cfg *config.Config, fxUC fx.UseCase, log logger.Logger) fx.Handlers {
+	return &fxHandlers{cfg: cfg, fxUC: fxUC, logger: log}
+}
+
+// CreateQuote godoc
+// @Summary Create FX quote
+// @Description Lock an exchange rate for a short time, returns quote a transfer can reference
+// @Tags FX
+// @Accept json
+// @Produce json
+// @Param body body dto.RequestFXQuote true "quote request"
+// @Success 201 {object} models.FXQuote
+// @Router /wallets/fx/quotes [post]
+func (h *fxHandlers) CreateQuote() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "fx.CreateQuote")
+		defer span.Finish()
+
+		quoteRequest := &dto.RequestFXQuote{}
+		if err := utils.ReadRequest(c, quoteRequest); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		quote, err := h.fxUC.CreateQuote(ctx, quoteRequest)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusCreated, quote)
+	}
+}
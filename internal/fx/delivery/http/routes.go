@@ -0,0 +1,12 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/fx"
+)
+
+// Map fx routes, mounted under the authenticated wallets group
+func MapFXRoutes(fxGroup *echo.Group, h fx.Handlers) {
+	fxGroup.POST("/quotes", h.CreateQuote())
+}
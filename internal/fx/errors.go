@@ -0,0 +1,16 @@
+package fx
+
+import (
+	"net/http"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/httpErrors"
+)
+
+// FX domain errors
+var (
+	ErrRateNotFound  = httpErrors.NewRestError(http.StatusUnprocessableEntity, "Exchange rate not available", nil)
+	ErrQuoteNotFound = httpErrors.NewRestError(http.StatusNotFound, "FX quote not found", nil)
+	ErrQuoteInvalid  = httpErrors.NewRestError(http.StatusBadRequest, "FX quote id must be a UUID", nil)
+	ErrQuoteExpired  = httpErrors.NewRestError(http.StatusConflict, "FX quote expired or already used", nil)
+	ErrQuoteMismatch = httpErrors.NewRestError(http.StatusBadRequest, "FX quote does not match transfer", nil)
+)
@@ -0,0 +1,14 @@
+//go:generate mockgen -source pg_repository.go -destination mock/pg_repository_mock.go -package mock
+package fx
+
+import (
+	"context"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+)
+
+// FX repository interface
+type Repository interface {
+	CreateQuote(ctx context.Context, quote *models.FXQuote) (*models.FXQuote, error)
+	GetQuote(ctx context.Context, quoteID string) (*models.FXQuote, error)
+}
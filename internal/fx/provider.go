@@ -0,0 +1,12 @@
+package fx
+
+import (
+	"context"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+)
+
+// Exchange rate source interface
+type RateProvider interface {
+	GetRate(ctx context.Context, base, quote string) (*models.FXRate, error)
+}
@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/fx"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+)
+
+// Rates fetched from a remote service answering GET <url>?base=USD&quote=IDR
+// with {"base":"USD","quote":"IDR","rate":16000,"effective_at":"..."}
+type httpProvider struct {
+	url    string
+	client *http.Client
+}
+
+// HTTP rate provider constructor
+func NewHTTPProvider(rawURL string, timeout time.Duration) fx.RateProvider {
+	return &httpProvider{url: rawURL, client: &http.Client{Timeout: timeout}}
+}
+
+// Get rate from remote service
+func (p *httpProvider) GetRate(ctx context.Context, base, quote string) (*models.FXRate, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "httpProvider.GetRate")
+	defer span.Finish()
+
+	if base == quote {
+		return &models.FXRate{Base: base, Quote: quote, Rate: decimal.NewFromInt(1)}, nil
+	}
+
+	u, err := url.Parse(p.url)
+	if err != nil {
+		return nil, errors.Wrap(err, "httpProvider.GetRate.url.Parse")
+	}
+	q := u.Query()
+	q.Set("base", base)
+	q.Set("quote", quote)
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "httpProvider.GetRate.NewRequest")
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "httpProvider.GetRate.client.Do")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fx.ErrRateNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("httpProvider.GetRate: unexpected status %d", resp.StatusCode)
+	}
+
+	rate := &models.FXRate{}
+	if err = json.NewDecoder(resp.Body).Decode(rate); err != nil {
+		return nil, errors.Wrap(err, "httpProvider.GetRate.json.Decode")
+	}
+	if !rate.Rate.IsPositive() {
+		return nil, fx.ErrRateNotFound
+	}
+	rate.Base, rate.Quote = base, quote
+
+	return rate, nil
+}
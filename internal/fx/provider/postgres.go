@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/fx"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+)
+
+const getEffectiveRateQuery = `SELECT base, quote, rate, effective_at
+						FROM fx_rates
+						WHERE base = $1 AND quote = $2 AND effective_at <= now()
+						ORDER BY effective_at DESC
+						LIMIT 1`
+
+// Rates stored in the fx_rates table
+type postgresProvider struct {
+	db *sqlx.DB
+}
+
+// Postgres rate provider constructor
+func NewPostgresProvider(db *sqlx.DB) fx.RateProvider {
+	return &postgresProvider{db: db}
+}
+
+// Get the latest effective rate, falling back to the inverse of the opposite pair
+func (p *postgresProvider) GetRate(ctx context.Context, base, quote string) (*models.FXRate, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "postgresProvider.GetRate")
+	defer span.Finish()
+
+	if base == quote {
+		return &models.FXRate{Base: base, Quote: quote, Rate: decimal.NewFromInt(1)}, nil
+	}
+
+	rate := &models.FXRate{}
+	err := p.db.GetContext(ctx, rate, getEffectiveRateQuery, base, quote)
+	if err == nil {
+		return rate, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.Wrap(err, "postgresProvider.GetRate.GetContext")
+	}
+
+	inverse := &models.FXRate{}
+	if err = p.db.GetContext(ctx, inverse, getEffectiveRateQuery, quote, base); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fx.ErrRateNotFound
+		}
+		return nil, errors.Wrap(err, "postgresProvider.GetRate.GetContext.inverse")
+	}
+
+	return &models.FXRate{Base: base, Quote: quote, Rate: decimal.NewFromInt(1).Div(inverse.Rate), EffectiveAt: inverse.EffectiveAt}, nil
+}
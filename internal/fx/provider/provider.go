@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/shopspring/decimal"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/config"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/fx"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/utils"
+)
+
+const defaultRequestTimeout = 3 * time.Second
+
+// Build the rate provider selected by cfg.FX.Provider: file, postgres or http
+func NewRateProvider(cfg *config.Config, db *sqlx.DB) (fx.RateProvider, error) {
+	switch cfg.FX.Provider {
+	case "file":
+		return NewFileProvider(cfg.FX.RatesFile)
+	case "postgres":
+		return NewPostgresProvider(db), nil
+	case "http":
+		timeout := time.Second * cfg.FX.RequestTimeout
+		if timeout <= 0 {
+			timeout = defaultRequestTimeout
+		}
+		return NewHTTPProvider(cfg.FX.URL, timeout), nil
+	default:
+		return nil, fmt.Errorf("unknown fx provider %q", cfg.FX.Provider)
+	}
+}
+
+// Currency converter backed by a rate provider
+type rateConverter struct {
+	provider fx.RateProvider
+}
+
+// Rate converter constructor
+func NewRateConverter(provider fx.RateProvider) utils.CurrencyConverter {
+	return &rateConverter{provider: provider}
+}
+
+func (c *rateConverter) Convert(ctx context.Context, from, to string, amount decimal.Decimal) (decimal.Decimal, error) {
+	if from == to {
+		return amount, nil
+	}
+
+	rate, err := c.provider.GetRate(ctx, from, to)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	return amount.Mul(rate.Rate), nil
+}
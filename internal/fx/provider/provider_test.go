@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/fx"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+)
+
+func TestHTTPProvider_GetRate(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("base") != "USD" || r.URL.Query().Get("quote") != "IDR" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(models.FXRate{Base: "USD", Quote: "IDR", Rate: decimal.NewFromInt(16000), EffectiveAt: time.Now().UTC()})
+	}))
+	defer srv.Close()
+
+	p := NewHTTPProvider(srv.URL, time.Second)
+
+	rate, err := p.GetRate(context.Background(), "USD", "IDR")
+	require.NoError(t, err)
+	require.True(t, rate.Rate.Equal(decimal.NewFromInt(16000)))
+
+	_, err = p.GetRate(context.Background(), "USD", "EUR")
+	require.ErrorIs(t, err, fx.ErrRateNotFound)
+}
+
+func TestStaticProvider_GetRate(t *testing.T) {
+	t.Parallel()
+
+	p := NewStaticProvider(map[string]decimal.Decimal{"USD:IDR": decimal.NewFromInt(16000)})
+
+	rate, err := p.GetRate(context.Background(), "USD", "IDR")
+	require.NoError(t, err)
+	require.True(t, rate.Rate.Equal(decimal.NewFromInt(16000)))
+
+	inverse, err := p.GetRate(context.Background(), "IDR", "USD")
+	require.NoError(t, err)
+	require.Equal(t, "0.0000625", inverse.Rate.String())
+
+	_, err = p.GetRate(context.Background(), "USD", "EUR")
+	require.ErrorIs(t, err, fx.ErrRateNotFound)
+}
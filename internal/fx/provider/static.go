@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/fx"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+)
+
+// Rates held in memory, keyed by "BASE:QUOTE"
+type staticProvider struct {
+	rates    map[string]decimal.Decimal
+	loadedAt time.Time
+}
+
+// Static rate provider constructor
+func NewStaticProvider(rates map[string]decimal.Decimal) fx.RateProvider {
+	normalized := make(map[string]decimal.Decimal, len(rates))
+	for pair, rate := range rates {
+		normalized[strings.ToUpper(pair)] = rate
+	}
+	return &staticProvider{rates: normalized, loadedAt: time.Now().UTC()}
+}
+
+// File rate provider constructor, reads a JSON object of "BASE:QUOTE": rate
+func NewFileProvider(path string) (fx.RateProvider, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "NewFileProvider.ReadFile")
+	}
+
+	rates := make(map[string]decimal.Decimal)
+	if err = json.Unmarshal(raw, &rates); err != nil {
+		return nil, errors.Wrap(err, "NewFileProvider.json.Unmarshal")
+	}
+
+	return NewStaticProvider(rates), nil
+}
+
+// Get rate, falling back to the inverse of the opposite pair
+func (p *staticProvider) GetRate(ctx context.Context, base, quote string) (*models.FXRate, error) {
+	if base == quote {
+		return &models.FXRate{Base: base, Quote: quote, Rate: decimal.NewFromInt(1), EffectiveAt: p.loadedAt}, nil
+	}
+
+	if rate, ok := p.rates[base+":"+quote]; ok && rate.IsPositive() {
+		return &models.FXRate{Base: base, Quote: quote, Rate: rate, EffectiveAt: p.loadedAt}, nil
+	}
+
+	if rate, ok := p.rates[quote+":"+base]; ok && rate.IsPositive() {
+		return &models.FXRate{Base: base, Quote: quote, Rate: decimal.NewFromInt(1).Div(rate), EffectiveAt: p.loadedAt}, nil
+	}
+
+	return nil, fx.ErrRateNotFound
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/fx"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+)
+
+// FX Repository
+type fxRepo struct {
+	db *sqlx.DB
+}
+
+// FX Repository constructor
+func NewFXRepository(db *sqlx.DB) fx.Repository {
+	return &fxRepo{db: db}
+}
+
+// Create locked quote
+func (r *fxRepo) CreateQuote(ctx context.Context, quote *models.FXQuote) (*models.FXQuote, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "fxRepo.CreateQuote")
+	defer span.Finish()
+
+	q := &models.FXQuote{}
+	if err := r.db.QueryRowxContext(
+		ctx,
+		createQuoteQuery,
+		quote.ID,
+		quote.UserID,
+		quote.FromCurrency,
+		quote.ToCurrency,
+		quote.Rate,
+		quote.Amount,
+		quote.ConvertedAmount,
+		quote.ExpiresAt,
+	).StructScan(q); err != nil {
+		return nil, errors.Wrap(err, "fxRepo.CreateQuote.StructScan")
+	}
+
+	return q, nil
+}
+
+// Get quote by id
+func (r *fxRepo) GetQuote(ctx context.Context, quoteID string) (*models.FXQuote, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "fxRepo.GetQuote")
+	defer span.Finish()
+
+	q := &models.FXQuote{}
+	if err := r.db.GetContext(ctx, q, getQuoteQuery, quoteID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fx.ErrQuoteNotFound
+		}
+		return nil, errors.Wrap(err, "fxRepo.GetQuote.GetContext")
+	}
+
+	return q, nil
+}
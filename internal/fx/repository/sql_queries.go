@@ -0,0 +1,8 @@
+package repository
+
+const (
+	createQuoteQuery = `INSERT INTO fx_quotes (id, user_id, from_currency, to_currency, rate, amount, converted_amount, expires_at)
+						VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING *`
+
+	getQuoteQuery = `SELECT * FROM fx_quotes WHERE id = $1`
+)
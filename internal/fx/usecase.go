@@ -0,0 +1,15 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase_mock.go -package mock
+package fx
+
+import (
+	"context"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/dto"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+)
+
+// FX UseCase interface
+type UseCase interface {
+	CreateQuote(ctx context.Context, dto *dto.RequestFXQuote) (*models.FXQuote, error)
+	GetQuote(ctx context.Context, quoteID string) (*models.FXQuote, error)
+}
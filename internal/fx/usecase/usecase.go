@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/opentracing/opentracing-go"
+	"github.com/shopspring/decimal"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/config"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/currency"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/dto"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/fx"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/httpErrors"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/logger"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/utils"
+)
+
+const defaultQuoteTTL = 30 * time.Second
+
+// FX UseCase
+type fxUC struct {
+	cfg        *config.Config
+	fxRepo     fx.Repository
+	provider   fx.RateProvider
+	currencyUC currency.UseCase
+	logger     logger.Logger
+}
+
+// FX UseCase constructor
+func NewFXUseCase(cfg *config.Config, fxRepo fx.Repository, provider fx.RateProvider, currencyUC currency.UseCase, log logger.Logger) fx.UseCase {
+	return &fxUC{cfg: cfg, fxRepo: fxRepo, provider: provider, currencyUC: currencyUC, logger: log}
+}
+
+// Lock the current rate for the caller for cfg.FX.QuoteTTLSeconds
+func (u *fxUC) CreateQuote(ctx context.Context, dto *dto.RequestFXQuote) (*models.FXQuote, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "fxUC.CreateQuote")
+	defer span.Finish()
+
+	user, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !dto.Amount.IsPositive() {
+		return nil, httpErrors.NewBadRequestError("amount must be > 0")
+	}
+
+	if _, err = u.currencyUC.Validate(ctx, dto.FromCurrency, dto.Amount); err != nil {
+		return nil, err
+	}
+
+	to, err := u.currencyUC.Validate(ctx, dto.ToCurrency, decimal.Zero)
+	if err != nil {
+		return nil, err
+	}
+
+	rate, err := u.provider.GetRate(ctx, dto.FromCurrency, dto.ToCurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	ttl := time.Duration(u.cfg.FX.QuoteTTLSeconds) * time.Second
+	if ttl <= 0 {
+		ttl = defaultQuoteTTL
+	}
+
+	return u.fxRepo.CreateQuote(ctx, &models.FXQuote{
+		ID:              uuid.New().String(),
+		UserID:          user.User.ID,
+		FromCurrency:    dto.FromCurrency,
+		ToCurrency:      dto.ToCurrency,
+		Rate:            rate.Rate,
+		Amount:          dto.Amount,
+		ConvertedAmount: to.Round(dto.Amount.Mul(rate.Rate)),
+		ExpiresAt:       time.Now().UTC().Add(ttl),
+	})
+}
+
+// Get quote by id, an id that is not a UUID is rejected before the lookup
+func (u *fxUC) GetQuote(ctx context.Context, quoteID string) (*models.FXQuote, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "fxUC.GetQuote")
+	defer span.Finish()
+
+	if _, err := uuid.Parse(quoteID); err != nil {
+		return nil, fx.ErrQuoteInvalid
+	}
+
+	return u.fxRepo.GetQuote(ctx, quoteID)
+}
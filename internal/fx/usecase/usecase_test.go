@@ -0,0 +1,34 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/config"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/fx"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/fx/mock"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+)
+
+func TestFXUC_GetQuote(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFXRepo := mock.NewMockRepository(ctrl)
+	fxUC := NewFXUseCase(&config.Config{}, mockFXRepo, nil, nil, nil)
+
+	_, err := fxUC.GetQuote(context.Background(), "not-a-uuid")
+	require.ErrorIs(t, err, fx.ErrQuoteInvalid)
+
+	quoteID := "0b5c3a4e-8f1d-4c2a-9e6b-7d3f2a1c5e90"
+	mockFXRepo.EXPECT().GetQuote(gomock.Any(), quoteID).Return(&models.FXQuote{ID: quoteID}, nil)
+
+	quote, err := fxUC.GetQuote(context.Background(), quoteID)
+	require.NoError(t, err)
+	require.Equal(t, quoteID, quote.ID)
+}
@@ -0,0 +1,18 @@
+//go:generate mockgen -source redis_repository.go -destination mock/redis_repository_mock.go -package mock
+package idempotency
+
+import (
+	"context"
+	"time"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+)
+
+// Idempotency key store interface
+type RedisRepository interface {
+	// Reserve stores record only when key is absent, returns false when key already exists
+	Reserve(ctx context.Context, key string, record *models.IdempotencyRecord, ttl time.Duration) (bool, error)
+	Get(ctx context.Context, key string) (*models.IdempotencyRecord, error)
+	Save(ctx context.Context, key string, record *models.IdempotencyRecord, ttl time.Duration) error
+	Delete(ctx context.Context, key string) error
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/idempotency"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+)
+
+// Idempotency redis repository
+type idempotencyRedisRepo struct {
+	redisClient *redis.Client
+}
+
+// Idempotency redis repository constructor
+func NewIdempotencyRedisRepo(redisClient *redis.Client) idempotency.RedisRepository {
+	return &idempotencyRedisRepo{redisClient: redisClient}
+}
+
+// Reserve key with SETNX
+func (r *idempotencyRedisRepo) Reserve(ctx context.Context, key string, record *models.IdempotencyRecord, ttl time.Duration) (bool, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "idempotencyRedisRepo.Reserve")
+	defer span.Finish()
+
+	recordBytes, err := json.Marshal(record)
+	if err != nil {
+		return false, errors.Wrap(err, "idempotencyRedisRepo.Reserve.json.Marshal")
+	}
+
+	ok, err := r.redisClient.SetNX(ctx, key, recordBytes, ttl).Result()
+	if err != nil {
+		return false, errors.Wrap(err, "idempotencyRedisRepo.Reserve.redisClient.SetNX")
+	}
+
+	return ok, nil
+}
+
+// Get record by key
+func (r *idempotencyRedisRepo) Get(ctx context.Context, key string) (*models.IdempotencyRecord, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "idempotencyRedisRepo.Get")
+	defer span.Finish()
+
+	recordBytes, err := r.redisClient.Get(ctx, key).Bytes()
+	if err != nil {
+		return nil, errors.Wrap(err, "idempotencyRedisRepo.Get.redisClient.Get")
+	}
+
+	record := &models.IdempotencyRecord{}
+	if err = json.Unmarshal(recordBytes, record); err != nil {
+		return nil, errors.Wrap(err, "idempotencyRedisRepo.Get.json.Unmarshal")
+	}
+
+	return record, nil
+}
+
+// Save record, overwriting the reservation
+func (r *idempotencyRedisRepo) Save(ctx context.Context, key string, record *models.IdempotencyRecord, ttl time.Duration) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "idempotencyRedisRepo.Save")
+	defer span.Finish()
+
+	recordBytes, err := json.Marshal(record)
+	if err != nil {
+		return errors.Wrap(err, "idempotencyRedisRepo.Save.json.Marshal")
+	}
+
+	if err = r.redisClient.Set(ctx, key, recordBytes, ttl).Err(); err != nil {
+		return errors.Wrap(err, "idempotencyRedisRepo.Save.redisClient.Set")
+	}
+
+	return nil
+}
+
+// Delete record by key
+func (r *idempotencyRedisRepo) Delete(ctx context.Context, key string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "idempotencyRedisRepo.Delete")
+	defer span.Finish()
+
+	if err := r.redisClient.Del(ctx, key).Err(); err != nil {
+		return errors.Wrap(err, "idempotencyRedisRepo.Delete.redisClient.Del")
+	}
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package checkpointer
+
+import (
+	"context"
+	"time"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/config"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/ledger"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/logger"
+)
+
+const defaultInterval = 24 * time.Hour
+
+// Checkpointer signs the ledger chain heads every interval. Instances that
+// find a recent checkpoint skip their turn.
+type Checkpointer struct {
+	ledgerUC ledger.UseCase
+	interval time.Duration
+	logger   logger.Logger
+}
+
+// Checkpointer constructor, a zero interval falls back to the default
+func NewCheckpointer(cfg *config.Config, ledgerUC ledger.UseCase, log logger.Logger) *Checkpointer {
+	c := &Checkpointer{
+		ledgerUC: ledgerUC,
+		interval: time.Duration(cfg.Ledger.CheckpointIntervalMinutes) * time.Minute,
+		logger:   log,
+	}
+
+	if c.interval <= 0 {
+		c.interval = defaultInterval
+	}
+
+	return c
+}
+
+// Create a checkpoint on start and then every interval until ctx is done
+func (c *Checkpointer) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := c.ledgerUC.CreateCheckpoint(ctx, true); err != nil {
+			c.logger.Errorf("ledger checkpointer: %s", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,11 @@
+package ledger
+
+import "github.com/labstack/echo/v4"
+
+// Ledger HTTP Handlers interface
+type Handlers interface {
+	Verify() echo.HandlerFunc
+	CreateCheckpoint() echo.HandlerFunc
+	ListCheckpoints() echo.HandlerFunc
+	ExportCheckpoint() echo.HandlerFunc
+}
@@ -0,0 +1,132 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/opentracing/opentracing-go"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/ledger"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/httpErrors"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/logger"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/utils"
+)
+
+type ledgerHandlers struct {
+	ledgerUC ledger.UseCase
+	logger   logger.Logger
+}
+
+func NewLedgerHandlers(ledgerUC ledger.UseCase, log logger.Logger) ledger.Handlers {
+	return &ledgerHandlers{ledgerUC: ledgerUC, logger: log}
+}
+
+// Verify godoc
+// @Summary Verify ledger chain
+// @Description Walk the hash chain of one wallet, or of every wallet, and check the latest signed checkpoint. Reports the first broken link.
+// @Tags Ledger
+// @Produce json
+// @Param wallet_id query int false "only verify this wallet"
+// @Success 200 {object} models.ChainVerification
+// @Router /ledger/verify [get]
+func (h *ledgerHandlers) Verify() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "ledger.Verify")
+		defer span.Finish()
+
+		var walletID int64
+		if param := c.QueryParam("wallet_id"); param != "" {
+			id, err := strconv.ParseInt(param, 10, 64)
+			if err != nil || id <= 0 {
+				badRequest := httpErrors.NewBadRequestError("wallet_id must be a positive integer")
+				utils.LogResponseError(c, h.logger, badRequest)
+				return c.JSON(httpErrors.ErrorResponse(badRequest))
+			}
+			walletID = id
+		}
+
+		verification, err := h.ledgerUC.Verify(ctx, walletID)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, verification)
+	}
+}
+
+// CreateCheckpoint godoc
+// @Summary Create ledger checkpoint
+// @Description Sign the current head of every wallet chain
+// @Tags Ledger
+// @Produce json
+// @Success 201 {object} models.LedgerCheckpoint
+// @Router /ledger/checkpoints [post]
+func (h *ledgerHandlers) CreateCheckpoint() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "ledger.CreateCheckpoint")
+		defer span.Finish()
+
+		checkpoint, err := h.ledgerUC.CreateCheckpoint(ctx, false)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusCreated, checkpoint)
+	}
+}
+
+// ListCheckpoints godoc
+// @Summary List ledger checkpoints
+// @Description List the latest signed checkpoints, newest first
+// @Tags Ledger
+// @Produce json
+// @Success 200 {array} models.LedgerCheckpoint
+// @Router /ledger/checkpoints [get]
+func (h *ledgerHandlers) ListCheckpoints() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "ledger.ListCheckpoints")
+		defer span.Finish()
+
+		checkpoints, err := h.ledgerUC.ListCheckpoints(ctx)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, checkpoints)
+	}
+}
+
+// ExportCheckpoint godoc
+// @Summary Export ledger checkpoint
+// @Description Download a checkpoint with its chain heads, the signed message and the public key that verifies it
+// @Tags Ledger
+// @Produce json
+// @Param id path int true "checkpoint_id"
+// @Success 200 {object} models.CheckpointExport
+// @Router /ledger/checkpoints/{id}/export [get]
+func (h *ledgerHandlers) ExportCheckpoint() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "ledger.ExportCheckpoint")
+		defer span.Finish()
+
+		checkpointID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(httpErrors.NewBadRequestError(err.Error())))
+		}
+
+		export, err := h.ledgerUC.ExportCheckpoint(ctx, checkpointID)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", fmt.Sprintf("ledger-checkpoint-%d.json", checkpointID)))
+		return c.JSON(http.StatusOK, export)
+	}
+}
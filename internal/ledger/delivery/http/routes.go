@@ -0,0 +1,22 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/config"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/auth"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/ledger"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/middleware"
+)
+
+// Map ledger audit routes, all of them are for wallet administrators
+func MapLedgerRoutes(ledgerGroup *echo.Group, h ledger.Handlers, mw *middleware.MiddlewareManager, rbacMw *middleware.RBACMiddleware, authUc auth.UseCase, cfg *config.Config) {
+	ledgerGroup.Use(mw.AuthJWTMiddleware(authUc, cfg))
+	ledgerGroup.Use(mw.AuthSessionMiddleware)
+	ledgerGroup.Use(rbacMw.RequirePermission("manage", "wallets", nil))
+
+	ledgerGroup.GET("/verify", h.Verify())
+	ledgerGroup.POST("/checkpoints", h.CreateCheckpoint())
+	ledgerGroup.GET("/checkpoints", h.ListCheckpoints())
+	ledgerGroup.GET("/checkpoints/:id/export", h.ExportCheckpoint())
+}
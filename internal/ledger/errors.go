@@ -0,0 +1,13 @@
+package ledger
+
+import (
+	"net/http"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/httpErrors"
+)
+
+// Ledger domain errors
+var (
+	ErrCheckpointNotFound   = httpErrors.NewRestError(http.StatusNotFound, "Ledger checkpoint not found", nil)
+	ErrCheckpointKeyUnknown = httpErrors.NewRestError(http.StatusConflict, "Ledger checkpoint was signed with a key that is no longer configured", nil)
+)
@@ -0,0 +1,171 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pg_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateCheckpoint mocks base method.
+func (m *MockRepository) CreateCheckpoint(ctx context.Context, checkpoint *models.LedgerCheckpoint) (*models.LedgerCheckpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCheckpoint", ctx, checkpoint)
+	ret0, _ := ret[0].(*models.LedgerCheckpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCheckpoint indicates an expected call of CreateCheckpoint.
+func (mr *MockRepositoryMockRecorder) CreateCheckpoint(ctx, checkpoint interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCheckpoint", reflect.TypeOf((*MockRepository)(nil).CreateCheckpoint), ctx, checkpoint)
+}
+
+// FindChainRows mocks base method.
+func (m *MockRepository) FindChainRows(ctx context.Context, walletID, afterSeq int64, limit int) ([]*models.ChainRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindChainRows", ctx, walletID, afterSeq, limit)
+	ret0, _ := ret[0].([]*models.ChainRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindChainRows indicates an expected call of FindChainRows.
+func (mr *MockRepositoryMockRecorder) FindChainRows(ctx, walletID, afterSeq, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindChainRows", reflect.TypeOf((*MockRepository)(nil).FindChainRows), ctx, walletID, afterSeq, limit)
+}
+
+// FindCheckpointMismatch mocks base method.
+func (m *MockRepository) FindCheckpointMismatch(ctx context.Context, checkpointID, walletID int64) (*models.ChainBreak, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindCheckpointMismatch", ctx, checkpointID, walletID)
+	ret0, _ := ret[0].(*models.ChainBreak)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindCheckpointMismatch indicates an expected call of FindCheckpointMismatch.
+func (mr *MockRepositoryMockRecorder) FindCheckpointMismatch(ctx, checkpointID, walletID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCheckpointMismatch", reflect.TypeOf((*MockRepository)(nil).FindCheckpointMismatch), ctx, checkpointID, walletID)
+}
+
+// GetChainHead mocks base method.
+func (m *MockRepository) GetChainHead(ctx context.Context, walletID int64) (*models.ChainHead, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChainHead", ctx, walletID)
+	ret0, _ := ret[0].(*models.ChainHead)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChainHead indicates an expected call of GetChainHead.
+func (mr *MockRepositoryMockRecorder) GetChainHead(ctx, walletID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChainHead", reflect.TypeOf((*MockRepository)(nil).GetChainHead), ctx, walletID)
+}
+
+// GetCheckpoint mocks base method.
+func (m *MockRepository) GetCheckpoint(ctx context.Context, checkpointID int64) (*models.LedgerCheckpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCheckpoint", ctx, checkpointID)
+	ret0, _ := ret[0].(*models.LedgerCheckpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCheckpoint indicates an expected call of GetCheckpoint.
+func (mr *MockRepositoryMockRecorder) GetCheckpoint(ctx, checkpointID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCheckpoint", reflect.TypeOf((*MockRepository)(nil).GetCheckpoint), ctx, checkpointID)
+}
+
+// LatestCheckpoint mocks base method.
+func (m *MockRepository) LatestCheckpoint(ctx context.Context) (*models.LedgerCheckpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LatestCheckpoint", ctx)
+	ret0, _ := ret[0].(*models.LedgerCheckpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LatestCheckpoint indicates an expected call of LatestCheckpoint.
+func (mr *MockRepositoryMockRecorder) LatestCheckpoint(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LatestCheckpoint", reflect.TypeOf((*MockRepository)(nil).LatestCheckpoint), ctx)
+}
+
+// ListChainHeads mocks base method.
+func (m *MockRepository) ListChainHeads(ctx context.Context) ([]models.ChainHead, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListChainHeads", ctx)
+	ret0, _ := ret[0].([]models.ChainHead)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListChainHeads indicates an expected call of ListChainHeads.
+func (mr *MockRepositoryMockRecorder) ListChainHeads(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListChainHeads", reflect.TypeOf((*MockRepository)(nil).ListChainHeads), ctx)
+}
+
+// ListCheckpoints mocks base method.
+func (m *MockRepository) ListCheckpoints(ctx context.Context, limit int) ([]*models.LedgerCheckpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCheckpoints", ctx, limit)
+	ret0, _ := ret[0].([]*models.LedgerCheckpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCheckpoints indicates an expected call of ListCheckpoints.
+func (mr *MockRepositoryMockRecorder) ListCheckpoints(ctx, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCheckpoints", reflect.TypeOf((*MockRepository)(nil).ListCheckpoints), ctx, limit)
+}
+
+// NextWalletIDs mocks base method.
+func (m *MockRepository) NextWalletIDs(ctx context.Context, afterID int64, limit int) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NextWalletIDs", ctx, afterID, limit)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NextWalletIDs indicates an expected call of NextWalletIDs.
+func (mr *MockRepositoryMockRecorder) NextWalletIDs(ctx, afterID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NextWalletIDs", reflect.TypeOf((*MockRepository)(nil).NextWalletIDs), ctx, afterID, limit)
+}
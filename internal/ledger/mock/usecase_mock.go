@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUseCase is a mock of UseCase interface.
+type MockUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockUseCaseMockRecorder
+}
+
+// MockUseCaseMockRecorder is the mock recorder for MockUseCase.
+type MockUseCaseMockRecorder struct {
+	mock *MockUseCase
+}
+
+// NewMockUseCase creates a new mock instance.
+func NewMockUseCase(ctrl *gomock.Controller) *MockUseCase {
+	mock := &MockUseCase{ctrl: ctrl}
+	mock.recorder = &MockUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUseCase) EXPECT() *MockUseCaseMockRecorder {
+	return m.recorder
+}
+
+// CreateCheckpoint mocks base method.
+func (m *MockUseCase) CreateCheckpoint(ctx context.Context, scheduled bool) (*models.LedgerCheckpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCheckpoint", ctx, scheduled)
+	ret0, _ := ret[0].(*models.LedgerCheckpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCheckpoint indicates an expected call of CreateCheckpoint.
+func (mr *MockUseCaseMockRecorder) CreateCheckpoint(ctx, scheduled interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCheckpoint", reflect.TypeOf((*MockUseCase)(nil).CreateCheckpoint), ctx, scheduled)
+}
+
+// ExportCheckpoint mocks base method.
+func (m *MockUseCase) ExportCheckpoint(ctx context.Context, checkpointID int64) (*models.CheckpointExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportCheckpoint", ctx, checkpointID)
+	ret0, _ := ret[0].(*models.CheckpointExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportCheckpoint indicates an expected call of ExportCheckpoint.
+func (mr *MockUseCaseMockRecorder) ExportCheckpoint(ctx, checkpointID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportCheckpoint", reflect.TypeOf((*MockUseCase)(nil).ExportCheckpoint), ctx, checkpointID)
+}
+
+// ListCheckpoints mocks base method.
+func (m *MockUseCase) ListCheckpoints(ctx context.Context) ([]*models.LedgerCheckpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCheckpoints", ctx)
+	ret0, _ := ret[0].([]*models.LedgerCheckpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCheckpoints indicates an expected call of ListCheckpoints.
+func (mr *MockUseCaseMockRecorder) ListCheckpoints(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCheckpoints", reflect.TypeOf((*MockUseCase)(nil).ListCheckpoints), ctx)
+}
+
+// Verify mocks base method.
+func (m *MockUseCase) Verify(ctx context.Context, walletID int64) (*models.ChainVerification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", ctx, walletID)
+	ret0, _ := ret[0].(*models.ChainVerification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockUseCaseMockRecorder) Verify(ctx, walletID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockUseCase)(nil).Verify), ctx, walletID)
+}
@@ -0,0 +1,28 @@
+//go:generate mockgen -source pg_repository.go -destination mock/pg_repository_mock.go -package mock
+package ledger
+
+import (
+	"context"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+)
+
+// Ledger repository interface
+type Repository interface {
+	// Wallet ids after afterID in id order
+	NextWalletIDs(ctx context.Context, afterID int64, limit int) ([]int64, error)
+	// GetChainHead returns an empty head for wallets without rows
+	GetChainHead(ctx context.Context, walletID int64) (*models.ChainHead, error)
+	// Rows of a wallet chain after afterSeq in seq order
+	FindChainRows(ctx context.Context, walletID int64, afterSeq int64, limit int) ([]*models.ChainRow, error)
+	ListChainHeads(ctx context.Context) ([]models.ChainHead, error)
+
+	CreateCheckpoint(ctx context.Context, checkpoint *models.LedgerCheckpoint) (*models.LedgerCheckpoint, error)
+	// LatestCheckpoint returns nil when no checkpoint was taken yet
+	LatestCheckpoint(ctx context.Context) (*models.LedgerCheckpoint, error)
+	GetCheckpoint(ctx context.Context, checkpointID int64) (*models.LedgerCheckpoint, error)
+	ListCheckpoints(ctx context.Context, limit int) ([]*models.LedgerCheckpoint, error)
+	// First checkpoint head, of walletID or of every wallet when it is 0,
+	// whose row is missing or no longer has the signed hash. Nil when none.
+	FindCheckpointMismatch(ctx context.Context, checkpointID int64, walletID int64) (*models.ChainBreak, error)
+}
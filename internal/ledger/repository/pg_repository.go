@@ -0,0 +1,196 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/ledger"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+)
+
+// Ledger Repository
+type ledgerRepo struct {
+	db *sqlx.DB
+}
+
+// Ledger Repository constructor
+func NewLedgerRepository(db *sqlx.DB) ledger.Repository {
+	return &ledgerRepo{db: db}
+}
+
+// Wallet ids after afterID in id order
+func (r *ledgerRepo) NextWalletIDs(ctx context.Context, afterID int64, limit int) ([]int64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ledgerRepo.NextWalletIDs")
+	defer span.Finish()
+
+	ids := make([]int64, 0, limit)
+	if err := r.db.SelectContext(ctx, &ids, nextWalletIDsQuery, afterID, limit); err != nil {
+		return nil, errors.Wrap(err, "ledgerRepo.NextWalletIDs.SelectContext")
+	}
+
+	return ids, nil
+}
+
+// Get the last link of a wallet chain, empty for wallets without rows
+func (r *ledgerRepo) GetChainHead(ctx context.Context, walletID int64) (*models.ChainHead, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ledgerRepo.GetChainHead")
+	defer span.Finish()
+
+	head := &models.ChainHead{}
+	if err := r.db.GetContext(ctx, head, getChainHeadQuery, walletID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return &models.ChainHead{WalletID: walletID}, nil
+		}
+		return nil, errors.Wrap(err, "ledgerRepo.GetChainHead.GetContext")
+	}
+
+	return head, nil
+}
+
+// Rows of a wallet chain after afterSeq in seq order
+func (r *ledgerRepo) FindChainRows(ctx context.Context, walletID int64, afterSeq int64, limit int) ([]*models.ChainRow, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ledgerRepo.FindChainRows")
+	defer span.Finish()
+
+	rows := make([]*models.ChainRow, 0, limit)
+	if err := r.db.SelectContext(ctx, &rows, findChainRowsQuery, walletID, afterSeq, limit); err != nil {
+		return nil, errors.Wrap(err, "ledgerRepo.FindChainRows.SelectContext")
+	}
+
+	return rows, nil
+}
+
+// Heads of every chain with rows, in wallet order
+func (r *ledgerRepo) ListChainHeads(ctx context.Context) ([]models.ChainHead, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ledgerRepo.ListChainHeads")
+	defer span.Finish()
+
+	heads := make([]models.ChainHead, 0)
+	if err := r.db.SelectContext(ctx, &heads, listChainHeadsQuery); err != nil {
+		return nil, errors.Wrap(err, "ledgerRepo.ListChainHeads.SelectContext")
+	}
+
+	return heads, nil
+}
+
+// Store a signed checkpoint with its heads
+func (r *ledgerRepo) CreateCheckpoint(ctx context.Context, checkpoint *models.LedgerCheckpoint) (*models.LedgerCheckpoint, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ledgerRepo.CreateCheckpoint")
+	defer span.Finish()
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "ledgerRepo.CreateCheckpoint.BeginTxx")
+	}
+	defer tx.Rollback()
+
+	created := &models.LedgerCheckpoint{}
+	if err := tx.QueryRowxContext(ctx, createCheckpointQuery,
+		checkpoint.WalletCount, checkpoint.RowCount, checkpoint.RootHash, checkpoint.KeyID, checkpoint.Signature, checkpoint.CreatedAt,
+	).StructScan(created); err != nil {
+		return nil, errors.Wrap(err, "ledgerRepo.CreateCheckpoint.StructScan")
+	}
+
+	stmt, err := tx.PreparexContext(ctx, createCheckpointHeadQuery)
+	if err != nil {
+		return nil, errors.Wrap(err, "ledgerRepo.CreateCheckpoint.PreparexContext")
+	}
+	defer stmt.Close()
+
+	for _, h := range checkpoint.Heads {
+		if _, err := stmt.ExecContext(ctx, created.ID, h.WalletID, h.Seq, h.Hash); err != nil {
+			return nil, errors.Wrap(err, "ledgerRepo.CreateCheckpoint.ExecContext.head")
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "ledgerRepo.CreateCheckpoint.Commit")
+	}
+
+	created.Heads = checkpoint.Heads
+	return created, nil
+}
+
+// Latest checkpoint without its heads, nil when there is none
+func (r *ledgerRepo) LatestCheckpoint(ctx context.Context) (*models.LedgerCheckpoint, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ledgerRepo.LatestCheckpoint")
+	defer span.Finish()
+
+	checkpoint := &models.LedgerCheckpoint{}
+	if err := r.db.GetContext(ctx, checkpoint, latestCheckpointQuery); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "ledgerRepo.LatestCheckpoint.GetContext")
+	}
+
+	return checkpoint, nil
+}
+
+// Get checkpoint by id with its heads
+func (r *ledgerRepo) GetCheckpoint(ctx context.Context, checkpointID int64) (*models.LedgerCheckpoint, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ledgerRepo.GetCheckpoint")
+	defer span.Finish()
+
+	checkpoint := &models.LedgerCheckpoint{}
+	if err := r.db.GetContext(ctx, checkpoint, getCheckpointQuery, checkpointID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ledger.ErrCheckpointNotFound
+		}
+		return nil, errors.Wrap(err, "ledgerRepo.GetCheckpoint.GetContext")
+	}
+
+	heads := make([]models.ChainHead, 0, checkpoint.WalletCount)
+	if err := r.db.SelectContext(ctx, &heads, findCheckpointHeadsQuery, checkpointID); err != nil {
+		return nil, errors.Wrap(err, "ledgerRepo.GetCheckpoint.SelectContext")
+	}
+
+	checkpoint.Heads = heads
+	return checkpoint, nil
+}
+
+// List the latest checkpoints without their heads, newest first
+func (r *ledgerRepo) ListCheckpoints(ctx context.Context, limit int) ([]*models.LedgerCheckpoint, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ledgerRepo.ListCheckpoints")
+	defer span.Finish()
+
+	checkpoints := make([]*models.LedgerCheckpoint, 0, limit)
+	if err := r.db.SelectContext(ctx, &checkpoints, listCheckpointsQuery, limit); err != nil {
+		return nil, errors.Wrap(err, "ledgerRepo.ListCheckpoints.SelectContext")
+	}
+
+	return checkpoints, nil
+}
+
+// First checkpoint head whose row is missing or was rewritten
+func (r *ledgerRepo) FindCheckpointMismatch(ctx context.Context, checkpointID int64, walletID int64) (*models.ChainBreak, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ledgerRepo.FindCheckpointMismatch")
+	defer span.Finish()
+
+	var row struct {
+		WalletID int64  `db:"wallet_id"`
+		Seq      int64  `db:"seq"`
+		Expected string `db:"expected"`
+		TxID     *int64 `db:"tx_id"`
+		Actual   string `db:"actual"`
+	}
+	if err := r.db.GetContext(ctx, &row, findCheckpointMismatchQuery, checkpointID, walletID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "ledgerRepo.FindCheckpointMismatch.GetContext")
+	}
+
+	return &models.ChainBreak{
+		WalletID: row.WalletID,
+		TxID:     row.TxID,
+		Seq:      row.Seq,
+		Reason:   models.ChainBreakCheckpoint,
+		Expected: row.Expected,
+		Actual:   row.Actual,
+	}, nil
+}
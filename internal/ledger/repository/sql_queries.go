@@ -0,0 +1,39 @@
+package repository
+
+const (
+	nextWalletIDsQuery = `SELECT id FROM wallets WHERE id > $1 ORDER BY id LIMIT $2`
+
+	getChainHeadQuery = `SELECT wallet_id, seq, hash FROM ledger_chain_heads WHERE wallet_id = $1`
+
+	// meta is read as text so the hash covers the same bytes as txs_chain_hash
+	findChainRowsQuery = `SELECT id, wallet_id, seq, type, currency, amount, ref_id, entry_id,
+							meta::text AS meta, created_at, prev_hash, hash
+						FROM txs WHERE wallet_id = $1 AND seq > $2
+						ORDER BY seq LIMIT $3`
+
+	listChainHeadsQuery = `SELECT wallet_id, seq, hash FROM ledger_chain_heads WHERE seq > 0 ORDER BY wallet_id`
+)
+
+const (
+	createCheckpointQuery = `INSERT INTO ledger_checkpoints (wallet_count, row_count, root_hash, key_id, signature, created_at)
+						VALUES ($1, $2, $3, $4, $5, $6) RETURNING *`
+
+	createCheckpointHeadQuery = `INSERT INTO ledger_checkpoint_heads (checkpoint_id, wallet_id, seq, hash)
+						VALUES ($1, $2, $3, $4)`
+
+	latestCheckpointQuery = `SELECT * FROM ledger_checkpoints ORDER BY created_at DESC, id DESC LIMIT 1`
+
+	getCheckpointQuery = `SELECT * FROM ledger_checkpoints WHERE id = $1`
+
+	findCheckpointHeadsQuery = `SELECT wallet_id, seq, hash FROM ledger_checkpoint_heads
+						WHERE checkpoint_id = $1 ORDER BY wallet_id`
+
+	listCheckpointsQuery = `SELECT * FROM ledger_checkpoints ORDER BY created_at DESC, id DESC LIMIT $1`
+
+	findCheckpointMismatchQuery = `SELECT h.wallet_id, h.seq, h.hash AS expected, t.id AS tx_id, COALESCE(t.hash, '') AS actual
+						FROM ledger_checkpoint_heads h
+						LEFT JOIN txs t ON t.wallet_id = h.wallet_id AND t.seq = h.seq
+						WHERE h.checkpoint_id = $1 AND ($2::bigint = 0 OR h.wallet_id = $2::bigint)
+						AND (t.id IS NULL OR t.hash <> h.hash)
+						ORDER BY h.wallet_id LIMIT 1`
+)
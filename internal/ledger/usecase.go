@@ -0,0 +1,18 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase_mock.go -package mock
+package ledger
+
+import (
+	"context"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+)
+
+// Ledger UseCase interface
+type UseCase interface {
+	// Verify walks the chain of walletID, or of every wallet when it is 0
+	Verify(ctx context.Context, walletID int64) (*models.ChainVerification, error)
+	// CreateCheckpoint returns nil when scheduled and the latest checkpoint is recent
+	CreateCheckpoint(ctx context.Context, scheduled bool) (*models.LedgerCheckpoint, error)
+	ListCheckpoints(ctx context.Context) ([]*models.LedgerCheckpoint, error)
+	ExportCheckpoint(ctx context.Context, checkpointID int64) (*models.CheckpointExport, error)
+}
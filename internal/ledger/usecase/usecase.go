@@ -0,0 +1,303 @@
+package usecase
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/config"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/ledger"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/logger"
+)
+
+const (
+	defaultCheckpointInterval = 24 * time.Hour
+	defaultVerifyBatchSize    = 1000
+	listCheckpointsLimit      = 50
+
+	signatureAlgorithm = "ed25519"
+	developmentMode    = "Development"
+)
+
+// Ledger UseCase
+type ledgerUC struct {
+	repo               ledger.Repository
+	privateKey         ed25519.PrivateKey
+	publicKey          ed25519.PublicKey
+	keyID              string
+	checkpointInterval time.Duration
+	batchSize          int
+	logger             logger.Logger
+	now                func() time.Time
+}
+
+// Ledger UseCase constructor, fails when the checkpoint signing key is not a
+// base64 ed25519 seed. Zero config values fall back to defaults.
+func NewLedgerUseCase(cfg *config.Config, repo ledger.Repository, log logger.Logger) (ledger.UseCase, error) {
+	privateKey, err := checkpointSigningKey(cfg, log)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey := privateKey.Public().(ed25519.PublicKey)
+	keySum := sha256.Sum256(publicKey)
+
+	u := &ledgerUC{
+		repo:               repo,
+		privateKey:         privateKey,
+		publicKey:          publicKey,
+		keyID:              hex.EncodeToString(keySum[:8]),
+		checkpointInterval: time.Duration(cfg.Ledger.CheckpointIntervalMinutes) * time.Minute,
+		batchSize:          cfg.Ledger.VerifyBatchSize,
+		logger:             log,
+		now:                time.Now,
+	}
+
+	if u.checkpointInterval <= 0 {
+		u.checkpointInterval = defaultCheckpointInterval
+	}
+	if u.batchSize <= 0 {
+		u.batchSize = defaultVerifyBatchSize
+	}
+
+	return u, nil
+}
+
+// Decode the checkpoint signing key. It is never committed to the config
+// files, outside development an unset key fails startup. In development a key
+// is generated per process, so its checkpoints do not verify after a restart.
+func checkpointSigningKey(cfg *config.Config, log logger.Logger) (ed25519.PrivateKey, error) {
+	if cfg.Ledger.CheckpointSigningKey == "" {
+		if cfg.Server.Mode != developmentMode {
+			return nil, errors.Errorf("ledger checkpoint signing key is not set, provide it in %s", config.LedgerSigningKeyEnv)
+		}
+
+		_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, errors.Wrap(err, "ledgerUC.checkpointSigningKey.GenerateKey")
+		}
+		log.Warnf("Ledger checkpoint signing key is not set, signing with a generated key until restart")
+		return privateKey, nil
+	}
+
+	seed, err := base64.StdEncoding.DecodeString(cfg.Ledger.CheckpointSigningKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "ledgerUC.checkpointSigningKey.DecodeString")
+	}
+	if len(seed) != ed25519.SeedSize {
+		return nil, errors.Errorf("ledger checkpoint signing key must be a %d byte seed, got %d bytes", ed25519.SeedSize, len(seed))
+	}
+
+	return ed25519.NewKeyFromSeed(seed), nil
+}
+
+// Walk the wallet chains and check the latest checkpoint still matches them,
+// stops at the first broken link
+func (u *ledgerUC) Verify(ctx context.Context, walletID int64) (*models.ChainVerification, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ledgerUC.Verify")
+	defer span.Finish()
+
+	result := &models.ChainVerification{}
+
+	walletIDs := []int64{walletID}
+	var afterID int64
+	for result.Break == nil {
+		if walletID == 0 {
+			ids, err := u.repo.NextWalletIDs(ctx, afterID, u.batchSize)
+			if err != nil {
+				return nil, err
+			}
+			if len(ids) == 0 {
+				break
+			}
+			walletIDs, afterID = ids, ids[len(ids)-1]
+		}
+
+		for _, id := range walletIDs {
+			rows, brk, err := u.verifyWallet(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+
+			result.WalletsChecked++
+			result.RowsChecked += rows
+			if brk != nil {
+				result.Break = brk
+				break
+			}
+		}
+
+		if walletID != 0 || len(walletIDs) < u.batchSize {
+			break
+		}
+	}
+
+	if result.Break == nil {
+		checkpointID, brk, err := u.verifyCheckpoint(ctx, walletID)
+		if err != nil {
+			return nil, err
+		}
+		result.CheckpointID, result.Break = checkpointID, brk
+	}
+
+	result.Valid = result.Break == nil
+	result.VerifiedAt = u.now().UTC()
+
+	if !result.Valid {
+		u.logger.Warnf("Ledger chain of wallet %d broken at seq %d: %s, expected %s, actual %s",
+			result.Break.WalletID, result.Break.Seq, result.Break.Reason, result.Break.Expected, result.Break.Actual)
+	}
+
+	return result, nil
+}
+
+// Sign the current chain heads. Scheduled checkpoints are skipped when the
+// latest one is less than half an interval old, so several instances share
+// one schedule.
+func (u *ledgerUC) CreateCheckpoint(ctx context.Context, scheduled bool) (*models.LedgerCheckpoint, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ledgerUC.CreateCheckpoint")
+	defer span.Finish()
+
+	if scheduled {
+		latest, err := u.repo.LatestCheckpoint(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if latest != nil && u.now().Sub(latest.CreatedAt) < u.checkpointInterval/2 {
+			return nil, nil
+		}
+	}
+
+	heads, err := u.repo.ListChainHeads(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// stored as TIMESTAMPTZ, the signed message must survive the round trip
+	checkpoint := &models.LedgerCheckpoint{KeyID: u.keyID, CreatedAt: u.now().UTC().Truncate(time.Microsecond)}
+	checkpoint.Summarize(heads)
+	checkpoint.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(u.privateKey, checkpoint.Message()))
+
+	created, err := u.repo.CreateCheckpoint(ctx, checkpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	u.logger.Infof("Ledger checkpoint %d signed %d wallets, %d rows, root %s",
+		created.ID, created.WalletCount, created.RowCount, created.RootHash)
+
+	return created, nil
+}
+
+// List the latest checkpoints, newest first
+func (u *ledgerUC) ListCheckpoints(ctx context.Context) ([]*models.LedgerCheckpoint, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ledgerUC.ListCheckpoints")
+	defer span.Finish()
+
+	return u.repo.ListCheckpoints(ctx, listCheckpointsLimit)
+}
+
+// Export a checkpoint with its heads and the public key that verifies it
+func (u *ledgerUC) ExportCheckpoint(ctx context.Context, checkpointID int64) (*models.CheckpointExport, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ledgerUC.ExportCheckpoint")
+	defer span.Finish()
+
+	checkpoint, err := u.repo.GetCheckpoint(ctx, checkpointID)
+	if err != nil {
+		return nil, err
+	}
+
+	if checkpoint.KeyID != u.keyID {
+		return nil, ledger.ErrCheckpointKeyUnknown
+	}
+
+	return &models.CheckpointExport{
+		Algorithm:  signatureAlgorithm,
+		PublicKey:  base64.StdEncoding.EncodeToString(u.publicKey),
+		Message:    string(checkpoint.Message()),
+		Checkpoint: checkpoint,
+	}, nil
+}
+
+// Walk one wallet chain and compare its last link with the head, returns how
+// many rows were checked
+func (u *ledgerUC) verifyWallet(ctx context.Context, walletID int64) (int64, *models.ChainBreak, error) {
+	var prevSeq, checked int64
+	var prevHash string
+
+	for {
+		rows, err := u.repo.FindChainRows(ctx, walletID, prevSeq, u.batchSize)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		for _, row := range rows {
+			if brk := row.Check(prevSeq, prevHash); brk != nil {
+				return checked, brk, nil
+			}
+			prevSeq, prevHash = row.Seq, row.Hash
+			checked++
+		}
+
+		if len(rows) == u.batchSize {
+			continue
+		}
+
+		// the head is read after the rows, rows appended in between are
+		// walked before the last link is compared
+		head, err := u.repo.GetChainHead(ctx, walletID)
+		if err != nil {
+			return 0, nil, err
+		}
+		if head.Seq > prevSeq && len(rows) > 0 {
+			continue
+		}
+
+		if head.Seq != prevSeq || head.Hash != prevHash {
+			return checked, &models.ChainBreak{WalletID: walletID, Seq: head.Seq, Reason: models.ChainBreakHead,
+				Expected: head.Hash, Actual: prevHash}, nil
+		}
+
+		return checked, nil, nil
+	}
+}
+
+// Check the signature of the latest checkpoint and that the signed heads are
+// still in the chains of walletID, or of every wallet when it is 0
+func (u *ledgerUC) verifyCheckpoint(ctx context.Context, walletID int64) (*int64, *models.ChainBreak, error) {
+	latest, err := u.repo.LatestCheckpoint(ctx)
+	if err != nil || latest == nil {
+		return nil, nil, err
+	}
+
+	checkpoint, err := u.repo.GetCheckpoint(ctx, latest.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(checkpoint.Signature)
+	if err != nil || checkpoint.KeyID != u.keyID || !ed25519.Verify(u.publicKey, checkpoint.Message(), signature) {
+		return &checkpoint.ID, &models.ChainBreak{Reason: models.ChainBreakBadSignature,
+			Expected: u.keyID, Actual: checkpoint.KeyID}, nil
+	}
+
+	if root := models.ChainRoot(checkpoint.Heads); root != checkpoint.RootHash {
+		return &checkpoint.ID, &models.ChainBreak{Reason: models.ChainBreakCheckpoint,
+			Expected: checkpoint.RootHash, Actual: root}, nil
+	}
+
+	brk, err := u.repo.FindCheckpointMismatch(ctx, checkpoint.ID, walletID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &checkpoint.ID, brk, nil
+}
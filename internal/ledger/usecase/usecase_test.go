@@ -0,0 +1,253 @@
+package usecase
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/config"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/ledger"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/ledger/mock"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/logger"
+)
+
+var testNow = time.Date(2026, 5, 4, 9, 30, 0, 123456789, time.UTC)
+
+func newTestUseCase(t *testing.T, repo *mock.MockRepository) *ledgerUC {
+	cfg := &config.Config{
+		Logger: config.Logger{Level: "error", Encoding: "console"},
+		Ledger: config.Ledger{
+			CheckpointSigningKey:      base64.StdEncoding.EncodeToString(make([]byte, ed25519.SeedSize)),
+			CheckpointIntervalMinutes: 60,
+			VerifyBatchSize:           2,
+		},
+	}
+	l := logger.NewApiLogger(cfg)
+	l.InitLogger()
+
+	uc, err := NewLedgerUseCase(cfg, repo, l)
+	require.NoError(t, err)
+
+	u := uc.(*ledgerUC)
+	u.now = func() time.Time { return testNow }
+	return u
+}
+
+// Chain of n deposits on a wallet
+func chain(walletID int64, n int) []*models.ChainRow {
+	rows := make([]*models.ChainRow, 0, n)
+	prevHash := ""
+	for i := 1; i <= n; i++ {
+		row := &models.ChainRow{
+			ID:        walletID*100 + int64(i),
+			WalletID:  walletID,
+			Seq:       int64(i),
+			Type:      models.TypeDeposit,
+			Currency:  "USD",
+			Amount:    decimal.NewFromInt(int64(i * 10)),
+			CreatedAt: time.Date(2026, 5, 1, 0, i, 0, 0, time.UTC),
+			PrevHash:  prevHash,
+		}
+		row.Hash = row.ComputeHash(prevHash)
+		prevHash = row.Hash
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func headOf(rows []*models.ChainRow) *models.ChainHead {
+	last := rows[len(rows)-1]
+	return &models.ChainHead{WalletID: last.WalletID, Seq: last.Seq, Hash: last.Hash}
+}
+
+func TestNewLedgerUseCase_InvalidKey(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.Config{Ledger: config.Ledger{CheckpointSigningKey: base64.StdEncoding.EncodeToString([]byte("short"))}}
+	_, err := NewLedgerUseCase(cfg, nil, nil)
+	require.Error(t, err)
+}
+
+func TestNewLedgerUseCase_UnsetKey(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.Config{Server: config.ServerConfig{Mode: "Production"}}
+	_, err := NewLedgerUseCase(cfg, nil, nil)
+	require.Error(t, err)
+
+	cfg = &config.Config{
+		Server: config.ServerConfig{Mode: "Development"},
+		Logger: config.Logger{Level: "error", Encoding: "console"},
+	}
+	l := logger.NewApiLogger(cfg)
+	l.InitLogger()
+
+	uc, err := NewLedgerUseCase(cfg, nil, l)
+	require.NoError(t, err)
+	require.NotEmpty(t, uc.(*ledgerUC).keyID)
+}
+
+func TestLedgerUC_Verify(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockRepository(ctrl)
+	u := newTestUseCase(t, repo)
+
+	first, second := chain(1, 3), chain(4, 1)
+
+	// wallets and rows are both walked in batches
+	repo.EXPECT().NextWalletIDs(gomock.Any(), int64(0), 2).Return([]int64{1, 4}, nil)
+	repo.EXPECT().FindChainRows(gomock.Any(), int64(1), int64(0), 2).Return(first[:2], nil)
+	repo.EXPECT().FindChainRows(gomock.Any(), int64(1), int64(2), 2).Return(first[2:], nil)
+	repo.EXPECT().GetChainHead(gomock.Any(), int64(1)).Return(headOf(first), nil)
+	repo.EXPECT().FindChainRows(gomock.Any(), int64(4), int64(0), 2).Return(second, nil)
+	repo.EXPECT().GetChainHead(gomock.Any(), int64(4)).Return(headOf(second), nil)
+	repo.EXPECT().NextWalletIDs(gomock.Any(), int64(4), 2).Return([]int64{}, nil)
+
+	// the latest checkpoint was signed over the same heads
+	checkpoint := &models.LedgerCheckpoint{ID: 6, KeyID: u.keyID, CreatedAt: testNow.Add(-time.Hour).Truncate(time.Microsecond)}
+	checkpoint.Summarize([]models.ChainHead{*headOf(first), *headOf(second)})
+	checkpoint.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(u.privateKey, checkpoint.Message()))
+
+	repo.EXPECT().LatestCheckpoint(gomock.Any()).Return(&models.LedgerCheckpoint{ID: 6}, nil)
+	repo.EXPECT().GetCheckpoint(gomock.Any(), int64(6)).Return(checkpoint, nil)
+	repo.EXPECT().FindCheckpointMismatch(gomock.Any(), int64(6), int64(0)).Return(nil, nil)
+
+	verification, err := u.Verify(context.Background(), 0)
+	require.NoError(t, err)
+	require.True(t, verification.Valid)
+	require.Equal(t, 2, verification.WalletsChecked)
+	require.Equal(t, int64(4), verification.RowsChecked)
+	require.Equal(t, int64(6), *verification.CheckpointID)
+}
+
+func TestLedgerUC_Verify_Broken(t *testing.T) {
+	t.Parallel()
+
+	tampered := chain(1, 3)
+	tampered[1].Amount = decimal.NewFromInt(1000)
+
+	deleted := chain(1, 3)
+
+	tests := []struct {
+		name   string
+		rows   []*models.ChainRow
+		head   *models.ChainHead
+		reason string
+		seq    int64
+	}{
+		{name: "edited row", rows: tampered, reason: models.ChainBreakHash, seq: 2},
+		{name: "deleted row", rows: []*models.ChainRow{deleted[0], deleted[2]}, reason: models.ChainBreakSequenceGap, seq: 3},
+		{name: "deleted last row", rows: deleted[:2], head: headOf(deleted), reason: models.ChainBreakHead, seq: 3},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockRepository(ctrl)
+			u := newTestUseCase(t, repo)
+			u.batchSize = 10
+
+			repo.EXPECT().FindChainRows(gomock.Any(), int64(1), int64(0), 10).Return(tt.rows, nil)
+			if tt.head != nil {
+				// a head ahead of the rows read is rechecked before it is reported
+				repo.EXPECT().GetChainHead(gomock.Any(), int64(1)).Return(tt.head, nil).Times(2)
+				repo.EXPECT().FindChainRows(gomock.Any(), int64(1), int64(2), 10).Return([]*models.ChainRow{}, nil)
+			}
+
+			verification, err := u.Verify(context.Background(), 1)
+			require.NoError(t, err)
+			require.False(t, verification.Valid)
+			require.Equal(t, tt.reason, verification.Break.Reason)
+			require.Equal(t, tt.seq, verification.Break.Seq)
+		})
+	}
+}
+
+func TestLedgerUC_Verify_BadSignature(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockRepository(ctrl)
+	u := newTestUseCase(t, repo)
+
+	rows := chain(1, 1)
+	repo.EXPECT().FindChainRows(gomock.Any(), int64(1), int64(0), 2).Return(rows, nil)
+	repo.EXPECT().GetChainHead(gomock.Any(), int64(1)).Return(headOf(rows), nil)
+
+	// counts edited after signing
+	checkpoint := &models.LedgerCheckpoint{ID: 2, KeyID: u.keyID, CreatedAt: testNow}
+	checkpoint.Summarize([]models.ChainHead{*headOf(rows)})
+	checkpoint.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(u.privateKey, checkpoint.Message()))
+	checkpoint.RowCount = 5
+
+	repo.EXPECT().LatestCheckpoint(gomock.Any()).Return(&models.LedgerCheckpoint{ID: 2}, nil)
+	repo.EXPECT().GetCheckpoint(gomock.Any(), int64(2)).Return(checkpoint, nil)
+
+	verification, err := u.Verify(context.Background(), 1)
+	require.NoError(t, err)
+	require.False(t, verification.Valid)
+	require.Equal(t, models.ChainBreakBadSignature, verification.Break.Reason)
+}
+
+func TestLedgerUC_CreateCheckpoint(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockRepository(ctrl)
+	u := newTestUseCase(t, repo)
+
+	// scheduled checkpoints keep half an interval away from the latest one
+	repo.EXPECT().LatestCheckpoint(gomock.Any()).Return(&models.LedgerCheckpoint{ID: 1, CreatedAt: testNow.Add(-20 * time.Minute)}, nil)
+	skipped, err := u.CreateCheckpoint(context.Background(), true)
+	require.NoError(t, err)
+	require.Nil(t, skipped)
+
+	heads := []models.ChainHead{*headOf(chain(1, 3)), *headOf(chain(4, 1))}
+	repo.EXPECT().ListChainHeads(gomock.Any()).Return(heads, nil)
+	repo.EXPECT().CreateCheckpoint(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, c *models.LedgerCheckpoint) (*models.LedgerCheckpoint, error) {
+			c.ID = 2
+			return c, nil
+		})
+
+	checkpoint, err := u.CreateCheckpoint(context.Background(), false)
+	require.NoError(t, err)
+	require.Equal(t, 2, checkpoint.WalletCount)
+	require.Equal(t, int64(4), checkpoint.RowCount)
+	require.Equal(t, testNow.Truncate(time.Microsecond), checkpoint.CreatedAt)
+
+	repo.EXPECT().GetCheckpoint(gomock.Any(), int64(2)).Return(checkpoint, nil)
+	export, err := u.ExportCheckpoint(context.Background(), 2)
+	require.NoError(t, err)
+
+	// the export alone is enough to check the signature
+	publicKey, err := base64.StdEncoding.DecodeString(export.PublicKey)
+	require.NoError(t, err)
+	signature, err := base64.StdEncoding.DecodeString(export.Checkpoint.Signature)
+	require.NoError(t, err)
+	require.True(t, ed25519.Verify(publicKey, []byte(export.Message), signature))
+
+	repo.EXPECT().GetCheckpoint(gomock.Any(), int64(3)).Return(&models.LedgerCheckpoint{ID: 3, KeyID: "rotated"}, nil)
+	_, err = u.ExportCheckpoint(context.Background(), 3)
+	require.ErrorIs(t, err, ledger.ErrCheckpointKeyUnknown)
+}
@@ -0,0 +1,10 @@
+package limits
+
+import "github.com/labstack/echo/v4"
+
+// Limits HTTP Handlers interface
+type Handlers interface {
+	CreateRule() echo.HandlerFunc
+	ListRules() echo.HandlerFunc
+	DeleteRule() echo.HandlerFunc
+}
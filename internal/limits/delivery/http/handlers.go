@@ -0,0 +1,103 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/opentracing/opentracing-go"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/dto"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/limits"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/httpErrors"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/logger"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/utils"
+)
+
+type limitsHandlers struct {
+	limitsUC limits.UseCase
+	logger   logger.Logger
+}
+
+func NewLimitsHandlers(limitsUC limits.UseCase, log logger.Logger) limits.Handlers {
+	return &limitsHandlers{limitsUC: limitsUC, logger: log}
+}
+
+// CreateRule godoc
+// @Summary Create limit rule
+// @Description Create a velocity limit for a currency and operation, optionally scoped to a user or role
+// @Tags Limits
+// @Accept json
+// @Produce json
+// @Param body body dto.RequestLimitRule true "rule"
+// @Success 201 {object} models.LimitRule
+// @Router /limits [post]
+func (h *limitsHandlers) CreateRule() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "limits.CreateRule")
+		defer span.Finish()
+
+		ruleRequest := &dto.RequestLimitRule{}
+		if err := utils.ReadRequest(c, ruleRequest); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		rule, err := h.limitsUC.CreateRule(ctx, ruleRequest)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusCreated, rule)
+	}
+}
+
+// ListRules godoc
+// @Summary List limit rules
+// @Description List every velocity limit rule
+// @Tags Limits
+// @Produce json
+// @Success 200 {array} models.LimitRule
+// @Router /limits [get]
+func (h *limitsHandlers) ListRules() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "limits.ListRules")
+		defer span.Finish()
+
+		rules, err := h.limitsUC.ListRules(ctx)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, rules)
+	}
+}
+
+// DeleteRule godoc
+// @Summary Delete limit rule
+// @Description Delete a velocity limit rule
+// @Tags Limits
+// @Param id path int true "rule_id"
+// @Success 204
+// @Router /limits/{id} [delete]
+func (h *limitsHandlers) DeleteRule() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "limits.DeleteRule")
+		defer span.Finish()
+
+		ruleID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(httpErrors.NewBadRequestError(err.Error())))
+		}
+
+		if err = h.limitsUC.DeleteRule(ctx, ruleID); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,21 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/config"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/auth"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/limits"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/middleware"
+)
+
+// Map limits routes, rules are managed by wallet administrators
+func MapLimitsRoutes(limitsGroup *echo.Group, h limits.Handlers, mw *middleware.MiddlewareManager, rbacMw *middleware.RBACMiddleware, authUc auth.UseCase, cfg *config.Config) {
+	limitsGroup.Use(mw.AuthJWTMiddleware(authUc, cfg))
+	limitsGroup.Use(mw.AuthSessionMiddleware)
+	limitsGroup.Use(rbacMw.RequirePermission("manage", "wallets", nil))
+
+	limitsGroup.GET("", h.ListRules())
+	limitsGroup.POST("", h.CreateRule())
+	limitsGroup.DELETE("/:id", h.DeleteRule())
+}
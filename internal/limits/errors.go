@@ -0,0 +1,13 @@
+package limits
+
+import (
+	"net/http"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/httpErrors"
+)
+
+// Limits domain errors
+var (
+	ErrRuleNotFound = httpErrors.NewRestError(http.StatusNotFound, "Limit rule not found", nil)
+	ErrInvalidRule  = httpErrors.NewRestError(http.StatusBadRequest, "Limit rule may target a user or a role, not both", nil)
+)
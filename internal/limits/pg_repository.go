@@ -0,0 +1,16 @@
+//go:generate mockgen -source pg_repository.go -destination mock/pg_repository_mock.go -package mock
+package limits
+
+import (
+	"context"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+)
+
+// Limits repository interface
+type Repository interface {
+	CreateRule(ctx context.Context, rule *models.LimitRule) (*models.LimitRule, error)
+	ListRules(ctx context.Context) ([]*models.LimitRule, error)
+	DeleteRule(ctx context.Context, ruleID int64) error
+	FindRules(ctx context.Context, currency, operation string) ([]models.LimitRule, error)
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/limits"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+)
+
+// Limits Repository
+type limitsRepo struct {
+	db *sqlx.DB
+}
+
+// Limits Repository constructor
+func NewLimitsRepository(db *sqlx.DB) limits.Repository {
+	return &limitsRepo{db: db}
+}
+
+// Create limit rule
+func (r *limitsRepo) CreateRule(ctx context.Context, rule *models.LimitRule) (*models.LimitRule, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "limitsRepo.CreateRule")
+	defer span.Finish()
+
+	created := &models.LimitRule{}
+	if err := r.db.QueryRowxContext(
+		ctx,
+		createRuleQuery,
+		rule.Currency,
+		rule.Operation,
+		rule.Period,
+		rule.Aggregate,
+		rule.MaxAmount,
+		rule.UserID,
+		rule.RoleID,
+	).StructScan(created); err != nil {
+		return nil, errors.Wrap(err, "limitsRepo.CreateRule.StructScan")
+	}
+
+	return created, nil
+}
+
+// List all limit rules
+func (r *limitsRepo) ListRules(ctx context.Context) ([]*models.LimitRule, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "limitsRepo.ListRules")
+	defer span.Finish()
+
+	rules := make([]*models.LimitRule, 0)
+	if err := r.db.SelectContext(ctx, &rules, listRulesQuery); err != nil {
+		return nil, errors.Wrap(err, "limitsRepo.ListRules.SelectContext")
+	}
+
+	return rules, nil
+}
+
+// Delete limit rule
+func (r *limitsRepo) DeleteRule(ctx context.Context, ruleID int64) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "limitsRepo.DeleteRule")
+	defer span.Finish()
+
+	result, err := r.db.ExecContext(ctx, deleteRuleQuery, ruleID)
+	if err != nil {
+		return errors.Wrap(err, "limitsRepo.DeleteRule.ExecContext")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "limitsRepo.DeleteRule.RowsAffected")
+	}
+	if rowsAffected == 0 {
+		return limits.ErrRuleNotFound
+	}
+
+	return nil
+}
+
+// Find every rule of a currency and operation, across all scopes
+func (r *limitsRepo) FindRules(ctx context.Context, currency, operation string) ([]models.LimitRule, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "limitsRepo.FindRules")
+	defer span.Finish()
+
+	rules := make([]models.LimitRule, 0)
+	if err := r.db.SelectContext(ctx, &rules, findRulesQuery, currency, operation); err != nil {
+		return nil, errors.Wrap(err, "limitsRepo.FindRules.SelectContext")
+	}
+
+	return rules, nil
+}
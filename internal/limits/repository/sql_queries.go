@@ -0,0 +1,12 @@
+package repository
+
+const (
+	createRuleQuery = `INSERT INTO limit_rules (currency, operation, period, aggregate, max_amount, user_id, role_id)
+						VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING *`
+
+	listRulesQuery = `SELECT * FROM limit_rules ORDER BY currency, operation, period, id`
+
+	deleteRuleQuery = `DELETE FROM limit_rules WHERE id = $1`
+
+	findRulesQuery = `SELECT * FROM limit_rules WHERE currency = $1 AND operation = $2 ORDER BY id`
+)
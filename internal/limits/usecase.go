@@ -0,0 +1,19 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase_mock.go -package mock
+package limits
+
+import (
+	"context"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/dto"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+)
+
+// Limits UseCase interface
+type UseCase interface {
+	CreateRule(ctx context.Context, dto *dto.RequestLimitRule) (*models.LimitRule, error)
+	ListRules(ctx context.Context) ([]*models.LimitRule, error)
+	DeleteRule(ctx context.Context, ruleID int64) error
+	Resolve(ctx context.Context, userID, walletID int64, operation, currency string, amount decimal.Decimal) (*models.LimitCheck, error)
+}
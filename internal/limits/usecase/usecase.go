@@ -0,0 +1,109 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/dto"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/limits"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/rbac"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/httpErrors"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/logger"
+)
+
+// Limits UseCase
+type limitsUC struct {
+	limitsRepo limits.Repository
+	rbac       rbac.RBACServiceInterface
+	logger     logger.Logger
+}
+
+// Limits UseCase constructor
+func NewLimitsUseCase(limitsRepo limits.Repository, rbacService rbac.RBACServiceInterface, log logger.Logger) limits.UseCase {
+	return &limitsUC{limitsRepo: limitsRepo, rbac: rbacService, logger: log}
+}
+
+// Create limit rule, aggregate defaults to user
+func (u *limitsUC) CreateRule(ctx context.Context, dto *dto.RequestLimitRule) (*models.LimitRule, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "limitsUC.CreateRule")
+	defer span.Finish()
+
+	if dto.UserID != nil && dto.RoleID != nil {
+		return nil, limits.ErrInvalidRule
+	}
+
+	if dto.MaxAmount.IsNegative() {
+		return nil, httpErrors.NewBadRequestError("max_amount must be >= 0")
+	}
+
+	aggregate := dto.Aggregate
+	if aggregate == "" {
+		aggregate = models.LimitAggregateUser
+	}
+
+	return u.limitsRepo.CreateRule(ctx, &models.LimitRule{
+		Currency:  dto.Currency,
+		Operation: dto.Operation,
+		Period:    dto.Period,
+		Aggregate: aggregate,
+		MaxAmount: dto.MaxAmount,
+		UserID:    dto.UserID,
+		RoleID:    dto.RoleID,
+	})
+}
+
+// List limit rules
+func (u *limitsUC) ListRules(ctx context.Context) ([]*models.LimitRule, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "limitsUC.ListRules")
+	defer span.Finish()
+
+	return u.limitsRepo.ListRules(ctx)
+}
+
+// Delete limit rule
+func (u *limitsUC) DeleteRule(ctx context.Context, ruleID int64) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "limitsUC.DeleteRule")
+	defer span.Finish()
+
+	return u.limitsRepo.DeleteRule(ctx, ruleID)
+}
+
+// Resolve the rules that apply to a wallet owner for one money movement,
+// the returned check is enforced by the wallet repository inside the transaction
+func (u *limitsUC) Resolve(ctx context.Context, userID, walletID int64, operation, currency string, amount decimal.Decimal) (*models.LimitCheck, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "limitsUC.Resolve")
+	defer span.Finish()
+
+	rules, err := u.limitsRepo.FindRules(ctx, currency, operation)
+	if err != nil {
+		return nil, err
+	}
+
+	check := &models.LimitCheck{
+		UserID:    userID,
+		WalletID:  walletID,
+		Operation: operation,
+		Currency:  currency,
+		Amount:    amount,
+	}
+	if len(rules) == 0 {
+		return check, nil
+	}
+
+	roles, err := u.rbac.GetUserDirectRoles(int(userID))
+	if err != nil {
+		return nil, errors.Wrap(err, "limitsUC.Resolve.GetUserDirectRoles")
+	}
+
+	roleIDs := make([]int64, 0, len(roles))
+	for _, role := range roles {
+		roleIDs = append(roleIDs, int64(role.ID))
+	}
+
+	check.Rules = models.EffectiveLimitRules(rules, userID, roleIDs)
+	return check, nil
+}
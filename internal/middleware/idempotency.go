@@ -0,0 +1,138 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/idempotency"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/httpErrors"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/logger"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/utils"
+)
+
+const (
+	IdempotencyKeyHeader      = "Idempotency-Key"
+	IdempotencyReplayedHeader = "Idempotent-Replayed"
+
+	idempotencyPrefix     = "api-idempotency:"
+	idempotencyLockTTL    = time.Minute
+	idempotencyRecordTTL  = 24 * time.Hour
+	idempotencyMaxKeySize = 255
+)
+
+// IdempotencyMiddleware replays the first response of requests sent with the same Idempotency-Key
+type IdempotencyMiddleware struct {
+	repo   idempotency.RedisRepository
+	logger logger.Logger
+}
+
+// NewIdempotencyMiddleware creates a new idempotency middleware instance
+func NewIdempotencyMiddleware(repo idempotency.RedisRepository, logger logger.Logger) *IdempotencyMiddleware {
+	return &IdempotencyMiddleware{repo: repo, logger: logger}
+}
+
+// Idempotent middleware, requests without the header pass through unchanged.
+// Keys are scoped per user, a key reused with another payload or while the
+// first request is still running returns 409.
+func (m *IdempotencyMiddleware) Idempotent(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		key := c.Request().Header.Get(IdempotencyKeyHeader)
+		if key == "" {
+			return next(c)
+		}
+		if len(key) > idempotencyMaxKeySize {
+			return c.JSON(http.StatusBadRequest, httpErrors.NewBadRequestError("Idempotency-Key is too long"))
+		}
+
+		user, ok := c.Get("user").(*models.UserWithRole)
+		if !ok {
+			return c.JSON(http.StatusUnauthorized, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+		}
+
+		body, err := io.ReadAll(c.Request().Body)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, httpErrors.NewBadRequestError(err.Error()))
+		}
+		c.Request().Body = io.NopCloser(bytes.NewReader(body))
+
+		ctx := c.Request().Context()
+		storeKey := idempotencyPrefix + strconv.Itoa(user.User.ID) + ":" + key
+		fingerprint := requestFingerprint(c.Request().Method, c.Request().URL.Path, body)
+
+		reserved, err := m.repo.Reserve(ctx, storeKey, &models.IdempotencyRecord{
+			Fingerprint: fingerprint,
+			Status:      models.IdempotencyInProgress,
+		}, idempotencyLockTTL)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, m.logger, err)
+		}
+
+		if !reserved {
+			record, err := m.repo.Get(ctx, storeKey)
+			if err != nil {
+				return utils.ErrResponseWithLog(c, m.logger, err)
+			}
+
+			if record.Fingerprint != fingerprint {
+				return c.JSON(http.StatusConflict, httpErrors.NewRestError(http.StatusConflict, "Idempotency-Key reused with a different payload", nil))
+			}
+			if record.Status != models.IdempotencyCompleted {
+				return c.JSON(http.StatusConflict, httpErrors.NewRestError(http.StatusConflict, "Request with this Idempotency-Key is in progress", nil))
+			}
+
+			m.logger.Infof("Idempotent replay RequestID: %s, UserID: %d, Key: %s", utils.GetRequestID(c), user.User.ID, key)
+			c.Response().Header().Set(IdempotencyReplayedHeader, "true")
+			return c.Blob(record.StatusCode, record.ContentType, record.Body)
+		}
+
+		recorder := &bodyRecorder{ResponseWriter: c.Response().Writer}
+		c.Response().Writer = recorder
+
+		if err = next(c); err != nil || c.Response().Status >= http.StatusInternalServerError {
+			// let the client retry failed requests with the same key
+			if delErr := m.repo.Delete(ctx, storeKey); delErr != nil {
+				m.logger.Errorf("Idempotency Delete RequestID: %s, Error: %s", utils.GetRequestID(c), delErr)
+			}
+			return err
+		}
+
+		if err = m.repo.Save(ctx, storeKey, &models.IdempotencyRecord{
+			Fingerprint: fingerprint,
+			Status:      models.IdempotencyCompleted,
+			StatusCode:  c.Response().Status,
+			ContentType: c.Response().Header().Get(echo.HeaderContentType),
+			Body:        recorder.body.Bytes(),
+		}, idempotencyRecordTTL); err != nil {
+			m.logger.Errorf("Idempotency Save RequestID: %s, Error: %s", utils.GetRequestID(c), err)
+		}
+
+		return nil
+	}
+}
+
+// Hash of method, path and raw body identifying the request payload
+func requestFingerprint(method, path string, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(method + " " + path + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// Response writer keeping a copy of the written body
+type bodyRecorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *bodyRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
@@ -0,0 +1,119 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/config"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/idempotency/mock"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/logger"
+)
+
+func newTestLogger() logger.Logger {
+	l := logger.NewApiLogger(&config.Config{Logger: config.Logger{Level: "error", Encoding: "console"}})
+	l.InitLogger()
+	return l
+}
+
+func newIdempotentContext(body string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/wallets/transfer", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set(IdempotencyKeyHeader, "key-1")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user", &models.UserWithRole{User: models.User{ID: 7}})
+	return c, rec
+}
+
+func TestIdempotencyMiddleware_FirstRequestIsSaved(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockRedisRepository(ctrl)
+	mw := NewIdempotencyMiddleware(repo, newTestLogger())
+
+	body := `{"amount":10}`
+	c, rec := newIdempotentContext(body)
+	storeKey := idempotencyPrefix + "7:key-1"
+
+	repo.EXPECT().Reserve(gomock.Any(), storeKey, gomock.Any(), idempotencyLockTTL).Return(true, nil)
+	repo.EXPECT().Save(gomock.Any(), storeKey, gomock.Any(), idempotencyRecordTTL).
+		DoAndReturn(func(_ interface{}, _ string, record *models.IdempotencyRecord, _ interface{}) error {
+			require.Equal(t, models.IdempotencyCompleted, record.Status)
+			require.Equal(t, http.StatusOK, record.StatusCode)
+			require.JSONEq(t, `{"ok":true}`, string(record.Body))
+			return nil
+		})
+
+	err := mw.Idempotent(func(c echo.Context) error {
+		return c.JSON(http.StatusOK, map[string]bool{"ok": true})
+	})(c)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestIdempotencyMiddleware_ReplaysCompletedRequest(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockRedisRepository(ctrl)
+	mw := NewIdempotencyMiddleware(repo, newTestLogger())
+
+	body := `{"amount":10}`
+	c, rec := newIdempotentContext(body)
+
+	repo.EXPECT().Reserve(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+	repo.EXPECT().Get(gomock.Any(), gomock.Any()).Return(&models.IdempotencyRecord{
+		Fingerprint: requestFingerprint(http.MethodPost, "/api/v1/wallets/transfer", []byte(body)),
+		Status:      models.IdempotencyCompleted,
+		StatusCode:  http.StatusOK,
+		ContentType: echo.MIMEApplicationJSON,
+		Body:        []byte(`{"ok":true}`),
+	}, nil)
+
+	err := mw.Idempotent(func(c echo.Context) error {
+		t.Fatal("handler must not run on replay")
+		return nil
+	})(c)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "true", rec.Header().Get(IdempotencyReplayedHeader))
+	require.JSONEq(t, `{"ok":true}`, rec.Body.String())
+}
+
+func TestIdempotencyMiddleware_ConflictOnDifferentPayload(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockRedisRepository(ctrl)
+	mw := NewIdempotencyMiddleware(repo, newTestLogger())
+
+	c, rec := newIdempotentContext(`{"amount":20}`)
+
+	repo.EXPECT().Reserve(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+	repo.EXPECT().Get(gomock.Any(), gomock.Any()).Return(&models.IdempotencyRecord{
+		Fingerprint: requestFingerprint(http.MethodPost, "/api/v1/wallets/transfer", []byte(`{"amount":10}`)),
+		Status:      models.IdempotencyCompleted,
+	}, nil)
+
+	err := mw.Idempotent(func(c echo.Context) error {
+		t.Fatal("handler must not run on conflict")
+		return nil
+	})(c)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusConflict, rec.Code)
+}
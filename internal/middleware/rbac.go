@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
 	"github.com/aditwar-man/go-microservice-boilerplate/internal/rbac"
 	"github.com/aditwar-man/go-microservice-boilerplate/pkg/logger"
 	"github.com/aditwar-man/go-microservice-boilerplate/pkg/utils"
@@ -210,6 +211,10 @@ func (m *RBACMiddleware) getUserIDFromContext(c echo.Context) int {
 
 	// Try to get from user object
 	if user := c.Get("user"); user != nil {
+		// Set by AuthJWTMiddleware and AuthSessionMiddleware
+		if userObj, ok := user.(*models.UserWithRole); ok {
+			return userObj.User.ID
+		}
 		// Assuming user object has ID field
 		// You might need to adjust this based on your user struct
 		if userObj, ok := user.(map[string]interface{}); ok {
@@ -0,0 +1,99 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Adjustment directions
+const (
+	AdjustmentCredit = "credit"
+	AdjustmentDebit  = "debit"
+)
+
+// Adjustment states, only pending adjustments can be reviewed or cancelled
+const (
+	AdjustmentPending   = "pending"
+	AdjustmentApproved  = "approved"
+	AdjustmentRejected  = "rejected"
+	AdjustmentCancelled = "cancelled"
+)
+
+// Supporting document of an adjustment, stored where it was uploaded and
+// referenced by URL
+type AdjustmentAttachment struct {
+	Name string `json:"name" validate:"required,lte=200"`
+	URL  string `json:"url" validate:"required,url"`
+}
+
+// Attachments stored as a jsonb array
+type AdjustmentAttachments []AdjustmentAttachment
+
+// Value implements driver.Valuer
+func (a AdjustmentAttachments) Value() (driver.Value, error) {
+	if a == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(a)
+}
+
+// Scan implements sql.Scanner
+func (a *AdjustmentAttachments) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*a = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, a)
+	case string:
+		return json.Unmarshal([]byte(v), a)
+	default:
+		return errors.New("adjustment attachments: unsupported column type")
+	}
+}
+
+// Manual balance correction proposed by one admin and reviewed by another.
+// Only an approved adjustment posts a journal entry, EntryID is set then.
+type Adjustment struct {
+	ID          ID                    `json:"id" db:"id"`
+	WalletID    ID                    `json:"wallet_id" db:"wallet_id"`
+	Currency    string                `json:"currency" db:"currency"`
+	Direction   string                `json:"direction" db:"direction"`
+	Amount      decimal.Decimal       `json:"amount" db:"amount"`
+	Reason      string                `json:"reason" db:"reason"`
+	Attachments AdjustmentAttachments `json:"attachments" db:"attachments"`
+	Status      string                `json:"status" db:"status"`
+	RequestedBy int64                 `json:"requested_by" db:"requested_by"`
+	ReviewedBy  *int64                `json:"reviewed_by,omitempty" db:"reviewed_by"`
+	ReviewNote  *string               `json:"review_note,omitempty" db:"review_note"`
+	EntryID     *int64                `json:"entry_id,omitempty" db:"entry_id"`
+	CreatedAt   time.Time             `json:"created_at" db:"created_at"`
+	ReviewedAt  *time.Time            `json:"reviewed_at,omitempty" db:"reviewed_at"`
+}
+
+// Signed amount the adjustment moves on the wallet
+func (a *Adjustment) SignedAmount() decimal.Decimal {
+	if a.Direction == AdjustmentDebit {
+		return a.Amount.Neg()
+	}
+	return a.Amount
+}
+
+// Reference of the journal entry posted on approval, a system reference no
+// client can post first
+func (a *Adjustment) RefID() string {
+	return SystemRef("adjustment", strconv.FormatInt(a.ID, 10))
+}
+
+// Adjustment list filter, zero values match everything
+type AdjustmentFilter struct {
+	Status      string
+	WalletID    int64
+	RequestedBy int64
+	Limit       int
+}
@@ -0,0 +1,171 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Reasons a wallet chain fails verification
+const (
+	ChainBreakSequenceGap  = "sequence_gap"
+	ChainBreakPrevHash     = "prev_hash_mismatch"
+	ChainBreakHash         = "hash_mismatch"
+	ChainBreakHead         = "head_mismatch"
+	ChainBreakCheckpoint   = "checkpoint_mismatch"
+	ChainBreakBadSignature = "bad_signature"
+)
+
+const (
+	// scale of ledger amounts, NUMERIC(36,18)
+	chainAmountScale = 18
+	chainTimeLayout  = "2006-01-02T15:04:05.000000Z"
+	chainSeparator   = "\x1f"
+)
+
+// A txs row with its link in the wallet hash chain
+type ChainRow struct {
+	ID        int64           `db:"id"`
+	WalletID  int64           `db:"wallet_id"`
+	Seq       int64           `db:"seq"`
+	Type      string          `db:"type"`
+	Currency  string          `db:"currency"`
+	Amount    decimal.Decimal `db:"amount"`
+	RefID     *string         `db:"ref_id"`
+	EntryID   *int64          `db:"entry_id"`
+	Meta      *string         `db:"meta"`
+	CreatedAt time.Time       `db:"created_at"`
+	PrevHash  string          `db:"prev_hash"`
+	Hash      string          `db:"hash"`
+}
+
+// Hash of the row linked after prevHash, the txs_chain_hash SQL function
+// computes the same value when the row is written
+func (r *ChainRow) ComputeHash(prevHash string) string {
+	var ref, entry, meta string
+	if r.RefID != nil {
+		ref = *r.RefID
+	}
+	if r.EntryID != nil {
+		entry = strconv.FormatInt(*r.EntryID, 10)
+	}
+	if r.Meta != nil {
+		meta = *r.Meta
+	}
+
+	payload := strings.Join([]string{
+		prevHash,
+		strconv.FormatInt(r.ID, 10),
+		strconv.FormatInt(r.WalletID, 10),
+		strconv.FormatInt(r.Seq, 10),
+		r.Type,
+		r.Currency,
+		r.Amount.StringFixed(chainAmountScale),
+		ref,
+		entry,
+		meta,
+		r.CreatedAt.UTC().Format(chainTimeLayout),
+	}, chainSeparator)
+
+	sum := sha256.Sum256([]byte(payload))
+	return hex.EncodeToString(sum[:])
+}
+
+// Check the row follows the link (prevSeq, prevHash), nil when it does
+func (r *ChainRow) Check(prevSeq int64, prevHash string) *ChainBreak {
+	txID := r.ID
+	switch {
+	case r.Seq != prevSeq+1:
+		return &ChainBreak{WalletID: r.WalletID, TxID: &txID, Seq: r.Seq, Reason: ChainBreakSequenceGap,
+			Expected: strconv.FormatInt(prevSeq+1, 10), Actual: strconv.FormatInt(r.Seq, 10)}
+	case r.PrevHash != prevHash:
+		return &ChainBreak{WalletID: r.WalletID, TxID: &txID, Seq: r.Seq, Reason: ChainBreakPrevHash,
+			Expected: prevHash, Actual: r.PrevHash}
+	}
+
+	if hash := r.ComputeHash(prevHash); hash != r.Hash {
+		return &ChainBreak{WalletID: r.WalletID, TxID: &txID, Seq: r.Seq, Reason: ChainBreakHash,
+			Expected: hash, Actual: r.Hash}
+	}
+
+	return nil
+}
+
+// Last link of a wallet chain
+type ChainHead struct {
+	WalletID int64  `json:"wallet_id" db:"wallet_id"`
+	Seq      int64  `json:"seq" db:"seq"`
+	Hash     string `json:"hash" db:"hash"`
+}
+
+// First link that failed verification, Expected and Actual are the values
+// that disagree
+type ChainBreak struct {
+	WalletID int64  `json:"wallet_id"`
+	TxID     *int64 `json:"tx_id,omitempty"`
+	Seq      int64  `json:"seq"`
+	Reason   string `json:"reason"`
+	Expected string `json:"expected"`
+	Actual   string `json:"actual"`
+}
+
+// Outcome of walking the wallet chains and checking the latest checkpoint
+type ChainVerification struct {
+	Valid          bool        `json:"valid"`
+	WalletsChecked int         `json:"wallets_checked"`
+	RowsChecked    int64       `json:"rows_checked"`
+	CheckpointID   *int64      `json:"checkpoint_id,omitempty"`
+	Break          *ChainBreak `json:"break,omitempty"`
+	VerifiedAt     time.Time   `json:"verified_at"`
+}
+
+// Signed snapshot of every wallet chain head
+type LedgerCheckpoint struct {
+	ID          int64       `json:"id" db:"id"`
+	WalletCount int         `json:"wallet_count" db:"wallet_count"`
+	RowCount    int64       `json:"row_count" db:"row_count"`
+	RootHash    string      `json:"root_hash" db:"root_hash"`
+	KeyID       string      `json:"key_id" db:"key_id"`
+	Signature   string      `json:"signature" db:"signature"`
+	CreatedAt   time.Time   `json:"created_at" db:"created_at"`
+	Heads       []ChainHead `json:"heads,omitempty" db:"-"`
+}
+
+// Set the counts and root hash from heads in wallet order
+func (c *LedgerCheckpoint) Summarize(heads []ChainHead) {
+	c.Heads = heads
+	c.WalletCount = len(heads)
+	c.RowCount = 0
+	for _, h := range heads {
+		c.RowCount += h.Seq
+	}
+	c.RootHash = ChainRoot(heads)
+}
+
+// Bytes covered by the checkpoint signature
+func (c *LedgerCheckpoint) Message() []byte {
+	return []byte(fmt.Sprintf("ledger-checkpoint\n%s\n%d\n%d\n%s",
+		c.CreatedAt.UTC().Format(chainTimeLayout), c.WalletCount, c.RowCount, c.RootHash))
+}
+
+// Exported checkpoint with what an auditor needs to check the signature
+type CheckpointExport struct {
+	Algorithm  string            `json:"algorithm"`
+	PublicKey  string            `json:"public_key"`
+	Message    string            `json:"message"`
+	Checkpoint *LedgerCheckpoint `json:"checkpoint"`
+}
+
+// Hex sha256 over "wallet_id:seq:hash" lines of heads in wallet order
+func ChainRoot(heads []ChainHead) string {
+	h := sha256.New()
+	for _, head := range heads {
+		fmt.Fprintf(h, "%d:%d:%s\n", head.WalletID, head.Seq, head.Hash)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,94 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func chainRows(n int) []*ChainRow {
+	ref := "dep-1-deposit"
+	entryID := int64(40)
+	created := time.Date(2026, 5, 1, 9, 0, 0, 123456000, time.FixedZone("WIB", 7*3600))
+
+	rows := make([]*ChainRow, 0, n)
+	prevHash := ""
+	for i := 1; i <= n; i++ {
+		r := &ChainRow{
+			ID:        int64(100 + i),
+			WalletID:  7,
+			Seq:       int64(i),
+			Type:      TypeDeposit,
+			Currency:  "USD",
+			Amount:    decimal.RequireFromString("100.5"),
+			RefID:     &ref,
+			EntryID:   &entryID,
+			CreatedAt: created.Add(time.Duration(i) * time.Minute),
+			PrevHash:  prevHash,
+		}
+		r.Hash = r.ComputeHash(prevHash)
+		prevHash = r.Hash
+		rows = append(rows, r)
+	}
+	return rows
+}
+
+func TestChainRow_ComputeHash(t *testing.T) {
+	t.Parallel()
+
+	// the payload has to match the txs_chain_hash SQL function field for field
+	r := chainRows(1)[0]
+	payload := "\x1f101\x1f7\x1f1\x1fdeposit\x1fUSD\x1f100.500000000000000000\x1fdep-1-deposit\x1f40\x1f\x1f2026-05-01T02:01:00.123456Z"
+	sum := sha256.Sum256([]byte(payload))
+	require.Equal(t, hex.EncodeToString(sum[:]), r.Hash)
+}
+
+func TestChainRow_Check(t *testing.T) {
+	t.Parallel()
+
+	rows := chainRows(3)
+	prevSeq, prevHash := int64(0), ""
+	for _, r := range rows {
+		require.Nil(t, r.Check(prevSeq, prevHash))
+		prevSeq, prevHash = r.Seq, r.Hash
+	}
+
+	// an edited amount no longer matches its hash
+	edited := *rows[1]
+	edited.Amount = decimal.RequireFromString("1000.5")
+	brk := edited.Check(rows[0].Seq, rows[0].Hash)
+	require.NotNil(t, brk)
+	require.Equal(t, ChainBreakHash, brk.Reason)
+	require.Equal(t, int64(102), *brk.TxID)
+
+	// a deleted row leaves a gap in seq
+	brk = rows[2].Check(rows[0].Seq, rows[0].Hash)
+	require.NotNil(t, brk)
+	require.Equal(t, ChainBreakSequenceGap, brk.Reason)
+	require.Equal(t, "2", brk.Expected)
+
+	// a deleted and renumbered row breaks the prev_hash link
+	renumbered := *rows[2]
+	renumbered.Seq = 2
+	brk = renumbered.Check(rows[0].Seq, rows[0].Hash)
+	require.NotNil(t, brk)
+	require.Equal(t, ChainBreakPrevHash, brk.Reason)
+}
+
+func TestLedgerCheckpoint_Summarize(t *testing.T) {
+	t.Parallel()
+
+	heads := []ChainHead{{WalletID: 1, Seq: 4, Hash: "aa"}, {WalletID: 3, Seq: 2, Hash: "bb"}}
+	c := &LedgerCheckpoint{CreatedAt: time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)}
+	c.Summarize(heads)
+
+	sum := sha256.Sum256([]byte("1:4:aa\n3:2:bb\n"))
+	require.Equal(t, 2, c.WalletCount)
+	require.Equal(t, int64(6), c.RowCount)
+	require.Equal(t, hex.EncodeToString(sum[:]), c.RootHash)
+	require.Equal(t, "ledger-checkpoint\n2026-05-01T00:00:00.000000Z\n2\n6\n"+c.RootHash, string(c.Message()))
+}
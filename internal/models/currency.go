@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Rounding modes applied when an amount is brought to a currency's minor unit
+const (
+	RoundingHalfEven = "half_even"
+	RoundingHalfUp   = "half_up"
+	RoundingDown     = "down"
+)
+
+// Currency registry entry, Exponent is the number of minor unit digits (USD 2, JPY 0)
+type Currency struct {
+	Code         string    `json:"code" db:"code"`
+	Name         string    `json:"name" db:"name"`
+	Exponent     int32     `json:"exponent" db:"exponent"`
+	Enabled      bool      `json:"enabled" db:"enabled"`
+	RoundingMode string    `json:"rounding_mode" db:"rounding_mode"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+}
+
+// Round amount to the currency minor unit using its rounding mode
+func (c *Currency) Round(amount decimal.Decimal) decimal.Decimal {
+	switch c.RoundingMode {
+	case RoundingHalfUp:
+		return amount.Round(c.Exponent)
+	case RoundingDown:
+		return amount.Truncate(c.Exponent)
+	default:
+		return amount.RoundBank(c.Exponent)
+	}
+}
+
+// Amount has no digits below the currency minor unit
+func (c *Currency) Fits(amount decimal.Decimal) bool {
+	return amount.Equal(amount.Truncate(c.Exponent))
+}
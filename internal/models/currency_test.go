@@ -0,0 +1,34 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCurrency_Round(t *testing.T) {
+	t.Parallel()
+
+	usd := &Currency{Code: "USD", Exponent: 2, RoundingMode: RoundingHalfEven}
+	require.Equal(t, "0.12", usd.Round(decimal.RequireFromString("0.125")).String())
+	require.Equal(t, "0.14", usd.Round(decimal.RequireFromString("0.135")).String())
+
+	jpy := &Currency{Code: "JPY", Exponent: 0, RoundingMode: RoundingHalfUp}
+	require.Equal(t, "148", jpy.Round(decimal.RequireFromString("147.5")).String())
+
+	idr := &Currency{Code: "IDR", Exponent: 2, RoundingMode: RoundingDown}
+	require.Equal(t, "16000.99", idr.Round(decimal.RequireFromString("16000.999")).String())
+}
+
+func TestCurrency_Fits(t *testing.T) {
+	t.Parallel()
+
+	jpy := &Currency{Code: "JPY", Exponent: 0}
+	require.True(t, jpy.Fits(decimal.RequireFromString("1500")))
+	require.False(t, jpy.Fits(decimal.RequireFromString("1500.5")))
+
+	usd := &Currency{Code: "USD", Exponent: 2}
+	require.True(t, usd.Fits(decimal.RequireFromString("10.10")))
+	require.False(t, usd.Fits(decimal.RequireFromString("10.101")))
+}
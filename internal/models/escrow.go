@@ -0,0 +1,79 @@
+package models
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Escrow states
+const (
+	EscrowStatusHeld     = "held"
+	EscrowStatusReleased = "released"
+	EscrowStatusRefunded = "refunded"
+	EscrowStatusDisputed = "disputed"
+)
+
+// Allowed escrow transitions, keyed by target state. A disputed escrow only
+// leaves the dispute through an admin resolution.
+var escrowTransitions = map[string][]string{
+	EscrowStatusReleased: {EscrowStatusHeld, EscrowStatusDisputed},
+	EscrowStatusRefunded: {EscrowStatusHeld, EscrowStatusDisputed},
+	EscrowStatusDisputed: {EscrowStatusHeld},
+}
+
+// Conditional payment from a buyer to a seller wallet. The funds leave the
+// buyer on creation and are held on the escrow ledger account until released
+// to the seller or refunded to the buyer.
+type Escrow struct {
+	ID             ID              `json:"id" db:"id"`
+	RefID          string          `json:"ref_id" db:"ref_id"`
+	BuyerWalletID  ID              `json:"buyer_wallet_id" db:"buyer_wallet_id"`
+	SellerWalletID ID              `json:"seller_wallet_id" db:"seller_wallet_id"`
+	Currency       string          `json:"currency" db:"currency"`
+	Amount         decimal.Decimal `json:"amount" db:"amount"`
+	Status         string          `json:"status" db:"status"`
+	Description    string          `json:"description" db:"description"`
+	// held escrows are released to the seller automatically from this time
+	ReleaseAt     *time.Time `json:"release_at,omitempty" db:"release_at"`
+	DisputeReason *string    `json:"dispute_reason,omitempty" db:"dispute_reason"`
+	CreatedBy     *int64     `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// Escrow holds the same amount between the same wallets as o, what a
+// replayed reference has to match
+func (e *Escrow) SameTerms(o *Escrow) bool {
+	return e.BuyerWalletID == o.BuyerWalletID && e.SellerWalletID == o.SellerWalletID &&
+		e.Currency == o.Currency && e.Amount.Equal(o.Amount)
+}
+
+// Escrow can move from its current state to status
+func (e *Escrow) CanTransition(status string) bool {
+	for _, from := range escrowTransitions[status] {
+		if e.Status == from {
+			return true
+		}
+	}
+	return false
+}
+
+// Ledger account holding the funds of an escrow
+func EscrowAccount(escrowID ID) string {
+	return "escrow:" + strconv.FormatInt(escrowID, 10)
+}
+
+// Audit record of an escrow state change
+type EscrowEvent struct {
+	ID         ID     `json:"id" db:"id"`
+	EscrowID   ID     `json:"escrow_id" db:"escrow_id"`
+	FromStatus string `json:"from_status" db:"from_status"`
+	ToStatus   string `json:"to_status" db:"to_status"`
+	Reason     string `json:"reason" db:"reason"`
+	ActorID    *int64 `json:"actor_id,omitempty" db:"actor_id"`
+	// journal entry of the funds movement, nil for a dispute
+	EntryID   *int64    `json:"entry_id,omitempty" db:"entry_id"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
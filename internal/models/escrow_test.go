@@ -0,0 +1,37 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEscrow_CanTransition(t *testing.T) {
+	t.Parallel()
+
+	e := &Escrow{Status: EscrowStatusHeld}
+	require.True(t, e.CanTransition(EscrowStatusReleased))
+	require.True(t, e.CanTransition(EscrowStatusRefunded))
+	require.True(t, e.CanTransition(EscrowStatusDisputed))
+
+	e.Status = EscrowStatusDisputed
+	require.True(t, e.CanTransition(EscrowStatusReleased))
+	require.True(t, e.CanTransition(EscrowStatusRefunded))
+	require.False(t, e.CanTransition(EscrowStatusHeld))
+
+	e.Status = EscrowStatusReleased
+	require.False(t, e.CanTransition(EscrowStatusRefunded))
+	require.False(t, e.CanTransition(EscrowStatusDisputed))
+}
+
+func TestEscrow_SameTerms(t *testing.T) {
+	t.Parallel()
+
+	e := &Escrow{BuyerWalletID: 1, SellerWalletID: 2, Currency: "USD", Amount: decimal.NewFromInt(25)}
+	require.True(t, e.SameTerms(&Escrow{BuyerWalletID: 1, SellerWalletID: 2, Currency: "USD", Amount: decimal.RequireFromString("25.00")}))
+	require.False(t, e.SameTerms(&Escrow{BuyerWalletID: 3, SellerWalletID: 2, Currency: "USD", Amount: decimal.NewFromInt(25)}))
+	require.False(t, e.SameTerms(&Escrow{BuyerWalletID: 1, SellerWalletID: 3, Currency: "USD", Amount: decimal.NewFromInt(25)}))
+	require.False(t, e.SameTerms(&Escrow{BuyerWalletID: 1, SellerWalletID: 2, Currency: "EUR", Amount: decimal.NewFromInt(25)}))
+	require.False(t, e.SameTerms(&Escrow{BuyerWalletID: 1, SellerWalletID: 2, Currency: "USD", Amount: decimal.NewFromInt(30)}))
+}
@@ -0,0 +1,133 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Transaction types a fee schedule applies to
+const (
+	FeeTxTypeTransfer = "transfer"
+	FeeTxTypeFX       = "fx"
+)
+
+var percentDivisor = decimal.NewFromInt(100)
+
+// Fee band of a tiered schedule, applies to amounts up to UpTo (inclusive),
+// the last tier may leave UpTo empty to cover everything above
+type FeeTier struct {
+	UpTo    *decimal.Decimal `json:"up_to,omitempty"`
+	Flat    decimal.Decimal  `json:"flat"`
+	Percent decimal.Decimal  `json:"percent"`
+}
+
+// Tiers stored as a jsonb column
+type FeeTiers []FeeTier
+
+// Value implements driver.Valuer
+func (t FeeTiers) Value() (driver.Value, error) {
+	if len(t) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(t)
+}
+
+// Scan implements sql.Scanner
+func (t *FeeTiers) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*t = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, t)
+	case string:
+		return json.Unmarshal([]byte(v), t)
+	default:
+		return errors.New("fee tiers: unsupported column type")
+	}
+}
+
+// Fee schedule charged in the source currency. Without tiers the fee is
+// Flat plus Percent of the amount, a matching tier replaces both. The result
+// is then clamped to MinFee and MaxFee when set.
+type FeeSchedule struct {
+	ID           ID               `json:"id" db:"id"`
+	TxType       string           `json:"tx_type" db:"tx_type"`
+	FromCurrency string           `json:"from_currency" db:"from_currency"`
+	ToCurrency   *string          `json:"to_currency,omitempty" db:"to_currency"`
+	RoleID       *int64           `json:"role_id,omitempty" db:"role_id"`
+	Flat         decimal.Decimal  `json:"flat" db:"flat"`
+	Percent      decimal.Decimal  `json:"percent" db:"percent"`
+	Tiers        FeeTiers         `json:"tiers,omitempty" db:"tiers"`
+	MinFee       *decimal.Decimal `json:"min_fee,omitempty" db:"min_fee"`
+	MaxFee       *decimal.Decimal `json:"max_fee,omitempty" db:"max_fee"`
+	CreatedAt    time.Time        `json:"created_at" db:"created_at"`
+}
+
+// Unrounded fee for amount
+func (s *FeeSchedule) Compute(amount decimal.Decimal) decimal.Decimal {
+	flat, percent := s.Flat, s.Percent
+	for _, tier := range s.Tiers {
+		if tier.UpTo == nil || amount.LessThanOrEqual(*tier.UpTo) {
+			flat, percent = tier.Flat, tier.Percent
+			break
+		}
+	}
+
+	fee := flat.Add(amount.Mul(percent).Div(percentDivisor))
+	if s.MinFee != nil && fee.LessThan(*s.MinFee) {
+		fee = *s.MinFee
+	}
+	if s.MaxFee != nil && fee.GreaterThan(*s.MaxFee) {
+		fee = *s.MaxFee
+	}
+
+	return fee
+}
+
+// Pick the most specific schedule for a currency pair and the roles of the user:
+// a role schedule beats a default one, an exact target currency beats a wildcard,
+// ties go to the earliest schedule
+func SelectFeeSchedule(schedules []FeeSchedule, toCurrency string, roleIDs []int64) *FeeSchedule {
+	roles := make(map[int64]struct{}, len(roleIDs))
+	for _, id := range roleIDs {
+		roles[id] = struct{}{}
+	}
+
+	var best *FeeSchedule
+	bestScore := -1
+	for i := range schedules {
+		s := &schedules[i]
+
+		score := 0
+		if s.RoleID != nil {
+			if _, ok := roles[*s.RoleID]; !ok {
+				continue
+			}
+			score += 2
+		}
+		if s.ToCurrency != nil {
+			if *s.ToCurrency != toCurrency {
+				continue
+			}
+			score++
+		}
+
+		if score > bestScore {
+			best, bestScore = s, score
+		}
+	}
+
+	return best
+}
+
+// Fee charged on one transfer
+type Fee struct {
+	ScheduleID *int64          `json:"schedule_id,omitempty"`
+	Currency   string          `json:"currency"`
+	Amount     decimal.Decimal `json:"amount"`
+}
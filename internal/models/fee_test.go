@@ -0,0 +1,50 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeeSchedule_Compute(t *testing.T) {
+	t.Parallel()
+
+	d := decimal.RequireFromString
+	ptr := func(s string) *decimal.Decimal { v := d(s); return &v }
+
+	flat := FeeSchedule{Flat: d("1"), Percent: d("1.5")}
+	require.Equal(t, "2.5", flat.Compute(d("100")).String())
+
+	clamped := FeeSchedule{Percent: d("1"), MinFee: ptr("0.5"), MaxFee: ptr("10")}
+	require.Equal(t, "0.5", clamped.Compute(d("10")).String())
+	require.Equal(t, "10", clamped.Compute(d("5000")).String())
+
+	tiered := FeeSchedule{Tiers: FeeTiers{
+		{UpTo: ptr("100"), Flat: d("1")},
+		{UpTo: ptr("1000"), Percent: d("0.5")},
+		{Percent: d("0.25")},
+	}}
+	require.Equal(t, "1", tiered.Compute(d("100")).String())
+	require.Equal(t, "2.5", tiered.Compute(d("500")).String())
+	require.Equal(t, "5", tiered.Compute(d("2000")).String())
+}
+
+func TestSelectFeeSchedule(t *testing.T) {
+	t.Parallel()
+
+	idr, eur := "IDR", "EUR"
+	vip := int64(3)
+
+	schedules := []FeeSchedule{
+		{ID: 1},
+		{ID: 2, ToCurrency: &idr},
+		{ID: 3, ToCurrency: &eur},
+		{ID: 4, RoleID: &vip},
+	}
+
+	require.Equal(t, ID(2), SelectFeeSchedule(schedules, "IDR", nil).ID)
+	require.Equal(t, ID(1), SelectFeeSchedule(schedules, "JPY", nil).ID)
+	require.Equal(t, ID(4), SelectFeeSchedule(schedules, "IDR", []int64{vip}).ID)
+	require.Nil(t, SelectFeeSchedule(schedules[2:3], "IDR", nil))
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Exchange rate of one unit of Base in Quote currency
+type FXRate struct {
+	Base        string          `json:"base" db:"base"`
+	Quote       string          `json:"quote" db:"quote"`
+	Rate        decimal.Decimal `json:"rate" db:"rate"`
+	EffectiveAt time.Time       `json:"effective_at" db:"effective_at"`
+}
+
+// Locked rate a transfer can reference until it expires
+type FXQuote struct {
+	ID              string          `json:"id" db:"id"`
+	UserID          int             `json:"user_id" db:"user_id"`
+	FromCurrency    string          `json:"from_currency" db:"from_currency"`
+	ToCurrency      string          `json:"to_currency" db:"to_currency"`
+	Rate            decimal.Decimal `json:"rate" db:"rate"`
+	Amount          decimal.Decimal `json:"amount" db:"amount"`
+	ConvertedAmount decimal.Decimal `json:"converted_amount" db:"converted_amount"`
+	ExpiresAt       time.Time       `json:"expires_at" db:"expires_at"`
+	UsedAt          *time.Time      `json:"used_at,omitempty" db:"used_at"`
+	UsedRefID       *string         `json:"used_ref_id,omitempty" db:"used_ref_id"`
+	CreatedAt       time.Time       `json:"created_at" db:"created_at"`
+}
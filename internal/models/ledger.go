@@ -0,0 +1,135 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// System ledger accounts, wallet accounts are named wallet:<id>
+const (
+	AccountCashIn  = "system:cash_in"
+	AccountCashOut = "system:cash_out"
+	AccountFX      = "system:fx"
+	AccountFees    = "system:fees"
+	AccountOpening = "system:opening"
+
+	// suspense account holding the balance drift written off by reconciliation repairs
+	AccountReconciliation = "system:reconciliation"
+	// counterpart of approved manual balance adjustments
+	AccountAdjustments = "system:adjustments"
+
+	EntryTypeDeposit        = "deposit"
+	EntryTypeWithdrawal     = "withdrawal"
+	EntryTypeTransfer       = "transfer"
+	EntryTypeReversal       = "reversal"
+	EntryTypeEscrow         = "escrow"
+	EntryTypePocket         = "pocket"
+	EntryTypeReconciliation = "reconciliation"
+	EntryTypeAdjustment     = "adjustment"
+
+	TypeOpeningBalance = "opening_balance"
+	TypeFXOut          = "fx_out"
+	TypeFXIn           = "fx_in"
+	TypeReconciliation = "reconciliation"
+	TypeAdjustment     = "adjustment"
+)
+
+// References the service derives itself join their parts with
+// SystemRefSeparator. Client references may not contain it, so a client can
+// never post a system reference before the service does.
+const SystemRefSeparator = ":"
+
+// Reference derived by the service from parts
+func SystemRef(parts ...string) string {
+	return strings.Join(parts, SystemRefSeparator)
+}
+
+// Reference supplied by a client stays outside the system namespace
+func IsClientRef(ref string) bool {
+	return !strings.Contains(ref, SystemRefSeparator)
+}
+
+// Journal entry groups the postings of one business event
+type JournalEntry struct {
+	ID          int64  `json:"id" db:"id"`
+	Type        string `json:"type" db:"type"`
+	RefID       string `json:"ref_id" db:"ref_id"`
+	Description string `json:"description" db:"description"`
+	Meta        []byte `json:"-" db:"meta"`
+	// set on compensating entries to the entry they reverse
+	ReversesEntryID *int64    `json:"reverses_entry_id,omitempty" db:"reverses_entry_id"`
+	CreatedAt       time.Time `json:"created_at" db:"created_at"`
+	Postings        []Posting `json:"postings"`
+}
+
+// Posting is a signed movement on one account, credits are positive
+type Posting struct {
+	ID        int64           `json:"id" db:"id"`
+	EntryID   int64           `json:"entry_id" db:"entry_id"`
+	Account   string          `json:"account" db:"account"`
+	WalletID  *ID             `json:"wallet_id,omitempty" db:"wallet_id"`
+	Type      string          `json:"type" db:"type"`
+	Currency  string          `json:"currency" db:"currency"`
+	Amount    decimal.Decimal `json:"amount" db:"amount"`
+	CreatedAt time.Time       `json:"created_at" db:"created_at"`
+}
+
+// Ledger account name of a wallet
+func WalletAccount(walletID ID) string {
+	return "wallet:" + strconv.FormatInt(walletID, 10)
+}
+
+// Posting on a wallet account
+func WalletPosting(walletID ID, txType, currency string, amount decimal.Decimal) Posting {
+	id := walletID
+	return Posting{Account: WalletAccount(walletID), WalletID: &id, Type: txType, Currency: currency, Amount: amount}
+}
+
+// Posting on a system account
+func SystemPosting(account, txType, currency string, amount decimal.Decimal) Posting {
+	return Posting{Account: account, Type: txType, Currency: currency, Amount: amount}
+}
+
+// Validate entry has at least two postings summing to zero per currency
+func (e *JournalEntry) Validate() error {
+	if len(e.Postings) < 2 {
+		return fmt.Errorf("journal entry %s: at least two postings required", e.RefID)
+	}
+
+	sums := make(map[string]decimal.Decimal, len(e.Postings))
+	for _, p := range e.Postings {
+		if p.Amount.IsZero() {
+			return fmt.Errorf("journal entry %s: zero amount posting on %s", e.RefID, p.Account)
+		}
+		sums[p.Currency] = sums[p.Currency].Add(p.Amount)
+	}
+
+	for currency, sum := range sums {
+		if !sum.IsZero() {
+			return fmt.Errorf("journal entry %s: postings in %s do not balance (%s)", e.RefID, currency, sum)
+		}
+	}
+
+	return nil
+}
+
+// Entry moves exactly postings, in order, what a replayed reference has to
+// match. Ids and timestamps are ignored.
+func (e *JournalEntry) SamePostings(postings []Posting) bool {
+	if len(e.Postings) != len(postings) {
+		return false
+	}
+
+	for i, p := range e.Postings {
+		o := postings[i]
+		if p.Account != o.Account || p.Type != o.Type || p.Currency != o.Currency || !p.Amount.Equal(o.Amount) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,78 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJournalEntry_Validate(t *testing.T) {
+	t.Parallel()
+
+	deposit := &JournalEntry{RefID: "dep-1", Postings: []Posting{
+		SystemPosting(AccountCashIn, TypeDeposit, "USD", decimal.NewFromInt(-100)),
+		WalletPosting(1, TypeDeposit, "USD", decimal.NewFromInt(100)),
+	}}
+	require.NoError(t, deposit.Validate())
+
+	fx := &JournalEntry{RefID: "fx-1", Postings: []Posting{
+		WalletPosting(1, TypeTransferOut, "USD", decimal.NewFromInt(-10)),
+		SystemPosting(AccountFX, TypeFXIn, "USD", decimal.NewFromInt(10)),
+		SystemPosting(AccountFX, TypeFXOut, "IDR", decimal.NewFromInt(-160000)),
+		WalletPosting(2, TypeTransferIn, "IDR", decimal.NewFromInt(160000)),
+	}}
+	require.NoError(t, fx.Validate())
+
+	fractional := &JournalEntry{RefID: "frac-1", Postings: []Posting{
+		WalletPosting(1, TypeTransferOut, "USD", decimal.RequireFromString("-0.3")),
+		WalletPosting(2, TypeTransferIn, "USD", decimal.RequireFromString("0.1")),
+		WalletPosting(3, TypeTransferIn, "USD", decimal.RequireFromString("0.2")),
+	}}
+	require.NoError(t, fractional.Validate())
+
+	unbalanced := &JournalEntry{RefID: "bad-1", Postings: []Posting{
+		WalletPosting(1, TypeTransferOut, "USD", decimal.NewFromInt(-10)),
+		WalletPosting(2, TypeTransferIn, "IDR", decimal.NewFromInt(10)),
+	}}
+	require.Error(t, unbalanced.Validate())
+
+	single := &JournalEntry{RefID: "bad-2", Postings: []Posting{WalletPosting(1, TypeDeposit, "USD", decimal.NewFromInt(0))}}
+	require.Error(t, single.Validate())
+}
+
+func TestJournalEntry_SamePostings(t *testing.T) {
+	t.Parallel()
+
+	deposit := &JournalEntry{RefID: "dep-1", Postings: []Posting{
+		SystemPosting(AccountCashIn, TypeDeposit, "USD", decimal.NewFromInt(-100)),
+		WalletPosting(1, TypeDeposit, "USD", decimal.NewFromInt(100)),
+	}}
+
+	posted := []Posting{
+		{ID: 7, Account: AccountCashIn, Type: TypeDeposit, Currency: "USD", Amount: decimal.RequireFromString("-100.00")},
+		{ID: 8, Account: WalletAccount(1), Type: TypeDeposit, Currency: "USD", Amount: decimal.RequireFromString("100.00")},
+	}
+	require.True(t, deposit.SamePostings(posted))
+
+	otherWallet := []Posting{posted[0], WalletPosting(2, TypeDeposit, "USD", decimal.NewFromInt(100))}
+	require.False(t, deposit.SamePostings(otherWallet))
+
+	otherAmount := []Posting{
+		SystemPosting(AccountCashIn, TypeDeposit, "USD", decimal.NewFromInt(-50)),
+		WalletPosting(1, TypeDeposit, "USD", decimal.NewFromInt(50)),
+	}
+	require.False(t, deposit.SamePostings(otherAmount))
+
+	require.False(t, deposit.SamePostings(posted[:1]))
+}
+
+func TestSystemRef(t *testing.T) {
+	t.Parallel()
+
+	ref := SystemRef("payreq", "12")
+	require.Equal(t, "payreq:12", ref)
+	require.False(t, IsClientRef(ref))
+	require.True(t, IsClientRef("payreq-12"))
+	require.True(t, IsClientRef(""))
+}
@@ -0,0 +1,135 @@
+package models
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Operations a limit rule applies to
+const (
+	LimitOperationDeposit    = "deposit"
+	LimitOperationTransfer   = "transfer"
+	LimitOperationWithdrawal = "withdrawal"
+)
+
+// Limit periods, transaction caps a single ticket, day and month are rolling windows
+const (
+	LimitPeriodTransaction = "transaction"
+	LimitPeriodDay         = "day"
+	LimitPeriodMonth       = "month"
+)
+
+// Limit aggregates, usage is summed per wallet or across all wallets of the user
+const (
+	LimitAggregateWallet = "wallet"
+	LimitAggregateUser   = "user"
+)
+
+// Velocity limit rule. A rule without UserID and RoleID is the currency default,
+// role rules override it and user rules override both.
+type LimitRule struct {
+	ID        ID              `json:"id" db:"id"`
+	Currency  string          `json:"currency" db:"currency"`
+	Operation string          `json:"operation" db:"operation"`
+	Period    string          `json:"period" db:"period"`
+	Aggregate string          `json:"aggregate" db:"aggregate"`
+	MaxAmount decimal.Decimal `json:"max_amount" db:"max_amount"`
+	UserID    *int64          `json:"user_id,omitempty" db:"user_id"`
+	RoleID    *int64          `json:"role_id,omitempty" db:"role_id"`
+	CreatedAt time.Time       `json:"created_at" db:"created_at"`
+}
+
+// Limit name reported to clients, e.g. user_day_transfer
+func (r *LimitRule) Name() string {
+	if r.Period == LimitPeriodTransaction {
+		return "max_ticket_" + r.Operation
+	}
+	return r.Aggregate + "_" + r.Period + "_" + r.Operation
+}
+
+// Rolling window length, zero for per transaction caps
+func (r *LimitRule) Window() time.Duration {
+	switch r.Period {
+	case LimitPeriodDay:
+		return 24 * time.Hour
+	case LimitPeriodMonth:
+		return 30 * 24 * time.Hour
+	default:
+		return 0
+	}
+}
+
+// Pick the rules that apply to a user holding roleIDs: per (period, aggregate)
+// a user rule wins, then the most generous role rule, then the default
+func EffectiveLimitRules(rules []LimitRule, userID int64, roleIDs []int64) []LimitRule {
+	type slot struct{ period, aggregate string }
+
+	roles := make(map[int64]struct{}, len(roleIDs))
+	for _, id := range roleIDs {
+		roles[id] = struct{}{}
+	}
+
+	const (
+		rankDefault = iota + 1
+		rankRole
+		rankUser
+	)
+
+	chosen := make(map[slot]LimitRule)
+	ranks := make(map[slot]int)
+	order := make([]slot, 0)
+
+	for _, r := range rules {
+		rank := rankDefault
+		switch {
+		case r.UserID != nil:
+			if *r.UserID != userID {
+				continue
+			}
+			rank = rankUser
+		case r.RoleID != nil:
+			if _, ok := roles[*r.RoleID]; !ok {
+				continue
+			}
+			rank = rankRole
+		}
+
+		s := slot{r.Period, r.Aggregate}
+		current, ok := ranks[s]
+		switch {
+		case !ok:
+			order = append(order, s)
+		case rank < current:
+			continue
+		case rank == current && rank == rankRole && r.MaxAmount.LessThan(chosen[s].MaxAmount):
+			continue
+		}
+
+		chosen[s] = r
+		ranks[s] = rank
+	}
+
+	effective := make([]LimitRule, 0, len(order))
+	for _, s := range order {
+		effective = append(effective, chosen[s])
+	}
+
+	return effective
+}
+
+// Limits to enforce for one money movement, checked inside its DB transaction
+type LimitCheck struct {
+	UserID    int64
+	WalletID  int64
+	Operation string
+	Currency  string
+	Amount    decimal.Decimal
+	Rules     []LimitRule
+}
+
+// Usage recorded against rolling window limits
+type LimitUsage struct {
+	Amount    decimal.Decimal `db:"amount"`
+	CreatedAt time.Time       `db:"created_at"`
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEffectiveLimitRules(t *testing.T) {
+	t.Parallel()
+
+	userID, otherUserID := int64(7), int64(8)
+	vipRole, staffRole := int64(3), int64(4)
+
+	rules := []LimitRule{
+		{ID: 1, Period: LimitPeriodDay, Aggregate: LimitAggregateUser, MaxAmount: decimal.NewFromInt(1000)},
+		{ID: 2, Period: LimitPeriodDay, Aggregate: LimitAggregateUser, MaxAmount: decimal.NewFromInt(5000), RoleID: &vipRole},
+		{ID: 3, Period: LimitPeriodDay, Aggregate: LimitAggregateUser, MaxAmount: decimal.NewFromInt(3000), RoleID: &staffRole},
+		{ID: 4, Period: LimitPeriodTransaction, Aggregate: LimitAggregateWallet, MaxAmount: decimal.NewFromInt(500)},
+		{ID: 5, Period: LimitPeriodTransaction, Aggregate: LimitAggregateWallet, MaxAmount: decimal.NewFromInt(50), UserID: &userID},
+		{ID: 6, Period: LimitPeriodMonth, Aggregate: LimitAggregateUser, MaxAmount: decimal.NewFromInt(1), UserID: &otherUserID},
+	}
+
+	ids := func(rs []LimitRule) []ID {
+		out := make([]ID, 0, len(rs))
+		for _, r := range rs {
+			out = append(out, r.ID)
+		}
+		return out
+	}
+
+	// defaults only
+	require.Equal(t, []ID{1, 4}, ids(EffectiveLimitRules(rules, 9, nil)))
+
+	// most generous role wins over default, user rule wins over default
+	require.Equal(t, []ID{2, 5}, ids(EffectiveLimitRules(rules, userID, []int64{staffRole, vipRole})))
+
+	// other users' overrides never apply
+	require.Equal(t, []ID{3, 4}, ids(EffectiveLimitRules(rules, 9, []int64{staffRole})))
+}
+
+func TestLimitRule_Name(t *testing.T) {
+	t.Parallel()
+
+	daily := &LimitRule{Operation: LimitOperationTransfer, Period: LimitPeriodDay, Aggregate: LimitAggregateUser}
+	require.Equal(t, "user_day_transfer", daily.Name())
+
+	ticket := &LimitRule{Operation: LimitOperationDeposit, Period: LimitPeriodTransaction, Aggregate: LimitAggregateWallet}
+	require.Equal(t, "max_ticket_deposit", ticket.Name())
+}
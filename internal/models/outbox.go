@@ -0,0 +1,70 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Aggregates events are published for
+const (
+	AggregateWallet = "wallet"
+	AggregateUser   = "user"
+	AggregateRole   = "role"
+)
+
+// Domain event types
+const (
+	EventWalletDeposited        = "wallet.deposited"
+	EventWalletTransferred      = "wallet.transferred"
+	EventWalletTransferReversed = "wallet.transfer_reversed"
+	EventWalletStatusChanged    = "wallet.status_changed"
+	EventWalletAdjusted         = "wallet.adjusted"
+	EventTransactionHeld        = "wallet.transaction_held"
+	EventTransactionRejected    = "wallet.transaction_rejected"
+	EventWithdrawalHeld         = "wallet.withdrawal_held"
+	EventWithdrawalSettled      = "wallet.withdrawal_settled"
+	EventWithdrawalReleased     = "wallet.withdrawal_released"
+	EventEscrowHeld             = "wallet.escrow_held"
+	EventEscrowReleased         = "wallet.escrow_released"
+	EventEscrowRefunded         = "wallet.escrow_refunded"
+	EventEscrowDisputed         = "wallet.escrow_disputed"
+	EventUserRegistered         = "user.registered"
+	EventUserRolesChanged       = "user.roles_changed"
+	EventRolePermissionsChanged = "role.permissions_changed"
+)
+
+// Domain event waiting in the outbox, EventID is stable across redeliveries
+// so consumers can deduplicate
+type OutboxEvent struct {
+	ID             ID         `json:"-" db:"id"`
+	EventID        string     `json:"event_id" db:"event_id"`
+	AggregateType  string     `json:"aggregate_type" db:"aggregate_type"`
+	AggregateID    string     `json:"aggregate_id" db:"aggregate_id"`
+	EventType      string     `json:"event_type" db:"event_type"`
+	Payload        []byte     `json:"-" db:"payload"`
+	Attempts       int        `json:"-" db:"attempts"`
+	LastError      *string    `json:"-" db:"last_error"`
+	AvailableAt    time.Time  `json:"-" db:"available_at"`
+	PublishedAt    *time.Time `json:"-" db:"published_at"`
+	DeadLetteredAt *time.Time `json:"-" db:"dead_lettered_at"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+}
+
+// New event with a fresh id and payload marshalled to JSON
+func NewOutboxEvent(aggregateType, aggregateID, eventType string, payload interface{}) (*OutboxEvent, error) {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OutboxEvent{
+		EventID:       uuid.New().String(),
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		EventType:     eventType,
+		Payload:       raw,
+		CreatedAt:     time.Now().UTC(),
+	}, nil
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Payment request states, only pending requests can change. A held request
+// was paid by a transfer under risk review and settles with its decision.
+const (
+	PaymentRequestPending   = "pending"
+	PaymentRequestHeld      = "held"
+	PaymentRequestPaid      = "paid"
+	PaymentRequestDeclined  = "declined"
+	PaymentRequestExpired   = "expired"
+	PaymentRequestCancelled = "cancelled"
+)
+
+// Money requested by a user from a payer. Accepting it transfers the amount
+// from a wallet of the payer to the requester wallet.
+type PaymentRequest struct {
+	ID                ID              `json:"id" db:"id"`
+	RequesterID       int64           `json:"requester_id" db:"requester_id"`
+	RequesterWalletID int64           `json:"requester_wallet_id" db:"requester_wallet_id"`
+	PayerID           int64           `json:"payer_id" db:"payer_id"`
+	Currency          string          `json:"currency" db:"currency"`
+	Amount            decimal.Decimal `json:"amount" db:"amount"`
+	Memo              string          `json:"memo" db:"memo"`
+	Status            string          `json:"status" db:"status"`
+	ExpiresAt         time.Time       `json:"expires_at" db:"expires_at"`
+	PaidFromWalletID  *int64          `json:"paid_from_wallet_id,omitempty" db:"paid_from_wallet_id"`
+	TransferRef       *string         `json:"transfer_ref,omitempty" db:"transfer_ref"`
+	DeclineReason     *string         `json:"decline_reason,omitempty" db:"decline_reason"`
+	CreatedAt         time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt         time.Time       `json:"updated_at" db:"updated_at"`
+	ResolvedAt        *time.Time      `json:"resolved_at,omitempty" db:"resolved_at"`
+}
+
+// Ref_id of the transfer paying the request, one per request so a repeated
+// accept cannot pay twice. It is a system reference no client can post first.
+func (p *PaymentRequest) PaymentRef() string {
+	return SystemRef("payreq", strconv.FormatInt(p.ID, 10))
+}
+
+// Request can no longer be paid at now
+func (p *PaymentRequest) Expired(now time.Time) bool {
+	return !now.Before(p.ExpiresAt)
+}
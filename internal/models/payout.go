@@ -0,0 +1,64 @@
+package models
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Payout batch states
+const (
+	PayoutBatchPending    = "pending"
+	PayoutBatchProcessing = "processing"
+	PayoutBatchCompleted  = "completed"
+)
+
+// Payout row outcomes, a held row waits for the risk review of its transfer
+const (
+	PayoutRowPending   = "pending"
+	PayoutRowHeld      = "held"
+	PayoutRowSucceeded = "succeeded"
+	PayoutRowFailed    = "failed"
+)
+
+// Batch of payouts from one wallet, the rows are transferred asynchronously
+// by the payout processor as the user who uploaded the batch
+type PayoutBatch struct {
+	ID           ID         `json:"id" db:"id"`
+	BatchID      string     `json:"batch_id" db:"batch_id"`
+	UserID       int64      `json:"user_id" db:"user_id"`
+	FromWalletID int64      `json:"from_wallet_id" db:"from_wallet_id"`
+	Status       string     `json:"status" db:"status"`
+	TotalRows    int        `json:"total_rows" db:"total_rows"`
+	Succeeded    int        `json:"succeeded" db:"succeeded"`
+	Failed       int        `json:"failed" db:"failed"`
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at" db:"updated_at"`
+	CompletedAt  *time.Time `json:"completed_at,omitempty" db:"completed_at"`
+}
+
+// Transfer ref_id of a row, a system reference built from the batch key and
+// row number so no two rows and no client reference can share it
+func (b *PayoutBatch) RowRef(rowNumber int) string {
+	return SystemRef("payout", strconv.FormatInt(b.ID, 10), strconv.Itoa(rowNumber))
+}
+
+// One payout of a batch. UserID is set when the file named a user, WalletID
+// is then the wallet it resolved to.
+type PayoutRow struct {
+	ID          ID              `json:"id" db:"id"`
+	BatchID     ID              `json:"-" db:"batch_id"`
+	RowNumber   int             `json:"row" db:"row_number"`
+	WalletID    int64           `json:"wallet_id" db:"wallet_id"`
+	UserID      *int64          `json:"user_id,omitempty" db:"user_id"`
+	Currency    string          `json:"currency" db:"currency"`
+	Amount      decimal.Decimal `json:"amount" db:"amount"`
+	Reference   string          `json:"reference" db:"reference"`
+	RefID       string          `json:"ref_id" db:"ref_id"`
+	Status      string          `json:"status" db:"status"`
+	Error       *string         `json:"error,omitempty" db:"error"`
+	Attempts    int             `json:"attempts" db:"attempts"`
+	LockedUntil *time.Time      `json:"-" db:"locked_until"`
+	ProcessedAt *time.Time      `json:"processed_at,omitempty" db:"processed_at"`
+}
@@ -0,0 +1,75 @@
+package models
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Pocket is a wallet kept under a parent wallet, it holds a single currency
+// and may carry a savings goal
+type Pocket struct {
+	ID             ID               `json:"id" db:"wallet_id"`
+	ParentWalletID ID               `json:"parent_wallet_id" db:"parent_wallet_id"`
+	Name           string           `json:"name" db:"name"`
+	Status         string           `json:"status" db:"status"`
+	Currency       string           `json:"currency" db:"currency"`
+	Balance        decimal.Decimal  `json:"balance" db:"balance"`
+	TargetAmount   *decimal.Decimal `json:"target_amount,omitempty" db:"target_amount"`
+	TargetDate     *time.Time       `json:"target_date,omitempty" db:"target_date"`
+	CreatedAt      time.Time        `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time        `json:"updated_at" db:"updated_at"`
+	Progress       *PocketProgress  `json:"progress,omitempty" db:"-"`
+}
+
+// Progress of a pocket towards its target amount
+type PocketProgress struct {
+	// share of the target saved, 0 to 100
+	Percent   decimal.Decimal `json:"percent"`
+	Remaining decimal.Decimal `json:"remaining"`
+	Reached   bool            `json:"reached"`
+	// whole days until the target date, 0 once it has passed
+	DaysLeft *int `json:"days_left,omitempty"`
+}
+
+var hundred = decimal.NewFromInt(100)
+
+// Progress towards the target at now, nil for a pocket without a target amount
+func (p *Pocket) ComputeProgress(now time.Time) *PocketProgress {
+	if p.TargetAmount == nil || !p.TargetAmount.IsPositive() {
+		return nil
+	}
+
+	target := *p.TargetAmount
+	progress := &PocketProgress{
+		Percent:   decimal.Min(p.Balance.Div(target).Mul(hundred), hundred).Round(2),
+		Remaining: decimal.Max(target.Sub(p.Balance), decimal.Zero),
+		Reached:   p.Balance.GreaterThanOrEqual(target),
+	}
+	if progress.Percent.IsNegative() {
+		progress.Percent = decimal.Zero
+	}
+
+	if p.TargetDate != nil {
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+		due := time.Date(p.TargetDate.Year(), p.TargetDate.Month(), p.TargetDate.Day(), 0, 0, 0, 0, time.UTC)
+
+		days := 0
+		if due.After(today) {
+			days = int(due.Sub(today).Hours() / 24)
+		}
+		progress.DaysLeft = &days
+	}
+
+	return progress
+}
+
+// Funds moved between a wallet and its pockets, or between two of its pockets
+type PocketMove struct {
+	ParentWalletID ID
+	FromWalletID   ID
+	ToWalletID     ID
+	Currency       string
+	Amount         decimal.Decimal
+	RefID          string
+}
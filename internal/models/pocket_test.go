@@ -0,0 +1,37 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPocket_ComputeProgress(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 3, 10, 15, 0, 0, 0, time.UTC)
+
+	p := &Pocket{Balance: decimal.RequireFromString("25")}
+	require.Nil(t, p.ComputeProgress(now))
+
+	target := decimal.RequireFromString("80")
+	due := time.Date(2026, 3, 20, 0, 0, 0, 0, time.UTC)
+	p.TargetAmount, p.TargetDate = &target, &due
+
+	progress := p.ComputeProgress(now)
+	require.NotNil(t, progress)
+	require.True(t, progress.Percent.Equal(decimal.RequireFromString("31.25")))
+	require.True(t, progress.Remaining.Equal(decimal.RequireFromString("55")))
+	require.False(t, progress.Reached)
+	require.Equal(t, 10, *progress.DaysLeft)
+
+	// saving past the target caps the percent, a passed date leaves no days
+	p.Balance = decimal.RequireFromString("100")
+	progress = p.ComputeProgress(due.AddDate(0, 0, 3))
+	require.True(t, progress.Percent.Equal(decimal.NewFromInt(100)))
+	require.True(t, progress.Remaining.IsZero())
+	require.True(t, progress.Reached)
+	require.Equal(t, 0, *progress.DaysLeft)
+}
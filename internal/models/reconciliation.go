@@ -0,0 +1,66 @@
+package models
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Reconciliation run triggers
+const (
+	ReconciliationTriggerSchedule = "schedule"
+	ReconciliationTriggerManual   = "manual"
+	ReconciliationTriggerCLI      = "cli"
+)
+
+// Reconciliation run states
+const (
+	ReconciliationRunning   = "running"
+	ReconciliationCompleted = "completed"
+	ReconciliationFailed    = "failed"
+)
+
+// Drift states, open drifts of older runs are superseded when a run completes
+const (
+	DriftOpen       = "open"
+	DriftRepaired   = "repaired"
+	DriftSuperseded = "superseded"
+)
+
+// One pass comparing every wallet balance with the sum of its ledger rows
+type ReconciliationRun struct {
+	ID             ID              `json:"id" db:"id"`
+	Trigger        string          `json:"trigger" db:"trigger"`
+	RequestedBy    *int64          `json:"requested_by,omitempty" db:"requested_by"`
+	Status         string          `json:"status" db:"status"`
+	WalletsChecked int             `json:"wallets_checked" db:"wallets_checked"`
+	DriftCount     int             `json:"drift_count" db:"drift_count"`
+	Error          *string         `json:"error,omitempty" db:"error"`
+	StartedAt      time.Time       `json:"started_at" db:"started_at"`
+	FinishedAt     *time.Time      `json:"finished_at,omitempty" db:"finished_at"`
+	Drifts         []*BalanceDrift `json:"drifts,omitempty" db:"-"`
+}
+
+// Balance of a wallet and currency that disagrees with its ledger,
+// Difference is Balance - Ledger
+type BalanceDrift struct {
+	ID            ID              `json:"id" db:"id"`
+	RunID         ID              `json:"run_id" db:"run_id"`
+	WalletID      int64           `json:"wallet_id" db:"wallet_id"`
+	Currency      string          `json:"currency" db:"currency"`
+	Balance       decimal.Decimal `json:"balance" db:"balance"`
+	Ledger        decimal.Decimal `json:"ledger" db:"ledger"`
+	Difference    decimal.Decimal `json:"difference" db:"difference"`
+	Status        string          `json:"status" db:"status"`
+	RepairEntryID *int64          `json:"repair_entry_id,omitempty" db:"repair_entry_id"`
+	RepairedBy    *int64          `json:"repaired_by,omitempty" db:"repaired_by"`
+	RepairReason  *string         `json:"repair_reason,omitempty" db:"repair_reason"`
+	CreatedAt     time.Time       `json:"created_at" db:"created_at"`
+	ResolvedAt    *time.Time      `json:"resolved_at,omitempty" db:"resolved_at"`
+}
+
+// Ref id of the journal entry repairing a drift
+func (d *BalanceDrift) RepairRef() string {
+	return SystemRef("reconciliation", strconv.FormatInt(d.ID, 10))
+}
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Posted transfer rebuilt from its journal entry, with the totals already
+// reversed. Rate is the rate that was applied when it was posted.
+type TransferEntry struct {
+	Transfer
+	EntryID           int64
+	Reversed          decimal.Decimal
+	ReversedConverted decimal.Decimal
+	ReversedFee       decimal.Decimal
+}
+
+// Amount of the transfer that can still be reversed, in FromCurrency
+func (e *TransferEntry) Remaining() decimal.Decimal {
+	return e.Amount.Sub(e.Reversed)
+}
+
+// Amount still held by the recipient from this transfer, in ToCurrency
+func (e *TransferEntry) RemainingConverted() decimal.Decimal {
+	return e.ConvertedAmount.Sub(e.ReversedConverted)
+}
+
+// Fee that has not been refunded yet, in FromCurrency
+func (e *TransferEntry) RemainingFee() decimal.Decimal {
+	return e.Fee.Amount.Sub(e.ReversedFee)
+}
+
+// Amounts taken back from the recipient and refunded as fee when amount of the
+// transfer is reversed at the original rate. The reversal that brings the
+// transfer to zero settles whatever is left, so rounding never leaves a residue.
+func (e *TransferEntry) ReversalAmounts(amount decimal.Decimal, refundFee bool, from, to *Currency) (converted, fee decimal.Decimal) {
+	if amount.Equal(e.Remaining()) {
+		converted = e.RemainingConverted()
+		if refundFee {
+			fee = e.RemainingFee()
+		}
+		return converted, fee
+	}
+
+	converted = decimal.Min(to.Round(amount.Mul(e.Rate)), e.RemainingConverted())
+	if refundFee && e.Fee.Amount.IsPositive() {
+		fee = decimal.Min(from.Round(e.Fee.Amount.Mul(amount).Div(e.Amount)), e.RemainingFee())
+	}
+
+	return converted, fee
+}
+
+// Full or partial reversal of a transfer. Amount and FeeAmount are returned to
+// the sender in FromCurrency, ConvertedAmount is taken back from the recipient
+// in ToCurrency at the original Rate.
+type Reversal struct {
+	ID              ID              `json:"id" db:"id"`
+	RefID           string          `json:"ref_id" db:"ref_id"`
+	EntryID         int64           `json:"entry_id" db:"entry_id"`
+	OriginalEntryID int64           `json:"original_entry_id" db:"original_entry_id"`
+	OriginalRefID   string          `json:"original_ref_id" db:"original_ref_id"`
+	Amount          decimal.Decimal `json:"amount" db:"amount"`
+	ConvertedAmount decimal.Decimal `json:"converted_amount" db:"converted_amount"`
+	FeeAmount       decimal.Decimal `json:"fee_amount" db:"fee_amount"`
+	Rate            decimal.Decimal `json:"rate" db:"rate"`
+	Reason          string          `json:"reason" db:"reason"`
+	ActorID         *int64          `json:"actor_id,omitempty" db:"actor_id"`
+	CreatedAt       time.Time       `json:"created_at" db:"created_at"`
+}
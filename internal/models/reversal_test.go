@@ -0,0 +1,39 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransferEntry_ReversalAmounts(t *testing.T) {
+	t.Parallel()
+
+	d := decimal.RequireFromString
+	usd := &Currency{Code: "USD", Exponent: 2}
+	idr := &Currency{Code: "IDR", Exponent: 2}
+
+	entry := &TransferEntry{Transfer: Transfer{
+		FromCurrency:    "USD",
+		ToCurrency:      "IDR",
+		Amount:          d("10"),
+		ConvertedAmount: d("160001.23"),
+		Rate:            d("16000.123"),
+		Fee:             Fee{Currency: "USD", Amount: d("0.37")},
+	}}
+
+	// partial reversals run at the original rate
+	converted, fee := entry.ReversalAmounts(d("3.33"), true, usd, idr)
+	require.Equal(t, "53280.41", converted.String())
+	require.Equal(t, "0.12", fee.String())
+
+	_, fee = entry.ReversalAmounts(d("3.33"), false, usd, idr)
+	require.True(t, fee.IsZero())
+
+	// the last reversal takes exactly what is left
+	entry.Reversed, entry.ReversedConverted, entry.ReversedFee = d("3.33"), converted, d("0.12")
+	converted, fee = entry.ReversalAmounts(entry.Remaining(), true, usd, idr)
+	require.Equal(t, "106720.82", converted.String())
+	require.Equal(t, "0.25", fee.String())
+}
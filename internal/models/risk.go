@@ -0,0 +1,270 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Risk decisions in increasing strictness, the strictest fired rule wins
+const (
+	RiskDecisionAllow  = "allow"
+	RiskDecisionReview = "review"
+	RiskDecisionDeny   = "deny"
+)
+
+// Operations the risk rules are checked on
+const (
+	RiskOperationDeposit  = "deposit"
+	RiskOperationTransfer = "transfer"
+)
+
+// Risk rule kinds, each reads its own RiskRuleParams fields
+const (
+	// amount >= min_amount
+	RiskRuleAmountThreshold = "amount_threshold"
+	// more than max_count operations, or more than max_amount in total,
+	// within window_minutes including this one
+	RiskRuleVelocity = "velocity"
+	// account younger than min_age_hours, only from min_amount when set
+	RiskRuleAccountAge = "account_age"
+	// at least min_count operations just below threshold, within margin of
+	// it, in window_minutes including this one
+	RiskRuleStructuring = "structuring"
+	// counterparty wallet in wallet_ids or counterparty user in user_ids
+	RiskRuleBlockedCounterparty = "blocked_counterparty"
+)
+
+// Parameters of a risk rule, stored as jsonb. Amounts are in the rule currency.
+type RiskRuleParams struct {
+	MinAmount     *decimal.Decimal `json:"min_amount,omitempty"`
+	MaxAmount     *decimal.Decimal `json:"max_amount,omitempty"`
+	MaxCount      int              `json:"max_count,omitempty"`
+	WindowMinutes int              `json:"window_minutes,omitempty"`
+	MinAgeHours   int              `json:"min_age_hours,omitempty"`
+	Threshold     *decimal.Decimal `json:"threshold,omitempty"`
+	Margin        *decimal.Decimal `json:"margin,omitempty"`
+	MinCount      int              `json:"min_count,omitempty"`
+	WalletIDs     []int64          `json:"wallet_ids,omitempty"`
+	UserIDs       []int64          `json:"user_ids,omitempty"`
+}
+
+// Value implements driver.Valuer
+func (p RiskRuleParams) Value() (driver.Value, error) {
+	return json.Marshal(p)
+}
+
+// Scan implements sql.Scanner
+func (p *RiskRuleParams) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case []byte:
+		return json.Unmarshal(v, p)
+	case string:
+		return json.Unmarshal([]byte(v), p)
+	default:
+		return errors.New("risk rule params: unsupported column type")
+	}
+}
+
+// Window the rule looks back over, zero when it only reads the current operation
+func (p *RiskRuleParams) Window() time.Duration {
+	return time.Duration(p.WindowMinutes) * time.Minute
+}
+
+// Declarative risk rule. A rule without Operation or Currency applies to all
+// of them, Action is what happens when it fires.
+type RiskRule struct {
+	ID        ID             `json:"id" db:"id"`
+	Name      string         `json:"name" db:"name"`
+	Kind      string         `json:"kind" db:"kind"`
+	Operation *string        `json:"operation,omitempty" db:"operation"`
+	Currency  *string        `json:"currency,omitempty" db:"currency"`
+	Action    string         `json:"action" db:"action"`
+	Params    RiskRuleParams `json:"params" db:"params"`
+	CreatedAt time.Time      `json:"created_at" db:"created_at"`
+}
+
+// Rule is checked on operation in currency
+func (r *RiskRule) Applies(operation, currency string) bool {
+	return (r.Operation == nil || *r.Operation == operation) &&
+		(r.Currency == nil || *r.Currency == currency)
+}
+
+// Money movement to assess, UserID owns WalletID
+type RiskRequest struct {
+	Operation            string
+	UserID               int64
+	WalletID             int64
+	CounterpartyWalletID int64
+	Currency             string
+	Amount               decimal.Decimal
+}
+
+// Earlier operation of the same user counted by windowed rules
+type RiskActivity struct {
+	Currency  string          `db:"currency"`
+	Amount    decimal.Decimal `db:"amount"`
+	CreatedAt time.Time       `db:"created_at"`
+}
+
+// Everything the rules read, History holds the user's earlier operations of
+// the same kind, oldest first
+type RiskFacts struct {
+	RiskRequest
+	CounterpartyUserID int64
+	AccountCreatedAt   time.Time
+	History            []RiskActivity
+	Now                time.Time
+}
+
+// Rule that fired and why
+type RiskHit struct {
+	RuleID int64  `json:"rule_id"`
+	Name   string `json:"name"`
+	Kind   string `json:"kind"`
+	Action string `json:"action"`
+	Reason string `json:"reason"`
+}
+
+// Fired rules stored as a jsonb array
+type RiskHits []RiskHit
+
+// Value implements driver.Valuer
+func (h RiskHits) Value() (driver.Value, error) {
+	if h == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(h)
+}
+
+// Scan implements sql.Scanner
+func (h *RiskHits) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*h = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, h)
+	case string:
+		return json.Unmarshal([]byte(v), h)
+	default:
+		return errors.New("risk hits: unsupported column type")
+	}
+}
+
+// Outcome of checking one operation against the rules
+type RiskAssessment struct {
+	Decision string   `json:"decision"`
+	Hits     RiskHits `json:"hits"`
+}
+
+// Review states, only pending reviews hold funds
+const (
+	RiskReviewPending  = "pending"
+	RiskReviewApproved = "approved"
+	RiskReviewRejected = "rejected"
+)
+
+// Deposit or transfer held for manual review. A held transfer reserves
+// amount plus fee on the sender balance until it is approved and posted, or
+// rejected and released. A held deposit is only posted once approved.
+type RiskReview struct {
+	ID              ID               `json:"id" db:"id"`
+	Operation       string           `json:"operation" db:"operation"`
+	WalletID        ID               `json:"wallet_id" db:"wallet_id"`
+	UserID          int64            `json:"user_id" db:"user_id"`
+	Currency        string           `json:"currency" db:"currency"`
+	Amount          decimal.Decimal  `json:"amount" db:"amount"`
+	Held            decimal.Decimal  `json:"held" db:"held"`
+	ToWalletID      *int64           `json:"to_wallet_id,omitempty" db:"to_wallet_id"`
+	ToCurrency      *string          `json:"to_currency,omitempty" db:"to_currency"`
+	ConvertedAmount *decimal.Decimal `json:"converted_amount,omitempty" db:"converted_amount"`
+	Rate            *decimal.Decimal `json:"rate,omitempty" db:"rate"`
+	QuoteID         *string          `json:"quote_id,omitempty" db:"quote_id"`
+	Fee             decimal.Decimal  `json:"fee" db:"fee"`
+	FeeScheduleID   *int64           `json:"fee_schedule_id,omitempty" db:"fee_schedule_id"`
+	FeeWalletID     *int64           `json:"fee_wallet_id,omitempty" db:"fee_wallet_id"`
+	RefID           string           `json:"ref_id" db:"ref_id"`
+	Hits            RiskHits         `json:"hits" db:"hits"`
+	Status          string           `json:"status" db:"status"`
+	RequestedBy     *int64           `json:"requested_by,omitempty" db:"requested_by"`
+	ReviewedBy      *int64           `json:"reviewed_by,omitempty" db:"reviewed_by"`
+	ReviewNote      *string          `json:"review_note,omitempty" db:"review_note"`
+	EntryID         *int64           `json:"entry_id,omitempty" db:"entry_id"`
+	CreatedAt       time.Time        `json:"created_at" db:"created_at"`
+	ReviewedAt      *time.Time       `json:"reviewed_at,omitempty" db:"reviewed_at"`
+}
+
+// Review of a priced transfer, amount plus fee is held
+func NewTransferReview(t *Transfer, userID int64, hits RiskHits) *RiskReview {
+	review := &RiskReview{
+		Operation:       RiskOperationTransfer,
+		WalletID:        t.FromWalletID,
+		UserID:          userID,
+		Currency:        t.FromCurrency,
+		Amount:          t.Amount,
+		Held:            t.Amount.Add(t.Fee.Amount),
+		ToWalletID:      &t.ToWalletID,
+		ToCurrency:      &t.ToCurrency,
+		ConvertedAmount: &t.ConvertedAmount,
+		Rate:            &t.Rate,
+		Fee:             t.Fee.Amount,
+		FeeScheduleID:   t.Fee.ScheduleID,
+		RefID:           t.RefID,
+		Hits:            hits,
+	}
+	if t.QuoteID != "" {
+		review.QuoteID = &t.QuoteID
+	}
+	if t.FeeWalletID != 0 {
+		review.FeeWalletID = &t.FeeWalletID
+	}
+	return review
+}
+
+// Transfer to post when a transfer review is approved
+func (r *RiskReview) Transfer() *Transfer {
+	t := &Transfer{
+		FromWalletID: r.WalletID,
+		FromCurrency: r.Currency,
+		Amount:       r.Amount,
+		RefID:        r.RefID,
+		Fee:          Fee{ScheduleID: r.FeeScheduleID, Currency: r.Currency, Amount: r.Fee},
+	}
+	if r.ToWalletID != nil {
+		t.ToWalletID = *r.ToWalletID
+	}
+	if r.ToCurrency != nil {
+		t.ToCurrency = *r.ToCurrency
+	}
+	if r.ConvertedAmount != nil {
+		t.ConvertedAmount = *r.ConvertedAmount
+	}
+	if r.Rate != nil {
+		t.Rate = *r.Rate
+	}
+	if r.QuoteID != nil {
+		t.QuoteID = *r.QuoteID
+	}
+	if r.FeeWalletID != nil {
+		t.FeeWalletID = *r.FeeWalletID
+	}
+	return t
+}
+
+// Whether the review holds the same operation as o, wallets, currencies
+// and amount must match for a reference to replay the review's outcome
+func (r *RiskReview) SameOperation(o *RiskReview) bool {
+	return r.Operation == o.Operation && r.Transfer().SameMovement(o.Transfer())
+}
+
+// Risk review list filter, zero values match everything
+type RiskReviewFilter struct {
+	Status   string
+	WalletID int64
+	UserID   int64
+	Limit    int
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRiskReview_SameOperation(t *testing.T) {
+	t.Parallel()
+
+	deposit := &RiskReview{Operation: RiskOperationDeposit, WalletID: 1, Currency: "USD", Amount: decimal.NewFromInt(10)}
+	require.True(t, deposit.SameOperation(&RiskReview{Operation: RiskOperationDeposit, WalletID: 1, Currency: "USD", Amount: decimal.RequireFromString("10.00")}))
+	require.False(t, deposit.SameOperation(&RiskReview{Operation: RiskOperationDeposit, WalletID: 2, Currency: "USD", Amount: decimal.NewFromInt(10)}))
+	require.False(t, deposit.SameOperation(&RiskReview{Operation: RiskOperationDeposit, WalletID: 1, Currency: "EUR", Amount: decimal.NewFromInt(10)}))
+	require.False(t, deposit.SameOperation(&RiskReview{Operation: RiskOperationDeposit, WalletID: 1, Currency: "USD", Amount: decimal.NewFromInt(20)}))
+
+	transfer := NewTransferReview(&Transfer{FromWalletID: 1, ToWalletID: 2, FromCurrency: "USD", ToCurrency: "IDR", Amount: decimal.NewFromInt(10)}, 7, nil)
+	require.True(t, transfer.SameOperation(NewTransferReview(&Transfer{FromWalletID: 1, ToWalletID: 2, FromCurrency: "USD", ToCurrency: "IDR", Amount: decimal.NewFromInt(10)}, 7, nil)))
+	require.False(t, transfer.SameOperation(NewTransferReview(&Transfer{FromWalletID: 1, ToWalletID: 3, FromCurrency: "USD", ToCurrency: "IDR", Amount: decimal.NewFromInt(10)}, 7, nil)))
+	require.False(t, transfer.SameOperation(NewTransferReview(&Transfer{FromWalletID: 1, ToWalletID: 2, FromCurrency: "USD", ToCurrency: "USD", Amount: decimal.NewFromInt(10)}, 7, nil)))
+	require.False(t, transfer.SameOperation(&RiskReview{Operation: RiskOperationDeposit, WalletID: 1, Currency: "USD", Amount: decimal.NewFromInt(10)}))
+}
@@ -0,0 +1,91 @@
+package models
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Scheduled transfer states
+const (
+	ScheduleStatusActive    = "active"
+	ScheduleStatusPaused    = "paused"
+	ScheduleStatusCompleted = "completed"
+	ScheduleStatusCancelled = "cancelled"
+)
+
+// Recurrence kinds, Rule holds the cron expression or RRULE
+const (
+	RecurrenceOnce  = "once"
+	RecurrenceCron  = "cron"
+	RecurrenceRRule = "rrule"
+)
+
+// Outcome of one occurrence of a schedule, a held run settles as succeeded
+// or failed once its transfer is decided in risk review
+const (
+	ScheduleRunSucceeded = "succeeded"
+	ScheduleRunFailed    = "failed"
+	ScheduleRunSkipped   = "skipped"
+	ScheduleRunHeld      = "held"
+)
+
+// Standing order executed by the schedule runner as its owner. Rule is
+// evaluated in Timezone, Occurrences counts every occurrence that was run,
+// failed or skipped.
+type ScheduledTransfer struct {
+	ID             ID              `json:"id" db:"id"`
+	UserID         int64           `json:"user_id" db:"user_id"`
+	FromWalletID   int64           `json:"from_wallet_id" db:"from_wallet_id"`
+	ToWalletID     int64           `json:"to_wallet_id" db:"to_wallet_id"`
+	FromCurrency   string          `json:"from_currency" db:"from_currency"`
+	ToCurrency     string          `json:"to_currency" db:"to_currency"`
+	Amount         decimal.Decimal `json:"amount" db:"amount"`
+	Description    string          `json:"description" db:"description"`
+	Recurrence     string          `json:"recurrence" db:"recurrence"`
+	Rule           string          `json:"rule,omitempty" db:"rule"`
+	Timezone       string          `json:"timezone" db:"timezone"`
+	StartAt        time.Time       `json:"start_at" db:"start_at"`
+	EndAt          *time.Time      `json:"end_at,omitempty" db:"end_at"`
+	MaxOccurrences *int            `json:"max_occurrences,omitempty" db:"max_occurrences"`
+	Occurrences    int             `json:"occurrences" db:"occurrences"`
+	Status         string          `json:"status" db:"status"`
+	NextRunAt      *time.Time      `json:"next_run_at,omitempty" db:"next_run_at"`
+	LastRunAt      *time.Time      `json:"last_run_at,omitempty" db:"last_run_at"`
+	Attempts       int             `json:"-" db:"attempts"`
+	LastError      *string         `json:"last_error,omitempty" db:"last_error"`
+	LockedUntil    *time.Time      `json:"-" db:"locked_until"`
+	CreatedAt      time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at" db:"updated_at"`
+}
+
+// Occurrence number n falling at t is within the end date and max occurrences
+func (s *ScheduledTransfer) Within(t time.Time, n int) bool {
+	if s.EndAt != nil && t.After(*s.EndAt) {
+		return false
+	}
+	if s.MaxOccurrences != nil && n > *s.MaxOccurrences {
+		return false
+	}
+	return true
+}
+
+// Deterministic ref_id of the occurrence due at t, a retried occurrence reuses it
+// so TransferTx treats the retry as a replay. It is a system reference no client
+// can post first.
+func (s *ScheduledTransfer) OccurrenceRef(t time.Time) string {
+	return SystemRef("sched", strconv.FormatInt(s.ID, 10), strconv.FormatInt(t.Unix(), 10))
+}
+
+// Record of one occurrence of a schedule
+type ScheduledTransferRun struct {
+	ID           ID        `json:"id" db:"id"`
+	ScheduleID   ID        `json:"schedule_id" db:"schedule_id"`
+	Occurrence   int       `json:"occurrence" db:"occurrence"`
+	ScheduledFor time.Time `json:"scheduled_for" db:"scheduled_for"`
+	RefID        string    `json:"ref_id" db:"ref_id"`
+	Status       string    `json:"status" db:"status"`
+	Error        *string   `json:"error,omitempty" db:"error"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+}
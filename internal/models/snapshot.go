@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Daily balance snapshot, its lines hold the balances built from the posted
+// ledger rows created before AsOf
+type BalanceSnapshot struct {
+	AsOf        time.Time `json:"as_of" db:"as_of"`
+	WalletCount int       `json:"wallet_count" db:"wallet_count"`
+	LineCount   int       `json:"line_count" db:"line_count"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
+
+// Balance of a wallet currency at a point in time, UserID is only set in
+// bulk exports
+type HistoricalBalance struct {
+	WalletID int64           `json:"wallet_id" db:"wallet_id"`
+	UserID   int64           `json:"user_id,omitempty" db:"user_id"`
+	Currency string          `json:"currency" db:"currency"`
+	Amount   decimal.Decimal `json:"amount" db:"amount"`
+}
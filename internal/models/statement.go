@@ -0,0 +1,65 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Statement states
+const (
+	StatementPending   = "pending"
+	StatementCompleted = "completed"
+	StatementFailed    = "failed"
+)
+
+// Statement file formats
+const (
+	StatementFormatCSV = "csv"
+	StatementFormatPDF = "pdf"
+)
+
+// Statement of a wallet in one currency over [PeriodFrom, PeriodTo). The file
+// is rendered by the statement generator, DownloadURL is a presigned link
+// that is only set on completed statements.
+type Statement struct {
+	ID             ID               `json:"id" db:"id"`
+	UserID         int64            `json:"user_id" db:"user_id"`
+	WalletID       int64            `json:"wallet_id" db:"wallet_id"`
+	Currency       string           `json:"currency" db:"currency"`
+	Format         string           `json:"format" db:"format"`
+	PeriodFrom     time.Time        `json:"period_from" db:"period_from"`
+	PeriodTo       time.Time        `json:"period_to" db:"period_to"`
+	Status         string           `json:"status" db:"status"`
+	ObjectKey      *string          `json:"-" db:"object_key"`
+	OpeningBalance *decimal.Decimal `json:"opening_balance,omitempty" db:"opening_balance"`
+	ClosingBalance *decimal.Decimal `json:"closing_balance,omitempty" db:"closing_balance"`
+	TxCount        int              `json:"tx_count" db:"tx_count"`
+	Error          *string          `json:"error,omitempty" db:"error"`
+	Attempts       int              `json:"attempts" db:"attempts"`
+	LockedUntil    *time.Time       `json:"-" db:"locked_until"`
+	CreatedAt      time.Time        `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time        `json:"updated_at" db:"updated_at"`
+	CompletedAt    *time.Time       `json:"completed_at,omitempty" db:"completed_at"`
+	DownloadURL    string           `json:"download_url,omitempty" db:"-"`
+}
+
+// File name offered for download
+func (s *Statement) FileName() string {
+	return fmt.Sprintf("statement-%d-%s-%s-%s.%s",
+		s.WalletID, s.Currency, s.PeriodFrom.UTC().Format("20060102"), s.PeriodTo.UTC().Format("20060102"), s.Format)
+}
+
+// Object key of the rendered file, statements of a user share a prefix
+func (s *Statement) Key() string {
+	return fmt.Sprintf("statements/%d/%d/%s", s.UserID, s.ID, s.FileName())
+}
+
+// Content type of the rendered file
+func (s *Statement) ContentType() string {
+	if s.Format == StatementFormatPDF {
+		return "application/pdf"
+	}
+	return "text/csv"
+}
@@ -1,45 +1,173 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
 
 type ID = int64
 
 type Wallet struct {
+	ID              ID              `json:"id" db:"id"`
+	UserID          uint            `json:"user_id" db:"user_id"`
+	Name            string          `json:"name" db:"name"`
+	Status          string          `json:"status" db:"status"`
+	StatusReason    *string         `json:"status_reason,omitempty" db:"status_reason"`
+	StatusChangedBy *int64          `json:"status_changed_by,omitempty" db:"status_changed_by"`
+	StatusChangedAt *time.Time      `json:"status_changed_at,omitempty" db:"status_changed_at"`
+	CreatedAt       time.Time       `json:"created_at" db:"created_at"`
+	Balances        []WalletBalance `json:"balances"`
+	Totals          []WalletBalance `json:"totals,omitempty"`
+}
+
+// Wallet lifecycle states
+const (
+	WalletStatusActive   = "active"
+	WalletStatusFrozen   = "frozen"
+	WalletStatusClosed   = "closed"
+	WalletStatusArchived = "archived"
+)
+
+// Allowed lifecycle transitions, keyed by target state
+var walletTransitions = map[string][]string{
+	WalletStatusFrozen:   {WalletStatusActive},
+	WalletStatusActive:   {WalletStatusFrozen},
+	WalletStatusClosed:   {WalletStatusActive, WalletStatusFrozen},
+	WalletStatusArchived: {WalletStatusClosed},
+}
+
+// Wallet can move from its current state to status
+func (w *Wallet) CanTransition(status string) bool {
+	for _, from := range walletTransitions[status] {
+		if w.Status == from {
+			return true
+		}
+	}
+	return false
+}
+
+// Audit record of a wallet state change
+type WalletStatusEvent struct {
+	ID         ID        `json:"id" db:"id"`
+	WalletID   ID        `json:"wallet_id" db:"wallet_id"`
+	FromStatus string    `json:"from_status" db:"from_status"`
+	ToStatus   string    `json:"to_status" db:"to_status"`
+	Reason     string    `json:"reason" db:"reason"`
+	ActorID    *int64    `json:"actor_id,omitempty" db:"actor_id"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
+
+type WalletBalance struct {
+	WalletID ID              `json:"wallet_id" db:"wallet_id"`
+	Currency string          `json:"currency" db:"currency"`
+	Amount   decimal.Decimal `json:"amount" db:"amount"`
+	Held     decimal.Decimal `json:"held" db:"held"`
+}
+
+// Amount that is not reserved by pending withdrawals
+func (b *WalletBalance) Available() decimal.Decimal {
+	return b.Amount.Sub(b.Held)
+}
+
+// Withdrawal places a hold on a balance until it is settled or released
+type Withdrawal struct {
 	ID        ID              `json:"id" db:"id"`
-	UserID    uint            `json:"user_id" db:"user_id"`
-	Name      string          `json:"name" db:"name"`
+	WalletID  ID              `json:"wallet_id" db:"wallet_id"`
+	Currency  string          `json:"currency" db:"currency"`
+	Amount    decimal.Decimal `json:"amount" db:"amount"`
+	Status    string          `json:"status" db:"status"`
+	RefID     string          `json:"ref_id" db:"ref_id"`
 	CreatedAt time.Time       `json:"created_at" db:"created_at"`
-	Balances  []WalletBalance `json:"balances"`
+	UpdatedAt time.Time       `json:"updated_at" db:"updated_at"`
 }
 
-type WalletBalance struct {
-	WalletID ID     `json:"wallet_id" db:"wallet_id"`
-	Currency string `json:"currency" db:"currency"`
-	Amount   int64  `json:"amount" db:"amount"`
+// Withdrawal holds the same amount on the same wallet as o, what a replayed
+// reference has to match
+func (w *Withdrawal) SameHold(o *Withdrawal) bool {
+	return w.WalletID == o.WalletID && w.Currency == o.Currency && w.Amount.Equal(o.Amount)
 }
 
 type Transaction struct {
-	ID        int64     `db:"id"`
-	WalletID  int64     `db:"wallet_id"`
-	Type      string    `db:"type"`
-	Currency  string    `db:"currency"`
-	Amount    int64     `db:"amount"`
-	RefID     *string   `db:"ref_id"`
-	Meta      []byte    `db:"meta"`
-	CreatedAt time.Time `db:"created_at"`
+	ID        int64           `db:"id"`
+	WalletID  int64           `db:"wallet_id"`
+	Type      string          `db:"type"`
+	Currency  string          `db:"currency"`
+	Amount    decimal.Decimal `db:"amount"`
+	RefID     *string         `db:"ref_id"`
+	Meta      []byte          `db:"meta"`
+	CreatedAt time.Time       `db:"created_at"`
+}
+
+// Transfer between two wallets, ConvertedAmount is credited in ToCurrency
+type Transfer struct {
+	FromWalletID    int64
+	ToWalletID      int64
+	FromCurrency    string
+	ToCurrency      string
+	Amount          decimal.Decimal
+	ConvertedAmount decimal.Decimal
+	Rate            decimal.Decimal
+	QuoteID         string
+	RefID           string
+	// Fee is charged to the sender in FromCurrency on top of Amount
+	// and credited to FeeWalletID
+	Fee         Fee
+	FeeWalletID int64
+}
+
+// Transfer moves the same amount between the same wallets as o, what a
+// replayed reference has to match
+func (t *Transfer) SameMovement(o *Transfer) bool {
+	return t.FromWalletID == o.FromWalletID && t.ToWalletID == o.ToWalletID &&
+		t.FromCurrency == o.FromCurrency && t.ToCurrency == o.ToCurrency &&
+		t.Amount.Equal(o.Amount)
 }
 
 const (
-	TypeDeposit     = "deposit"
-	TypeWithdraw    = "withdraw"
-	TypeTransferOut = "transfer_out"
-	TypeTransferIn  = "transfer_in"
-	TypePayment     = "payment"
+	TypeDeposit         = "deposit"
+	TypeWithdraw        = "withdraw"
+	TypeWithdrawHold    = "withdraw_hold"
+	TypeWithdrawRelease = "withdraw_release"
+	TypeTransferOut     = "transfer_out"
+	TypeTransferIn      = "transfer_in"
+	TypePayment         = "payment"
+	TypeFee             = "fee"
+	TypeFeeIncome       = "fee_income"
+	TypeReversalIn      = "reversal_in"
+	TypeReversalOut     = "reversal_out"
+	TypeFeeRefund       = "fee_refund"
+	TypeFeeReversal     = "fee_reversal"
+	TypeEscrowHold      = "escrow_hold"
+	TypeEscrowRelease   = "escrow_release"
+	TypeEscrowRefund    = "escrow_refund"
+	TypePocketOut       = "pocket_out"
+	TypePocketIn        = "pocket_in"
+	TypeRiskHold        = "risk_hold"
+	TypeRiskRelease     = "risk_release"
 
 	StatusSuccess = "success"
 	StatusFailed  = "failed"
+
+	WithdrawalStatusHeld     = "held"
+	WithdrawalStatusSettled  = "settled"
+	WithdrawalStatusReleased = "released"
 )
 
+// Transaction history filter, rows are returned newest first
+type TransactionFilter struct {
+	WalletID ID
+	Type     string
+	Currency string
+	RefID    string
+	From     *time.Time
+	To       *time.Time
+	// keyset position, rows strictly older than (BeforeCreatedAt, BeforeID)
+	BeforeCreatedAt *time.Time
+	BeforeID        int64
+	Limit           int
+}
+
 type WalletList struct {
 	TotalCount int       `json:"total_count"`
 	TotalPages int       `json:"total_pages"`
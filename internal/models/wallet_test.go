@@ -0,0 +1,33 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWallet_CanTransition(t *testing.T) {
+	t.Parallel()
+
+	w := &Wallet{Status: WalletStatusActive}
+	require.True(t, w.CanTransition(WalletStatusFrozen))
+	require.True(t, w.CanTransition(WalletStatusClosed))
+	require.False(t, w.CanTransition(WalletStatusArchived))
+	require.False(t, w.CanTransition(WalletStatusActive))
+
+	w.Status = WalletStatusClosed
+	require.True(t, w.CanTransition(WalletStatusArchived))
+	require.False(t, w.CanTransition(WalletStatusActive))
+	require.False(t, w.CanTransition(WalletStatusFrozen))
+}
+
+func TestWithdrawal_SameHold(t *testing.T) {
+	t.Parallel()
+
+	w := &Withdrawal{WalletID: 1, Currency: "USD", Amount: decimal.RequireFromString("10.50")}
+	require.True(t, w.SameHold(&Withdrawal{WalletID: 1, Currency: "USD", Amount: decimal.RequireFromString("10.5")}))
+	require.False(t, w.SameHold(&Withdrawal{WalletID: 2, Currency: "USD", Amount: decimal.RequireFromString("10.50")}))
+	require.False(t, w.SameHold(&Withdrawal{WalletID: 1, Currency: "EUR", Amount: decimal.RequireFromString("10.50")}))
+	require.False(t, w.SameHold(&Withdrawal{WalletID: 1, Currency: "USD", Amount: decimal.RequireFromString("11")}))
+}
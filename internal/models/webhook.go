@@ -0,0 +1,93 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// Events merchants can subscribe a webhook endpoint to
+var WebhookEventTypes = []string{
+	EventWalletDeposited, EventWalletTransferred, EventWalletTransferReversed, EventWalletAdjusted,
+	EventTransactionHeld, EventTransactionRejected,
+	EventEscrowHeld, EventEscrowReleased, EventEscrowRefunded, EventEscrowDisputed,
+}
+
+// Webhook delivery states
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliveryDelivered = "delivered"
+	WebhookDeliveryFailed    = "failed"
+)
+
+// List of strings stored as a jsonb array
+type StringList []string
+
+// Value implements driver.Valuer
+func (l StringList) Value() (driver.Value, error) {
+	if l == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(l)
+}
+
+// Scan implements sql.Scanner
+func (l *StringList) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*l = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, l)
+	case string:
+		return json.Unmarshal([]byte(v), l)
+	default:
+		return errors.New("string list: unsupported column type")
+	}
+}
+
+// Contains reports whether s is in the list
+func (l StringList) Contains(s string) bool {
+	for _, v := range l {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Merchant callback endpoint, Secret is only returned when the endpoint is created
+type WebhookEndpoint struct {
+	ID         ID         `json:"id" db:"id"`
+	UserID     int        `json:"user_id" db:"user_id"`
+	URL        string     `json:"url" db:"url"`
+	Secret     string     `json:"secret,omitempty" db:"secret"`
+	EventTypes StringList `json:"event_types" db:"event_types"`
+	Active     bool       `json:"active" db:"active"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+}
+
+// One event to deliver to one endpoint, with the outcome of the last attempt
+type WebhookDelivery struct {
+	ID             ID         `json:"id" db:"id"`
+	EndpointID     ID         `json:"endpoint_id" db:"endpoint_id"`
+	EventID        string     `json:"event_id" db:"event_id"`
+	EventType      string     `json:"event_type" db:"event_type"`
+	Payload        []byte     `json:"-" db:"payload"`
+	Status         string     `json:"status" db:"status"`
+	Attempts       int        `json:"attempts" db:"attempts"`
+	LastStatusCode *int       `json:"last_status_code,omitempty" db:"last_status_code"`
+	LastError      *string    `json:"last_error,omitempty" db:"last_error"`
+	NextAttemptAt  time.Time  `json:"next_attempt_at" db:"next_attempt_at"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty" db:"delivered_at"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// Delivery claimed for sending, joined with its endpoint
+type WebhookDispatch struct {
+	WebhookDelivery
+	URL    string `db:"url"`
+	Secret string `db:"secret"`
+}
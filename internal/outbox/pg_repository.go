@@ -0,0 +1,17 @@
+//go:generate mockgen -source pg_repository.go -destination mock/pg_repository_mock.go -package mock
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+)
+
+// Outbox repository interface, used by the relay
+type Repository interface {
+	ClaimBatch(ctx context.Context, limit int, lease time.Duration) ([]*models.OutboxEvent, error)
+	MarkPublished(ctx context.Context, id int64) error
+	MarkFailed(ctx context.Context, id int64, reason string, retryAt time.Time) error
+	MarkDeadLettered(ctx context.Context, id int64, reason string) error
+}
@@ -0,0 +1,12 @@
+package outbox
+
+import (
+	"context"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+)
+
+// Delivers outbox events to consumers, an error leaves the event for a retry
+type EventPublisher interface {
+	Publish(ctx context.Context, event *models.OutboxEvent) error
+}
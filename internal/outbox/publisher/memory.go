@@ -0,0 +1,52 @@
+package publisher
+
+import (
+	"context"
+	"sync"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+)
+
+// In-memory publisher for tests and local runs, failures can be injected
+type MemoryPublisher struct {
+	mu       sync.Mutex
+	events   []*models.OutboxEvent
+	failures int
+	err      error
+}
+
+// Memory publisher constructor
+func NewMemoryPublisher() *MemoryPublisher {
+	return &MemoryPublisher{}
+}
+
+func (p *MemoryPublisher) Publish(ctx context.Context, event *models.OutboxEvent) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.failures > 0 {
+		p.failures--
+		return p.err
+	}
+
+	p.events = append(p.events, event)
+	return nil
+}
+
+// Fail the next n publishes with err
+func (p *MemoryPublisher) FailNext(n int, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.failures, p.err = n, err
+}
+
+// Events published so far, in publish order
+func (p *MemoryPublisher) Events() []*models.OutboxEvent {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	events := make([]*models.OutboxEvent, len(p.events))
+	copy(events, p.events)
+	return events
+}
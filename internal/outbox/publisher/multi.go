@@ -0,0 +1,29 @@
+package publisher
+
+import (
+	"context"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/outbox"
+)
+
+// Publishes every event to each publisher in turn. The first error stops the
+// event and it is retried on all of them, so publishers must tolerate duplicates.
+type multiPublisher struct {
+	publishers []outbox.EventPublisher
+}
+
+// Multi publisher constructor
+func NewMultiPublisher(publishers ...outbox.EventPublisher) outbox.EventPublisher {
+	return &multiPublisher{publishers: publishers}
+}
+
+func (p *multiPublisher) Publish(ctx context.Context, event *models.OutboxEvent) error {
+	for _, publisher := range p.publishers {
+		if err := publisher.Publish(ctx, event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
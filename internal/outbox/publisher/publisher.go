@@ -0,0 +1,22 @@
+package publisher
+
+import (
+	"fmt"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/config"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/outbox"
+)
+
+// Build the event publisher selected by cfg.Outbox.Publisher: redis or memory
+func NewEventPublisher(cfg *config.Config, redisClient *redis.Client) (outbox.EventPublisher, error) {
+	switch cfg.Outbox.Publisher {
+	case "redis":
+		return NewRedisStreamPublisher(redisClient, cfg.Outbox.Stream, cfg.Outbox.StreamMaxLen), nil
+	case "memory":
+		return NewMemoryPublisher(), nil
+	default:
+		return nil, fmt.Errorf("unknown outbox publisher %q", cfg.Outbox.Publisher)
+	}
+}
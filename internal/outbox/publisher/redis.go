@@ -0,0 +1,48 @@
+package publisher
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/pkg/errors"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/outbox"
+)
+
+// Publishes events to a Redis stream, trimmed to roughly maxLen entries
+type redisStreamPublisher struct {
+	client *redis.Client
+	stream string
+	maxLen int64
+}
+
+// Redis Streams publisher constructor, maxLen 0 keeps the stream untrimmed
+func NewRedisStreamPublisher(client *redis.Client, stream string, maxLen int64) outbox.EventPublisher {
+	return &redisStreamPublisher{client: client, stream: stream, maxLen: maxLen}
+}
+
+func (p *redisStreamPublisher) Publish(ctx context.Context, event *models.OutboxEvent) error {
+	args := &redis.XAddArgs{
+		Stream: p.stream,
+		Values: map[string]interface{}{
+			"event_id":       event.EventID,
+			"event_type":     event.EventType,
+			"aggregate_type": event.AggregateType,
+			"aggregate_id":   event.AggregateID,
+			"payload":        string(event.Payload),
+			"created_at":     event.CreatedAt.UTC().Format(time.RFC3339Nano),
+		},
+	}
+	if p.maxLen > 0 {
+		args.MaxLen = p.maxLen
+		args.Approx = true
+	}
+
+	if err := p.client.XAdd(ctx, args).Err(); err != nil {
+		return errors.Wrap(err, "redisStreamPublisher.Publish.XAdd")
+	}
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package relay
+
+import (
+	"context"
+	"time"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/config"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/outbox"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/logger"
+)
+
+const (
+	defaultBatchSize    = 100
+	defaultMaxAttempts  = 10
+	defaultPollInterval = time.Second
+	defaultRetryBackoff = 5 * time.Second
+	defaultLease        = 30 * time.Second
+	maxRetryBackoff     = time.Hour
+)
+
+// Relay moves outbox events to the publisher with at-least-once delivery.
+// Failed events are retried with exponential backoff and dead-lettered
+// after maxAttempts.
+type Relay struct {
+	outboxRepo   outbox.Repository
+	publisher    outbox.EventPublisher
+	batchSize    int
+	maxAttempts  int
+	pollInterval time.Duration
+	retryBackoff time.Duration
+	lease        time.Duration
+	logger       logger.Logger
+}
+
+// Relay constructor, zero config values fall back to defaults
+func NewRelay(cfg *config.Config, outboxRepo outbox.Repository, publisher outbox.EventPublisher, log logger.Logger) *Relay {
+	r := &Relay{
+		outboxRepo:   outboxRepo,
+		publisher:    publisher,
+		batchSize:    cfg.Outbox.BatchSize,
+		maxAttempts:  cfg.Outbox.MaxAttempts,
+		pollInterval: time.Duration(cfg.Outbox.PollIntervalSeconds) * time.Second,
+		retryBackoff: time.Duration(cfg.Outbox.RetryBackoffSeconds) * time.Second,
+		lease:        time.Duration(cfg.Outbox.LeaseSeconds) * time.Second,
+		logger:       log,
+	}
+
+	if r.batchSize <= 0 {
+		r.batchSize = defaultBatchSize
+	}
+	if r.maxAttempts <= 0 {
+		r.maxAttempts = defaultMaxAttempts
+	}
+	if r.pollInterval <= 0 {
+		r.pollInterval = defaultPollInterval
+	}
+	if r.retryBackoff <= 0 {
+		r.retryBackoff = defaultRetryBackoff
+	}
+	if r.lease <= 0 {
+		r.lease = defaultLease
+	}
+
+	return r
+}
+
+// Poll the outbox until ctx is done, a full batch is followed immediately by the next one
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		n, err := r.ProcessBatch(ctx)
+		if err != nil {
+			r.logger.Errorf("outbox relay: %s", err)
+		}
+
+		if err == nil && n == r.batchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Publish one batch of due events, returns how many were claimed
+func (r *Relay) ProcessBatch(ctx context.Context) (int, error) {
+	events, err := r.outboxRepo.ClaimBatch(ctx, r.batchSize, r.lease)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, event := range events {
+		if err := r.deliver(ctx, event); err != nil {
+			return len(events), err
+		}
+	}
+
+	return len(events), nil
+}
+
+// Publish event and record the outcome, only repository errors are returned
+func (r *Relay) deliver(ctx context.Context, event *models.OutboxEvent) error {
+	publishErr := r.publisher.Publish(ctx, event)
+	if publishErr == nil {
+		return r.outboxRepo.MarkPublished(ctx, event.ID)
+	}
+
+	attempts := event.Attempts + 1
+	if attempts >= r.maxAttempts {
+		r.logger.Errorf("outbox relay: dead-lettering event %s (%s) after %d attempts: %s",
+			event.EventID, event.EventType, attempts, publishErr)
+		return r.outboxRepo.MarkDeadLettered(ctx, event.ID, publishErr.Error())
+	}
+
+	r.logger.Warnf("outbox relay: publishing event %s failed, attempt %d: %s", event.EventID, attempts, publishErr)
+	return r.outboxRepo.MarkFailed(ctx, event.ID, publishErr.Error(), time.Now().Add(r.backoff(attempts)))
+}
+
+// Delay before the next attempt, doubling per attempt
+func (r *Relay) backoff(attempts int) time.Duration {
+	delay := r.retryBackoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= maxRetryBackoff {
+			return maxRetryBackoff
+		}
+	}
+
+	return delay
+}
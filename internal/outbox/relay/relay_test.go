@@ -0,0 +1,70 @@
+package relay
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/config"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/outbox/mock"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/outbox/publisher"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/logger"
+)
+
+func newTestRelay(repo *mock.MockRepository, pub *publisher.MemoryPublisher) *Relay {
+	cfg := &config.Config{
+		Logger: config.Logger{Level: "error", Encoding: "console"},
+		Outbox: config.Outbox{BatchSize: 10, MaxAttempts: 3, RetryBackoffSeconds: 1},
+	}
+	l := logger.NewApiLogger(cfg)
+	l.InitLogger()
+
+	return NewRelay(cfg, repo, pub, l)
+}
+
+func TestRelay_ProcessBatch(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockRepository(ctrl)
+	pub := publisher.NewMemoryPublisher()
+	relay := newTestRelay(repo, pub)
+
+	retried := &models.OutboxEvent{ID: 1, EventID: "a", EventType: models.EventWalletTransferred}
+	dead := &models.OutboxEvent{ID: 2, EventID: "b", EventType: models.EventWalletTransferred, Attempts: 2}
+	published := &models.OutboxEvent{ID: 3, EventID: "c", EventType: models.EventWalletDeposited}
+
+	// the broker is down for the first two events
+	pub.FailNext(2, errors.New("broker down"))
+
+	repo.EXPECT().ClaimBatch(gomock.Any(), 10, 30*time.Second).Return([]*models.OutboxEvent{retried, dead, published}, nil)
+	repo.EXPECT().MarkFailed(gomock.Any(), int64(1), "broker down", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ int64, _ string, retryAt time.Time) error {
+			require.WithinDuration(t, time.Now().Add(time.Second), retryAt, time.Second)
+			return nil
+		})
+	repo.EXPECT().MarkDeadLettered(gomock.Any(), int64(2), "broker down").Return(nil)
+	repo.EXPECT().MarkPublished(gomock.Any(), int64(3)).Return(nil)
+
+	n, err := relay.ProcessBatch(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 3, n)
+	require.Len(t, pub.Events(), 1)
+	require.Equal(t, "c", pub.Events()[0].EventID)
+}
+
+func TestRelay_Backoff(t *testing.T) {
+	t.Parallel()
+
+	relay := &Relay{retryBackoff: 5 * time.Second}
+	require.Equal(t, 5*time.Second, relay.backoff(1))
+	require.Equal(t, 20*time.Second, relay.backoff(3))
+	require.Equal(t, maxRetryBackoff, relay.backoff(30))
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/outbox"
+)
+
+// Outbox Repository
+type outboxRepo struct {
+	db *sqlx.DB
+}
+
+// Outbox Repository constructor
+func NewOutboxRepository(db *sqlx.DB) outbox.Repository {
+	return &outboxRepo{db: db}
+}
+
+// Append events to the outbox inside the transaction of the change they describe
+func WriteEventsTx(ctx context.Context, tx sqlx.ExecerContext, events ...*models.OutboxEvent) error {
+	for _, e := range events {
+		if _, err := tx.ExecContext(ctx, insertEventQuery,
+			e.EventID, e.AggregateType, e.AggregateID, e.EventType, e.Payload, e.CreatedAt,
+		); err != nil {
+			return errors.Wrap(err, "outbox.WriteEventsTx.ExecContext")
+		}
+	}
+
+	return nil
+}
+
+// Claim up to limit due events for lease, oldest first
+func (r *outboxRepo) ClaimBatch(ctx context.Context, limit int, lease time.Duration) ([]*models.OutboxEvent, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "outboxRepo.ClaimBatch")
+	defer span.Finish()
+
+	events := make([]*models.OutboxEvent, 0, limit)
+	if err := r.db.SelectContext(ctx, &events, claimBatchQuery, limit, lease.Seconds()); err != nil {
+		return nil, errors.Wrap(err, "outboxRepo.ClaimBatch.SelectContext")
+	}
+
+	sort.Slice(events, func(i, j int) bool { return events[i].ID < events[j].ID })
+	return events, nil
+}
+
+// Mark event delivered
+func (r *outboxRepo) MarkPublished(ctx context.Context, id int64) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "outboxRepo.MarkPublished")
+	defer span.Finish()
+
+	if _, err := r.db.ExecContext(ctx, markPublishedQuery, id); err != nil {
+		return errors.Wrap(err, "outboxRepo.MarkPublished.ExecContext")
+	}
+
+	return nil
+}
+
+// Record a failed attempt and schedule the next one
+func (r *outboxRepo) MarkFailed(ctx context.Context, id int64, reason string, retryAt time.Time) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "outboxRepo.MarkFailed")
+	defer span.Finish()
+
+	if _, err := r.db.ExecContext(ctx, markFailedQuery, id, reason, retryAt); err != nil {
+		return errors.Wrap(err, "outboxRepo.MarkFailed.ExecContext")
+	}
+
+	return nil
+}
+
+// Stop retrying event, it stays in the table for inspection
+func (r *outboxRepo) MarkDeadLettered(ctx context.Context, id int64, reason string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "outboxRepo.MarkDeadLettered")
+	defer span.Finish()
+
+	if _, err := r.db.ExecContext(ctx, markDeadLetteredQuery, id, reason); err != nil {
+		return errors.Wrap(err, "outboxRepo.MarkDeadLettered.ExecContext")
+	}
+
+	return nil
+}
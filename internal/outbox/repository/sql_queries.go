@@ -0,0 +1,22 @@
+package repository
+
+const (
+	insertEventQuery = `INSERT INTO outbox (event_id, aggregate_type, aggregate_id, event_type, payload, created_at)
+						VALUES ($1, $2, $3, $4, $5, $6)`
+
+	// lease due events so concurrent relays skip them until the lease expires
+	claimBatchQuery = `UPDATE outbox SET available_at = now() + make_interval(secs => $2)
+						WHERE id IN (
+							SELECT id FROM outbox
+							WHERE published_at IS NULL AND dead_lettered_at IS NULL AND available_at <= now()
+							ORDER BY id LIMIT $1
+							FOR UPDATE SKIP LOCKED
+						)
+						RETURNING *`
+
+	markPublishedQuery = `UPDATE outbox SET published_at = now(), attempts = attempts + 1, last_error = NULL WHERE id = $1`
+
+	markFailedQuery = `UPDATE outbox SET attempts = attempts + 1, last_error = $2, available_at = $3 WHERE id = $1`
+
+	markDeadLetteredQuery = `UPDATE outbox SET attempts = attempts + 1, last_error = $2, dead_lettered_at = now() WHERE id = $1`
+)
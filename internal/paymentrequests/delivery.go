@@ -0,0 +1,14 @@
+package paymentrequests
+
+import "github.com/labstack/echo/v4"
+
+// Payment requests HTTP Handlers interface
+type Handlers interface {
+	Create() echo.HandlerFunc
+	ListIncoming() echo.HandlerFunc
+	ListOutgoing() echo.HandlerFunc
+	Get() echo.HandlerFunc
+	Pay() echo.HandlerFunc
+	Decline() echo.HandlerFunc
+	Cancel() echo.HandlerFunc
+}
@@ -0,0 +1,222 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/opentracing/opentracing-go"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/dto"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/paymentrequests"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/httpErrors"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/logger"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/utils"
+)
+
+type paymentRequestsHandlers struct {
+	requestsUC paymentrequests.UseCase
+	logger     logger.Logger
+}
+
+func NewPaymentRequestsHandlers(requestsUC paymentrequests.UseCase, log logger.Logger) paymentrequests.Handlers {
+	return &paymentRequestsHandlers{requestsUC: requestsUC, logger: log}
+}
+
+// Create godoc
+// @Summary Request money
+// @Description Request money from a user found by username or email, paid into a wallet of the current user
+// @Tags PaymentRequests
+// @Accept json
+// @Produce json
+// @Param body body dto.RequestPaymentRequest true "payment request"
+// @Success 201 {object} models.PaymentRequest
+// @Router /payment-requests [post]
+func (h *paymentRequestsHandlers) Create() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "paymentRequests.Create")
+		defer span.Finish()
+
+		paymentRequest := &dto.RequestPaymentRequest{}
+		if err := utils.ReadRequest(c, paymentRequest); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		created, err := h.requestsUC.Create(ctx, paymentRequest)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusCreated, created)
+	}
+}
+
+// ListIncoming godoc
+// @Summary List incoming payment requests
+// @Description List the requests the current user has to pay, newest first
+// @Tags PaymentRequests
+// @Produce json
+// @Param status query string false "pending, held, paid, declined, expired or cancelled"
+// @Success 200 {array} models.PaymentRequest
+// @Router /payment-requests/incoming [get]
+func (h *paymentRequestsHandlers) ListIncoming() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "paymentRequests.ListIncoming")
+		defer span.Finish()
+
+		list, err := h.requestsUC.ListIncoming(ctx, c.QueryParam("status"))
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, list)
+	}
+}
+
+// ListOutgoing godoc
+// @Summary List outgoing payment requests
+// @Description List the requests sent by the current user, newest first
+// @Tags PaymentRequests
+// @Produce json
+// @Param status query string false "pending, held, paid, declined, expired or cancelled"
+// @Success 200 {array} models.PaymentRequest
+// @Router /payment-requests/outgoing [get]
+func (h *paymentRequestsHandlers) ListOutgoing() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "paymentRequests.ListOutgoing")
+		defer span.Finish()
+
+		list, err := h.requestsUC.ListOutgoing(ctx, c.QueryParam("status"))
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, list)
+	}
+}
+
+// Get godoc
+// @Summary Get payment request
+// @Description Get a payment request the current user sent or has to pay
+// @Tags PaymentRequests
+// @Produce json
+// @Param id path int true "payment_request_id"
+// @Success 200 {object} models.PaymentRequest
+// @Router /payment-requests/{id} [get]
+func (h *paymentRequestsHandlers) Get() echo.HandlerFunc {
+	return h.requestAction("paymentRequests.Get", h.requestsUC.Get)
+}
+
+// Pay godoc
+// @Summary Pay payment request
+// @Description Accept a payment request, transferring the amount from a wallet of the payer
+// @Tags PaymentRequests
+// @Accept json
+// @Produce json
+// @Param id path int true "payment_request_id"
+// @Param body body dto.RequestPayPaymentRequest true "source wallet"
+// @Success 200 {object} models.PaymentRequest
+// @Router /payment-requests/{id}/pay [post]
+func (h *paymentRequestsHandlers) Pay() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "paymentRequests.Pay")
+		defer span.Finish()
+
+		requestID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(httpErrors.NewBadRequestError(err.Error())))
+		}
+
+		payRequest := &dto.RequestPayPaymentRequest{}
+		if err := utils.ReadRequest(c, payRequest); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		paid, err := h.requestsUC.Pay(ctx, requestID, payRequest)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, paid)
+	}
+}
+
+// Decline godoc
+// @Summary Decline payment request
+// @Description Payer declines a pending payment request
+// @Tags PaymentRequests
+// @Accept json
+// @Produce json
+// @Param id path int true "payment_request_id"
+// @Param body body dto.RequestDeclinePaymentRequest false "reason"
+// @Success 200 {object} models.PaymentRequest
+// @Router /payment-requests/{id}/decline [post]
+func (h *paymentRequestsHandlers) Decline() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "paymentRequests.Decline")
+		defer span.Finish()
+
+		requestID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(httpErrors.NewBadRequestError(err.Error())))
+		}
+
+		declineRequest := &dto.RequestDeclinePaymentRequest{}
+		if err := utils.ReadRequest(c, declineRequest); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		declined, err := h.requestsUC.Decline(ctx, requestID, declineRequest)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, declined)
+	}
+}
+
+// Cancel godoc
+// @Summary Cancel payment request
+// @Description Requester withdraws a pending payment request
+// @Tags PaymentRequests
+// @Produce json
+// @Param id path int true "payment_request_id"
+// @Success 200 {object} models.PaymentRequest
+// @Router /payment-requests/{id}/cancel [post]
+func (h *paymentRequestsHandlers) Cancel() echo.HandlerFunc {
+	return h.requestAction("paymentRequests.Cancel", h.requestsUC.Cancel)
+}
+
+// Handler for a use case method taking the request id path param
+func (h *paymentRequestsHandlers) requestAction(operation string, action func(ctx context.Context, requestID int64) (*models.PaymentRequest, error)) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), operation)
+		defer span.Finish()
+
+		requestID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(httpErrors.NewBadRequestError(err.Error())))
+		}
+
+		p, err := action(ctx, requestID)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, p)
+	}
+}
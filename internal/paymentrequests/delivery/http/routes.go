@@ -0,0 +1,24 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/config"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/auth"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/middleware"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/paymentrequests"
+)
+
+// Map payment requests routes, requests are scoped to their requester and payer
+func MapPaymentRequestsRoutes(requestsGroup *echo.Group, h paymentrequests.Handlers, mw *middleware.MiddlewareManager, idemMw *middleware.IdempotencyMiddleware, authUc auth.UseCase, cfg *config.Config) {
+	requestsGroup.Use(mw.AuthJWTMiddleware(authUc, cfg))
+	requestsGroup.Use(mw.AuthSessionMiddleware)
+
+	requestsGroup.POST("", h.Create())
+	requestsGroup.GET("/incoming", h.ListIncoming())
+	requestsGroup.GET("/outgoing", h.ListOutgoing())
+	requestsGroup.GET("/:id", h.Get())
+	requestsGroup.POST("/:id/pay", h.Pay(), idemMw.Idempotent)
+	requestsGroup.POST("/:id/decline", h.Decline())
+	requestsGroup.POST("/:id/cancel", h.Cancel())
+}
@@ -0,0 +1,16 @@
+package paymentrequests
+
+import (
+	"net/http"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/httpErrors"
+)
+
+// Payment requests domain errors
+var (
+	ErrRequestNotFound     = httpErrors.NewRestError(http.StatusNotFound, "Payment request not found", nil)
+	ErrRequestAccessDenied = httpErrors.NewRestError(http.StatusForbidden, "Payment request belongs to other users", nil)
+	ErrRequestNotPending   = httpErrors.NewRestError(http.StatusConflict, "Payment request is no longer pending", nil)
+	ErrRequestExpired      = httpErrors.NewRestError(http.StatusUnprocessableEntity, "Payment request has expired", nil)
+	ErrPayerNotFound       = httpErrors.NewRestError(http.StatusNotFound, "Payer not found", nil)
+)
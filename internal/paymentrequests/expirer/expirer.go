@@ -0,0 +1,103 @@
+package expirer
+
+import (
+	"context"
+	"time"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/config"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/paymentrequests"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/wallet"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/logger"
+)
+
+const (
+	defaultBatchSize    = 100
+	defaultPollInterval = time.Minute
+)
+
+// Expirer marks pending payment requests past their expiry as expired and
+// settles requests held for risk review once their transfer is decided. Rows
+// are claimed with SKIP LOCKED, so several instances can sweep together.
+type Expirer struct {
+	requestsRepo paymentrequests.Repository
+	batchSize    int
+	pollInterval time.Duration
+	logger       logger.Logger
+	now          func() time.Time
+}
+
+// Expirer constructor, zero config values fall back to defaults
+func NewExpirer(cfg *config.Config, requestsRepo paymentrequests.Repository, log logger.Logger) *Expirer {
+	e := &Expirer{
+		requestsRepo: requestsRepo,
+		batchSize:    cfg.PaymentRequests.BatchSize,
+		pollInterval: time.Duration(cfg.PaymentRequests.PollIntervalSeconds) * time.Second,
+		logger:       log,
+		now:          time.Now,
+	}
+
+	if e.batchSize <= 0 {
+		e.batchSize = defaultBatchSize
+	}
+	if e.pollInterval <= 0 {
+		e.pollInterval = defaultPollInterval
+	}
+
+	return e
+}
+
+// Sweep until ctx is done, a full batch is followed immediately by the next one
+func (e *Expirer) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := e.SettleHeld(ctx); err != nil {
+			e.logger.Errorf("payment request expirer: %s", err)
+		}
+
+		n, err := e.ProcessBatch(ctx)
+		if err != nil {
+			e.logger.Errorf("payment request expirer: %s", err)
+		}
+
+		if err == nil && n == int64(e.batchSize) {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Expire one batch of due requests, returns how many were expired
+func (e *Expirer) ProcessBatch(ctx context.Context) (int64, error) {
+	n, err := e.requestsRepo.ExpireDue(ctx, e.now(), e.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	if n > 0 {
+		e.logger.Infof("payment request expirer: expired %d requests", n)
+	}
+
+	return n, nil
+}
+
+// Settle one batch of held requests whose transfer was decided in risk review,
+// returns how many were settled
+func (e *Expirer) SettleHeld(ctx context.Context) (int64, error) {
+	n, err := e.requestsRepo.SettleHeld(ctx, wallet.ErrTransactionDenied.Error(), e.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	if n > 0 {
+		e.logger.Infof("payment request expirer: settled %d held requests", n)
+	}
+
+	return n, nil
+}
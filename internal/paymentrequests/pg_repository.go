@@ -0,0 +1,27 @@
+//go:generate mockgen -source pg_repository.go -destination mock/pg_repository_mock.go -package mock
+package paymentrequests
+
+import (
+	"context"
+	"time"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+)
+
+// Payment requests repository interface, an empty status lists all states
+type Repository interface {
+	Create(ctx context.Context, request *models.PaymentRequest) (*models.PaymentRequest, error)
+	GetByID(ctx context.Context, requestID int64) (*models.PaymentRequest, error)
+	ListIncoming(ctx context.Context, payerID int64, status string) ([]*models.PaymentRequest, error)
+	ListOutgoing(ctx context.Context, requesterID int64, status string) ([]*models.PaymentRequest, error)
+
+	// Resolve moves a pending request to status, MarkPaid records the transfer
+	// and MarkHeld records one held for risk review
+	Resolve(ctx context.Context, requestID int64, status string, reason *string) (*models.PaymentRequest, error)
+	MarkPaid(ctx context.Context, requestID int64, fromWalletID int64, transferRef string) (*models.PaymentRequest, error)
+	MarkHeld(ctx context.Context, requestID int64, fromWalletID int64, transferRef string) (*models.PaymentRequest, error)
+
+	// Expiry and review sweeps
+	ExpireDue(ctx context.Context, now time.Time, limit int) (int64, error)
+	SettleHeld(ctx context.Context, reason string, limit int) (int64, error)
+}
@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/paymentrequests"
+)
+
+// Payment requests Repository
+type paymentRequestsRepo struct {
+	db *sqlx.DB
+}
+
+// Payment requests Repository constructor
+func NewPaymentRequestsRepository(db *sqlx.DB) paymentrequests.Repository {
+	return &paymentRequestsRepo{db: db}
+}
+
+// Create payment request
+func (r *paymentRequestsRepo) Create(ctx context.Context, p *models.PaymentRequest) (*models.PaymentRequest, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "paymentRequestsRepo.Create")
+	defer span.Finish()
+
+	created := &models.PaymentRequest{}
+	if err := r.db.QueryRowxContext(ctx, createPaymentRequestQuery,
+		p.RequesterID, p.RequesterWalletID, p.PayerID, p.Currency, p.Amount, p.Memo, p.ExpiresAt,
+	).StructScan(created); err != nil {
+		return nil, errors.Wrap(err, "paymentRequestsRepo.Create.StructScan")
+	}
+
+	return created, nil
+}
+
+// Get payment request by id
+func (r *paymentRequestsRepo) GetByID(ctx context.Context, requestID int64) (*models.PaymentRequest, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "paymentRequestsRepo.GetByID")
+	defer span.Finish()
+
+	p := &models.PaymentRequest{}
+	if err := r.db.GetContext(ctx, p, getPaymentRequestQuery, requestID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, paymentrequests.ErrRequestNotFound
+		}
+		return nil, errors.Wrap(err, "paymentRequestsRepo.GetByID.GetContext")
+	}
+
+	return p, nil
+}
+
+// List requests addressed to a payer, newest first
+func (r *paymentRequestsRepo) ListIncoming(ctx context.Context, payerID int64, status string) ([]*models.PaymentRequest, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "paymentRequestsRepo.ListIncoming")
+	defer span.Finish()
+
+	list := make([]*models.PaymentRequest, 0)
+	if err := r.db.SelectContext(ctx, &list, listIncomingQuery, payerID, status); err != nil {
+		return nil, errors.Wrap(err, "paymentRequestsRepo.ListIncoming.SelectContext")
+	}
+
+	return list, nil
+}
+
+// List requests sent by a requester, newest first
+func (r *paymentRequestsRepo) ListOutgoing(ctx context.Context, requesterID int64, status string) ([]*models.PaymentRequest, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "paymentRequestsRepo.ListOutgoing")
+	defer span.Finish()
+
+	list := make([]*models.PaymentRequest, 0)
+	if err := r.db.SelectContext(ctx, &list, listOutgoingQuery, requesterID, status); err != nil {
+		return nil, errors.Wrap(err, "paymentRequestsRepo.ListOutgoing.SelectContext")
+	}
+
+	return list, nil
+}
+
+// Move a pending request to a final status
+func (r *paymentRequestsRepo) Resolve(ctx context.Context, requestID int64, status string, reason *string) (*models.PaymentRequest, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "paymentRequestsRepo.Resolve")
+	defer span.Finish()
+
+	updated := &models.PaymentRequest{}
+	if err := r.db.QueryRowxContext(ctx, resolvePaymentRequestQuery, requestID, status, reason).StructScan(updated); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, paymentrequests.ErrRequestNotPending
+		}
+		return nil, errors.Wrap(err, "paymentRequestsRepo.Resolve.StructScan")
+	}
+
+	return updated, nil
+}
+
+// Record the transfer that paid a request, a request already paid is returned as is
+func (r *paymentRequestsRepo) MarkPaid(ctx context.Context, requestID int64, fromWalletID int64, transferRef string) (*models.PaymentRequest, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "paymentRequestsRepo.MarkPaid")
+	defer span.Finish()
+
+	updated := &models.PaymentRequest{}
+	if err := r.db.QueryRowxContext(ctx, markPaidQuery, requestID, fromWalletID, transferRef).StructScan(updated); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return r.GetByID(ctx, requestID)
+		}
+		return nil, errors.Wrap(err, "paymentRequestsRepo.MarkPaid.StructScan")
+	}
+
+	return updated, nil
+}
+
+// Record the transfer held for risk review on a request, a request already
+// paid or held is returned as is
+func (r *paymentRequestsRepo) MarkHeld(ctx context.Context, requestID int64, fromWalletID int64, transferRef string) (*models.PaymentRequest, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "paymentRequestsRepo.MarkHeld")
+	defer span.Finish()
+
+	updated := &models.PaymentRequest{}
+	if err := r.db.QueryRowxContext(ctx, markHeldQuery, requestID, fromWalletID, transferRef).StructScan(updated); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return r.GetByID(ctx, requestID)
+		}
+		return nil, errors.Wrap(err, "paymentRequestsRepo.MarkHeld.StructScan")
+	}
+
+	return updated, nil
+}
+
+// Expire pending requests past their expiry, returns how many were expired
+func (r *paymentRequestsRepo) ExpireDue(ctx context.Context, now time.Time, limit int) (int64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "paymentRequestsRepo.ExpireDue")
+	defer span.Finish()
+
+	result, err := r.db.ExecContext(ctx, expireDueQuery, now, limit)
+	if err != nil {
+		return 0, errors.Wrap(err, "paymentRequestsRepo.ExpireDue.ExecContext")
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "paymentRequestsRepo.ExpireDue.RowsAffected")
+	}
+
+	return n, nil
+}
+
+// Settle up to limit held requests whose transfer was decided in risk review,
+// returns how many were settled
+func (r *paymentRequestsRepo) SettleHeld(ctx context.Context, reason string, limit int) (int64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "paymentRequestsRepo.SettleHeld")
+	defer span.Finish()
+
+	result, err := r.db.ExecContext(ctx, settleHeldQuery, reason, limit)
+	if err != nil {
+		return 0, errors.Wrap(err, "paymentRequestsRepo.SettleHeld.ExecContext")
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "paymentRequestsRepo.SettleHeld.RowsAffected")
+	}
+
+	return n, nil
+}
@@ -0,0 +1,60 @@
+package repository
+
+const (
+	createPaymentRequestQuery = `INSERT INTO payment_requests (requester_id, requester_wallet_id, payer_id, currency, amount, memo, expires_at)
+						VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING *`
+
+	getPaymentRequestQuery = `SELECT * FROM payment_requests WHERE id = $1`
+
+	listIncomingQuery = `SELECT * FROM payment_requests
+						WHERE payer_id = $1 AND ($2::text = '' OR status = $2::text)
+						ORDER BY created_at DESC, id DESC`
+
+	listOutgoingQuery = `SELECT * FROM payment_requests
+						WHERE requester_id = $1 AND ($2::text = '' OR status = $2::text)
+						ORDER BY created_at DESC, id DESC`
+
+	resolvePaymentRequestQuery = `UPDATE payment_requests
+						SET status = $2, decline_reason = $3, resolved_at = now(), updated_at = now()
+						WHERE id = $1 AND status = 'pending'
+						RETURNING *`
+
+	// the transfer has posted, so the request is paid even if it was
+	// cancelled or expired while the transfer ran
+	markPaidQuery = `UPDATE payment_requests
+						SET status = 'paid', paid_from_wallet_id = $2, transfer_ref = $3, decline_reason = NULL,
+							resolved_at = now(), updated_at = now()
+						WHERE id = $1 AND status <> 'paid'
+						RETURNING *`
+
+	// the transfer is held for risk review, the request waits for the decision
+	// and no longer expires
+	markHeldQuery = `UPDATE payment_requests
+						SET status = 'held', paid_from_wallet_id = $2, transfer_ref = $3, updated_at = now()
+						WHERE id = $1 AND status NOT IN ('paid', 'held')
+						RETURNING *`
+
+	expireDueQuery = `UPDATE payment_requests SET status = 'expired', resolved_at = now(), updated_at = now()
+						WHERE id IN (
+							SELECT id FROM payment_requests
+							WHERE status = 'pending' AND expires_at <= $1
+							ORDER BY expires_at, id LIMIT $2
+							FOR UPDATE SKIP LOCKED
+						)`
+
+	// a held request is paid when its transfer is approved and declined with
+	// reason when it is rejected
+	settleHeldQuery = `UPDATE payment_requests p
+						SET status = CASE WHEN v.status = 'approved' THEN 'paid' ELSE 'declined' END,
+						decline_reason = CASE WHEN v.status = 'approved' THEN NULL ELSE $1 END,
+						resolved_at = now(), updated_at = now()
+						FROM risk_reviews v
+						WHERE v.operation = 'transfer' AND v.ref_id = p.transfer_ref AND v.status <> 'pending'
+						AND p.status = 'held' AND p.id IN (
+							SELECT h.id FROM payment_requests h
+							JOIN risk_reviews d ON d.operation = 'transfer' AND d.ref_id = h.transfer_ref
+							WHERE h.status = 'held' AND d.status <> 'pending'
+							ORDER BY h.id LIMIT $2
+							FOR UPDATE OF h SKIP LOCKED
+						)`
+)
@@ -0,0 +1,20 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase_mock.go -package mock
+package paymentrequests
+
+import (
+	"context"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/dto"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+)
+
+// Payment requests UseCase interface, requests are visible to their requester and payer
+type UseCase interface {
+	Create(ctx context.Context, request *dto.RequestPaymentRequest) (*models.PaymentRequest, error)
+	ListIncoming(ctx context.Context, status string) ([]*models.PaymentRequest, error)
+	ListOutgoing(ctx context.Context, status string) ([]*models.PaymentRequest, error)
+	Get(ctx context.Context, requestID int64) (*models.PaymentRequest, error)
+	Pay(ctx context.Context, requestID int64, request *dto.RequestPayPaymentRequest) (*models.PaymentRequest, error)
+	Decline(ctx context.Context, requestID int64, request *dto.RequestDeclinePaymentRequest) (*models.PaymentRequest, error)
+	Cancel(ctx context.Context, requestID int64) (*models.PaymentRequest, error)
+}
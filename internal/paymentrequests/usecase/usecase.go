@@ -0,0 +1,323 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/config"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/auth"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/currency"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/dto"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/paymentrequests"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/wallet"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/httpErrors"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/logger"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/utils"
+)
+
+const (
+	defaultExpiry = 7 * 24 * time.Hour
+	defaultMaxTTL = 90 * 24 * time.Hour
+)
+
+// Payment requests UseCase
+type paymentRequestsUC struct {
+	requestsRepo paymentrequests.Repository
+	authRepo     auth.Repository
+	walletRepo   wallet.Repository
+	walletUC     wallet.UseCase
+	currencyUC   currency.UseCase
+	expiry       time.Duration
+	maxExpiry    time.Duration
+	logger       logger.Logger
+	now          func() time.Time
+}
+
+// Payment requests UseCase constructor, zero config values fall back to defaults
+func NewPaymentRequestsUseCase(cfg *config.Config, requestsRepo paymentrequests.Repository, authRepo auth.Repository, walletRepo wallet.Repository, walletUC wallet.UseCase, currencyUC currency.UseCase, log logger.Logger) paymentrequests.UseCase {
+	u := &paymentRequestsUC{
+		requestsRepo: requestsRepo,
+		authRepo:     authRepo,
+		walletRepo:   walletRepo,
+		walletUC:     walletUC,
+		currencyUC:   currencyUC,
+		expiry:       time.Duration(cfg.PaymentRequests.DefaultExpiryHours) * time.Hour,
+		maxExpiry:    time.Duration(cfg.PaymentRequests.MaxExpiryHours) * time.Hour,
+		logger:       log,
+		now:          time.Now,
+	}
+
+	if u.expiry <= 0 {
+		u.expiry = defaultExpiry
+	}
+	if u.maxExpiry <= 0 {
+		u.maxExpiry = defaultMaxTTL
+	}
+
+	return u
+}
+
+// Request money from a user into a wallet of the ctx user
+func (u *paymentRequestsUC) Create(ctx context.Context, request *dto.RequestPaymentRequest) (*models.PaymentRequest, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "paymentRequestsUC.Create")
+	defer span.Finish()
+
+	user, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !request.Amount.IsPositive() {
+		return nil, httpErrors.NewBadRequestError("amount must be > 0")
+	}
+
+	now := u.now()
+	expiresAt := now.Add(u.expiry)
+	if request.ExpiresAt != nil {
+		if !request.ExpiresAt.After(now) {
+			return nil, httpErrors.NewBadRequestError("expires_at must be in the future")
+		}
+		if request.ExpiresAt.After(now.Add(u.maxExpiry)) {
+			return nil, httpErrors.NewBadRequestError("expires_at is too far in the future")
+		}
+		expiresAt = *request.ExpiresAt
+	}
+
+	if _, err := u.currencyUC.Validate(ctx, request.Currency, request.Amount); err != nil {
+		return nil, err
+	}
+
+	w, err := u.walletRepo.GetByID(ctx, request.RequesterWalletID)
+	if err != nil {
+		return nil, err
+	}
+
+	if int(w.UserID) != user.User.ID {
+		return nil, wallet.ErrWalletAccessDenied
+	}
+
+	if err := wallet.StatusError(w.Status); err != nil {
+		return nil, err
+	}
+
+	payer, err := u.findPayer(ctx, request.Payer)
+	if err != nil {
+		return nil, err
+	}
+
+	if payer.ID == user.User.ID {
+		return nil, httpErrors.NewBadRequestError("cannot request money from yourself")
+	}
+
+	created, err := u.requestsRepo.Create(ctx, &models.PaymentRequest{
+		RequesterID:       int64(user.User.ID),
+		RequesterWalletID: w.ID,
+		PayerID:           int64(payer.ID),
+		Currency:          request.Currency,
+		Amount:            request.Amount,
+		Memo:              request.Memo,
+		ExpiresAt:         expiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	u.logger.Infof("Payment request %d created by user %d for user %d", created.ID, user.User.ID, payer.ID)
+	return created, nil
+}
+
+// List requests the ctx user has to pay
+func (u *paymentRequestsUC) ListIncoming(ctx context.Context, status string) ([]*models.PaymentRequest, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "paymentRequestsUC.ListIncoming")
+	defer span.Finish()
+
+	user, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.requestsRepo.ListIncoming(ctx, int64(user.User.ID), status)
+}
+
+// List requests sent by the ctx user
+func (u *paymentRequestsUC) ListOutgoing(ctx context.Context, status string) ([]*models.PaymentRequest, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "paymentRequestsUC.ListOutgoing")
+	defer span.Finish()
+
+	user, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.requestsRepo.ListOutgoing(ctx, int64(user.User.ID), status)
+}
+
+// Get a request the ctx user sent or has to pay
+func (u *paymentRequestsUC) Get(ctx context.Context, requestID int64) (*models.PaymentRequest, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "paymentRequestsUC.Get")
+	defer span.Finish()
+
+	user, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := u.requestsRepo.GetByID(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.RequesterID != int64(user.User.ID) && p.PayerID != int64(user.User.ID) {
+		return nil, paymentrequests.ErrRequestAccessDenied
+	}
+
+	return p, nil
+}
+
+// Payer accepts a request, the amount is transferred from a wallet of the
+// payer through the regular transfer path. The transfer ref_id is derived
+// from the request, so a repeated accept cannot pay twice, and a ref_id that
+// posted any other transfer fails with ErrReferenceInUse. A transfer held for
+// risk review leaves the request held until the review is decided.
+func (u *paymentRequestsUC) Pay(ctx context.Context, requestID int64, request *dto.RequestPayPaymentRequest) (*models.PaymentRequest, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "paymentRequestsUC.Pay")
+	defer span.Finish()
+
+	p, err := u.pendingForPayer(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	ref := p.PaymentRef()
+	if _, err := u.walletUC.Transfer(ctx, &dto.RequestTransfer{
+		FromWalletID: uint(request.FromWalletID),
+		ToWalletID:   uint(p.RequesterWalletID),
+		FromCurrency: p.Currency,
+		ToCurrency:   p.Currency,
+		Amount:       p.Amount,
+		Reference:    ref,
+	}); err != nil {
+		if errors.Is(err, wallet.ErrTransactionHeld) {
+			if _, holdErr := u.requestsRepo.MarkHeld(ctx, p.ID, request.FromWalletID, ref); holdErr != nil {
+				return nil, holdErr
+			}
+			u.logger.Infof("Payment request %d held for review", p.ID)
+		}
+		return nil, err
+	}
+
+	paid, err := u.requestsRepo.MarkPaid(ctx, p.ID, request.FromWalletID, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	u.logger.Infof("Payment request %d paid from wallet %d", p.ID, request.FromWalletID)
+	return paid, nil
+}
+
+// Payer declines a request
+func (u *paymentRequestsUC) Decline(ctx context.Context, requestID int64, request *dto.RequestDeclinePaymentRequest) (*models.PaymentRequest, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "paymentRequestsUC.Decline")
+	defer span.Finish()
+
+	p, err := u.pendingForPayer(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	var reason *string
+	if request.Reason != "" {
+		reason = &request.Reason
+	}
+
+	return u.requestsRepo.Resolve(ctx, p.ID, models.PaymentRequestDeclined, reason)
+}
+
+// Requester withdraws a pending request
+func (u *paymentRequestsUC) Cancel(ctx context.Context, requestID int64) (*models.PaymentRequest, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "paymentRequestsUC.Cancel")
+	defer span.Finish()
+
+	user, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := u.requestsRepo.GetByID(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.RequesterID != int64(user.User.ID) {
+		return nil, paymentrequests.ErrRequestAccessDenied
+	}
+
+	if p.Status != models.PaymentRequestPending {
+		return nil, paymentrequests.ErrRequestNotPending
+	}
+
+	return u.requestsRepo.Resolve(ctx, p.ID, models.PaymentRequestCancelled, nil)
+}
+
+// Load a request addressed to the ctx user that can still be paid or declined
+func (u *paymentRequestsUC) pendingForPayer(ctx context.Context, requestID int64) (*models.PaymentRequest, error) {
+	user, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := u.requestsRepo.GetByID(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.PayerID != int64(user.User.ID) {
+		return nil, paymentrequests.ErrRequestAccessDenied
+	}
+
+	if p.Status != models.PaymentRequestPending {
+		return nil, paymentrequests.ErrRequestNotPending
+	}
+
+	// the expiry sweep may not have run yet
+	if p.Expired(u.now()) {
+		return nil, paymentrequests.ErrRequestExpired
+	}
+
+	return p, nil
+}
+
+// Find a user by email when the handle contains @, by username otherwise
+func (u *paymentRequestsUC) findPayer(ctx context.Context, handle string) (*models.User, error) {
+	handle = strings.TrimSpace(handle)
+	if handle == "" {
+		return nil, httpErrors.NewBadRequestError("payer is required")
+	}
+
+	var (
+		payer *models.User
+		err   error
+	)
+	if strings.Contains(handle, "@") {
+		payer, err = u.authRepo.FindByEmail(ctx, strings.ToLower(handle))
+	} else {
+		var found *models.UserWithRole
+		if found, err = u.authRepo.FindByUsername(ctx, handle); err == nil {
+			payer = &found.User
+		}
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, paymentrequests.ErrPayerNotFound
+		}
+		return nil, err
+	}
+
+	return payer, nil
+}
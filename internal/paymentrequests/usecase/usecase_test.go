@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/config"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/dto"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/paymentrequests"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/paymentrequests/mock"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/wallet"
+	walletMock "github.com/aditwar-man/go-microservice-boilerplate/internal/wallet/mock"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/logger"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/utils"
+)
+
+var testNow = time.Date(2026, 3, 10, 9, 30, 0, 0, time.UTC)
+
+func newTestUseCase(repo *mock.MockRepository, walletUC *walletMock.MockUseCase) *paymentRequestsUC {
+	cfg := &config.Config{Logger: config.Logger{Level: "error", Encoding: "console"}}
+	l := logger.NewApiLogger(cfg)
+	l.InitLogger()
+
+	u := NewPaymentRequestsUseCase(cfg, repo, nil, nil, walletUC, nil, l).(*paymentRequestsUC)
+	u.now = func() time.Time { return testNow }
+	return u
+}
+
+func userCtx(userID int) context.Context {
+	return context.WithValue(context.Background(), utils.UserCtxKey{}, &models.UserWithRole{User: models.User{ID: userID}})
+}
+
+func pendingRequest() *models.PaymentRequest {
+	return &models.PaymentRequest{
+		ID:                12,
+		RequesterID:       3,
+		RequesterWalletID: 30,
+		PayerID:           5,
+		Currency:          "USD",
+		Amount:            decimal.NewFromInt(40),
+		Status:            models.PaymentRequestPending,
+		ExpiresAt:         testNow.Add(time.Hour),
+	}
+}
+
+func TestPaymentRequestsUC_Pay(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockRepository(ctrl)
+	walletUC := walletMock.NewMockUseCase(ctrl)
+	u := newTestUseCase(repo, walletUC)
+
+	p := pendingRequest()
+	repo.EXPECT().GetByID(gomock.Any(), int64(12)).Return(p, nil)
+	walletUC.EXPECT().Transfer(gomock.Any(), &dto.RequestTransfer{
+		FromWalletID: 50,
+		ToWalletID:   30,
+		FromCurrency: "USD",
+		ToCurrency:   "USD",
+		Amount:       p.Amount,
+		Reference:    "payreq:12",
+	}).Return(nil, nil)
+	repo.EXPECT().MarkPaid(gomock.Any(), int64(12), int64(50), "payreq:12").Return(&models.PaymentRequest{ID: 12, Status: models.PaymentRequestPaid}, nil)
+
+	paid, err := u.Pay(userCtx(5), 12, &dto.RequestPayPaymentRequest{FromWalletID: 50})
+	require.NoError(t, err)
+	require.Equal(t, models.PaymentRequestPaid, paid.Status)
+}
+
+func TestPaymentRequestsUC_Pay_Rejected(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockRepository(ctrl)
+	walletUC := walletMock.NewMockUseCase(ctrl)
+	u := newTestUseCase(repo, walletUC)
+
+	// only the payer can pay
+	repo.EXPECT().GetByID(gomock.Any(), int64(12)).Return(pendingRequest(), nil)
+	_, err := u.Pay(userCtx(3), 12, &dto.RequestPayPaymentRequest{FromWalletID: 50})
+	require.ErrorIs(t, err, paymentrequests.ErrRequestAccessDenied)
+
+	// expired before the sweep marked it
+	expired := pendingRequest()
+	expired.ExpiresAt = testNow
+	repo.EXPECT().GetByID(gomock.Any(), int64(12)).Return(expired, nil)
+	_, err = u.Pay(userCtx(5), 12, &dto.RequestPayPaymentRequest{FromWalletID: 50})
+	require.ErrorIs(t, err, paymentrequests.ErrRequestExpired)
+
+	declined := pendingRequest()
+	declined.Status = models.PaymentRequestDeclined
+	repo.EXPECT().GetByID(gomock.Any(), int64(12)).Return(declined, nil)
+	_, err = u.Pay(userCtx(5), 12, &dto.RequestPayPaymentRequest{FromWalletID: 50})
+	require.ErrorIs(t, err, paymentrequests.ErrRequestNotPending)
+
+	// the payment ref already posted a different transfer, the request stays unpaid
+	repo.EXPECT().GetByID(gomock.Any(), int64(12)).Return(pendingRequest(), nil)
+	walletUC.EXPECT().Transfer(gomock.Any(), gomock.Any()).Return(nil, wallet.ErrReferenceInUse)
+	_, err = u.Pay(userCtx(5), 12, &dto.RequestPayPaymentRequest{FromWalletID: 50})
+	require.ErrorIs(t, err, wallet.ErrReferenceInUse)
+}
+
+func TestPaymentRequestsUC_Pay_Held(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockRepository(ctrl)
+	walletUC := walletMock.NewMockUseCase(ctrl)
+	u := newTestUseCase(repo, walletUC)
+
+	// the request waits for the review instead of staying pending and expiring
+	repo.EXPECT().GetByID(gomock.Any(), int64(12)).Return(pendingRequest(), nil)
+	walletUC.EXPECT().Transfer(gomock.Any(), gomock.Any()).Return(nil, wallet.ErrTransactionHeld)
+	repo.EXPECT().MarkHeld(gomock.Any(), int64(12), int64(50), "payreq:12").Return(&models.PaymentRequest{ID: 12, Status: models.PaymentRequestHeld}, nil)
+
+	_, err := u.Pay(userCtx(5), 12, &dto.RequestPayPaymentRequest{FromWalletID: 50})
+	require.ErrorIs(t, err, wallet.ErrTransactionHeld)
+}
@@ -0,0 +1,12 @@
+package payouts
+
+import "github.com/labstack/echo/v4"
+
+// Payouts HTTP Handlers interface
+type Handlers interface {
+	Create() echo.HandlerFunc
+	List() echo.HandlerFunc
+	Get() echo.HandlerFunc
+	ListRows() echo.HandlerFunc
+	Report() echo.HandlerFunc
+}
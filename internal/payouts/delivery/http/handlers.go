@@ -0,0 +1,215 @@
+package http
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/opentracing/opentracing-go"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/dto"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/payouts"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/httpErrors"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/logger"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/utils"
+)
+
+// Columns of the downloadable batch report
+var reportColumns = []string{"row", "wallet_id", "user_id", "currency", "amount", "reference", "ref_id", "status", "error"}
+
+type payoutsHandlers struct {
+	payoutsUC payouts.UseCase
+	logger    logger.Logger
+}
+
+func NewPayoutsHandlers(payoutsUC payouts.UseCase, log logger.Logger) payouts.Handlers {
+	return &payoutsHandlers{payoutsUC: payoutsUC, logger: log}
+}
+
+// Create godoc
+// @Summary Upload payout batch
+// @Description Validate a CSV or JSON payout file and queue its rows. Invalid files are rejected with the error of every row, re-uploading a batch_id returns the existing batch.
+// @Tags Payouts
+// @Accept mpfd
+// @Produce json
+// @Param batch_id formData string true "client batch id"
+// @Param from_wallet_id formData int true "source wallet"
+// @Param file formData file true "payout rows (.csv or .json)"
+// @Success 202 {object} models.PayoutBatch
+// @Failure 422 {object} httpErrors.RowsError
+// @Router /payouts [post]
+func (h *payoutsHandlers) Create() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "payouts.Create")
+		defer span.Finish()
+
+		batchRequest := &dto.RequestPayoutBatch{}
+		if err := utils.ReadRequest(c, batchRequest); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		header, err := c.FormFile("file")
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(httpErrors.NewBadRequestError("file is required")))
+		}
+		batchRequest.Format = strings.ToLower(strings.TrimPrefix(filepath.Ext(header.Filename), "."))
+
+		file, err := header.Open()
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+		defer file.Close()
+
+		batch, err := h.payoutsUC.Create(ctx, batchRequest, file)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusAccepted, batch)
+	}
+}
+
+// List godoc
+// @Summary List payout batches
+// @Description List the payout batches uploaded by the current user, newest first
+// @Tags Payouts
+// @Produce json
+// @Success 200 {array} models.PayoutBatch
+// @Router /payouts [get]
+func (h *payoutsHandlers) List() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "payouts.List")
+		defer span.Finish()
+
+		batches, err := h.payoutsUC.List(ctx)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, batches)
+	}
+}
+
+// Get godoc
+// @Summary Get payout batch
+// @Description Get a payout batch with its progress counters
+// @Tags Payouts
+// @Produce json
+// @Param batchID path string true "batch_id"
+// @Success 200 {object} models.PayoutBatch
+// @Router /payouts/{batchID} [get]
+func (h *payoutsHandlers) Get() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "payouts.Get")
+		defer span.Finish()
+
+		batch, err := h.payoutsUC.Get(ctx, c.Param("batchID"))
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, batch)
+	}
+}
+
+// ListRows godoc
+// @Summary List payout rows
+// @Description List the rows of a payout batch with their status
+// @Tags Payouts
+// @Produce json
+// @Param batchID path string true "batch_id"
+// @Success 200 {array} models.PayoutRow
+// @Router /payouts/{batchID}/rows [get]
+func (h *payoutsHandlers) ListRows() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "payouts.ListRows")
+		defer span.Finish()
+
+		rows, err := h.payoutsUC.ListRows(ctx, c.Param("batchID"))
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, rows)
+	}
+}
+
+// Report godoc
+// @Summary Download payout report
+// @Description Download the rows of a payout batch with their status as CSV
+// @Tags Payouts
+// @Produce text/csv
+// @Param batchID path string true "batch_id"
+// @Success 200 {file} file
+// @Router /payouts/{batchID}/report [get]
+func (h *payoutsHandlers) Report() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "payouts.Report")
+		defer span.Finish()
+
+		batchID := c.Param("batchID")
+		rows, err := h.payoutsUC.ListRows(ctx, batchID)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		report, err := writeReport(rows)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", "payout-"+batchID+".csv"))
+		return c.Blob(http.StatusOK, "text/csv", report)
+	}
+}
+
+func writeReport(rows []*models.PayoutRow) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	if err := w.Write(reportColumns); err != nil {
+		return nil, err
+	}
+
+	for _, r := range rows {
+		var userID, reason string
+		if r.UserID != nil {
+			userID = strconv.FormatInt(*r.UserID, 10)
+		}
+		if r.Error != nil {
+			reason = *r.Error
+		}
+
+		if err := w.Write([]string{
+			strconv.Itoa(r.RowNumber),
+			strconv.FormatInt(r.WalletID, 10),
+			userID,
+			r.Currency,
+			r.Amount.String(),
+			r.Reference,
+			r.RefID,
+			r.Status,
+			reason,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
@@ -0,0 +1,23 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/config"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/auth"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/middleware"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/payouts"
+)
+
+// Map payouts routes, batch payouts are an operator tool
+func MapPayoutsRoutes(payoutsGroup *echo.Group, h payouts.Handlers, mw *middleware.MiddlewareManager, rbacMw *middleware.RBACMiddleware, authUc auth.UseCase, cfg *config.Config) {
+	payoutsGroup.Use(mw.AuthJWTMiddleware(authUc, cfg))
+	payoutsGroup.Use(mw.AuthSessionMiddleware)
+	payoutsGroup.Use(rbacMw.RequirePermission("manage", "wallets", nil))
+
+	payoutsGroup.GET("", h.List())
+	payoutsGroup.POST("", h.Create())
+	payoutsGroup.GET("/:batchID", h.Get())
+	payoutsGroup.GET("/:batchID/rows", h.ListRows())
+	payoutsGroup.GET("/:batchID/report", h.Report())
+}
@@ -0,0 +1,16 @@
+package payouts
+
+import (
+	"net/http"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/httpErrors"
+)
+
+// Payouts domain errors
+var (
+	ErrBatchNotFound     = httpErrors.NewRestError(http.StatusNotFound, "Payout batch not found", nil)
+	ErrBatchIDInUse      = httpErrors.NewRestError(http.StatusConflict, "Payout batch id belongs to another batch", nil)
+	ErrEmptyBatch        = httpErrors.NewRestError(http.StatusBadRequest, "Payout file has no rows", nil)
+	ErrBatchTooLarge     = httpErrors.NewRestError(http.StatusRequestEntityTooLarge, "Payout file has too many rows", nil)
+	ErrUnsupportedFormat = httpErrors.NewRestError(http.StatusUnsupportedMediaType, "Payout file must be .csv or .json", nil)
+)
@@ -0,0 +1,214 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pg_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimRows mocks base method.
+func (m *MockRepository) ClaimRows(ctx context.Context, limit int, lease time.Duration) ([]*models.PayoutRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimRows", ctx, limit, lease)
+	ret0, _ := ret[0].([]*models.PayoutRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimRows indicates an expected call of ClaimRows.
+func (mr *MockRepositoryMockRecorder) ClaimRows(ctx, limit, lease interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimRows", reflect.TypeOf((*MockRepository)(nil).ClaimRows), ctx, limit, lease)
+}
+
+// CompleteRow mocks base method.
+func (m *MockRepository) CompleteRow(ctx context.Context, row *models.PayoutRow, status string, reason *string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteRow", ctx, row, status, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteRow indicates an expected call of CompleteRow.
+func (mr *MockRepositoryMockRecorder) CompleteRow(ctx, row, status, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteRow", reflect.TypeOf((*MockRepository)(nil).CompleteRow), ctx, row, status, reason)
+}
+
+// CreateBatch mocks base method.
+func (m *MockRepository) CreateBatch(ctx context.Context, batch *models.PayoutBatch, rows []*models.PayoutRow) (*models.PayoutBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBatch", ctx, batch, rows)
+	ret0, _ := ret[0].(*models.PayoutBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBatch indicates an expected call of CreateBatch.
+func (mr *MockRepositoryMockRecorder) CreateBatch(ctx, batch, rows interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBatch", reflect.TypeOf((*MockRepository)(nil).CreateBatch), ctx, batch, rows)
+}
+
+// FindPrimaryWallets mocks base method.
+func (m *MockRepository) FindPrimaryWallets(ctx context.Context, userIDs []int64) (map[int64]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPrimaryWallets", ctx, userIDs)
+	ret0, _ := ret[0].(map[int64]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPrimaryWallets indicates an expected call of FindPrimaryWallets.
+func (mr *MockRepositoryMockRecorder) FindPrimaryWallets(ctx, userIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPrimaryWallets", reflect.TypeOf((*MockRepository)(nil).FindPrimaryWallets), ctx, userIDs)
+}
+
+// FindWallets mocks base method.
+func (m *MockRepository) FindWallets(ctx context.Context, walletIDs []int64) ([]*models.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindWallets", ctx, walletIDs)
+	ret0, _ := ret[0].([]*models.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindWallets indicates an expected call of FindWallets.
+func (mr *MockRepositoryMockRecorder) FindWallets(ctx, walletIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindWallets", reflect.TypeOf((*MockRepository)(nil).FindWallets), ctx, walletIDs)
+}
+
+// GetBatch mocks base method.
+func (m *MockRepository) GetBatch(ctx context.Context, batchID string) (*models.PayoutBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBatch", ctx, batchID)
+	ret0, _ := ret[0].(*models.PayoutBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBatch indicates an expected call of GetBatch.
+func (mr *MockRepositoryMockRecorder) GetBatch(ctx, batchID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBatch", reflect.TypeOf((*MockRepository)(nil).GetBatch), ctx, batchID)
+}
+
+// GetBatchByID mocks base method.
+func (m *MockRepository) GetBatchByID(ctx context.Context, id int64) (*models.PayoutBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBatchByID", ctx, id)
+	ret0, _ := ret[0].(*models.PayoutBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBatchByID indicates an expected call of GetBatchByID.
+func (mr *MockRepositoryMockRecorder) GetBatchByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBatchByID", reflect.TypeOf((*MockRepository)(nil).GetBatchByID), ctx, id)
+}
+
+// HoldRow mocks base method.
+func (m *MockRepository) HoldRow(ctx context.Context, rowID int64, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HoldRow", ctx, rowID, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HoldRow indicates an expected call of HoldRow.
+func (mr *MockRepositoryMockRecorder) HoldRow(ctx, rowID, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HoldRow", reflect.TypeOf((*MockRepository)(nil).HoldRow), ctx, rowID, reason)
+}
+
+// ListBatches mocks base method.
+func (m *MockRepository) ListBatches(ctx context.Context, userID int64) ([]*models.PayoutBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBatches", ctx, userID)
+	ret0, _ := ret[0].([]*models.PayoutBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBatches indicates an expected call of ListBatches.
+func (mr *MockRepositoryMockRecorder) ListBatches(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBatches", reflect.TypeOf((*MockRepository)(nil).ListBatches), ctx, userID)
+}
+
+// ListDecidedRows mocks base method.
+func (m *MockRepository) ListDecidedRows(ctx context.Context, reviewStatus string, limit int) ([]*models.PayoutRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDecidedRows", ctx, reviewStatus, limit)
+	ret0, _ := ret[0].([]*models.PayoutRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDecidedRows indicates an expected call of ListDecidedRows.
+func (mr *MockRepositoryMockRecorder) ListDecidedRows(ctx, reviewStatus, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDecidedRows", reflect.TypeOf((*MockRepository)(nil).ListDecidedRows), ctx, reviewStatus, limit)
+}
+
+// ListRows mocks base method.
+func (m *MockRepository) ListRows(ctx context.Context, id int64) ([]*models.PayoutRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRows", ctx, id)
+	ret0, _ := ret[0].([]*models.PayoutRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRows indicates an expected call of ListRows.
+func (mr *MockRepositoryMockRecorder) ListRows(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRows", reflect.TypeOf((*MockRepository)(nil).ListRows), ctx, id)
+}
+
+// RetryRow mocks base method.
+func (m *MockRepository) RetryRow(ctx context.Context, rowID int64, reason string, retryAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetryRow", ctx, rowID, reason, retryAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RetryRow indicates an expected call of RetryRow.
+func (mr *MockRepositoryMockRecorder) RetryRow(ctx, rowID, reason, retryAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryRow", reflect.TypeOf((*MockRepository)(nil).RetryRow), ctx, rowID, reason, retryAt)
+}
@@ -0,0 +1,29 @@
+//go:generate mockgen -source pg_repository.go -destination mock/pg_repository_mock.go -package mock
+package payouts
+
+import (
+	"context"
+	"time"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+)
+
+// Payouts repository interface
+type Repository interface {
+	CreateBatch(ctx context.Context, batch *models.PayoutBatch, rows []*models.PayoutRow) (*models.PayoutBatch, error)
+	GetBatch(ctx context.Context, batchID string) (*models.PayoutBatch, error)
+	GetBatchByID(ctx context.Context, id int64) (*models.PayoutBatch, error)
+	ListBatches(ctx context.Context, userID int64) ([]*models.PayoutBatch, error)
+	ListRows(ctx context.Context, id int64) ([]*models.PayoutRow, error)
+
+	// Validation
+	FindWallets(ctx context.Context, walletIDs []int64) ([]*models.Wallet, error)
+	FindPrimaryWallets(ctx context.Context, userIDs []int64) (map[int64]int64, error)
+
+	// Processor
+	ClaimRows(ctx context.Context, limit int, lease time.Duration) ([]*models.PayoutRow, error)
+	CompleteRow(ctx context.Context, row *models.PayoutRow, status string, reason *string) error
+	RetryRow(ctx context.Context, rowID int64, reason string, retryAt time.Time) error
+	HoldRow(ctx context.Context, rowID int64, reason string) error
+	ListDecidedRows(ctx context.Context, reviewStatus string, limit int) ([]*models.PayoutRow, error)
+}
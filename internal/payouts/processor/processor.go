@@ -0,0 +1,219 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/config"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/dto"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/payouts"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/wallet"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/httpErrors"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/logger"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/utils"
+)
+
+const (
+	defaultBatchSize    = 100
+	defaultMaxAttempts  = 5
+	defaultPollInterval = 5 * time.Second
+	defaultRetryBackoff = 30 * time.Second
+	defaultLease        = time.Minute
+	maxRetryBackoff     = time.Hour
+)
+
+// Processor transfers pending payout rows through the wallet use case as the
+// user who uploaded the batch. A row fails on its own without stopping the
+// rest of its batch, and its ref_id makes a retried row a replay. A row held
+// for risk review waits as held and completes with the review decision.
+type Processor struct {
+	payoutsRepo  payouts.Repository
+	walletUC     wallet.UseCase
+	batchSize    int
+	maxAttempts  int
+	pollInterval time.Duration
+	retryBackoff time.Duration
+	lease        time.Duration
+	logger       logger.Logger
+	now          func() time.Time
+}
+
+// Processor constructor, zero config values fall back to defaults
+func NewProcessor(cfg *config.Config, payoutsRepo payouts.Repository, walletUC wallet.UseCase, log logger.Logger) *Processor {
+	p := &Processor{
+		payoutsRepo:  payoutsRepo,
+		walletUC:     walletUC,
+		batchSize:    cfg.Payouts.BatchSize,
+		maxAttempts:  cfg.Payouts.MaxAttempts,
+		pollInterval: time.Duration(cfg.Payouts.PollIntervalSeconds) * time.Second,
+		retryBackoff: time.Duration(cfg.Payouts.RetryBackoffSeconds) * time.Second,
+		lease:        time.Duration(cfg.Payouts.LeaseSeconds) * time.Second,
+		logger:       log,
+		now:          time.Now,
+	}
+
+	if p.batchSize <= 0 {
+		p.batchSize = defaultBatchSize
+	}
+	if p.maxAttempts <= 0 {
+		p.maxAttempts = defaultMaxAttempts
+	}
+	if p.pollInterval <= 0 {
+		p.pollInterval = defaultPollInterval
+	}
+	if p.retryBackoff <= 0 {
+		p.retryBackoff = defaultRetryBackoff
+	}
+	if p.lease <= 0 {
+		p.lease = defaultLease
+	}
+
+	return p
+}
+
+// Poll for pending rows until ctx is done, a full batch is followed immediately by the next one
+func (p *Processor) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := p.SettleHeld(ctx); err != nil {
+			p.logger.Errorf("payout processor: %s", err)
+		}
+
+		n, err := p.ProcessBatch(ctx)
+		if err != nil {
+			p.logger.Errorf("payout processor: %s", err)
+		}
+
+		if err == nil && n == p.batchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Transfer one batch of pending rows, returns how many were claimed
+func (p *Processor) ProcessBatch(ctx context.Context) (int, error) {
+	rows, err := p.payoutsRepo.ClaimRows(ctx, p.batchSize, p.lease)
+	if err != nil {
+		return 0, err
+	}
+
+	batches := make(map[models.ID]*models.PayoutBatch)
+	for _, row := range rows {
+		batch, ok := batches[row.BatchID]
+		if !ok {
+			if batch, err = p.payoutsRepo.GetBatchByID(ctx, int64(row.BatchID)); err != nil {
+				return len(rows), err
+			}
+			batches[row.BatchID] = batch
+		}
+
+		if err := p.execute(ctx, batch, row); err != nil {
+			return len(rows), err
+		}
+	}
+
+	return len(rows), nil
+}
+
+// Complete held rows whose transfer was decided in risk review, an approved
+// transfer has posted and a rejected one fails the row. Returns how many were completed.
+func (p *Processor) SettleHeld(ctx context.Context) (int, error) {
+	denied := wallet.ErrTransactionDenied.Error()
+
+	settled := 0
+	for _, decision := range []struct {
+		review, status string
+		reason         *string
+	}{
+		{models.RiskReviewApproved, models.PayoutRowSucceeded, nil},
+		{models.RiskReviewRejected, models.PayoutRowFailed, &denied},
+	} {
+		rows, err := p.payoutsRepo.ListDecidedRows(ctx, decision.review, p.batchSize)
+		if err != nil {
+			return settled, err
+		}
+
+		for _, row := range rows {
+			if err := p.payoutsRepo.CompleteRow(ctx, row, decision.status, decision.reason); err != nil {
+				return settled, err
+			}
+			settled++
+		}
+	}
+
+	if settled > 0 {
+		p.logger.Infof("payout processor: settled %d held rows", settled)
+	}
+
+	return settled, nil
+}
+
+// Transfer a row and record the outcome, only repository errors are returned
+func (p *Processor) execute(ctx context.Context, batch *models.PayoutBatch, row *models.PayoutRow) error {
+	transferErr := p.transfer(ctx, batch, row)
+	switch {
+	case transferErr == nil:
+		return p.payoutsRepo.CompleteRow(ctx, row, models.PayoutRowSucceeded, nil)
+	case errors.Is(transferErr, wallet.ErrTransactionHeld):
+		p.logger.Infof("payout processor: batch %s row %d held for review", batch.BatchID, row.RowNumber)
+		return p.payoutsRepo.HoldRow(ctx, int64(row.ID), transferErr.Error())
+	case rejected(transferErr) || row.Attempts+1 >= p.maxAttempts:
+		p.logger.Warnf("payout processor: batch %s row %d failed: %s", batch.BatchID, row.RowNumber, transferErr)
+		reason := transferErr.Error()
+		return p.payoutsRepo.CompleteRow(ctx, row, models.PayoutRowFailed, &reason)
+	default:
+		attempts := row.Attempts + 1
+		p.logger.Warnf("payout processor: batch %s row %d, attempt %d: %s", batch.BatchID, row.RowNumber, attempts, transferErr)
+		return p.payoutsRepo.RetryRow(ctx, int64(row.ID), transferErr.Error(), p.now().Add(p.backoff(attempts)))
+	}
+}
+
+// Transfer a row as the user who uploaded the batch
+func (p *Processor) transfer(ctx context.Context, batch *models.PayoutBatch, row *models.PayoutRow) error {
+	ownerCtx := context.WithValue(ctx, utils.UserCtxKey{}, &models.UserWithRole{User: models.User{ID: int(batch.UserID)}})
+
+	_, err := p.walletUC.Transfer(ownerCtx, &dto.RequestTransfer{
+		FromWalletID: uint(batch.FromWalletID),
+		ToWalletID:   uint(row.WalletID),
+		FromCurrency: row.Currency,
+		ToCurrency:   row.Currency,
+		Amount:       row.Amount,
+		Reference:    row.RefID,
+	})
+
+	return err
+}
+
+// Delay before the next attempt, doubling per attempt
+func (p *Processor) backoff(attempts int) time.Duration {
+	delay := p.retryBackoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= maxRetryBackoff {
+			return maxRetryBackoff
+		}
+	}
+
+	return delay
+}
+
+// Business rejections such as insufficient funds or a frozen wallet fail the
+// row, anything else is retried
+func rejected(err error) bool {
+	var restErr httpErrors.RestErr
+	if errors.As(err, &restErr) {
+		return restErr.Status() < http.StatusInternalServerError
+	}
+	return false
+}
@@ -0,0 +1,125 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/config"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/dto"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/payouts/mock"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/wallet"
+	walletMock "github.com/aditwar-man/go-microservice-boilerplate/internal/wallet/mock"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/logger"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/utils"
+)
+
+var testNow = time.Date(2026, 3, 10, 9, 30, 0, 0, time.UTC)
+
+func newTestProcessor(repo *mock.MockRepository, walletUC *walletMock.MockUseCase) *Processor {
+	cfg := &config.Config{
+		Logger:  config.Logger{Level: "error", Encoding: "console"},
+		Payouts: config.Payouts{BatchSize: 10, MaxAttempts: 3, RetryBackoffSeconds: 30},
+	}
+	l := logger.NewApiLogger(cfg)
+	l.InitLogger()
+
+	p := NewProcessor(cfg, repo, walletUC, l)
+	p.now = func() time.Time { return testNow }
+	return p
+}
+
+func payoutRow(id int64, walletID int64, attempts int) *models.PayoutRow {
+	return &models.PayoutRow{
+		ID:        models.ID(id),
+		BatchID:   4,
+		RowNumber: int(id),
+		WalletID:  walletID,
+		Currency:  "USD",
+		Amount:    decimal.NewFromInt(25),
+		Reference: "inv",
+		RefID:     "payout-june-inv",
+		Status:    models.PayoutRowPending,
+		Attempts:  attempts,
+	}
+}
+
+func TestProcessor_ProcessBatch(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockRepository(ctrl)
+	walletUC := walletMock.NewMockUseCase(ctrl)
+	p := newTestProcessor(repo, walletUC)
+
+	batch := &models.PayoutBatch{ID: 4, BatchID: "june", UserID: 3, FromWalletID: 1}
+	paid, frozen, flaky, exhausted := payoutRow(1, 11, 0), payoutRow(2, 12, 0), payoutRow(3, 13, 1), payoutRow(4, 14, 2)
+	held := payoutRow(5, 15, 2)
+
+	repo.EXPECT().ClaimRows(gomock.Any(), 10, time.Minute).Return([]*models.PayoutRow{paid, frozen, flaky, exhausted, held}, nil)
+	// the batch is loaded once for all of its rows
+	repo.EXPECT().GetBatchByID(gomock.Any(), int64(4)).Return(batch, nil)
+
+	walletUC.EXPECT().Transfer(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, request *dto.RequestTransfer) (*models.Transaction, error) {
+			user, err := utils.GetUserFromCtx(ctx)
+			require.NoError(t, err)
+			require.Equal(t, 3, user.User.ID)
+			require.Equal(t, uint(1), request.FromWalletID)
+			require.Equal(t, paid.RefID, request.Reference)
+
+			switch request.ToWalletID {
+			case 12:
+				return nil, wallet.ErrWalletFrozen
+			case 13, 14:
+				return nil, errors.New("connection reset")
+			case 15:
+				return nil, wallet.ErrTransactionHeld
+			}
+			return nil, nil
+		}).Times(5)
+
+	repo.EXPECT().CompleteRow(gomock.Any(), paid, models.PayoutRowSucceeded, nil).Return(nil)
+	repo.EXPECT().CompleteRow(gomock.Any(), frozen, models.PayoutRowFailed, gomock.Any()).Return(nil)
+	repo.EXPECT().RetryRow(gomock.Any(), int64(3), "connection reset", testNow.Add(time.Minute)).Return(nil)
+	repo.EXPECT().CompleteRow(gomock.Any(), exhausted, models.PayoutRowFailed, gomock.Any()).Return(nil)
+	// a hold is not a rejection and does not use up the attempts left
+	repo.EXPECT().HoldRow(gomock.Any(), int64(5), wallet.ErrTransactionHeld.Error()).Return(nil)
+
+	n, err := p.ProcessBatch(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 5, n)
+}
+
+func TestProcessor_SettleHeld(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockRepository(ctrl)
+	p := newTestProcessor(repo, walletMock.NewMockUseCase(ctrl))
+
+	approved, rejected := payoutRow(1, 11, 0), payoutRow(2, 12, 0)
+
+	repo.EXPECT().ListDecidedRows(gomock.Any(), models.RiskReviewApproved, 10).Return([]*models.PayoutRow{approved}, nil)
+	repo.EXPECT().ListDecidedRows(gomock.Any(), models.RiskReviewRejected, 10).Return([]*models.PayoutRow{rejected}, nil)
+	repo.EXPECT().CompleteRow(gomock.Any(), approved, models.PayoutRowSucceeded, nil).Return(nil)
+	repo.EXPECT().CompleteRow(gomock.Any(), rejected, models.PayoutRowFailed, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *models.PayoutRow, _ string, reason *string) error {
+			require.Equal(t, wallet.ErrTransactionDenied.Error(), *reason)
+			return nil
+		})
+
+	n, err := p.SettleHeld(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+}
@@ -0,0 +1,249 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/payouts"
+)
+
+// Payouts Repository
+type payoutsRepo struct {
+	db *sqlx.DB
+}
+
+// Payouts Repository constructor
+func NewPayoutsRepository(db *sqlx.DB) payouts.Repository {
+	return &payoutsRepo{db: db}
+}
+
+// Create a batch with its rows, an existing batch id returns the batch already
+// stored and leaves its rows untouched
+func (r *payoutsRepo) CreateBatch(ctx context.Context, batch *models.PayoutBatch, rows []*models.PayoutRow) (*models.PayoutBatch, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "payoutsRepo.CreateBatch")
+	defer span.Finish()
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "payoutsRepo.CreateBatch.BeginTxx")
+	}
+	defer tx.Rollback()
+
+	created := &models.PayoutBatch{}
+	if err := tx.QueryRowxContext(ctx, createBatchQuery,
+		batch.BatchID, batch.UserID, batch.FromWalletID, len(rows),
+	).StructScan(created); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return r.GetBatch(ctx, batch.BatchID)
+		}
+		return nil, errors.Wrap(err, "payoutsRepo.CreateBatch.StructScan")
+	}
+
+	for _, row := range rows {
+		if _, err := tx.ExecContext(ctx, createRowQuery,
+			created.ID, row.RowNumber, row.WalletID, row.UserID, row.Currency, row.Amount, row.Reference, created.RowRef(row.RowNumber),
+		); err != nil {
+			return nil, errors.Wrap(err, "payoutsRepo.CreateBatch.ExecContext.row")
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "payoutsRepo.CreateBatch.Commit")
+	}
+
+	return created, nil
+}
+
+// Get batch by its client batch id
+func (r *payoutsRepo) GetBatch(ctx context.Context, batchID string) (*models.PayoutBatch, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "payoutsRepo.GetBatch")
+	defer span.Finish()
+
+	return r.getBatch(ctx, getBatchQuery, batchID)
+}
+
+// Get batch by its primary key
+func (r *payoutsRepo) GetBatchByID(ctx context.Context, id int64) (*models.PayoutBatch, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "payoutsRepo.GetBatchByID")
+	defer span.Finish()
+
+	return r.getBatch(ctx, getBatchByIDQuery, id)
+}
+
+// List batches uploaded by a user, newest first
+func (r *payoutsRepo) ListBatches(ctx context.Context, userID int64) ([]*models.PayoutBatch, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "payoutsRepo.ListBatches")
+	defer span.Finish()
+
+	batches := make([]*models.PayoutBatch, 0)
+	if err := r.db.SelectContext(ctx, &batches, listBatchesQuery, userID); err != nil {
+		return nil, errors.Wrap(err, "payoutsRepo.ListBatches.SelectContext")
+	}
+
+	return batches, nil
+}
+
+// List the rows of a batch in file order
+func (r *payoutsRepo) ListRows(ctx context.Context, id int64) ([]*models.PayoutRow, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "payoutsRepo.ListRows")
+	defer span.Finish()
+
+	rows := make([]*models.PayoutRow, 0)
+	if err := r.db.SelectContext(ctx, &rows, listRowsQuery, id); err != nil {
+		return nil, errors.Wrap(err, "payoutsRepo.ListRows.SelectContext")
+	}
+
+	return rows, nil
+}
+
+// Find wallets by id, missing ids are left out
+func (r *payoutsRepo) FindWallets(ctx context.Context, walletIDs []int64) ([]*models.Wallet, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "payoutsRepo.FindWallets")
+	defer span.Finish()
+
+	ids, err := json.Marshal(walletIDs)
+	if err != nil {
+		return nil, errors.Wrap(err, "payoutsRepo.FindWallets.json.Marshal")
+	}
+
+	wallets := make([]*models.Wallet, 0, len(walletIDs))
+	if err := r.db.SelectContext(ctx, &wallets, findWalletsQuery, ids); err != nil {
+		return nil, errors.Wrap(err, "payoutsRepo.FindWallets.SelectContext")
+	}
+
+	return wallets, nil
+}
+
+// Primary wallet of each user, keyed by user id. Users without an active
+// wallet are left out.
+func (r *payoutsRepo) FindPrimaryWallets(ctx context.Context, userIDs []int64) (map[int64]int64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "payoutsRepo.FindPrimaryWallets")
+	defer span.Finish()
+
+	ids, err := json.Marshal(userIDs)
+	if err != nil {
+		return nil, errors.Wrap(err, "payoutsRepo.FindPrimaryWallets.json.Marshal")
+	}
+
+	rows, err := r.db.QueryxContext(ctx, findPrimaryWalletsQuery, ids)
+	if err != nil {
+		return nil, errors.Wrap(err, "payoutsRepo.FindPrimaryWallets.QueryxContext")
+	}
+	defer rows.Close()
+
+	primary := make(map[int64]int64, len(userIDs))
+	for rows.Next() {
+		var userID, walletID int64
+		if err := rows.Scan(&userID, &walletID); err != nil {
+			return nil, errors.Wrap(err, "payoutsRepo.FindPrimaryWallets.Scan")
+		}
+		primary[userID] = walletID
+	}
+
+	return primary, errors.Wrap(rows.Err(), "payoutsRepo.FindPrimaryWallets.rows.Err")
+}
+
+// Claim up to limit pending rows for lease using FOR UPDATE SKIP LOCKED
+func (r *payoutsRepo) ClaimRows(ctx context.Context, limit int, lease time.Duration) ([]*models.PayoutRow, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "payoutsRepo.ClaimRows")
+	defer span.Finish()
+
+	rows := make([]*models.PayoutRow, 0, limit)
+	if err := r.db.SelectContext(ctx, &rows, claimRowsQuery, limit, lease.Seconds()); err != nil {
+		return nil, errors.Wrap(err, "payoutsRepo.ClaimRows.SelectContext")
+	}
+
+	return rows, nil
+}
+
+// Record the outcome of a row and count it on its batch. A row that was
+// already completed is left as is.
+func (r *payoutsRepo) CompleteRow(ctx context.Context, row *models.PayoutRow, status string, reason *string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "payoutsRepo.CompleteRow")
+	defer span.Finish()
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "payoutsRepo.CompleteRow.BeginTxx")
+	}
+	defer tx.Rollback()
+
+	// lock the batch first so the last two rows cannot both miss each other
+	if _, err := tx.ExecContext(ctx, lockBatchQuery, row.BatchID); err != nil {
+		return errors.Wrap(err, "payoutsRepo.CompleteRow.ExecContext.lock")
+	}
+
+	res, err := tx.ExecContext(ctx, completeRowQuery, row.ID, status, reason)
+	if err != nil {
+		return errors.Wrap(err, "payoutsRepo.CompleteRow.ExecContext.row")
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "payoutsRepo.CompleteRow.RowsAffected")
+	}
+	if n == 0 {
+		return nil
+	}
+
+	if _, err := tx.ExecContext(ctx, countRowQuery, row.BatchID, status); err != nil {
+		return errors.Wrap(err, "payoutsRepo.CompleteRow.ExecContext.batch")
+	}
+
+	return errors.Wrap(tx.Commit(), "payoutsRepo.CompleteRow.Commit")
+}
+
+// Keep a row pending and retry it after retryAt
+func (r *payoutsRepo) RetryRow(ctx context.Context, rowID int64, reason string, retryAt time.Time) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "payoutsRepo.RetryRow")
+	defer span.Finish()
+
+	if _, err := r.db.ExecContext(ctx, retryRowQuery, rowID, reason, retryAt); err != nil {
+		return errors.Wrap(err, "payoutsRepo.RetryRow.ExecContext")
+	}
+
+	return nil
+}
+
+func (r *payoutsRepo) getBatch(ctx context.Context, query string, arg interface{}) (*models.PayoutBatch, error) {
+	batch := &models.PayoutBatch{}
+	if err := r.db.GetContext(ctx, batch, query, arg); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, payouts.ErrBatchNotFound
+		}
+		return nil, errors.Wrap(err, "payoutsRepo.getBatch.GetContext")
+	}
+
+	return batch, nil
+}
+
+// Park a row whose transfer is held for risk review, it no longer counts attempts
+func (r *payoutsRepo) HoldRow(ctx context.Context, rowID int64, reason string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "payoutsRepo.HoldRow")
+	defer span.Finish()
+
+	if _, err := r.db.ExecContext(ctx, holdRowQuery, rowID, reason); err != nil {
+		return errors.Wrap(err, "payoutsRepo.HoldRow.ExecContext")
+	}
+
+	return nil
+}
+
+// Up to limit held rows whose transfer was approved or rejected in risk review
+func (r *payoutsRepo) ListDecidedRows(ctx context.Context, reviewStatus string, limit int) ([]*models.PayoutRow, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "payoutsRepo.ListDecidedRows")
+	defer span.Finish()
+
+	rows := make([]*models.PayoutRow, 0)
+	if err := r.db.SelectContext(ctx, &rows, listDecidedRowsQuery, reviewStatus, limit); err != nil {
+		return nil, errors.Wrap(err, "payoutsRepo.ListDecidedRows.SelectContext")
+	}
+
+	return rows, nil
+}
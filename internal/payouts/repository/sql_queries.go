@@ -0,0 +1,75 @@
+package repository
+
+const (
+	// a batch id already taken returns no row and the upload is a replay
+	createBatchQuery = `INSERT INTO payout_batches (batch_id, user_id, from_wallet_id, total_rows)
+						VALUES ($1, $2, $3, $4)
+						ON CONFLICT (batch_id) DO NOTHING
+						RETURNING *`
+
+	createRowQuery = `INSERT INTO payout_rows (batch_id, row_number, wallet_id, user_id, currency, amount, reference, ref_id)
+						VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	getBatchQuery = `SELECT * FROM payout_batches WHERE batch_id = $1`
+
+	getBatchByIDQuery = `SELECT * FROM payout_batches WHERE id = $1`
+
+	listBatchesQuery = `SELECT * FROM payout_batches WHERE user_id = $1 ORDER BY created_at DESC, id DESC`
+
+	listRowsQuery = `SELECT * FROM payout_rows WHERE batch_id = $1 ORDER BY row_number`
+
+	findWalletsQuery = `SELECT * FROM wallets
+						WHERE id IN (SELECT value::bigint FROM jsonb_array_elements_text($1::jsonb))`
+
+	// the oldest active wallet of a user that is not a pocket receives payouts addressed to the user
+	findPrimaryWalletsQuery = `SELECT DISTINCT ON (user_id) user_id, id FROM wallets
+						WHERE status = 'active'
+						AND NOT EXISTS (SELECT 1 FROM wallet_pockets p WHERE p.wallet_id = wallets.id)
+						AND user_id IN (SELECT value::bigint FROM jsonb_array_elements_text($1::jsonb))
+						ORDER BY user_id, id`
+)
+
+const (
+	// lease pending rows so concurrent processors skip them until the lease expires
+	claimRowsQuery = `UPDATE payout_rows SET locked_until = now() + make_interval(secs => $2)
+						WHERE id IN (
+							SELECT id FROM payout_rows
+							WHERE status = 'pending'
+							AND (locked_until IS NULL OR locked_until <= now())
+							ORDER BY batch_id, row_number LIMIT $1
+							FOR UPDATE SKIP LOCKED
+						)
+						RETURNING *`
+
+	// serialises the counters of a batch
+	lockBatchQuery = `SELECT id FROM payout_batches WHERE id = $1 FOR UPDATE`
+
+	completeRowQuery = `UPDATE payout_rows
+						SET status = $2, error = $3, processed_at = now(), locked_until = NULL
+						WHERE id = $1 AND status IN ('pending', 'held')`
+
+	// the batch completes with its last pending or held row
+	countRowQuery = `UPDATE payout_batches b
+						SET succeeded = succeeded + CASE WHEN $2 = 'succeeded' THEN 1 ELSE 0 END,
+						failed = failed + CASE WHEN $2 = 'failed' THEN 1 ELSE 0 END,
+						status = CASE WHEN EXISTS (SELECT 1 FROM payout_rows r WHERE r.batch_id = b.id AND r.status IN ('pending', 'held'))
+							THEN 'processing' ELSE 'completed' END,
+						completed_at = CASE WHEN EXISTS (SELECT 1 FROM payout_rows r WHERE r.batch_id = b.id AND r.status IN ('pending', 'held'))
+							THEN NULL ELSE now() END,
+						updated_at = now()
+						WHERE id = $1`
+
+	retryRowQuery = `UPDATE payout_rows
+						SET attempts = attempts + 1, error = $2, locked_until = $3
+						WHERE id = $1 AND status = 'pending'`
+
+	holdRowQuery = `UPDATE payout_rows
+						SET status = 'held', error = $2, locked_until = NULL
+						WHERE id = $1 AND status = 'pending'`
+
+	// held rows whose transfer review was decided as reviewStatus
+	listDecidedRowsQuery = `SELECT r.* FROM payout_rows r
+						JOIN risk_reviews v ON v.operation = 'transfer' AND v.ref_id = r.ref_id
+						WHERE r.status = 'held' AND v.status = $1
+						ORDER BY r.id LIMIT $2`
+)
@@ -0,0 +1,18 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase_mock.go -package mock
+package payouts
+
+import (
+	"context"
+	"io"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/dto"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+)
+
+// Payouts UseCase interface
+type UseCase interface {
+	Create(ctx context.Context, request *dto.RequestPayoutBatch, file io.Reader) (*models.PayoutBatch, error)
+	List(ctx context.Context) ([]*models.PayoutBatch, error)
+	Get(ctx context.Context, batchID string) (*models.PayoutBatch, error)
+	ListRows(ctx context.Context, batchID string) ([]*models.PayoutRow, error)
+}
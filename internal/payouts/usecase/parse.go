@@ -0,0 +1,167 @@
+package usecase
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/dto"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/payouts"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/httpErrors"
+)
+
+// CSV columns, the header row names them in any order
+var payoutColumns = []string{"wallet_id", "user_id", "currency", "amount", "reference"}
+
+// Row of a payout file with its 1-based position
+type payoutLine struct {
+	number int
+	row    dto.PayoutRow
+}
+
+// Parse a payout file. Rows that cannot be read are reported as row errors so
+// the whole file is checked in one pass, an unreadable file is an error.
+func parsePayoutFile(format string, r io.Reader, maxRows int) ([]payoutLine, []httpErrors.RowError, error) {
+	switch format {
+	case dto.PayoutFormatCSV:
+		return parsePayoutCSV(r, maxRows)
+	case dto.PayoutFormatJSON:
+		return parsePayoutJSON(r, maxRows)
+	default:
+		return nil, nil, payouts.ErrUnsupportedFormat
+	}
+}
+
+func parsePayoutCSV(r io.Reader, maxRows int) ([]payoutLine, []httpErrors.RowError, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil, payouts.ErrEmptyBatch
+	}
+	if err != nil {
+		return nil, nil, httpErrors.NewBadRequestError(err.Error())
+	}
+
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if !models.StringList(payoutColumns).Contains(name) {
+			return nil, nil, httpErrors.NewBadRequestError(fmt.Sprintf("unknown column %q", name))
+		}
+		index[name] = i
+	}
+	for _, required := range []string{"currency", "amount", "reference"} {
+		if _, ok := index[required]; !ok {
+			return nil, nil, httpErrors.NewBadRequestError(fmt.Sprintf("missing column %q", required))
+		}
+	}
+
+	lines := make([]payoutLine, 0)
+	rowErrs := make([]httpErrors.RowError, 0)
+	for number := 1; ; number++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if number > maxRows {
+			return nil, nil, payouts.ErrBatchTooLarge
+		}
+		if err != nil {
+			rowErrs = append(rowErrs, httpErrors.RowError{Row: number, Error: err.Error()})
+			continue
+		}
+
+		field := func(name string) string {
+			if i, ok := index[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		line := payoutLine{number: number}
+		line.row.Currency = strings.ToUpper(field("currency"))
+		line.row.Reference = field("reference")
+
+		if line.row.WalletID, err = parseOptionalID(field("wallet_id")); err != nil {
+			rowErrs = append(rowErrs, httpErrors.RowError{Row: number, Error: "invalid wallet_id"})
+			continue
+		}
+		if line.row.UserID, err = parseOptionalID(field("user_id")); err != nil {
+			rowErrs = append(rowErrs, httpErrors.RowError{Row: number, Error: "invalid user_id"})
+			continue
+		}
+		if line.row.Amount, err = decimal.NewFromString(field("amount")); err != nil {
+			rowErrs = append(rowErrs, httpErrors.RowError{Row: number, Error: "invalid amount"})
+			continue
+		}
+
+		lines = append(lines, line)
+	}
+
+	if len(lines) == 0 && len(rowErrs) == 0 {
+		return nil, nil, payouts.ErrEmptyBatch
+	}
+
+	return lines, rowErrs, nil
+}
+
+// JSON payout files are an array of row objects
+func parsePayoutJSON(r io.Reader, maxRows int) ([]payoutLine, []httpErrors.RowError, error) {
+	decoder := json.NewDecoder(r)
+
+	if tok, err := decoder.Token(); err != nil || tok != json.Delim('[') {
+		return nil, nil, httpErrors.NewBadRequestError("payout file must be a JSON array of rows")
+	}
+
+	lines := make([]payoutLine, 0)
+	rowErrs := make([]httpErrors.RowError, 0)
+	for number := 1; decoder.More(); number++ {
+		if number > maxRows {
+			return nil, nil, payouts.ErrBatchTooLarge
+		}
+
+		// a row of the wrong shape is decoded to its end, so the next row is still readable
+		line := payoutLine{number: number}
+		if err := decoder.Decode(&line.row); err != nil {
+			if _, ok := err.(*json.SyntaxError); ok {
+				return nil, nil, httpErrors.NewBadRequestError(err.Error())
+			}
+			rowErrs = append(rowErrs, httpErrors.RowError{Row: number, Error: err.Error()})
+			continue
+		}
+
+		line.row.Currency = strings.ToUpper(strings.TrimSpace(line.row.Currency))
+		line.row.Reference = strings.TrimSpace(line.row.Reference)
+		lines = append(lines, line)
+	}
+
+	if _, err := decoder.Token(); err != nil {
+		return nil, nil, httpErrors.NewBadRequestError(err.Error())
+	}
+
+	if len(lines) == 0 && len(rowErrs) == 0 {
+		return nil, nil, payouts.ErrEmptyBatch
+	}
+
+	return lines, rowErrs, nil
+}
+
+func parseOptionalID(s string) (int64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	id, err := strconv.ParseInt(s, 10, 64)
+	if err == nil && id <= 0 {
+		err = fmt.Errorf("invalid id %d", id)
+	}
+	return id, err
+}
@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/dto"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/payouts"
+)
+
+func TestParsePayoutFile_CSV(t *testing.T) {
+	t.Parallel()
+
+	file := "\ufeffReference,wallet_id,user_id,currency,amount\n" +
+		"inv-1,12,,usd,10.50\n" +
+		"inv-2,,7,EUR,3\n" +
+		"inv-3,abc,,USD,1\n" +
+		"inv-4,13,,USD,ten\n"
+
+	lines, rowErrs, err := parsePayoutFile(dto.PayoutFormatCSV, strings.NewReader(file), 10)
+	require.NoError(t, err)
+
+	require.Len(t, lines, 2)
+	require.Equal(t, 1, lines[0].number)
+	require.Equal(t, int64(12), lines[0].row.WalletID)
+	require.Equal(t, "USD", lines[0].row.Currency)
+	require.True(t, decimal.RequireFromString("10.50").Equal(lines[0].row.Amount))
+	require.Equal(t, int64(7), lines[1].row.UserID)
+
+	require.Len(t, rowErrs, 2)
+	require.Equal(t, 3, rowErrs[0].Row)
+	require.Equal(t, "invalid wallet_id", rowErrs[0].Error)
+	require.Equal(t, 4, rowErrs[1].Row)
+	require.Equal(t, "invalid amount", rowErrs[1].Error)
+}
+
+func TestParsePayoutFile_JSON(t *testing.T) {
+	t.Parallel()
+
+	file := `[
+		{"wallet_id": 12, "currency": "usd", "amount": "10.5", "reference": " inv-1 "},
+		{"wallet_id": "12", "currency": "USD", "amount": "1", "reference": "inv-2"},
+		{"user_id": 7, "currency": "EUR", "amount": 3, "reference": "inv-3"}
+	]`
+
+	lines, rowErrs, err := parsePayoutFile(dto.PayoutFormatJSON, strings.NewReader(file), 10)
+	require.NoError(t, err)
+
+	require.Len(t, lines, 2)
+	require.Equal(t, "inv-1", lines[0].row.Reference)
+	require.Equal(t, "USD", lines[0].row.Currency)
+	require.Equal(t, 3, lines[1].number)
+
+	require.Len(t, rowErrs, 1)
+	require.Equal(t, 2, rowErrs[0].Row)
+}
+
+func TestParsePayoutFile_Rejected(t *testing.T) {
+	t.Parallel()
+
+	_, _, err := parsePayoutFile(dto.PayoutFormatCSV, strings.NewReader("reference,currency,amount\na,USD,1\nb,USD,1\n"), 1)
+	require.ErrorIs(t, err, payouts.ErrBatchTooLarge)
+
+	_, _, err = parsePayoutFile(dto.PayoutFormatCSV, strings.NewReader("reference,currency,amount\n"), 10)
+	require.ErrorIs(t, err, payouts.ErrEmptyBatch)
+
+	_, _, err = parsePayoutFile(dto.PayoutFormatCSV, strings.NewReader("reference,currency\n"), 10)
+	require.Error(t, err)
+
+	_, _, err = parsePayoutFile(dto.PayoutFormatJSON, strings.NewReader(`[{"reference": "a",`), 10)
+	require.Error(t, err)
+
+	_, _, err = parsePayoutFile("xlsx", strings.NewReader(""), 10)
+	require.ErrorIs(t, err, payouts.ErrUnsupportedFormat)
+}
@@ -0,0 +1,292 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/config"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/currency"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/dto"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/payouts"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/wallet"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/httpErrors"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/logger"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/utils"
+)
+
+const (
+	defaultMaxRows     = 5000
+	maxReferenceLength = 100
+)
+
+// Payouts UseCase
+type payoutsUC struct {
+	payoutsRepo payouts.Repository
+	walletRepo  wallet.Repository
+	currencyUC  currency.UseCase
+	maxRows     int
+	logger      logger.Logger
+}
+
+// Payouts UseCase constructor
+func NewPayoutsUseCase(cfg *config.Config, payoutsRepo payouts.Repository, walletRepo wallet.Repository, currencyUC currency.UseCase, log logger.Logger) payouts.UseCase {
+	maxRows := cfg.Payouts.MaxRows
+	if maxRows <= 0 {
+		maxRows = defaultMaxRows
+	}
+
+	return &payoutsUC{payoutsRepo: payoutsRepo, walletRepo: walletRepo, currencyUC: currencyUC, maxRows: maxRows, logger: log}
+}
+
+// Validate every row of a payout file and queue the batch. Nothing is stored
+// unless all rows are valid, a batch id that was already uploaded returns
+// that batch without reading the file.
+func (u *payoutsUC) Create(ctx context.Context, request *dto.RequestPayoutBatch, file io.Reader) (*models.PayoutBatch, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "payoutsUC.Create")
+	defer span.Finish()
+
+	user, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := u.payoutsRepo.GetBatch(ctx, request.BatchID)
+	if err == nil {
+		return u.ownBatch(existing, user.User.ID)
+	}
+	if !errors.Is(err, payouts.ErrBatchNotFound) {
+		return nil, err
+	}
+
+	from, err := u.walletRepo.GetByID(ctx, request.FromWalletID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := wallet.StatusError(from.Status); err != nil {
+		return nil, err
+	}
+
+	lines, rowErrs, err := parsePayoutFile(request.Format, file, u.maxRows)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, validationErrs, err := u.validateRows(ctx, from.ID, lines)
+	if err != nil {
+		return nil, err
+	}
+
+	if rowErrs = append(rowErrs, validationErrs...); len(rowErrs) > 0 {
+		sort.SliceStable(rowErrs, func(i, j int) bool { return rowErrs[i].Row < rowErrs[j].Row })
+		return nil, httpErrors.NewRowsError(rowErrs)
+	}
+
+	batch, err := u.payoutsRepo.CreateBatch(ctx, &models.PayoutBatch{
+		BatchID:      request.BatchID,
+		UserID:       int64(user.User.ID),
+		FromWalletID: from.ID,
+	}, rows)
+	if err != nil {
+		return nil, err
+	}
+
+	// a concurrent upload may have taken the batch id first
+	if batch, err = u.ownBatch(batch, user.User.ID); err != nil {
+		return nil, err
+	}
+
+	u.logger.Infof("Payout batch %s queued by user %d: %d rows from wallet %d", batch.BatchID, user.User.ID, batch.TotalRows, from.ID)
+	return batch, nil
+}
+
+// List the batches uploaded by the ctx user
+func (u *payoutsUC) List(ctx context.Context) ([]*models.PayoutBatch, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "payoutsUC.List")
+	defer span.Finish()
+
+	user, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.payoutsRepo.ListBatches(ctx, int64(user.User.ID))
+}
+
+// Get a batch of the ctx user with its progress counters
+func (u *payoutsUC) Get(ctx context.Context, batchID string) (*models.PayoutBatch, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "payoutsUC.Get")
+	defer span.Finish()
+
+	return u.getOwnBatch(ctx, batchID)
+}
+
+// List the rows of a batch of the ctx user with their status
+func (u *payoutsUC) ListRows(ctx context.Context, batchID string) ([]*models.PayoutRow, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "payoutsUC.ListRows")
+	defer span.Finish()
+
+	batch, err := u.getOwnBatch(ctx, batchID)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.payoutsRepo.ListRows(ctx, batch.ID)
+}
+
+// Get a batch uploaded by the ctx user, batches of other users are not found
+func (u *payoutsUC) getOwnBatch(ctx context.Context, batchID string) (*models.PayoutBatch, error) {
+	user, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	batch, err := u.payoutsRepo.GetBatch(ctx, batchID)
+	if err != nil {
+		return nil, err
+	}
+
+	if batch.UserID != int64(user.User.ID) {
+		return nil, payouts.ErrBatchNotFound
+	}
+
+	return batch, nil
+}
+
+// Check the rows against currencies and wallets, resolving user targets to
+// their primary wallet. Only lookup failures are returned as error.
+func (u *payoutsUC) validateRows(ctx context.Context, fromWalletID int64, lines []payoutLine) ([]*models.PayoutRow, []httpErrors.RowError, error) {
+	currencies, err := u.currencyUC.List(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	currencyByCode := make(map[string]*models.Currency, len(currencies))
+	for _, c := range currencies {
+		currencyByCode[c.Code] = c
+	}
+
+	userIDs := make([]int64, 0)
+	for _, l := range lines {
+		if l.row.UserID != 0 && l.row.WalletID == 0 {
+			userIDs = append(userIDs, l.row.UserID)
+		}
+	}
+	primary := make(map[int64]int64)
+	if len(userIDs) > 0 {
+		if primary, err = u.payoutsRepo.FindPrimaryWallets(ctx, userIDs); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	walletIDs := make([]int64, 0, len(lines))
+	for _, l := range lines {
+		if l.row.WalletID != 0 {
+			walletIDs = append(walletIDs, l.row.WalletID)
+		}
+	}
+	for _, id := range primary {
+		walletIDs = append(walletIDs, id)
+	}
+	wallets, err := u.payoutsRepo.FindWallets(ctx, walletIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	walletByID := make(map[int64]*models.Wallet, len(wallets))
+	for _, w := range wallets {
+		walletByID[w.ID] = w
+	}
+
+	rows := make([]*models.PayoutRow, 0, len(lines))
+	rowErrs := make([]httpErrors.RowError, 0)
+	references := make(map[string]int, len(lines))
+
+	for _, l := range lines {
+		reject := func(format string, args ...interface{}) {
+			rowErrs = append(rowErrs, httpErrors.RowError{Row: l.number, Error: fmt.Sprintf(format, args...)})
+		}
+
+		r := l.row
+		switch {
+		case r.Reference == "":
+			reject("reference is required")
+			continue
+		case len(r.Reference) > maxReferenceLength:
+			reject("reference is longer than %d characters", maxReferenceLength)
+			continue
+		}
+		if first, ok := references[r.Reference]; ok {
+			reject("reference %q repeats row %d", r.Reference, first)
+			continue
+		}
+		references[r.Reference] = l.number
+
+		if !r.Amount.IsPositive() {
+			reject("amount must be > 0")
+			continue
+		}
+
+		c, ok := currencyByCode[r.Currency]
+		switch {
+		case !ok:
+			reject("currency %q is not supported", r.Currency)
+			continue
+		case !c.Enabled:
+			reject("currency %s is disabled", r.Currency)
+			continue
+		case !c.Fits(r.Amount):
+			reject("amount has more than %d decimals for %s", c.Exponent, r.Currency)
+			continue
+		}
+
+		row := &models.PayoutRow{RowNumber: l.number, Currency: r.Currency, Amount: r.Amount, Reference: r.Reference}
+		switch {
+		case r.WalletID != 0 && r.UserID != 0:
+			reject("wallet_id and user_id are exclusive")
+			continue
+		case r.WalletID != 0:
+			row.WalletID = r.WalletID
+		case r.UserID != 0:
+			walletID, ok := primary[r.UserID]
+			if !ok {
+				reject("user %d has no active wallet", r.UserID)
+				continue
+			}
+			userID := r.UserID
+			row.WalletID, row.UserID = walletID, &userID
+		default:
+			reject("wallet_id or user_id is required")
+			continue
+		}
+
+		w, ok := walletByID[row.WalletID]
+		switch {
+		case !ok:
+			reject("wallet %d not found", row.WalletID)
+			continue
+		case w.Status != models.WalletStatusActive:
+			reject("wallet %d is %s", w.ID, w.Status)
+			continue
+		case w.ID == fromWalletID:
+			reject("wallet %d is the source wallet", w.ID)
+			continue
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, rowErrs, nil
+}
+
+// Only the uploader may replay a batch id
+func (u *payoutsUC) ownBatch(batch *models.PayoutBatch, userID int) (*models.PayoutBatch, error) {
+	if batch.UserID != int64(userID) {
+		return nil, payouts.ErrBatchIDInUse
+	}
+	return batch, nil
+}
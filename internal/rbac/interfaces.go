@@ -1,3 +1,4 @@
+//go:generate mockgen -source interfaces.go -destination mock/interfaces_mock.go -package mock
 package rbac
 
 import (
@@ -0,0 +1,742 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interfaces.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	dto "github.com/aditwar-man/go-microservice-boilerplate/internal/dto"
+	models "github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	utils "github.com/aditwar-man/go-microservice-boilerplate/pkg/utils"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRbacUsecase is a mock of RbacUsecase interface.
+type MockRbacUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockRbacUsecaseMockRecorder
+}
+
+// MockRbacUsecaseMockRecorder is the mock recorder for MockRbacUsecase.
+type MockRbacUsecaseMockRecorder struct {
+	mock *MockRbacUsecase
+}
+
+// NewMockRbacUsecase creates a new mock instance.
+func NewMockRbacUsecase(ctrl *gomock.Controller) *MockRbacUsecase {
+	mock := &MockRbacUsecase{ctrl: ctrl}
+	mock.recorder = &MockRbacUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRbacUsecase) EXPECT() *MockRbacUsecaseMockRecorder {
+	return m.recorder
+}
+
+// AssignPermissionToRole mocks base method.
+func (m *MockRbacUsecase) AssignPermissionToRole(ctx context.Context, req *dto.AssignRolePermissionRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignPermissionToRole", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AssignPermissionToRole indicates an expected call of AssignPermissionToRole.
+func (mr *MockRbacUsecaseMockRecorder) AssignPermissionToRole(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignPermissionToRole", reflect.TypeOf((*MockRbacUsecase)(nil).AssignPermissionToRole), ctx, req)
+}
+
+// AssignRolesToUser mocks base method.
+func (m *MockRbacUsecase) AssignRolesToUser(ctx context.Context, userID int, roleIDs []int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignRolesToUser", ctx, userID, roleIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AssignRolesToUser indicates an expected call of AssignRolesToUser.
+func (mr *MockRbacUsecaseMockRecorder) AssignRolesToUser(ctx, userID, roleIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignRolesToUser", reflect.TypeOf((*MockRbacUsecase)(nil).AssignRolesToUser), ctx, userID, roleIDs)
+}
+
+// CheckUserPermission mocks base method.
+func (m *MockRbacUsecase) CheckUserPermission(ctx context.Context, userID int, permissionName, resourceName string, contextName *string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckUserPermission", ctx, userID, permissionName, resourceName, contextName)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckUserPermission indicates an expected call of CheckUserPermission.
+func (mr *MockRbacUsecaseMockRecorder) CheckUserPermission(ctx, userID, permissionName, resourceName, contextName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckUserPermission", reflect.TypeOf((*MockRbacUsecase)(nil).CheckUserPermission), ctx, userID, permissionName, resourceName, contextName)
+}
+
+// CheckUserRole mocks base method.
+func (m *MockRbacUsecase) CheckUserRole(ctx context.Context, userID int, roleName string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckUserRole", ctx, userID, roleName)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckUserRole indicates an expected call of CheckUserRole.
+func (mr *MockRbacUsecaseMockRecorder) CheckUserRole(ctx, userID, roleName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckUserRole", reflect.TypeOf((*MockRbacUsecase)(nil).CheckUserRole), ctx, userID, roleName)
+}
+
+// CreateRole mocks base method.
+func (m *MockRbacUsecase) CreateRole(ctx context.Context, req *dto.CreateRoleRequest) (*models.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRole", ctx, req)
+	ret0, _ := ret[0].(*models.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRole indicates an expected call of CreateRole.
+func (mr *MockRbacUsecaseMockRecorder) CreateRole(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRole", reflect.TypeOf((*MockRbacUsecase)(nil).CreateRole), ctx, req)
+}
+
+// DeleteRole mocks base method.
+func (m *MockRbacUsecase) DeleteRole(ctx context.Context, roleID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRole", ctx, roleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRole indicates an expected call of DeleteRole.
+func (mr *MockRbacUsecaseMockRecorder) DeleteRole(ctx, roleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRole", reflect.TypeOf((*MockRbacUsecase)(nil).DeleteRole), ctx, roleID)
+}
+
+// GetRoleByID mocks base method.
+func (m *MockRbacUsecase) GetRoleByID(ctx context.Context, roleID int) (*models.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRoleByID", ctx, roleID)
+	ret0, _ := ret[0].(*models.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRoleByID indicates an expected call of GetRoleByID.
+func (mr *MockRbacUsecaseMockRecorder) GetRoleByID(ctx, roleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoleByID", reflect.TypeOf((*MockRbacUsecase)(nil).GetRoleByID), ctx, roleID)
+}
+
+// GetRoles mocks base method.
+func (m *MockRbacUsecase) GetRoles(ctx context.Context, pq *utils.PaginationQuery) (*models.RolesList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRoles", ctx, pq)
+	ret0, _ := ret[0].(*models.RolesList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRoles indicates an expected call of GetRoles.
+func (mr *MockRbacUsecaseMockRecorder) GetRoles(ctx, pq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoles", reflect.TypeOf((*MockRbacUsecase)(nil).GetRoles), ctx, pq)
+}
+
+// GetUserRBACContext mocks base method.
+func (m *MockRbacUsecase) GetUserRBACContext(ctx context.Context, userID int) (*dto.RBACContext, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserRBACContext", ctx, userID)
+	ret0, _ := ret[0].(*dto.RBACContext)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserRBACContext indicates an expected call of GetUserRBACContext.
+func (mr *MockRbacUsecaseMockRecorder) GetUserRBACContext(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserRBACContext", reflect.TypeOf((*MockRbacUsecase)(nil).GetUserRBACContext), ctx, userID)
+}
+
+// GetUsersWithRole mocks base method.
+func (m *MockRbacUsecase) GetUsersWithRole(ctx context.Context, roleName string) ([]models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsersWithRole", ctx, roleName)
+	ret0, _ := ret[0].([]models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsersWithRole indicates an expected call of GetUsersWithRole.
+func (mr *MockRbacUsecaseMockRecorder) GetUsersWithRole(ctx, roleName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersWithRole", reflect.TypeOf((*MockRbacUsecase)(nil).GetUsersWithRole), ctx, roleName)
+}
+
+// RemovePermissionFromRole mocks base method.
+func (m *MockRbacUsecase) RemovePermissionFromRole(ctx context.Context, req *dto.AssignRolePermissionRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemovePermissionFromRole", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemovePermissionFromRole indicates an expected call of RemovePermissionFromRole.
+func (mr *MockRbacUsecaseMockRecorder) RemovePermissionFromRole(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemovePermissionFromRole", reflect.TypeOf((*MockRbacUsecase)(nil).RemovePermissionFromRole), ctx, req)
+}
+
+// UpdateRole mocks base method.
+func (m *MockRbacUsecase) UpdateRole(ctx context.Context, roleID int, req *dto.UpdateRoleRequest) (*models.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRole", ctx, roleID, req)
+	ret0, _ := ret[0].(*models.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRole indicates an expected call of UpdateRole.
+func (mr *MockRbacUsecaseMockRecorder) UpdateRole(ctx, roleID, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRole", reflect.TypeOf((*MockRbacUsecase)(nil).UpdateRole), ctx, roleID, req)
+}
+
+// MockRoleRepository is a mock of RoleRepository interface.
+type MockRoleRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRoleRepositoryMockRecorder
+}
+
+// MockRoleRepositoryMockRecorder is the mock recorder for MockRoleRepository.
+type MockRoleRepositoryMockRecorder struct {
+	mock *MockRoleRepository
+}
+
+// NewMockRoleRepository creates a new mock instance.
+func NewMockRoleRepository(ctrl *gomock.Controller) *MockRoleRepository {
+	mock := &MockRoleRepository{ctrl: ctrl}
+	mock.recorder = &MockRoleRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRoleRepository) EXPECT() *MockRoleRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateRole mocks base method.
+func (m *MockRoleRepository) CreateRole(ctx context.Context, req *dto.CreateRoleRequest) (*models.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRole", ctx, req)
+	ret0, _ := ret[0].(*models.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRole indicates an expected call of CreateRole.
+func (mr *MockRoleRepositoryMockRecorder) CreateRole(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRole", reflect.TypeOf((*MockRoleRepository)(nil).CreateRole), ctx, req)
+}
+
+// DeleteRole mocks base method.
+func (m *MockRoleRepository) DeleteRole(ctx context.Context, roleID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRole", ctx, roleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRole indicates an expected call of DeleteRole.
+func (mr *MockRoleRepositoryMockRecorder) DeleteRole(ctx, roleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRole", reflect.TypeOf((*MockRoleRepository)(nil).DeleteRole), ctx, roleID)
+}
+
+// GetRoleByID mocks base method.
+func (m *MockRoleRepository) GetRoleByID(ctx context.Context, roleID int) (*models.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRoleByID", ctx, roleID)
+	ret0, _ := ret[0].(*models.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRoleByID indicates an expected call of GetRoleByID.
+func (mr *MockRoleRepositoryMockRecorder) GetRoleByID(ctx, roleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoleByID", reflect.TypeOf((*MockRoleRepository)(nil).GetRoleByID), ctx, roleID)
+}
+
+// GetRoles mocks base method.
+func (m *MockRoleRepository) GetRoles(ctx context.Context, pq *utils.PaginationQuery) (*models.RolesList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRoles", ctx, pq)
+	ret0, _ := ret[0].(*models.RolesList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRoles indicates an expected call of GetRoles.
+func (mr *MockRoleRepositoryMockRecorder) GetRoles(ctx, pq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoles", reflect.TypeOf((*MockRoleRepository)(nil).GetRoles), ctx, pq)
+}
+
+// UpdateRole mocks base method.
+func (m *MockRoleRepository) UpdateRole(ctx context.Context, roleID int, req *dto.UpdateRoleRequest) (*models.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRole", ctx, roleID, req)
+	ret0, _ := ret[0].(*models.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRole indicates an expected call of UpdateRole.
+func (mr *MockRoleRepositoryMockRecorder) UpdateRole(ctx, roleID, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRole", reflect.TypeOf((*MockRoleRepository)(nil).UpdateRole), ctx, roleID, req)
+}
+
+// MockPermissionRepository is a mock of PermissionRepository interface.
+type MockPermissionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPermissionRepositoryMockRecorder
+}
+
+// MockPermissionRepositoryMockRecorder is the mock recorder for MockPermissionRepository.
+type MockPermissionRepositoryMockRecorder struct {
+	mock *MockPermissionRepository
+}
+
+// NewMockPermissionRepository creates a new mock instance.
+func NewMockPermissionRepository(ctrl *gomock.Controller) *MockPermissionRepository {
+	mock := &MockPermissionRepository{ctrl: ctrl}
+	mock.recorder = &MockPermissionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPermissionRepository) EXPECT() *MockPermissionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreatePermission mocks base method.
+func (m *MockPermissionRepository) CreatePermission(ctx context.Context, req *dto.CreatePermissionRequest) (*models.Permission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePermission", ctx, req)
+	ret0, _ := ret[0].(*models.Permission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePermission indicates an expected call of CreatePermission.
+func (mr *MockPermissionRepositoryMockRecorder) CreatePermission(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePermission", reflect.TypeOf((*MockPermissionRepository)(nil).CreatePermission), ctx, req)
+}
+
+// DeletePermission mocks base method.
+func (m *MockPermissionRepository) DeletePermission(ctx context.Context, permissionID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePermission", ctx, permissionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePermission indicates an expected call of DeletePermission.
+func (mr *MockPermissionRepositoryMockRecorder) DeletePermission(ctx, permissionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePermission", reflect.TypeOf((*MockPermissionRepository)(nil).DeletePermission), ctx, permissionID)
+}
+
+// GetPermissionByID mocks base method.
+func (m *MockPermissionRepository) GetPermissionByID(ctx context.Context, permissionID int) (*models.Permission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPermissionByID", ctx, permissionID)
+	ret0, _ := ret[0].(*models.Permission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPermissionByID indicates an expected call of GetPermissionByID.
+func (mr *MockPermissionRepositoryMockRecorder) GetPermissionByID(ctx, permissionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPermissionByID", reflect.TypeOf((*MockPermissionRepository)(nil).GetPermissionByID), ctx, permissionID)
+}
+
+// GetPermissions mocks base method.
+func (m *MockPermissionRepository) GetPermissions(ctx context.Context, pq *utils.PaginationQuery) (*dto.PermissionsList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPermissions", ctx, pq)
+	ret0, _ := ret[0].(*dto.PermissionsList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPermissions indicates an expected call of GetPermissions.
+func (mr *MockPermissionRepositoryMockRecorder) GetPermissions(ctx, pq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPermissions", reflect.TypeOf((*MockPermissionRepository)(nil).GetPermissions), ctx, pq)
+}
+
+// MockResourceRepository is a mock of ResourceRepository interface.
+type MockResourceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockResourceRepositoryMockRecorder
+}
+
+// MockResourceRepositoryMockRecorder is the mock recorder for MockResourceRepository.
+type MockResourceRepositoryMockRecorder struct {
+	mock *MockResourceRepository
+}
+
+// NewMockResourceRepository creates a new mock instance.
+func NewMockResourceRepository(ctrl *gomock.Controller) *MockResourceRepository {
+	mock := &MockResourceRepository{ctrl: ctrl}
+	mock.recorder = &MockResourceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockResourceRepository) EXPECT() *MockResourceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateResource mocks base method.
+func (m *MockResourceRepository) CreateResource(ctx context.Context, req *dto.CreateResourceRequest) (*models.Resource, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateResource", ctx, req)
+	ret0, _ := ret[0].(*models.Resource)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateResource indicates an expected call of CreateResource.
+func (mr *MockResourceRepositoryMockRecorder) CreateResource(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateResource", reflect.TypeOf((*MockResourceRepository)(nil).CreateResource), ctx, req)
+}
+
+// DeleteResource mocks base method.
+func (m *MockResourceRepository) DeleteResource(ctx context.Context, resourceID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteResource", ctx, resourceID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteResource indicates an expected call of DeleteResource.
+func (mr *MockResourceRepositoryMockRecorder) DeleteResource(ctx, resourceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteResource", reflect.TypeOf((*MockResourceRepository)(nil).DeleteResource), ctx, resourceID)
+}
+
+// GetResourceByID mocks base method.
+func (m *MockResourceRepository) GetResourceByID(ctx context.Context, resourceID int) (*models.Resource, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetResourceByID", ctx, resourceID)
+	ret0, _ := ret[0].(*models.Resource)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetResourceByID indicates an expected call of GetResourceByID.
+func (mr *MockResourceRepositoryMockRecorder) GetResourceByID(ctx, resourceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResourceByID", reflect.TypeOf((*MockResourceRepository)(nil).GetResourceByID), ctx, resourceID)
+}
+
+// GetResources mocks base method.
+func (m *MockResourceRepository) GetResources(ctx context.Context, pq *utils.PaginationQuery) (*dto.ResourcesList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetResources", ctx, pq)
+	ret0, _ := ret[0].(*dto.ResourcesList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetResources indicates an expected call of GetResources.
+func (mr *MockResourceRepositoryMockRecorder) GetResources(ctx, pq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResources", reflect.TypeOf((*MockResourceRepository)(nil).GetResources), ctx, pq)
+}
+
+// MockContextRepository is a mock of ContextRepository interface.
+type MockContextRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockContextRepositoryMockRecorder
+}
+
+// MockContextRepositoryMockRecorder is the mock recorder for MockContextRepository.
+type MockContextRepositoryMockRecorder struct {
+	mock *MockContextRepository
+}
+
+// NewMockContextRepository creates a new mock instance.
+func NewMockContextRepository(ctrl *gomock.Controller) *MockContextRepository {
+	mock := &MockContextRepository{ctrl: ctrl}
+	mock.recorder = &MockContextRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockContextRepository) EXPECT() *MockContextRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateContext mocks base method.
+func (m *MockContextRepository) CreateContext(ctx context.Context, req *dto.CreateContextRequest) (*models.Context, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateContext", ctx, req)
+	ret0, _ := ret[0].(*models.Context)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateContext indicates an expected call of CreateContext.
+func (mr *MockContextRepositoryMockRecorder) CreateContext(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateContext", reflect.TypeOf((*MockContextRepository)(nil).CreateContext), ctx, req)
+}
+
+// DeleteContext mocks base method.
+func (m *MockContextRepository) DeleteContext(ctx context.Context, contextID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteContext", ctx, contextID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteContext indicates an expected call of DeleteContext.
+func (mr *MockContextRepositoryMockRecorder) DeleteContext(ctx, contextID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteContext", reflect.TypeOf((*MockContextRepository)(nil).DeleteContext), ctx, contextID)
+}
+
+// GetContextByID mocks base method.
+func (m *MockContextRepository) GetContextByID(ctx context.Context, contextID int) (*models.Context, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContextByID", ctx, contextID)
+	ret0, _ := ret[0].(*models.Context)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContextByID indicates an expected call of GetContextByID.
+func (mr *MockContextRepositoryMockRecorder) GetContextByID(ctx, contextID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContextByID", reflect.TypeOf((*MockContextRepository)(nil).GetContextByID), ctx, contextID)
+}
+
+// GetContexts mocks base method.
+func (m *MockContextRepository) GetContexts(ctx context.Context, pq *utils.PaginationQuery) (*dto.ContextsList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContexts", ctx, pq)
+	ret0, _ := ret[0].(*dto.ContextsList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContexts indicates an expected call of GetContexts.
+func (mr *MockContextRepositoryMockRecorder) GetContexts(ctx, pq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContexts", reflect.TypeOf((*MockContextRepository)(nil).GetContexts), ctx, pq)
+}
+
+// MockRBACServiceInterface is a mock of RBACServiceInterface interface.
+type MockRBACServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockRBACServiceInterfaceMockRecorder
+}
+
+// MockRBACServiceInterfaceMockRecorder is the mock recorder for MockRBACServiceInterface.
+type MockRBACServiceInterfaceMockRecorder struct {
+	mock *MockRBACServiceInterface
+}
+
+// NewMockRBACServiceInterface creates a new mock instance.
+func NewMockRBACServiceInterface(ctrl *gomock.Controller) *MockRBACServiceInterface {
+	mock := &MockRBACServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockRBACServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRBACServiceInterface) EXPECT() *MockRBACServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// AssignPermissionToRole mocks base method.
+func (m *MockRBACServiceInterface) AssignPermissionToRole(roleID, permissionID, resourceID int, contextID *int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignPermissionToRole", roleID, permissionID, resourceID, contextID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AssignPermissionToRole indicates an expected call of AssignPermissionToRole.
+func (mr *MockRBACServiceInterfaceMockRecorder) AssignPermissionToRole(roleID, permissionID, resourceID, contextID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignPermissionToRole", reflect.TypeOf((*MockRBACServiceInterface)(nil).AssignPermissionToRole), roleID, permissionID, resourceID, contextID)
+}
+
+// AssignRolesToUser mocks base method.
+func (m *MockRBACServiceInterface) AssignRolesToUser(userID int, roleIDs []int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignRolesToUser", userID, roleIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AssignRolesToUser indicates an expected call of AssignRolesToUser.
+func (mr *MockRBACServiceInterfaceMockRecorder) AssignRolesToUser(userID, roleIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignRolesToUser", reflect.TypeOf((*MockRBACServiceInterface)(nil).AssignRolesToUser), userID, roleIDs)
+}
+
+// GetRolePermissions mocks base method.
+func (m *MockRBACServiceInterface) GetRolePermissions(roleID int) ([]models.RolePermission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRolePermissions", roleID)
+	ret0, _ := ret[0].([]models.RolePermission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRolePermissions indicates an expected call of GetRolePermissions.
+func (mr *MockRBACServiceInterfaceMockRecorder) GetRolePermissions(roleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRolePermissions", reflect.TypeOf((*MockRBACServiceInterface)(nil).GetRolePermissions), roleID)
+}
+
+// GetUserAllRoles mocks base method.
+func (m *MockRBACServiceInterface) GetUserAllRoles(userID int) ([]models.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserAllRoles", userID)
+	ret0, _ := ret[0].([]models.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserAllRoles indicates an expected call of GetUserAllRoles.
+func (mr *MockRBACServiceInterfaceMockRecorder) GetUserAllRoles(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserAllRoles", reflect.TypeOf((*MockRBACServiceInterface)(nil).GetUserAllRoles), userID)
+}
+
+// GetUserDirectRoles mocks base method.
+func (m *MockRBACServiceInterface) GetUserDirectRoles(userID int) ([]models.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserDirectRoles", userID)
+	ret0, _ := ret[0].([]models.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserDirectRoles indicates an expected call of GetUserDirectRoles.
+func (mr *MockRBACServiceInterfaceMockRecorder) GetUserDirectRoles(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserDirectRoles", reflect.TypeOf((*MockRBACServiceInterface)(nil).GetUserDirectRoles), userID)
+}
+
+// GetUserPermissions mocks base method.
+func (m *MockRBACServiceInterface) GetUserPermissions(userID int) ([]models.RolePermission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserPermissions", userID)
+	ret0, _ := ret[0].([]models.RolePermission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserPermissions indicates an expected call of GetUserPermissions.
+func (mr *MockRBACServiceInterfaceMockRecorder) GetUserPermissions(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserPermissions", reflect.TypeOf((*MockRBACServiceInterface)(nil).GetUserPermissions), userID)
+}
+
+// GetUserRBACContext mocks base method.
+func (m *MockRBACServiceInterface) GetUserRBACContext(userID int) (*dto.RBACContext, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserRBACContext", userID)
+	ret0, _ := ret[0].(*dto.RBACContext)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserRBACContext indicates an expected call of GetUserRBACContext.
+func (mr *MockRBACServiceInterfaceMockRecorder) GetUserRBACContext(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserRBACContext", reflect.TypeOf((*MockRBACServiceInterface)(nil).GetUserRBACContext), userID)
+}
+
+// GetUsersWithRole mocks base method.
+func (m *MockRBACServiceInterface) GetUsersWithRole(roleName string) ([]models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsersWithRole", roleName)
+	ret0, _ := ret[0].([]models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsersWithRole indicates an expected call of GetUsersWithRole.
+func (mr *MockRBACServiceInterfaceMockRecorder) GetUsersWithRole(roleName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersWithRole", reflect.TypeOf((*MockRBACServiceInterface)(nil).GetUsersWithRole), roleName)
+}
+
+// HasPermission mocks base method.
+func (m *MockRBACServiceInterface) HasPermission(userID int, permissionName, resourceName string, contextName *string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasPermission", userID, permissionName, resourceName, contextName)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasPermission indicates an expected call of HasPermission.
+func (mr *MockRBACServiceInterfaceMockRecorder) HasPermission(userID, permissionName, resourceName, contextName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasPermission", reflect.TypeOf((*MockRBACServiceInterface)(nil).HasPermission), userID, permissionName, resourceName, contextName)
+}
+
+// HasRole mocks base method.
+func (m *MockRBACServiceInterface) HasRole(userID int, roleName string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasRole", userID, roleName)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasRole indicates an expected call of HasRole.
+func (mr *MockRBACServiceInterfaceMockRecorder) HasRole(userID, roleName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasRole", reflect.TypeOf((*MockRBACServiceInterface)(nil).HasRole), userID, roleName)
+}
+
+// RemovePermissionFromRole mocks base method.
+func (m *MockRBACServiceInterface) RemovePermissionFromRole(roleID, permissionID, resourceID int, contextID *int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemovePermissionFromRole", roleID, permissionID, resourceID, contextID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemovePermissionFromRole indicates an expected call of RemovePermissionFromRole.
+func (mr *MockRBACServiceInterfaceMockRecorder) RemovePermissionFromRole(roleID, permissionID, resourceID, contextID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemovePermissionFromRole", reflect.TypeOf((*MockRBACServiceInterface)(nil).RemovePermissionFromRole), roleID, permissionID, resourceID, contextID)
+}
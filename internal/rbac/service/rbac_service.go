@@ -1,8 +1,12 @@
 package service
 
 import (
+	"context"
+	"strconv"
+
 	"github.com/aditwar-man/go-microservice-boilerplate/internal/dto"
 	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	outboxRepository "github.com/aditwar-man/go-microservice-boilerplate/internal/outbox/repository"
 	"github.com/aditwar-man/go-microservice-boilerplate/internal/rbac"
 	"github.com/jmoiron/sqlx"
 )
@@ -293,15 +297,36 @@ func (s *RBACService) AssignRolesToUser(userID int, roleIDs []int) error {
 		}
 	}
 
+	if err = s.writeEvent(tx, models.AggregateUser, userID, models.EventUserRolesChanged, map[string]interface{}{
+		"user_id":  userID,
+		"role_ids": roleIDs,
+	}); err != nil {
+		return err
+	}
+
 	return tx.Commit()
 }
 
 // AssignPermissionToRole assigns a permission to a role for a specific resource and context
 func (s *RBACService) AssignPermissionToRole(roleID, permissionID, resourceID int, contextID *int) error {
-	_, err := s.db.Exec(
+	tx, err := s.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(
 		"INSERT INTO role_permissions (role_id, permission_id, resource_id, context_id) VALUES ($1, $2, $3, $4) ON CONFLICT DO NOTHING",
 		roleID, permissionID, resourceID, contextID)
-	return err
+	if err != nil {
+		return err
+	}
+
+	if err = s.writeRolePermissionEvent(tx, "granted", roleID, permissionID, resourceID, contextID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 // RemovePermissionFromRole removes a permission from a role
@@ -316,8 +341,41 @@ func (s *RBACService) RemovePermissionFromRole(roleID, permissionID, resourceID
 		query += " AND context_id IS NULL"
 	}
 
-	_, err := s.db.Exec(query, args...)
-	return err
+	tx, err := s.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err = tx.Exec(query, args...); err != nil {
+		return err
+	}
+
+	if err = s.writeRolePermissionEvent(tx, "revoked", roleID, permissionID, resourceID, contextID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *RBACService) writeRolePermissionEvent(tx *sqlx.Tx, change string, roleID, permissionID, resourceID int, contextID *int) error {
+	return s.writeEvent(tx, models.AggregateRole, roleID, models.EventRolePermissionsChanged, map[string]interface{}{
+		"change":        change,
+		"role_id":       roleID,
+		"permission_id": permissionID,
+		"resource_id":   resourceID,
+		"context_id":    contextID,
+	})
+}
+
+// writeEvent appends a domain event to the outbox inside tx
+func (s *RBACService) writeEvent(tx *sqlx.Tx, aggregateType string, aggregateID int, eventType string, payload interface{}) error {
+	event, err := models.NewOutboxEvent(aggregateType, strconv.Itoa(aggregateID), eventType, payload)
+	if err != nil {
+		return err
+	}
+
+	return outboxRepository.WriteEventsTx(context.Background(), tx, event)
 }
 
 // GetRolePermissions gets all permissions for a role
@@ -0,0 +1,11 @@
+package reconciliation
+
+import "github.com/labstack/echo/v4"
+
+// Reconciliation HTTP Handlers interface
+type Handlers interface {
+	Run() echo.HandlerFunc
+	ListRuns() echo.HandlerFunc
+	GetRun() echo.HandlerFunc
+	RepairDrift() echo.HandlerFunc
+}
@@ -0,0 +1,135 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/opentracing/opentracing-go"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/dto"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/reconciliation"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/httpErrors"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/logger"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/utils"
+)
+
+type reconciliationHandlers struct {
+	reconciliationUC reconciliation.UseCase
+	logger           logger.Logger
+}
+
+func NewReconciliationHandlers(reconciliationUC reconciliation.UseCase, log logger.Logger) reconciliation.Handlers {
+	return &reconciliationHandlers{reconciliationUC: reconciliationUC, logger: log}
+}
+
+// Run godoc
+// @Summary Run reconciliation
+// @Description Recompute every wallet balance from the ledger and report the balances that disagree
+// @Tags Reconciliation
+// @Produce json
+// @Success 201 {object} models.ReconciliationRun
+// @Router /reconciliation/runs [post]
+func (h *reconciliationHandlers) Run() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "reconciliation.Run")
+		defer span.Finish()
+
+		run, err := h.reconciliationUC.Run(ctx, models.ReconciliationTriggerManual)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusCreated, run)
+	}
+}
+
+// ListRuns godoc
+// @Summary List reconciliation runs
+// @Description List the latest reconciliation runs, newest first
+// @Tags Reconciliation
+// @Produce json
+// @Success 200 {array} models.ReconciliationRun
+// @Router /reconciliation/runs [get]
+func (h *reconciliationHandlers) ListRuns() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "reconciliation.ListRuns")
+		defer span.Finish()
+
+		runs, err := h.reconciliationUC.ListRuns(ctx)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, runs)
+	}
+}
+
+// GetRun godoc
+// @Summary Get reconciliation run
+// @Description Get a reconciliation run with the drifts it found
+// @Tags Reconciliation
+// @Produce json
+// @Param id path int true "run_id"
+// @Success 200 {object} models.ReconciliationRun
+// @Router /reconciliation/runs/{id} [get]
+func (h *reconciliationHandlers) GetRun() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "reconciliation.GetRun")
+		defer span.Finish()
+
+		runID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(httpErrors.NewBadRequestError(err.Error())))
+		}
+
+		run, err := h.reconciliationUC.GetRun(ctx, runID)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, run)
+	}
+}
+
+// RepairDrift godoc
+// @Summary Repair balance drift
+// @Description Approve a correcting ledger entry that brings the ledger in line with the wallet balance, requires approve on wallets and repairs to be enabled
+// @Tags Reconciliation
+// @Accept json
+// @Produce json
+// @Param id path int true "drift_id"
+// @Param body body dto.RequestDriftRepair true "reason"
+// @Success 200 {object} models.BalanceDrift
+// @Router /reconciliation/drifts/{id}/repair [post]
+func (h *reconciliationHandlers) RepairDrift() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "reconciliation.RepairDrift")
+		defer span.Finish()
+
+		driftID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(httpErrors.NewBadRequestError(err.Error())))
+		}
+
+		repairRequest := &dto.RequestDriftRepair{}
+		if err := utils.ReadRequest(c, repairRequest); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		repaired, err := h.reconciliationUC.RepairDrift(ctx, driftID, repairRequest)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, repaired)
+	}
+}
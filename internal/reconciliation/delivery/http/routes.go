@@ -0,0 +1,22 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/config"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/auth"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/middleware"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/reconciliation"
+)
+
+// Map reconciliation routes, reports are for wallet administrators and repairs for approvers
+func MapReconciliationRoutes(reconciliationGroup *echo.Group, h reconciliation.Handlers, mw *middleware.MiddlewareManager, rbacMw *middleware.RBACMiddleware, authUc auth.UseCase, cfg *config.Config) {
+	reconciliationGroup.Use(mw.AuthJWTMiddleware(authUc, cfg))
+	reconciliationGroup.Use(mw.AuthSessionMiddleware)
+	reconciliationGroup.Use(rbacMw.RequirePermission("manage", "wallets", nil))
+
+	reconciliationGroup.POST("/runs", h.Run())
+	reconciliationGroup.GET("/runs", h.ListRuns())
+	reconciliationGroup.GET("/runs/:id", h.GetRun())
+	reconciliationGroup.POST("/drifts/:id/repair", h.RepairDrift(), rbacMw.RequirePermission("approve", "wallets", nil))
+}
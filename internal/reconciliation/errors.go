@@ -0,0 +1,17 @@
+package reconciliation
+
+import (
+	"net/http"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/httpErrors"
+)
+
+// Reconciliation domain errors
+var (
+	ErrRunNotFound    = httpErrors.NewRestError(http.StatusNotFound, "Reconciliation run not found", nil)
+	ErrRunInProgress  = httpErrors.NewRestError(http.StatusConflict, "A reconciliation run is already in progress", nil)
+	ErrDriftNotFound  = httpErrors.NewRestError(http.StatusNotFound, "Balance drift not found", nil)
+	ErrDriftNotOpen   = httpErrors.NewRestError(http.StatusConflict, "Balance drift is no longer open", nil)
+	ErrDriftChanged   = httpErrors.NewRestError(http.StatusConflict, "Balance drift changed since it was reported, run reconciliation again", nil)
+	ErrRepairDisabled = httpErrors.NewRestError(http.StatusForbidden, "Reconciliation repairs are disabled", nil)
+)
@@ -0,0 +1,186 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pg_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CompleteRun mocks base method.
+func (m *MockRepository) CompleteRun(ctx context.Context, runID int64, walletsChecked int, drifts []*models.BalanceDrift) (*models.ReconciliationRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteRun", ctx, runID, walletsChecked, drifts)
+	ret0, _ := ret[0].(*models.ReconciliationRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteRun indicates an expected call of CompleteRun.
+func (mr *MockRepositoryMockRecorder) CompleteRun(ctx, runID, walletsChecked, drifts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteRun", reflect.TypeOf((*MockRepository)(nil).CompleteRun), ctx, runID, walletsChecked, drifts)
+}
+
+// FailRun mocks base method.
+func (m *MockRepository) FailRun(ctx context.Context, runID int64, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailRun", ctx, runID, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FailRun indicates an expected call of FailRun.
+func (mr *MockRepositoryMockRecorder) FailRun(ctx, runID, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailRun", reflect.TypeOf((*MockRepository)(nil).FailRun), ctx, runID, reason)
+}
+
+// FindDrifts mocks base method.
+func (m *MockRepository) FindDrifts(ctx context.Context, fromID, toID int64) ([]*models.BalanceDrift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDrifts", ctx, fromID, toID)
+	ret0, _ := ret[0].([]*models.BalanceDrift)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDrifts indicates an expected call of FindDrifts.
+func (mr *MockRepositoryMockRecorder) FindDrifts(ctx, fromID, toID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDrifts", reflect.TypeOf((*MockRepository)(nil).FindDrifts), ctx, fromID, toID)
+}
+
+// FindRunDrifts mocks base method.
+func (m *MockRepository) FindRunDrifts(ctx context.Context, runID int64) ([]*models.BalanceDrift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRunDrifts", ctx, runID)
+	ret0, _ := ret[0].([]*models.BalanceDrift)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRunDrifts indicates an expected call of FindRunDrifts.
+func (mr *MockRepositoryMockRecorder) FindRunDrifts(ctx, runID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRunDrifts", reflect.TypeOf((*MockRepository)(nil).FindRunDrifts), ctx, runID)
+}
+
+// GetDrift mocks base method.
+func (m *MockRepository) GetDrift(ctx context.Context, driftID int64) (*models.BalanceDrift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDrift", ctx, driftID)
+	ret0, _ := ret[0].(*models.BalanceDrift)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDrift indicates an expected call of GetDrift.
+func (mr *MockRepositoryMockRecorder) GetDrift(ctx, driftID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDrift", reflect.TypeOf((*MockRepository)(nil).GetDrift), ctx, driftID)
+}
+
+// GetRun mocks base method.
+func (m *MockRepository) GetRun(ctx context.Context, runID int64) (*models.ReconciliationRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRun", ctx, runID)
+	ret0, _ := ret[0].(*models.ReconciliationRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRun indicates an expected call of GetRun.
+func (mr *MockRepositoryMockRecorder) GetRun(ctx, runID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRun", reflect.TypeOf((*MockRepository)(nil).GetRun), ctx, runID)
+}
+
+// ListRuns mocks base method.
+func (m *MockRepository) ListRuns(ctx context.Context, limit int) ([]*models.ReconciliationRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRuns", ctx, limit)
+	ret0, _ := ret[0].([]*models.ReconciliationRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRuns indicates an expected call of ListRuns.
+func (mr *MockRepositoryMockRecorder) ListRuns(ctx, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRuns", reflect.TypeOf((*MockRepository)(nil).ListRuns), ctx, limit)
+}
+
+// NextWalletIDs mocks base method.
+func (m *MockRepository) NextWalletIDs(ctx context.Context, afterID int64, limit int) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NextWalletIDs", ctx, afterID, limit)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NextWalletIDs indicates an expected call of NextWalletIDs.
+func (mr *MockRepositoryMockRecorder) NextWalletIDs(ctx, afterID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NextWalletIDs", reflect.TypeOf((*MockRepository)(nil).NextWalletIDs), ctx, afterID, limit)
+}
+
+// RepairDriftTx mocks base method.
+func (m *MockRepository) RepairDriftTx(ctx context.Context, driftID, userID int64, reason string) (*models.BalanceDrift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RepairDriftTx", ctx, driftID, userID, reason)
+	ret0, _ := ret[0].(*models.BalanceDrift)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RepairDriftTx indicates an expected call of RepairDriftTx.
+func (mr *MockRepositoryMockRecorder) RepairDriftTx(ctx, driftID, userID, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RepairDriftTx", reflect.TypeOf((*MockRepository)(nil).RepairDriftTx), ctx, driftID, userID, reason)
+}
+
+// StartRun mocks base method.
+func (m *MockRepository) StartRun(ctx context.Context, run *models.ReconciliationRun, notSince, staleAfter time.Duration) (*models.ReconciliationRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartRun", ctx, run, notSince, staleAfter)
+	ret0, _ := ret[0].(*models.ReconciliationRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartRun indicates an expected call of StartRun.
+func (mr *MockRepositoryMockRecorder) StartRun(ctx, run, notSince, staleAfter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartRun", reflect.TypeOf((*MockRepository)(nil).StartRun), ctx, run, notSince, staleAfter)
+}
@@ -0,0 +1,28 @@
+//go:generate mockgen -source pg_repository.go -destination mock/pg_repository_mock.go -package mock
+package reconciliation
+
+import (
+	"context"
+	"time"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+)
+
+// Reconciliation repository interface
+type Repository interface {
+	// StartRun fails with ErrRunInProgress while a run started within
+	// staleAfter is running or any run started within notSince
+	StartRun(ctx context.Context, run *models.ReconciliationRun, notSince, staleAfter time.Duration) (*models.ReconciliationRun, error)
+	CompleteRun(ctx context.Context, runID int64, walletsChecked int, drifts []*models.BalanceDrift) (*models.ReconciliationRun, error)
+	FailRun(ctx context.Context, runID int64, reason string) error
+	GetRun(ctx context.Context, runID int64) (*models.ReconciliationRun, error)
+	ListRuns(ctx context.Context, limit int) ([]*models.ReconciliationRun, error)
+
+	// Wallet ids after afterID in id order, and the drifts of wallets in [fromID, toID]
+	NextWalletIDs(ctx context.Context, afterID int64, limit int) ([]int64, error)
+	FindDrifts(ctx context.Context, fromID, toID int64) ([]*models.BalanceDrift, error)
+
+	GetDrift(ctx context.Context, driftID int64) (*models.BalanceDrift, error)
+	FindRunDrifts(ctx context.Context, runID int64) ([]*models.BalanceDrift, error)
+	RepairDriftTx(ctx context.Context, driftID int64, userID int64, reason string) (*models.BalanceDrift, error)
+}
@@ -0,0 +1,270 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/reconciliation"
+	walletRepository "github.com/aditwar-man/go-microservice-boilerplate/internal/wallet/repository"
+)
+
+// Reconciliation Repository
+type reconciliationRepo struct {
+	db *sqlx.DB
+}
+
+// Reconciliation Repository constructor
+func NewReconciliationRepository(db *sqlx.DB) reconciliation.Repository {
+	return &reconciliationRepo{db: db}
+}
+
+// Start a run unless another one is running or started too recently
+func (r *reconciliationRepo) StartRun(ctx context.Context, run *models.ReconciliationRun, notSince, staleAfter time.Duration) (*models.ReconciliationRun, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "reconciliationRepo.StartRun")
+	defer span.Finish()
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "reconciliationRepo.StartRun.BeginTxx")
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, lockRunsQuery); err != nil {
+		return nil, errors.Wrap(err, "reconciliationRepo.StartRun.ExecContext.lock")
+	}
+
+	started := &models.ReconciliationRun{}
+	if err := tx.QueryRowxContext(ctx, startRunQuery,
+		run.Trigger, run.RequestedBy, staleAfter.Seconds(), notSince.Seconds(),
+	).StructScan(started); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, reconciliation.ErrRunInProgress
+		}
+		return nil, errors.Wrap(err, "reconciliationRepo.StartRun.StructScan")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "reconciliationRepo.StartRun.Commit")
+	}
+
+	return started, nil
+}
+
+// Record the drifts of a run, complete it and supersede the open drifts of earlier runs
+func (r *reconciliationRepo) CompleteRun(ctx context.Context, runID int64, walletsChecked int, drifts []*models.BalanceDrift) (*models.ReconciliationRun, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "reconciliationRepo.CompleteRun")
+	defer span.Finish()
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "reconciliationRepo.CompleteRun.BeginTxx")
+	}
+	defer tx.Rollback()
+
+	recorded := make([]*models.BalanceDrift, 0, len(drifts))
+	for _, d := range drifts {
+		created := &models.BalanceDrift{}
+		if err := tx.QueryRowxContext(ctx, createDriftQuery,
+			runID, d.WalletID, d.Currency, d.Balance, d.Ledger, d.Difference,
+		).StructScan(created); err != nil {
+			return nil, errors.Wrap(err, "reconciliationRepo.CompleteRun.StructScan.drift")
+		}
+		recorded = append(recorded, created)
+	}
+
+	if _, err := tx.ExecContext(ctx, supersedeDriftsQuery, runID); err != nil {
+		return nil, errors.Wrap(err, "reconciliationRepo.CompleteRun.ExecContext.supersede")
+	}
+
+	run := &models.ReconciliationRun{}
+	if err := tx.QueryRowxContext(ctx, completeRunQuery, runID, walletsChecked, len(recorded)).StructScan(run); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, reconciliation.ErrRunNotFound
+		}
+		return nil, errors.Wrap(err, "reconciliationRepo.CompleteRun.StructScan.run")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "reconciliationRepo.CompleteRun.Commit")
+	}
+
+	run.Drifts = recorded
+	return run, nil
+}
+
+// Mark a running run failed
+func (r *reconciliationRepo) FailRun(ctx context.Context, runID int64, reason string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "reconciliationRepo.FailRun")
+	defer span.Finish()
+
+	if _, err := r.db.ExecContext(ctx, failRunQuery, runID, reason); err != nil {
+		return errors.Wrap(err, "reconciliationRepo.FailRun.ExecContext")
+	}
+
+	return nil
+}
+
+// Get run by id
+func (r *reconciliationRepo) GetRun(ctx context.Context, runID int64) (*models.ReconciliationRun, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "reconciliationRepo.GetRun")
+	defer span.Finish()
+
+	run := &models.ReconciliationRun{}
+	if err := r.db.GetContext(ctx, run, getRunQuery, runID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, reconciliation.ErrRunNotFound
+		}
+		return nil, errors.Wrap(err, "reconciliationRepo.GetRun.GetContext")
+	}
+
+	return run, nil
+}
+
+// List the latest runs, newest first
+func (r *reconciliationRepo) ListRuns(ctx context.Context, limit int) ([]*models.ReconciliationRun, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "reconciliationRepo.ListRuns")
+	defer span.Finish()
+
+	runs := make([]*models.ReconciliationRun, 0)
+	if err := r.db.SelectContext(ctx, &runs, listRunsQuery, limit); err != nil {
+		return nil, errors.Wrap(err, "reconciliationRepo.ListRuns.SelectContext")
+	}
+
+	return runs, nil
+}
+
+// Next batch of wallet ids in id order
+func (r *reconciliationRepo) NextWalletIDs(ctx context.Context, afterID int64, limit int) ([]int64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "reconciliationRepo.NextWalletIDs")
+	defer span.Finish()
+
+	ids := make([]int64, 0, limit)
+	if err := r.db.SelectContext(ctx, &ids, nextWalletIDsQuery, afterID, limit); err != nil {
+		return nil, errors.Wrap(err, "reconciliationRepo.NextWalletIDs.SelectContext")
+	}
+
+	return ids, nil
+}
+
+// Balances of wallets in [fromID, toID] that disagree with their ledger sum.
+// Both sides are read in one statement, so postings in flight cannot show up
+// as drift.
+func (r *reconciliationRepo) FindDrifts(ctx context.Context, fromID, toID int64) ([]*models.BalanceDrift, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "reconciliationRepo.FindDrifts")
+	defer span.Finish()
+
+	drifts := make([]*models.BalanceDrift, 0)
+	if err := r.db.SelectContext(ctx, &drifts, findDriftsQuery, fromID, toID); err != nil {
+		return nil, errors.Wrap(err, "reconciliationRepo.FindDrifts.SelectContext")
+	}
+
+	return drifts, nil
+}
+
+// Get drift by id
+func (r *reconciliationRepo) GetDrift(ctx context.Context, driftID int64) (*models.BalanceDrift, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "reconciliationRepo.GetDrift")
+	defer span.Finish()
+
+	d := &models.BalanceDrift{}
+	if err := r.db.GetContext(ctx, d, getDriftQuery, driftID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, reconciliation.ErrDriftNotFound
+		}
+		return nil, errors.Wrap(err, "reconciliationRepo.GetDrift.GetContext")
+	}
+
+	return d, nil
+}
+
+// Drifts recorded by a run in wallet and currency order
+func (r *reconciliationRepo) FindRunDrifts(ctx context.Context, runID int64) ([]*models.BalanceDrift, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "reconciliationRepo.FindRunDrifts")
+	defer span.Finish()
+
+	drifts := make([]*models.BalanceDrift, 0)
+	if err := r.db.SelectContext(ctx, &drifts, findRunDriftsQuery, runID); err != nil {
+		return nil, errors.Wrap(err, "reconciliationRepo.FindRunDrifts.SelectContext")
+	}
+
+	return drifts, nil
+}
+
+// Bring the ledger of an open drift in line with its balance. The wallet
+// balance is taken as correct: the difference is posted between the wallet
+// and the reconciliation suspense account through the wallet ledger path,
+// while wallet_balances is left untouched. Under the balance lock the entry
+// takes, the ledger must then match the balance, otherwise the drift moved
+// since it was reported.
+func (r *reconciliationRepo) RepairDriftTx(ctx context.Context, driftID int64, userID int64, reason string) (*models.BalanceDrift, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "reconciliationRepo.RepairDriftTx")
+	defer span.Finish()
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "reconciliationRepo.RepairDriftTx.BeginTxx")
+	}
+	defer tx.Rollback()
+
+	d := &models.BalanceDrift{}
+	if err := tx.GetContext(ctx, d, getDriftForUpdateQuery, driftID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, reconciliation.ErrDriftNotFound
+		}
+		return nil, errors.Wrap(err, "reconciliationRepo.RepairDriftTx.GetContext.drift")
+	}
+
+	if d.Status != models.DriftOpen {
+		return nil, reconciliation.ErrDriftNotOpen
+	}
+
+	meta, err := json.Marshal(map[string]interface{}{"run_id": d.RunID, "drift_id": d.ID, "repaired_by": userID})
+	if err != nil {
+		return nil, errors.Wrap(err, "reconciliationRepo.RepairDriftTx.Marshal")
+	}
+
+	entry := &models.JournalEntry{
+		Type:        models.EntryTypeReconciliation,
+		RefID:       d.RepairRef(),
+		Description: "Reconciliation: " + reason,
+		Meta:        meta,
+		Postings: []models.Posting{
+			models.WalletPosting(d.WalletID, models.TypeReconciliation, d.Currency, d.Difference),
+			models.SystemPosting(models.AccountReconciliation, models.TypeReconciliation, d.Currency, d.Difference.Neg()),
+		},
+	}
+	if err := walletRepository.PostRepairEntryTx(ctx, tx, entry); err != nil {
+		return nil, err
+	}
+
+	var balance, ledger decimal.Decimal
+	if err := tx.GetContext(ctx, &balance, getBalanceQuery, d.WalletID, d.Currency); err != nil {
+		return nil, errors.Wrap(err, "reconciliationRepo.RepairDriftTx.GetContext.balance")
+	}
+	if err := tx.GetContext(ctx, &ledger, ledgerSumQuery, d.WalletID, d.Currency); err != nil {
+		return nil, errors.Wrap(err, "reconciliationRepo.RepairDriftTx.GetContext.ledger")
+	}
+
+	if !balance.Equal(ledger) {
+		return nil, reconciliation.ErrDriftChanged
+	}
+
+	repaired := &models.BalanceDrift{}
+	if err := tx.QueryRowxContext(ctx, repairDriftQuery, d.ID, entry.ID, userID, reason).StructScan(repaired); err != nil {
+		return nil, errors.Wrap(err, "reconciliationRepo.RepairDriftTx.StructScan")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "reconciliationRepo.RepairDriftTx.Commit")
+	}
+
+	return repaired, nil
+}
@@ -0,0 +1,73 @@
+package repository
+
+const (
+	// serializes run starts across instances
+	lockRunsQuery = `SELECT pg_advisory_xact_lock(hashtext('reconciliation_runs'))`
+
+	startRunQuery = `INSERT INTO reconciliation_runs (trigger, requested_by)
+						SELECT $1, $2
+						WHERE NOT EXISTS (
+							SELECT 1 FROM reconciliation_runs
+							WHERE (status = 'running' AND started_at > now() - make_interval(secs => $3))
+							OR started_at > now() - make_interval(secs => $4)
+						)
+						RETURNING *`
+
+	completeRunQuery = `UPDATE reconciliation_runs
+						SET status = 'completed', wallets_checked = $2, drift_count = $3, finished_at = now()
+						WHERE id = $1 RETURNING *`
+
+	failRunQuery = `UPDATE reconciliation_runs SET status = 'failed', error = $2, finished_at = now()
+						WHERE id = $1 AND status = 'running'`
+
+	getRunQuery = `SELECT * FROM reconciliation_runs WHERE id = $1`
+
+	listRunsQuery = `SELECT * FROM reconciliation_runs ORDER BY started_at DESC, id DESC LIMIT $1`
+)
+
+const (
+	nextWalletIDsQuery = `SELECT id FROM wallets WHERE id > $1 ORDER BY id LIMIT $2`
+
+	// posted txs rows sum to the balance, rows without an entry do not move it
+	findDriftsQuery = `SELECT COALESCE(b.wallet_id, l.wallet_id) AS wallet_id,
+							COALESCE(b.currency, l.currency) AS currency,
+							COALESCE(b.amount, 0) AS balance,
+							COALESCE(l.amount, 0) AS ledger,
+							COALESCE(b.amount, 0) - COALESCE(l.amount, 0) AS difference
+						FROM (
+							SELECT wallet_id, currency, amount FROM wallet_balances
+							WHERE wallet_id BETWEEN $1 AND $2
+						) b
+						FULL JOIN (
+							SELECT wallet_id, currency, SUM(amount) AS amount FROM txs
+							WHERE wallet_id BETWEEN $1 AND $2 AND entry_id IS NOT NULL
+							GROUP BY wallet_id, currency
+						) l ON l.wallet_id = b.wallet_id AND l.currency = b.currency
+						WHERE COALESCE(b.amount, 0) <> COALESCE(l.amount, 0)
+						ORDER BY 1, 2`
+
+	createDriftQuery = `INSERT INTO reconciliation_drifts (run_id, wallet_id, currency, balance, ledger, difference)
+						VALUES ($1, $2, $3, $4, $5, $6) RETURNING *`
+
+	// only the drifts of the latest completed run stay open
+	supersedeDriftsQuery = `UPDATE reconciliation_drifts SET status = 'superseded', resolved_at = now()
+						WHERE status = 'open' AND run_id < $1`
+
+	getDriftQuery = `SELECT * FROM reconciliation_drifts WHERE id = $1`
+
+	findRunDriftsQuery = `SELECT * FROM reconciliation_drifts WHERE run_id = $1 ORDER BY wallet_id, currency`
+)
+
+const (
+	getDriftForUpdateQuery = `SELECT * FROM reconciliation_drifts WHERE id = $1 FOR UPDATE`
+
+	// the entry posting the repair holds the balance lock
+	getBalanceQuery = `SELECT amount FROM wallet_balances WHERE wallet_id = $1 AND currency = $2`
+
+	ledgerSumQuery = `SELECT COALESCE(SUM(amount), 0) FROM txs
+						WHERE wallet_id = $1 AND currency = $2 AND entry_id IS NOT NULL`
+
+	repairDriftQuery = `UPDATE reconciliation_drifts
+						SET status = 'repaired', repair_entry_id = $2, repaired_by = $3, repair_reason = $4, resolved_at = now()
+						WHERE id = $1 RETURNING *`
+)
@@ -0,0 +1,55 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/config"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/reconciliation"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/logger"
+)
+
+const defaultInterval = time.Hour
+
+// Scheduler starts a reconciliation run every interval. Instances that find
+// a recent run skip their turn, so one run happens per interval.
+type Scheduler struct {
+	reconciliationUC reconciliation.UseCase
+	interval         time.Duration
+	logger           logger.Logger
+}
+
+// Scheduler constructor, a zero interval falls back to the default
+func NewScheduler(cfg *config.Config, reconciliationUC reconciliation.UseCase, log logger.Logger) *Scheduler {
+	s := &Scheduler{
+		reconciliationUC: reconciliationUC,
+		interval:         time.Duration(cfg.Reconciliation.IntervalMinutes) * time.Minute,
+		logger:           log,
+	}
+
+	if s.interval <= 0 {
+		s.interval = defaultInterval
+	}
+
+	return s
+}
+
+// Run reconciliation on start and then every interval until ctx is done
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.reconciliationUC.Run(ctx, models.ReconciliationTriggerSchedule); err != nil && !errors.Is(err, reconciliation.ErrRunInProgress) {
+			s.logger.Errorf("reconciliation scheduler: %s", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
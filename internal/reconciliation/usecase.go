@@ -0,0 +1,17 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase_mock.go -package mock
+package reconciliation
+
+import (
+	"context"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/dto"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+)
+
+// Reconciliation UseCase interface
+type UseCase interface {
+	Run(ctx context.Context, trigger string) (*models.ReconciliationRun, error)
+	ListRuns(ctx context.Context) ([]*models.ReconciliationRun, error)
+	GetRun(ctx context.Context, runID int64) (*models.ReconciliationRun, error)
+	RepairDrift(ctx context.Context, driftID int64, request *dto.RequestDriftRepair) (*models.BalanceDrift, error)
+}
@@ -0,0 +1,187 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/config"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/dto"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/reconciliation"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/logger"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/utils"
+)
+
+const (
+	defaultInterval  = time.Hour
+	defaultBatchSize = 500
+	listRunsLimit    = 50
+
+	// a run still marked running after this long is taken as crashed
+	staleRunAfter = 6 * time.Hour
+)
+
+// Reconciliation UseCase
+type reconciliationUC struct {
+	repo          reconciliation.Repository
+	driftGauge    prometheus.Gauge
+	interval      time.Duration
+	batchSize     int
+	repairEnabled bool
+	logger        logger.Logger
+}
+
+// Reconciliation UseCase constructor, zero config values fall back to defaults
+func NewReconciliationUseCase(cfg *config.Config, repo reconciliation.Repository, driftGauge prometheus.Gauge, log logger.Logger) reconciliation.UseCase {
+	u := &reconciliationUC{
+		repo:          repo,
+		driftGauge:    driftGauge,
+		interval:      time.Duration(cfg.Reconciliation.IntervalMinutes) * time.Minute,
+		batchSize:     cfg.Reconciliation.BatchSize,
+		repairEnabled: cfg.Reconciliation.RepairEnabled,
+		logger:        log,
+	}
+
+	if u.interval <= 0 {
+		u.interval = defaultInterval
+	}
+	if u.batchSize <= 0 {
+		u.batchSize = defaultBatchSize
+	}
+
+	return u
+}
+
+// Recompute every wallet balance from the ledger and record the ones that
+// disagree. Scheduled runs are skipped when any run started within half an
+// interval, so several instances share one schedule.
+func (u *reconciliationUC) Run(ctx context.Context, trigger string) (*models.ReconciliationRun, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "reconciliationUC.Run")
+	defer span.Finish()
+
+	run := &models.ReconciliationRun{Trigger: trigger}
+	if user, err := utils.GetUserFromCtx(ctx); err == nil {
+		requestedBy := int64(user.User.ID)
+		run.RequestedBy = &requestedBy
+	}
+
+	var notSince time.Duration
+	if trigger == models.ReconciliationTriggerSchedule {
+		notSince = u.interval / 2
+	}
+
+	started, err := u.repo.StartRun(ctx, run, notSince, staleRunAfter)
+	if err != nil {
+		return nil, err
+	}
+
+	checked, drifts, err := u.scan(ctx)
+	if err != nil {
+		// recorded even when the request that started the run has gone away
+		if failErr := u.repo.FailRun(context.WithoutCancel(ctx), started.ID, err.Error()); failErr != nil {
+			u.logger.Errorf("Reconciliation run %d: %s", started.ID, failErr)
+		}
+		return nil, err
+	}
+
+	completed, err := u.repo.CompleteRun(ctx, started.ID, checked, drifts)
+	if err != nil {
+		return nil, err
+	}
+
+	u.driftGauge.Set(float64(completed.DriftCount))
+	for _, d := range completed.Drifts {
+		u.logger.Warnf("Balance drift %d on wallet %d %s: balance %s, ledger %s, difference %s",
+			d.ID, d.WalletID, d.Currency, d.Balance, d.Ledger, d.Difference)
+	}
+	u.logger.Infof("Reconciliation run %d (%s) checked %d wallets, found %d drifts",
+		completed.ID, completed.Trigger, completed.WalletsChecked, completed.DriftCount)
+
+	return completed, nil
+}
+
+// List the latest runs, newest first
+func (u *reconciliationUC) ListRuns(ctx context.Context) ([]*models.ReconciliationRun, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "reconciliationUC.ListRuns")
+	defer span.Finish()
+
+	return u.repo.ListRuns(ctx, listRunsLimit)
+}
+
+// Get a run with its drifts
+func (u *reconciliationUC) GetRun(ctx context.Context, runID int64) (*models.ReconciliationRun, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "reconciliationUC.GetRun")
+	defer span.Finish()
+
+	run, err := u.repo.GetRun(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	drifts, err := u.repo.FindRunDrifts(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	run.Drifts = drifts
+	return run, nil
+}
+
+// Approve a correcting entry for an open drift, only when repairs are enabled
+func (u *reconciliationUC) RepairDrift(ctx context.Context, driftID int64, request *dto.RequestDriftRepair) (*models.BalanceDrift, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "reconciliationUC.RepairDrift")
+	defer span.Finish()
+
+	if !u.repairEnabled {
+		return nil, reconciliation.ErrRepairDisabled
+	}
+
+	user, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	repaired, err := u.repo.RepairDriftTx(ctx, driftID, int64(user.User.ID), strings.TrimSpace(request.Reason))
+	if err != nil {
+		return nil, err
+	}
+
+	u.logger.Infof("Balance drift %d on wallet %d %s repaired by user %d with entry %d",
+		repaired.ID, repaired.WalletID, repaired.Currency, user.User.ID, *repaired.RepairEntryID)
+
+	return repaired, nil
+}
+
+// Walk the wallets in id order batch by batch, returns how many were checked
+func (u *reconciliationUC) scan(ctx context.Context) (int, []*models.BalanceDrift, error) {
+	drifts := make([]*models.BalanceDrift, 0)
+	checked := 0
+
+	var afterID int64
+	for {
+		ids, err := u.repo.NextWalletIDs(ctx, afterID, u.batchSize)
+		if err != nil {
+			return 0, nil, err
+		}
+		if len(ids) == 0 {
+			return checked, drifts, nil
+		}
+
+		found, err := u.repo.FindDrifts(ctx, ids[0], ids[len(ids)-1])
+		if err != nil {
+			return 0, nil, err
+		}
+
+		drifts = append(drifts, found...)
+		checked += len(ids)
+		afterID = ids[len(ids)-1]
+
+		if len(ids) < u.batchSize {
+			return checked, drifts, nil
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/config"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/dto"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/reconciliation"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/reconciliation/mock"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/logger"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/utils"
+)
+
+func newTestUseCase(repo *mock.MockRepository, repairEnabled bool) (*reconciliationUC, prometheus.Gauge) {
+	cfg := &config.Config{
+		Logger:         config.Logger{Level: "error", Encoding: "console"},
+		Reconciliation: config.Reconciliation{IntervalMinutes: 60, BatchSize: 2, RepairEnabled: repairEnabled},
+	}
+	l := logger.NewApiLogger(cfg)
+	l.InitLogger()
+
+	gauge := prometheus.NewGauge(prometheus.GaugeOpts{Name: "test_ledger_drift_count"})
+	return NewReconciliationUseCase(cfg, repo, gauge, l).(*reconciliationUC), gauge
+}
+
+func userCtx(userID int) context.Context {
+	return context.WithValue(context.Background(), utils.UserCtxKey{}, &models.UserWithRole{User: models.User{ID: userID}})
+}
+
+func TestReconciliationUC_Run(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockRepository(ctrl)
+	u, gauge := newTestUseCase(repo, false)
+
+	drift := &models.BalanceDrift{
+		WalletID:   3,
+		Currency:   "USD",
+		Balance:    decimal.NewFromInt(100),
+		Ledger:     decimal.NewFromInt(90),
+		Difference: decimal.NewFromInt(10),
+	}
+
+	requestedBy := int64(7)
+	repo.EXPECT().StartRun(gomock.Any(), &models.ReconciliationRun{Trigger: models.ReconciliationTriggerManual, RequestedBy: &requestedBy}, time.Duration(0), staleRunAfter).
+		Return(&models.ReconciliationRun{ID: 4, Trigger: models.ReconciliationTriggerManual, Status: models.ReconciliationRunning}, nil)
+
+	// wallets are walked in batches until a short one
+	gomock.InOrder(
+		repo.EXPECT().NextWalletIDs(gomock.Any(), int64(0), 2).Return([]int64{1, 3}, nil),
+		repo.EXPECT().FindDrifts(gomock.Any(), int64(1), int64(3)).Return([]*models.BalanceDrift{drift}, nil),
+		repo.EXPECT().NextWalletIDs(gomock.Any(), int64(3), 2).Return([]int64{8}, nil),
+		repo.EXPECT().FindDrifts(gomock.Any(), int64(8), int64(8)).Return([]*models.BalanceDrift{}, nil),
+	)
+
+	repo.EXPECT().CompleteRun(gomock.Any(), int64(4), 3, []*models.BalanceDrift{drift}).
+		Return(&models.ReconciliationRun{ID: 4, Status: models.ReconciliationCompleted, WalletsChecked: 3, DriftCount: 1, Drifts: []*models.BalanceDrift{drift}}, nil)
+
+	run, err := u.Run(userCtx(7), models.ReconciliationTriggerManual)
+	require.NoError(t, err)
+	require.Equal(t, 1, run.DriftCount)
+	require.Equal(t, float64(1), testutil.ToFloat64(gauge))
+}
+
+func TestReconciliationUC_Run_Failed(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockRepository(ctrl)
+	u, _ := newTestUseCase(repo, false)
+
+	// scheduled runs keep half an interval away from the previous run
+	repo.EXPECT().StartRun(gomock.Any(), gomock.Any(), 30*time.Minute, staleRunAfter).
+		Return(&models.ReconciliationRun{ID: 5, Status: models.ReconciliationRunning}, nil)
+
+	down := errors.New("connection refused")
+	repo.EXPECT().NextWalletIDs(gomock.Any(), int64(0), 2).Return(nil, down)
+	repo.EXPECT().FailRun(gomock.Any(), int64(5), down.Error()).Return(nil)
+
+	_, err := u.Run(context.Background(), models.ReconciliationTriggerSchedule)
+	require.ErrorIs(t, err, down)
+}
+
+func TestReconciliationUC_RepairDrift(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	request := &dto.RequestDriftRepair{Reason: " missing deposit posting "}
+
+	disabled, _ := newTestUseCase(mock.NewMockRepository(ctrl), false)
+	_, err := disabled.RepairDrift(userCtx(7), 9, request)
+	require.ErrorIs(t, err, reconciliation.ErrRepairDisabled)
+
+	repo := mock.NewMockRepository(ctrl)
+	enabled, _ := newTestUseCase(repo, true)
+
+	entryID := int64(40)
+	repo.EXPECT().RepairDriftTx(gomock.Any(), int64(9), int64(7), "missing deposit posting").
+		Return(&models.BalanceDrift{ID: 9, Status: models.DriftRepaired, RepairEntryID: &entryID}, nil)
+
+	repaired, err := enabled.RepairDrift(userCtx(7), 9, request)
+	require.NoError(t, err)
+	require.Equal(t, models.DriftRepaired, repaired.Status)
+}
@@ -0,0 +1,10 @@
+package risk
+
+import "github.com/labstack/echo/v4"
+
+// Risk HTTP Handlers interface
+type Handlers interface {
+	CreateRule() echo.HandlerFunc
+	ListRules() echo.HandlerFunc
+	DeleteRule() echo.HandlerFunc
+}
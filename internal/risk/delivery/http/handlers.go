@@ -0,0 +1,103 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/opentracing/opentracing-go"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/dto"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/risk"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/httpErrors"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/logger"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/utils"
+)
+
+type riskHandlers struct {
+	riskUC risk.UseCase
+	logger logger.Logger
+}
+
+func NewRiskHandlers(riskUC risk.UseCase, log logger.Logger) risk.Handlers {
+	return &riskHandlers{riskUC: riskUC, logger: log}
+}
+
+// CreateRule godoc
+// @Summary Create risk rule
+// @Description Create a rule checked before deposits and transfers, it holds them for review or denies them when it fires
+// @Tags Risk
+// @Accept json
+// @Produce json
+// @Param body body dto.RequestRiskRule true "rule"
+// @Success 201 {object} models.RiskRule
+// @Router /risk/rules [post]
+func (h *riskHandlers) CreateRule() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "risk.CreateRule")
+		defer span.Finish()
+
+		ruleRequest := &dto.RequestRiskRule{}
+		if err := utils.ReadRequest(c, ruleRequest); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		rule, err := h.riskUC.CreateRule(ctx, ruleRequest)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusCreated, rule)
+	}
+}
+
+// ListRules godoc
+// @Summary List risk rules
+// @Description List every risk rule
+// @Tags Risk
+// @Produce json
+// @Success 200 {array} models.RiskRule
+// @Router /risk/rules [get]
+func (h *riskHandlers) ListRules() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "risk.ListRules")
+		defer span.Finish()
+
+		rules, err := h.riskUC.ListRules(ctx)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, rules)
+	}
+}
+
+// DeleteRule godoc
+// @Summary Delete risk rule
+// @Description Delete a risk rule, operations already held stay in the review queue
+// @Tags Risk
+// @Param id path int true "rule_id"
+// @Success 204
+// @Router /risk/rules/{id} [delete]
+func (h *riskHandlers) DeleteRule() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "risk.DeleteRule")
+		defer span.Finish()
+
+		ruleID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(httpErrors.NewBadRequestError(err.Error())))
+		}
+
+		if err = h.riskUC.DeleteRule(ctx, ruleID); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
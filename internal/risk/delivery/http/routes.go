@@ -0,0 +1,21 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/config"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/auth"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/middleware"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/risk"
+)
+
+// Map risk routes, rules are managed by wallet administrators
+func MapRiskRoutes(riskGroup *echo.Group, h risk.Handlers, mw *middleware.MiddlewareManager, rbacMw *middleware.RBACMiddleware, authUc auth.UseCase, cfg *config.Config) {
+	riskGroup.Use(mw.AuthJWTMiddleware(authUc, cfg))
+	riskGroup.Use(mw.AuthSessionMiddleware)
+	riskGroup.Use(rbacMw.RequirePermission("manage", "wallets", nil))
+
+	riskGroup.GET("/rules", h.ListRules())
+	riskGroup.POST("/rules", h.CreateRule())
+	riskGroup.DELETE("/rules/:id", h.DeleteRule())
+}
@@ -0,0 +1,228 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+)
+
+// Evaluate every rule that applies to the operation, the decision is the
+// strictest action among the rules that fire and allow when none does. No I/O,
+// the risk usecase loads the rules and facts.
+func Evaluate(rules []*models.RiskRule, facts *models.RiskFacts) *models.RiskAssessment {
+	assessment := &models.RiskAssessment{Decision: models.RiskDecisionAllow, Hits: models.RiskHits{}}
+
+	for _, rule := range rules {
+		if !rule.Applies(facts.Operation, facts.Currency) {
+			continue
+		}
+
+		reason, fired := check(rule, facts)
+		if !fired {
+			continue
+		}
+
+		assessment.Hits = append(assessment.Hits, models.RiskHit{
+			RuleID: rule.ID,
+			Name:   rule.Name,
+			Kind:   rule.Kind,
+			Action: rule.Action,
+			Reason: reason,
+		})
+		if strictness(rule.Action) > strictness(assessment.Decision) {
+			assessment.Decision = rule.Action
+		}
+	}
+
+	return assessment
+}
+
+// Longest window among rules, how far back the history has to reach
+func Lookback(rules []*models.RiskRule) time.Duration {
+	var lookback time.Duration
+	for _, rule := range rules {
+		if w := rule.Params.Window(); w > lookback {
+			lookback = w
+		}
+	}
+	return lookback
+}
+
+// Check a rule has the parameters its kind reads
+func Validate(rule *models.RiskRule) error {
+	if rule.Action != models.RiskDecisionReview && rule.Action != models.RiskDecisionDeny {
+		return errors.New("action must be review or deny")
+	}
+
+	p := &rule.Params
+	switch rule.Kind {
+	case models.RiskRuleAmountThreshold:
+		if err := positive("min_amount", p.MinAmount); err != nil {
+			return err
+		}
+		return requireCurrency(rule)
+
+	case models.RiskRuleVelocity:
+		if p.WindowMinutes <= 0 {
+			return errors.New("window_minutes must be > 0")
+		}
+		if p.MaxCount <= 0 && p.MaxAmount == nil {
+			return errors.New("max_count or max_amount is required")
+		}
+		if p.MaxCount < 0 {
+			return errors.New("max_count must be > 0")
+		}
+		if p.MaxAmount != nil {
+			if err := positive("max_amount", p.MaxAmount); err != nil {
+				return err
+			}
+			return requireCurrency(rule)
+		}
+		return nil
+
+	case models.RiskRuleAccountAge:
+		if p.MinAgeHours <= 0 {
+			return errors.New("min_age_hours must be > 0")
+		}
+		if p.MinAmount != nil {
+			if err := positive("min_amount", p.MinAmount); err != nil {
+				return err
+			}
+			return requireCurrency(rule)
+		}
+		return nil
+
+	case models.RiskRuleStructuring:
+		if err := positive("threshold", p.Threshold); err != nil {
+			return err
+		}
+		if p.Margin == nil || !p.Margin.IsPositive() || p.Margin.GreaterThanOrEqual(decimal.NewFromInt(1)) {
+			return errors.New("margin must be between 0 and 1")
+		}
+		if p.WindowMinutes <= 0 {
+			return errors.New("window_minutes must be > 0")
+		}
+		if p.MinCount < 2 {
+			return errors.New("min_count must be >= 2")
+		}
+		return requireCurrency(rule)
+
+	case models.RiskRuleBlockedCounterparty:
+		if len(p.WalletIDs) == 0 && len(p.UserIDs) == 0 {
+			return errors.New("wallet_ids or user_ids is required")
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("unknown rule kind %q", rule.Kind)
+	}
+}
+
+// Reason the rule fires on facts, false when it does not
+func check(rule *models.RiskRule, facts *models.RiskFacts) (string, bool) {
+	p := &rule.Params
+	switch rule.Kind {
+	case models.RiskRuleAmountThreshold:
+		if facts.Amount.GreaterThanOrEqual(*p.MinAmount) {
+			return fmt.Sprintf("amount %s reaches %s", facts.Amount, p.MinAmount), true
+		}
+
+	case models.RiskRuleVelocity:
+		since := facts.Now.Add(-p.Window())
+		count, total := 1, facts.Amount
+		for _, a := range facts.History {
+			if a.CreatedAt.Before(since) {
+				continue
+			}
+			count++
+			if a.Currency == facts.Currency {
+				total = total.Add(a.Amount)
+			}
+		}
+		if p.MaxCount > 0 && count > p.MaxCount {
+			return fmt.Sprintf("%d operations in %d minutes, more than %d", count, p.WindowMinutes, p.MaxCount), true
+		}
+		if p.MaxAmount != nil && total.GreaterThan(*p.MaxAmount) {
+			return fmt.Sprintf("%s in %d minutes, more than %s", total, p.WindowMinutes, p.MaxAmount), true
+		}
+
+	case models.RiskRuleAccountAge:
+		age := facts.Now.Sub(facts.AccountCreatedAt)
+		if age >= time.Duration(p.MinAgeHours)*time.Hour {
+			return "", false
+		}
+		if p.MinAmount != nil && facts.Amount.LessThan(*p.MinAmount) {
+			return "", false
+		}
+		return fmt.Sprintf("account is %s old, younger than %d hours", age.Truncate(time.Minute), p.MinAgeHours), true
+
+	case models.RiskRuleStructuring:
+		floor := p.Threshold.Mul(decimal.NewFromInt(1).Sub(*p.Margin))
+		belowThreshold := func(amount decimal.Decimal) bool {
+			return amount.GreaterThanOrEqual(floor) && amount.LessThan(*p.Threshold)
+		}
+		if !belowThreshold(facts.Amount) {
+			return "", false
+		}
+
+		since := facts.Now.Add(-p.Window())
+		count := 1
+		for _, a := range facts.History {
+			if !a.CreatedAt.Before(since) && a.Currency == facts.Currency && belowThreshold(a.Amount) {
+				count++
+			}
+		}
+		if count >= p.MinCount {
+			return fmt.Sprintf("%d operations just below %s in %d minutes", count, p.Threshold, p.WindowMinutes), true
+		}
+
+	case models.RiskRuleBlockedCounterparty:
+		if facts.CounterpartyWalletID != 0 && contains(p.WalletIDs, facts.CounterpartyWalletID) {
+			return fmt.Sprintf("counterparty wallet %d is blocked", facts.CounterpartyWalletID), true
+		}
+		if facts.CounterpartyUserID != 0 && contains(p.UserIDs, facts.CounterpartyUserID) {
+			return fmt.Sprintf("counterparty user %d is blocked", facts.CounterpartyUserID), true
+		}
+	}
+
+	return "", false
+}
+
+func strictness(decision string) int {
+	switch decision {
+	case models.RiskDecisionDeny:
+		return 2
+	case models.RiskDecisionReview:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func positive(name string, amount *decimal.Decimal) error {
+	if amount == nil || !amount.IsPositive() {
+		return fmt.Errorf("%s must be > 0", name)
+	}
+	return nil
+}
+
+// Amounts only compare within one currency
+func requireCurrency(rule *models.RiskRule) error {
+	if rule.Currency == nil {
+		return fmt.Errorf("%s rules with amounts need a currency", rule.Kind)
+	}
+	return nil
+}
+
+func contains(ids []int64, id int64) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,143 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+)
+
+var testNow = time.Date(2026, 5, 4, 12, 0, 0, 0, time.UTC)
+
+func amount(v string) *decimal.Decimal {
+	d := decimal.RequireFromString(v)
+	return &d
+}
+
+func usd() *string {
+	c := "USD"
+	return &c
+}
+
+func transferFacts(v string, history ...models.RiskActivity) *models.RiskFacts {
+	return &models.RiskFacts{
+		RiskRequest: models.RiskRequest{
+			Operation:            models.RiskOperationTransfer,
+			UserID:               7,
+			WalletID:             1,
+			CounterpartyWalletID: 2,
+			Currency:             "USD",
+			Amount:               decimal.RequireFromString(v),
+		},
+		CounterpartyUserID: 9,
+		AccountCreatedAt:   testNow.Add(-90 * 24 * time.Hour),
+		History:            history,
+		Now:                testNow,
+	}
+}
+
+func activity(v string, ago time.Duration) models.RiskActivity {
+	return models.RiskActivity{Currency: "USD", Amount: decimal.RequireFromString(v), CreatedAt: testNow.Add(-ago)}
+}
+
+func TestEvaluate_Rules(t *testing.T) {
+	t.Parallel()
+
+	threshold := &models.RiskRule{ID: 1, Kind: models.RiskRuleAmountThreshold, Currency: usd(), Action: models.RiskDecisionReview,
+		Params: models.RiskRuleParams{MinAmount: amount("10000")}}
+	velocity := &models.RiskRule{ID: 2, Kind: models.RiskRuleVelocity, Action: models.RiskDecisionReview,
+		Params: models.RiskRuleParams{MaxCount: 3, WindowMinutes: 60}}
+	newAccount := &models.RiskRule{ID: 3, Kind: models.RiskRuleAccountAge, Currency: usd(), Action: models.RiskDecisionReview,
+		Params: models.RiskRuleParams{MinAgeHours: 72, MinAmount: amount("500")}}
+	structuring := &models.RiskRule{ID: 4, Kind: models.RiskRuleStructuring, Currency: usd(), Action: models.RiskDecisionReview,
+		Params: models.RiskRuleParams{Threshold: amount("10000"), Margin: amount("0.1"), WindowMinutes: 24 * 60, MinCount: 3}}
+	blocked := &models.RiskRule{ID: 5, Kind: models.RiskRuleBlockedCounterparty, Action: models.RiskDecisionDeny,
+		Params: models.RiskRuleParams{UserIDs: []int64{9}}}
+
+	young := transferFacts("600")
+	young.AccountCreatedAt = testNow.Add(-time.Hour)
+	youngSmall := transferFacts("100")
+	youngSmall.AccountCreatedAt = testNow.Add(-time.Hour)
+
+	tests := []struct {
+		name  string
+		rule  *models.RiskRule
+		facts *models.RiskFacts
+		fired bool
+	}{
+		{"threshold reached", threshold, transferFacts("10000"), true},
+		{"threshold below", threshold, transferFacts("9999.99"), false},
+		{"threshold other currency", threshold, &models.RiskFacts{RiskRequest: models.RiskRequest{Currency: "EUR", Amount: decimal.NewFromInt(20000)}}, false},
+		{"velocity over count", velocity, transferFacts("5", activity("5", 10*time.Minute), activity("5", 20*time.Minute), activity("5", 30*time.Minute)), true},
+		{"velocity outside window", velocity, transferFacts("5", activity("5", 10*time.Minute), activity("5", 20*time.Minute), activity("5", 2*time.Hour)), false},
+		{"new account", newAccount, young, true},
+		{"new account small amount", newAccount, youngSmall, false},
+		{"old account", newAccount, transferFacts("600"), false},
+		{"structuring", structuring, transferFacts("9500", activity("9900", time.Hour), activity("9100", 3*time.Hour)), true},
+		{"structuring too few", structuring, transferFacts("9500", activity("9900", time.Hour), activity("5000", 3*time.Hour)), false},
+		{"structuring at threshold", structuring, transferFacts("10000", activity("9900", time.Hour), activity("9100", 3*time.Hour)), false},
+		{"blocked counterparty", blocked, transferFacts("1"), true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assessment := Evaluate([]*models.RiskRule{tt.rule}, tt.facts)
+			if !tt.fired {
+				require.Equal(t, models.RiskDecisionAllow, assessment.Decision)
+				require.Empty(t, assessment.Hits)
+				return
+			}
+
+			require.Equal(t, tt.rule.Action, assessment.Decision)
+			require.Len(t, assessment.Hits, 1)
+			require.Equal(t, tt.rule.ID, assessment.Hits[0].RuleID)
+			require.NotEmpty(t, assessment.Hits[0].Reason)
+		})
+	}
+}
+
+func TestEvaluate_StrictestWins(t *testing.T) {
+	t.Parallel()
+
+	deposit := models.RiskOperationDeposit
+	rules := []*models.RiskRule{
+		{ID: 1, Kind: models.RiskRuleAmountThreshold, Currency: usd(), Action: models.RiskDecisionReview,
+			Params: models.RiskRuleParams{MinAmount: amount("100")}},
+		{ID: 2, Kind: models.RiskRuleBlockedCounterparty, Action: models.RiskDecisionDeny,
+			Params: models.RiskRuleParams{WalletIDs: []int64{2}}},
+		// deposits only, not checked on transfers
+		{ID: 3, Kind: models.RiskRuleAmountThreshold, Operation: &deposit, Currency: usd(), Action: models.RiskDecisionDeny,
+			Params: models.RiskRuleParams{MinAmount: amount("1")}},
+	}
+
+	assessment := Evaluate(rules, transferFacts("500"))
+	require.Equal(t, models.RiskDecisionDeny, assessment.Decision)
+	require.Len(t, assessment.Hits, 2)
+
+	require.Equal(t, 24*time.Hour, Lookback([]*models.RiskRule{
+		{Params: models.RiskRuleParams{WindowMinutes: 60}},
+		{Params: models.RiskRuleParams{WindowMinutes: 24 * 60}},
+	}))
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, Validate(&models.RiskRule{Kind: models.RiskRuleVelocity, Action: models.RiskDecisionReview,
+		Params: models.RiskRuleParams{MaxCount: 5, WindowMinutes: 60}}))
+
+	// amounts are only comparable within a currency
+	require.Error(t, Validate(&models.RiskRule{Kind: models.RiskRuleAmountThreshold, Action: models.RiskDecisionReview,
+		Params: models.RiskRuleParams{MinAmount: amount("100")}}))
+	require.Error(t, Validate(&models.RiskRule{Kind: models.RiskRuleStructuring, Currency: usd(), Action: models.RiskDecisionReview,
+		Params: models.RiskRuleParams{Threshold: amount("10000"), Margin: amount("1.5"), WindowMinutes: 60, MinCount: 3}}))
+	require.Error(t, Validate(&models.RiskRule{Kind: models.RiskRuleBlockedCounterparty, Action: models.RiskDecisionAllow,
+		Params: models.RiskRuleParams{UserIDs: []int64{1}}}))
+	require.Error(t, Validate(&models.RiskRule{Kind: "geo", Action: models.RiskDecisionDeny}))
+}
@@ -0,0 +1,12 @@
+package risk
+
+import (
+	"net/http"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/httpErrors"
+)
+
+// Risk domain errors
+var (
+	ErrRuleNotFound = httpErrors.NewRestError(http.StatusNotFound, "Risk rule not found", nil)
+)
@@ -0,0 +1,141 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pg_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateRule mocks base method.
+func (m *MockRepository) CreateRule(ctx context.Context, rule *models.RiskRule) (*models.RiskRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRule", ctx, rule)
+	ret0, _ := ret[0].(*models.RiskRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRule indicates an expected call of CreateRule.
+func (mr *MockRepositoryMockRecorder) CreateRule(ctx, rule interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRule", reflect.TypeOf((*MockRepository)(nil).CreateRule), ctx, rule)
+}
+
+// DeleteRule mocks base method.
+func (m *MockRepository) DeleteRule(ctx context.Context, ruleID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRule", ctx, ruleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRule indicates an expected call of DeleteRule.
+func (mr *MockRepositoryMockRecorder) DeleteRule(ctx, ruleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRule", reflect.TypeOf((*MockRepository)(nil).DeleteRule), ctx, ruleID)
+}
+
+// FindActivity mocks base method.
+func (m *MockRepository) FindActivity(ctx context.Context, userID int64, operation string, since time.Time) ([]models.RiskActivity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindActivity", ctx, userID, operation, since)
+	ret0, _ := ret[0].([]models.RiskActivity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindActivity indicates an expected call of FindActivity.
+func (mr *MockRepositoryMockRecorder) FindActivity(ctx, userID, operation, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindActivity", reflect.TypeOf((*MockRepository)(nil).FindActivity), ctx, userID, operation, since)
+}
+
+// FindRules mocks base method.
+func (m *MockRepository) FindRules(ctx context.Context, operation, currency string) ([]*models.RiskRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRules", ctx, operation, currency)
+	ret0, _ := ret[0].([]*models.RiskRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRules indicates an expected call of FindRules.
+func (mr *MockRepositoryMockRecorder) FindRules(ctx, operation, currency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRules", reflect.TypeOf((*MockRepository)(nil).FindRules), ctx, operation, currency)
+}
+
+// GetAccountCreatedAt mocks base method.
+func (m *MockRepository) GetAccountCreatedAt(ctx context.Context, userID int64) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountCreatedAt", ctx, userID)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountCreatedAt indicates an expected call of GetAccountCreatedAt.
+func (mr *MockRepositoryMockRecorder) GetAccountCreatedAt(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountCreatedAt", reflect.TypeOf((*MockRepository)(nil).GetAccountCreatedAt), ctx, userID)
+}
+
+// GetWalletOwner mocks base method.
+func (m *MockRepository) GetWalletOwner(ctx context.Context, walletID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWalletOwner", ctx, walletID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWalletOwner indicates an expected call of GetWalletOwner.
+func (mr *MockRepositoryMockRecorder) GetWalletOwner(ctx, walletID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWalletOwner", reflect.TypeOf((*MockRepository)(nil).GetWalletOwner), ctx, walletID)
+}
+
+// ListRules mocks base method.
+func (m *MockRepository) ListRules(ctx context.Context) ([]*models.RiskRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRules", ctx)
+	ret0, _ := ret[0].([]*models.RiskRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRules indicates an expected call of ListRules.
+func (mr *MockRepositoryMockRecorder) ListRules(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRules", reflect.TypeOf((*MockRepository)(nil).ListRules), ctx)
+}
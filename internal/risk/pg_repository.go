@@ -0,0 +1,20 @@
+//go:generate mockgen -source pg_repository.go -destination mock/pg_repository_mock.go -package mock
+package risk
+
+import (
+	"context"
+	"time"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+)
+
+// Risk repository interface
+type Repository interface {
+	CreateRule(ctx context.Context, rule *models.RiskRule) (*models.RiskRule, error)
+	ListRules(ctx context.Context) ([]*models.RiskRule, error)
+	DeleteRule(ctx context.Context, ruleID int64) error
+	FindRules(ctx context.Context, operation, currency string) ([]*models.RiskRule, error)
+	GetAccountCreatedAt(ctx context.Context, userID int64) (time.Time, error)
+	GetWalletOwner(ctx context.Context, walletID int64) (int64, error)
+	FindActivity(ctx context.Context, userID int64, operation string, since time.Time) ([]models.RiskActivity, error)
+}
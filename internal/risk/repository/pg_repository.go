@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/risk"
+)
+
+// Ledger row type counted as activity of each operation
+var activityTypes = map[string]string{
+	models.RiskOperationDeposit:  models.TypeDeposit,
+	models.RiskOperationTransfer: models.TypeTransferOut,
+}
+
+// Risk Repository
+type riskRepo struct {
+	db *sqlx.DB
+}
+
+// Risk Repository constructor
+func NewRiskRepository(db *sqlx.DB) risk.Repository {
+	return &riskRepo{db: db}
+}
+
+// Create risk rule
+func (r *riskRepo) CreateRule(ctx context.Context, rule *models.RiskRule) (*models.RiskRule, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "riskRepo.CreateRule")
+	defer span.Finish()
+
+	created := &models.RiskRule{}
+	if err := r.db.QueryRowxContext(
+		ctx,
+		createRuleQuery,
+		rule.Name,
+		rule.Kind,
+		rule.Operation,
+		rule.Currency,
+		rule.Action,
+		rule.Params,
+	).StructScan(created); err != nil {
+		return nil, errors.Wrap(err, "riskRepo.CreateRule.StructScan")
+	}
+
+	return created, nil
+}
+
+// List all risk rules
+func (r *riskRepo) ListRules(ctx context.Context) ([]*models.RiskRule, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "riskRepo.ListRules")
+	defer span.Finish()
+
+	rules := make([]*models.RiskRule, 0)
+	if err := r.db.SelectContext(ctx, &rules, listRulesQuery); err != nil {
+		return nil, errors.Wrap(err, "riskRepo.ListRules.SelectContext")
+	}
+
+	return rules, nil
+}
+
+// Delete risk rule
+func (r *riskRepo) DeleteRule(ctx context.Context, ruleID int64) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "riskRepo.DeleteRule")
+	defer span.Finish()
+
+	result, err := r.db.ExecContext(ctx, deleteRuleQuery, ruleID)
+	if err != nil {
+		return errors.Wrap(err, "riskRepo.DeleteRule.ExecContext")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "riskRepo.DeleteRule.RowsAffected")
+	}
+	if rowsAffected == 0 {
+		return risk.ErrRuleNotFound
+	}
+
+	return nil
+}
+
+// Find the rules checked on an operation in a currency
+func (r *riskRepo) FindRules(ctx context.Context, operation, currency string) ([]*models.RiskRule, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "riskRepo.FindRules")
+	defer span.Finish()
+
+	rules := make([]*models.RiskRule, 0)
+	if err := r.db.SelectContext(ctx, &rules, findRulesQuery, operation, currency); err != nil {
+		return nil, errors.Wrap(err, "riskRepo.FindRules.SelectContext")
+	}
+
+	return rules, nil
+}
+
+func (r *riskRepo) GetAccountCreatedAt(ctx context.Context, userID int64) (time.Time, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "riskRepo.GetAccountCreatedAt")
+	defer span.Finish()
+
+	var createdAt time.Time
+	if err := r.db.GetContext(ctx, &createdAt, getAccountCreatedAtQuery, userID); err != nil {
+		return time.Time{}, errors.Wrap(err, "riskRepo.GetAccountCreatedAt.GetContext")
+	}
+
+	return createdAt, nil
+}
+
+func (r *riskRepo) GetWalletOwner(ctx context.Context, walletID int64) (int64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "riskRepo.GetWalletOwner")
+	defer span.Finish()
+
+	var userID int64
+	if err := r.db.GetContext(ctx, &userID, getWalletOwnerQuery, walletID); err != nil {
+		return 0, errors.Wrap(err, "riskRepo.GetWalletOwner.GetContext")
+	}
+
+	return userID, nil
+}
+
+// Find the posted operations of a user since a time, oldest first
+func (r *riskRepo) FindActivity(ctx context.Context, userID int64, operation string, since time.Time) ([]models.RiskActivity, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "riskRepo.FindActivity")
+	defer span.Finish()
+
+	activity := make([]models.RiskActivity, 0)
+	if err := r.db.SelectContext(ctx, &activity, findActivityQuery, userID, activityTypes[operation], since); err != nil {
+		return nil, errors.Wrap(err, "riskRepo.FindActivity.SelectContext")
+	}
+
+	return activity, nil
+}
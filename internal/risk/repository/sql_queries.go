@@ -0,0 +1,30 @@
+package repository
+
+const (
+	createRuleQuery = `INSERT INTO risk_rules (name, kind, operation, currency, action, params)
+						VALUES ($1, $2, $3, $4, $5, $6) RETURNING *`
+
+	listRulesQuery = `SELECT * FROM risk_rules ORDER BY id`
+
+	deleteRuleQuery = `DELETE FROM risk_rules WHERE id = $1`
+
+	findRulesQuery = `SELECT * FROM risk_rules
+						WHERE (operation IS NULL OR operation = $1)
+						AND (currency IS NULL OR currency = $2)
+						ORDER BY id`
+)
+
+const (
+	// accounts without a creation time count as old
+	getAccountCreatedAtQuery = `SELECT COALESCE(created_at, to_timestamp(0)) FROM users WHERE id = $1`
+
+	getWalletOwnerQuery = `SELECT user_id FROM wallets WHERE id = $1`
+
+	// posted rows of one type across every wallet of the user, the txs row
+	// amount is signed so outgoing rows are negated back
+	findActivityQuery = `SELECT t.currency, ABS(t.amount) AS amount, t.created_at
+						FROM txs t
+						JOIN wallets w ON w.id = t.wallet_id
+						WHERE w.user_id = $1 AND t.type = $2 AND t.entry_id IS NOT NULL AND t.created_at >= $3
+						ORDER BY t.created_at, t.id`
+)
@@ -0,0 +1,17 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase_mock.go -package mock
+package risk
+
+import (
+	"context"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/dto"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+)
+
+// Risk UseCase interface
+type UseCase interface {
+	CreateRule(ctx context.Context, dto *dto.RequestRiskRule) (*models.RiskRule, error)
+	ListRules(ctx context.Context) ([]*models.RiskRule, error)
+	DeleteRule(ctx context.Context, ruleID int64) error
+	Assess(ctx context.Context, request *models.RiskRequest) (*models.RiskAssessment, error)
+}
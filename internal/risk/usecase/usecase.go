@@ -0,0 +1,116 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/dto"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/risk"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/risk/engine"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/httpErrors"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/logger"
+)
+
+// Risk UseCase
+type riskUC struct {
+	riskRepo risk.Repository
+	logger   logger.Logger
+	now      func() time.Time
+}
+
+// Risk UseCase constructor
+func NewRiskUseCase(riskRepo risk.Repository, log logger.Logger) risk.UseCase {
+	return &riskUC{riskRepo: riskRepo, logger: log, now: time.Now}
+}
+
+// Create risk rule, the params must fit the kind
+func (u *riskUC) CreateRule(ctx context.Context, dto *dto.RequestRiskRule) (*models.RiskRule, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "riskUC.CreateRule")
+	defer span.Finish()
+
+	rule := &models.RiskRule{
+		Name:      dto.Name,
+		Kind:      dto.Kind,
+		Operation: dto.Operation,
+		Currency:  dto.Currency,
+		Action:    dto.Action,
+		Params:    dto.Params,
+	}
+
+	if err := engine.Validate(rule); err != nil {
+		return nil, httpErrors.NewBadRequestError(err.Error())
+	}
+
+	return u.riskRepo.CreateRule(ctx, rule)
+}
+
+// List risk rules
+func (u *riskUC) ListRules(ctx context.Context) ([]*models.RiskRule, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "riskUC.ListRules")
+	defer span.Finish()
+
+	return u.riskRepo.ListRules(ctx)
+}
+
+// Delete risk rule
+func (u *riskUC) DeleteRule(ctx context.Context, ruleID int64) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "riskUC.DeleteRule")
+	defer span.Finish()
+
+	return u.riskRepo.DeleteRule(ctx, ruleID)
+}
+
+// Check a deposit or transfer against the rules, only the facts the rules
+// read are loaded
+func (u *riskUC) Assess(ctx context.Context, request *models.RiskRequest) (*models.RiskAssessment, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "riskUC.Assess")
+	defer span.Finish()
+
+	rules, err := u.riskRepo.FindRules(ctx, request.Operation, request.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	facts := &models.RiskFacts{RiskRequest: *request, Now: u.now()}
+	if len(rules) == 0 {
+		return engine.Evaluate(rules, facts), nil
+	}
+
+	if hasKind(rules, models.RiskRuleAccountAge) {
+		if facts.AccountCreatedAt, err = u.riskRepo.GetAccountCreatedAt(ctx, request.UserID); err != nil {
+			return nil, err
+		}
+	}
+
+	if request.CounterpartyWalletID != 0 && hasKind(rules, models.RiskRuleBlockedCounterparty) {
+		if facts.CounterpartyUserID, err = u.riskRepo.GetWalletOwner(ctx, request.CounterpartyWalletID); err != nil {
+			return nil, err
+		}
+	}
+
+	if lookback := engine.Lookback(rules); lookback > 0 {
+		if facts.History, err = u.riskRepo.FindActivity(ctx, request.UserID, request.Operation, facts.Now.Add(-lookback)); err != nil {
+			return nil, err
+		}
+	}
+
+	assessment := engine.Evaluate(rules, facts)
+	for _, hit := range assessment.Hits {
+		u.logger.Warnf("Risk rule %d (%s) fired on %s of %s %s from wallet %d: %s, %s",
+			hit.RuleID, hit.Name, request.Operation, request.Amount, request.Currency, request.WalletID, hit.Reason, hit.Action)
+	}
+
+	return assessment, nil
+}
+
+func hasKind(rules []*models.RiskRule, kind string) bool {
+	for _, rule := range rules {
+		if rule.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/config"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/dto"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/risk/mock"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/logger"
+)
+
+var testNow = time.Date(2026, 5, 4, 12, 0, 0, 0, time.UTC)
+
+func newTestUseCase(repo *mock.MockRepository) *riskUC {
+	l := logger.NewApiLogger(&config.Config{Logger: config.Logger{Level: "error", Encoding: "console"}})
+	l.InitLogger()
+
+	u := NewRiskUseCase(repo, l).(*riskUC)
+	u.now = func() time.Time { return testNow }
+	return u
+}
+
+func TestRiskUC_Assess(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockRepository(ctrl)
+	u := newTestUseCase(repo)
+
+	request := &models.RiskRequest{
+		Operation:            models.RiskOperationTransfer,
+		UserID:               7,
+		WalletID:             1,
+		CounterpartyWalletID: 2,
+		Currency:             "USD",
+		Amount:               decimal.NewFromInt(50),
+	}
+
+	// no rules, nothing else is loaded
+	repo.EXPECT().FindRules(gomock.Any(), models.RiskOperationTransfer, "USD").Return([]*models.RiskRule{}, nil)
+	assessment, err := u.Assess(context.Background(), request)
+	require.NoError(t, err)
+	require.Equal(t, models.RiskDecisionAllow, assessment.Decision)
+
+	rules := []*models.RiskRule{
+		{ID: 1, Kind: models.RiskRuleVelocity, Action: models.RiskDecisionReview, Params: models.RiskRuleParams{MaxCount: 2, WindowMinutes: 60}},
+		{ID: 2, Kind: models.RiskRuleBlockedCounterparty, Action: models.RiskDecisionDeny, Params: models.RiskRuleParams{UserIDs: []int64{13}}},
+	}
+	repo.EXPECT().FindRules(gomock.Any(), models.RiskOperationTransfer, "USD").Return(rules, nil)
+	repo.EXPECT().GetWalletOwner(gomock.Any(), int64(2)).Return(int64(9), nil)
+	repo.EXPECT().FindActivity(gomock.Any(), int64(7), models.RiskOperationTransfer, testNow.Add(-time.Hour)).Return([]models.RiskActivity{
+		{Currency: "USD", Amount: decimal.NewFromInt(40), CreatedAt: testNow.Add(-20 * time.Minute)},
+		{Currency: "USD", Amount: decimal.NewFromInt(30), CreatedAt: testNow.Add(-10 * time.Minute)},
+	}, nil)
+
+	assessment, err = u.Assess(context.Background(), request)
+	require.NoError(t, err)
+	require.Equal(t, models.RiskDecisionReview, assessment.Decision)
+	require.Len(t, assessment.Hits, 1)
+	require.Equal(t, int64(1), assessment.Hits[0].RuleID)
+}
+
+func TestRiskUC_CreateRule_Invalid(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	u := newTestUseCase(mock.NewMockRepository(ctrl))
+
+	// an amount threshold without a currency is rejected before it is stored
+	minAmount := decimal.NewFromInt(10000)
+	_, err := u.CreateRule(context.Background(), &dto.RequestRiskRule{
+		Name:   "large transfers",
+		Kind:   models.RiskRuleAmountThreshold,
+		Action: models.RiskDecisionReview,
+		Params: models.RiskRuleParams{MinAmount: &minAmount},
+	})
+	require.Error(t, err)
+}
@@ -0,0 +1,14 @@
+package schedules
+
+import "github.com/labstack/echo/v4"
+
+// Schedules HTTP Handlers interface
+type Handlers interface {
+	Create() echo.HandlerFunc
+	List() echo.HandlerFunc
+	Get() echo.HandlerFunc
+	Pause() echo.HandlerFunc
+	Resume() echo.HandlerFunc
+	Cancel() echo.HandlerFunc
+	ListRuns() echo.HandlerFunc
+}
@@ -0,0 +1,180 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/opentracing/opentracing-go"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/dto"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/schedules"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/httpErrors"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/logger"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/utils"
+)
+
+type schedulesHandlers struct {
+	schedulesUC schedules.UseCase
+	logger      logger.Logger
+}
+
+func NewSchedulesHandlers(schedulesUC schedules.UseCase, log logger.Logger) schedules.Handlers {
+	return &schedulesHandlers{schedulesUC: schedulesUC, logger: log}
+}
+
+// Create godoc
+// @Summary Create scheduled transfer
+// @Description Create a one-off or recurring (cron or RRULE) transfer from a wallet of the current user
+// @Tags Schedules
+// @Accept json
+// @Produce json
+// @Param body body dto.RequestScheduledTransfer true "schedule"
+// @Success 201 {object} models.ScheduledTransfer
+// @Router /schedules [post]
+func (h *schedulesHandlers) Create() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "schedules.Create")
+		defer span.Finish()
+
+		scheduleRequest := &dto.RequestScheduledTransfer{}
+		if err := utils.ReadRequest(c, scheduleRequest); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		schedule, err := h.schedulesUC.Create(ctx, scheduleRequest)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusCreated, schedule)
+	}
+}
+
+// List godoc
+// @Summary List scheduled transfers
+// @Description List the scheduled transfers of the current user, newest first
+// @Tags Schedules
+// @Produce json
+// @Success 200 {array} models.ScheduledTransfer
+// @Router /schedules [get]
+func (h *schedulesHandlers) List() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "schedules.List")
+		defer span.Finish()
+
+		list, err := h.schedulesUC.List(ctx)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, list)
+	}
+}
+
+// Get godoc
+// @Summary Get scheduled transfer
+// @Description Get a scheduled transfer of the current user
+// @Tags Schedules
+// @Produce json
+// @Param id path int true "schedule_id"
+// @Success 200 {object} models.ScheduledTransfer
+// @Router /schedules/{id} [get]
+func (h *schedulesHandlers) Get() echo.HandlerFunc {
+	return h.scheduleAction("schedules.Get", h.schedulesUC.Get)
+}
+
+// Pause godoc
+// @Summary Pause scheduled transfer
+// @Description Stop running an active schedule until it is resumed
+// @Tags Schedules
+// @Produce json
+// @Param id path int true "schedule_id"
+// @Success 200 {object} models.ScheduledTransfer
+// @Router /schedules/{id}/pause [post]
+func (h *schedulesHandlers) Pause() echo.HandlerFunc {
+	return h.scheduleAction("schedules.Pause", h.schedulesUC.Pause)
+}
+
+// Resume godoc
+// @Summary Resume scheduled transfer
+// @Description Resume a paused schedule from its next occurrence
+// @Tags Schedules
+// @Produce json
+// @Param id path int true "schedule_id"
+// @Success 200 {object} models.ScheduledTransfer
+// @Router /schedules/{id}/resume [post]
+func (h *schedulesHandlers) Resume() echo.HandlerFunc {
+	return h.scheduleAction("schedules.Resume", h.schedulesUC.Resume)
+}
+
+// Cancel godoc
+// @Summary Cancel scheduled transfer
+// @Description Cancel an active or paused schedule
+// @Tags Schedules
+// @Produce json
+// @Param id path int true "schedule_id"
+// @Success 200 {object} models.ScheduledTransfer
+// @Router /schedules/{id} [delete]
+func (h *schedulesHandlers) Cancel() echo.HandlerFunc {
+	return h.scheduleAction("schedules.Cancel", h.schedulesUC.Cancel)
+}
+
+// ListRuns godoc
+// @Summary Scheduled transfer runs
+// @Description List the latest occurrences of a schedule with their outcome
+// @Tags Schedules
+// @Produce json
+// @Param id path int true "schedule_id"
+// @Success 200 {array} models.ScheduledTransferRun
+// @Router /schedules/{id}/runs [get]
+func (h *schedulesHandlers) ListRuns() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "schedules.ListRuns")
+		defer span.Finish()
+
+		scheduleID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(httpErrors.NewBadRequestError(err.Error())))
+		}
+
+		runs, err := h.schedulesUC.ListRuns(ctx, scheduleID)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, runs)
+	}
+}
+
+// Shared handler for actions on one schedule
+func (h *schedulesHandlers) scheduleAction(
+	operation string,
+	action func(ctx context.Context, scheduleID int64) (*models.ScheduledTransfer, error),
+) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), operation)
+		defer span.Finish()
+
+		scheduleID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(httpErrors.NewBadRequestError(err.Error())))
+		}
+
+		schedule, err := action(ctx, scheduleID)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, schedule)
+	}
+}
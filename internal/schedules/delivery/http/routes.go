@@ -0,0 +1,24 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/config"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/auth"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/middleware"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/schedules"
+)
+
+// Map schedules routes, schedules are scoped to the authenticated user
+func MapSchedulesRoutes(schedulesGroup *echo.Group, h schedules.Handlers, mw *middleware.MiddlewareManager, authUc auth.UseCase, cfg *config.Config) {
+	schedulesGroup.Use(mw.AuthJWTMiddleware(authUc, cfg))
+	schedulesGroup.Use(mw.AuthSessionMiddleware)
+
+	schedulesGroup.GET("", h.List())
+	schedulesGroup.POST("", h.Create())
+	schedulesGroup.GET("/:id", h.Get())
+	schedulesGroup.DELETE("/:id", h.Cancel())
+	schedulesGroup.POST("/:id/pause", h.Pause())
+	schedulesGroup.POST("/:id/resume", h.Resume())
+	schedulesGroup.GET("/:id/runs", h.ListRuns())
+}
@@ -0,0 +1,15 @@
+package schedules
+
+import (
+	"net/http"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/httpErrors"
+)
+
+// Schedules domain errors
+var (
+	ErrScheduleNotFound     = httpErrors.NewRestError(http.StatusNotFound, "Scheduled transfer not found", nil)
+	ErrScheduleAccessDenied = httpErrors.NewRestError(http.StatusForbidden, "Scheduled transfer belongs to another user", nil)
+	ErrScheduleTransition   = httpErrors.NewRestError(http.StatusConflict, "Scheduled transfer status change not allowed", nil)
+	ErrScheduleFinished     = httpErrors.NewRestError(http.StatusUnprocessableEntity, "Scheduled transfer has no further occurrences", nil)
+)
@@ -0,0 +1,24 @@
+//go:generate mockgen -source pg_repository.go -destination mock/pg_repository_mock.go -package mock
+package schedules
+
+import (
+	"context"
+	"time"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+)
+
+// Schedules repository interface
+type Repository interface {
+	Create(ctx context.Context, schedule *models.ScheduledTransfer) (*models.ScheduledTransfer, error)
+	GetByID(ctx context.Context, scheduleID int64) (*models.ScheduledTransfer, error)
+	List(ctx context.Context, userID int64) ([]*models.ScheduledTransfer, error)
+	UpdateStatus(ctx context.Context, scheduleID int64, status string, nextRunAt *time.Time) (*models.ScheduledTransfer, error)
+	ListRuns(ctx context.Context, scheduleID int64, limit int) ([]*models.ScheduledTransferRun, error)
+
+	// Runner
+	ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*models.ScheduledTransfer, error)
+	CompleteOccurrence(ctx context.Context, scheduleID int64, runs []*models.ScheduledTransferRun, occurrences int, nextRunAt *time.Time) error
+	RetryOccurrence(ctx context.Context, scheduleID int64, reason string, retryAt time.Time) error
+	SettleHeldRuns(ctx context.Context, reason string, limit int) (int64, error)
+}
@@ -0,0 +1,52 @@
+package schedules
+
+import (
+	"time"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/utils"
+)
+
+// Recurrence of a schedule evaluated in its timezone, nil for a one-off
+func NewRecurrence(s *models.ScheduledTransfer) (utils.Recurrence, error) {
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	switch s.Recurrence {
+	case models.RecurrenceCron:
+		return utils.ParseCron(s.Rule, loc)
+	case models.RecurrenceRRule:
+		return utils.ParseRRule(s.Rule, s.StartAt.In(loc))
+	default:
+		return nil, nil
+	}
+}
+
+// First occurrence after t that may still run as occurrence number n, zero
+// when the schedule is finished
+func NextRun(s *models.ScheduledTransfer, rec utils.Recurrence, t time.Time, n int) time.Time {
+	if rec == nil {
+		return time.Time{}
+	}
+
+	next := rec.Next(t)
+	if next.IsZero() || !s.Within(next, n) {
+		return time.Time{}
+	}
+
+	return next
+}
+
+// First occurrence of a new schedule, at or after its start
+func FirstRun(s *models.ScheduledTransfer, rec utils.Recurrence) time.Time {
+	if rec == nil {
+		if !s.Within(s.StartAt, 1) {
+			return time.Time{}
+		}
+		return s.StartAt
+	}
+
+	return NextRun(s, rec, s.StartAt.Add(-time.Nanosecond), 1)
+}
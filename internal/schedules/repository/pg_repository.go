@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/schedules"
+)
+
+// Schedules Repository
+type schedulesRepo struct {
+	db *sqlx.DB
+}
+
+// Schedules Repository constructor
+func NewSchedulesRepository(db *sqlx.DB) schedules.Repository {
+	return &schedulesRepo{db: db}
+}
+
+// Create scheduled transfer
+func (r *schedulesRepo) Create(ctx context.Context, s *models.ScheduledTransfer) (*models.ScheduledTransfer, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "schedulesRepo.Create")
+	defer span.Finish()
+
+	created := &models.ScheduledTransfer{}
+	if err := r.db.QueryRowxContext(ctx, createScheduleQuery,
+		s.UserID, s.FromWalletID, s.ToWalletID, s.FromCurrency, s.ToCurrency,
+		s.Amount, s.Description, s.Recurrence, s.Rule, s.Timezone, s.StartAt, s.EndAt, s.MaxOccurrences, s.NextRunAt,
+	).StructScan(created); err != nil {
+		return nil, errors.Wrap(err, "schedulesRepo.Create.StructScan")
+	}
+
+	return created, nil
+}
+
+// Get scheduled transfer by id
+func (r *schedulesRepo) GetByID(ctx context.Context, scheduleID int64) (*models.ScheduledTransfer, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "schedulesRepo.GetByID")
+	defer span.Finish()
+
+	s := &models.ScheduledTransfer{}
+	if err := r.db.GetContext(ctx, s, getScheduleQuery, scheduleID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, schedules.ErrScheduleNotFound
+		}
+		return nil, errors.Wrap(err, "schedulesRepo.GetByID.GetContext")
+	}
+
+	return s, nil
+}
+
+// List scheduled transfers of a user, newest first
+func (r *schedulesRepo) List(ctx context.Context, userID int64) ([]*models.ScheduledTransfer, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "schedulesRepo.List")
+	defer span.Finish()
+
+	list := make([]*models.ScheduledTransfer, 0)
+	if err := r.db.SelectContext(ctx, &list, listSchedulesQuery, userID); err != nil {
+		return nil, errors.Wrap(err, "schedulesRepo.List.SelectContext")
+	}
+
+	return list, nil
+}
+
+// Set schedule status and next run, clearing pending retries
+func (r *schedulesRepo) UpdateStatus(ctx context.Context, scheduleID int64, status string, nextRunAt *time.Time) (*models.ScheduledTransfer, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "schedulesRepo.UpdateStatus")
+	defer span.Finish()
+
+	updated := &models.ScheduledTransfer{}
+	if err := r.db.QueryRowxContext(ctx, updateScheduleStatusQuery, scheduleID, status, nextRunAt).StructScan(updated); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, schedules.ErrScheduleNotFound
+		}
+		return nil, errors.Wrap(err, "schedulesRepo.UpdateStatus.StructScan")
+	}
+
+	return updated, nil
+}
+
+// List occurrences of a schedule, latest first
+func (r *schedulesRepo) ListRuns(ctx context.Context, scheduleID int64, limit int) ([]*models.ScheduledTransferRun, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "schedulesRepo.ListRuns")
+	defer span.Finish()
+
+	runs := make([]*models.ScheduledTransferRun, 0)
+	if err := r.db.SelectContext(ctx, &runs, listRunsQuery, scheduleID, limit); err != nil {
+		return nil, errors.Wrap(err, "schedulesRepo.ListRuns.SelectContext")
+	}
+
+	return runs, nil
+}
+
+// Claim up to limit due schedules for lease using FOR UPDATE SKIP LOCKED
+func (r *schedulesRepo) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*models.ScheduledTransfer, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "schedulesRepo.ClaimDue")
+	defer span.Finish()
+
+	due := make([]*models.ScheduledTransfer, 0, limit)
+	if err := r.db.SelectContext(ctx, &due, claimDueQuery, limit, lease.Seconds()); err != nil {
+		return nil, errors.Wrap(err, "schedulesRepo.ClaimDue.SelectContext")
+	}
+
+	return due, nil
+}
+
+// Record the runs of the current occurrence and move the schedule to nextRunAt,
+// a nil nextRunAt completes it
+func (r *schedulesRepo) CompleteOccurrence(ctx context.Context, scheduleID int64, runs []*models.ScheduledTransferRun, occurrences int, nextRunAt *time.Time) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "schedulesRepo.CompleteOccurrence")
+	defer span.Finish()
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "schedulesRepo.CompleteOccurrence.BeginTxx")
+	}
+	defer tx.Rollback()
+
+	for _, run := range runs {
+		if _, err := tx.ExecContext(ctx, createRunQuery,
+			scheduleID, run.Occurrence, run.ScheduledFor, run.RefID, run.Status, run.Error,
+		); err != nil {
+			return errors.Wrap(err, "schedulesRepo.CompleteOccurrence.ExecContext.run")
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, advanceScheduleQuery, scheduleID, occurrences, nextRunAt); err != nil {
+		return errors.Wrap(err, "schedulesRepo.CompleteOccurrence.ExecContext.schedule")
+	}
+
+	return errors.Wrap(tx.Commit(), "schedulesRepo.CompleteOccurrence.Commit")
+}
+
+// Keep the current occurrence due and retry it after retryAt
+func (r *schedulesRepo) RetryOccurrence(ctx context.Context, scheduleID int64, reason string, retryAt time.Time) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "schedulesRepo.RetryOccurrence")
+	defer span.Finish()
+
+	if _, err := r.db.ExecContext(ctx, retryScheduleQuery, scheduleID, reason, retryAt); err != nil {
+		return errors.Wrap(err, "schedulesRepo.RetryOccurrence.ExecContext")
+	}
+
+	return nil
+}
+
+// Settle up to limit held runs whose transfer was decided in risk review, a
+// rejected one fails with reason. Returns how many were settled.
+func (r *schedulesRepo) SettleHeldRuns(ctx context.Context, reason string, limit int) (int64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "schedulesRepo.SettleHeldRuns")
+	defer span.Finish()
+
+	result, err := r.db.ExecContext(ctx, settleHeldRunsQuery, reason, limit)
+	if err != nil {
+		return 0, errors.Wrap(err, "schedulesRepo.SettleHeldRuns.ExecContext")
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "schedulesRepo.SettleHeldRuns.RowsAffected")
+	}
+
+	return n, nil
+}
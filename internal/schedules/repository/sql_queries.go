@@ -0,0 +1,62 @@
+package repository
+
+const (
+	createScheduleQuery = `INSERT INTO scheduled_transfers (user_id, from_wallet_id, to_wallet_id, from_currency, to_currency,
+							amount, description, recurrence, rule, timezone, start_at, end_at, max_occurrences, next_run_at)
+						VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14) RETURNING *`
+
+	getScheduleQuery = `SELECT * FROM scheduled_transfers WHERE id = $1`
+
+	listSchedulesQuery = `SELECT * FROM scheduled_transfers WHERE user_id = $1 ORDER BY created_at DESC, id DESC`
+
+	updateScheduleStatusQuery = `UPDATE scheduled_transfers
+						SET status = $2, next_run_at = $3, attempts = 0, last_error = NULL, updated_at = now()
+						WHERE id = $1 RETURNING *`
+
+	listRunsQuery = `SELECT * FROM scheduled_transfer_runs
+						WHERE schedule_id = $1
+						ORDER BY scheduled_for DESC, id DESC LIMIT $2`
+)
+
+const (
+	// lease due schedules so concurrent runners skip them until the lease expires
+	claimDueQuery = `UPDATE scheduled_transfers SET locked_until = now() + make_interval(secs => $2)
+						WHERE id IN (
+							SELECT id FROM scheduled_transfers
+							WHERE status = 'active' AND next_run_at <= now()
+							AND (locked_until IS NULL OR locked_until <= now())
+							ORDER BY next_run_at, id LIMIT $1
+							FOR UPDATE SKIP LOCKED
+						)
+						RETURNING *`
+
+	// a run already recorded for the occurrence is kept
+	createRunQuery = `INSERT INTO scheduled_transfer_runs (schedule_id, occurrence, scheduled_for, ref_id, status, error)
+						VALUES ($1, $2, $3, $4, $5, $6)
+						ON CONFLICT (schedule_id, scheduled_for) DO NOTHING`
+
+	// a paused or cancelled schedule keeps its status, an active one without a next run is completed
+	advanceScheduleQuery = `UPDATE scheduled_transfers
+						SET occurrences = $2, next_run_at = $3, last_run_at = now(),
+						status = CASE WHEN $3::timestamptz IS NULL AND status = 'active' THEN 'completed' ELSE status END,
+						attempts = 0, last_error = NULL, locked_until = NULL, updated_at = now()
+						WHERE id = $1`
+
+	retryScheduleQuery = `UPDATE scheduled_transfers
+						SET attempts = attempts + 1, last_error = $2, locked_until = $3, updated_at = now()
+						WHERE id = $1`
+
+	// a held run takes the decision of the risk review of its transfer
+	settleHeldRunsQuery = `UPDATE scheduled_transfer_runs r
+						SET status = CASE WHEN v.status = 'approved' THEN 'succeeded' ELSE 'failed' END,
+						error = CASE WHEN v.status = 'approved' THEN NULL ELSE $1 END
+						FROM risk_reviews v
+						WHERE v.operation = 'transfer' AND v.ref_id = r.ref_id AND v.status <> 'pending'
+						AND r.status = 'held' AND r.id IN (
+							SELECT h.id FROM scheduled_transfer_runs h
+							JOIN risk_reviews d ON d.operation = 'transfer' AND d.ref_id = h.ref_id
+							WHERE h.status = 'held' AND d.status <> 'pending'
+							ORDER BY h.id LIMIT $2
+							FOR UPDATE OF h SKIP LOCKED
+						)`
+)
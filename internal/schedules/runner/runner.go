@@ -0,0 +1,238 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/config"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/dto"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/schedules"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/wallet"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/httpErrors"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/logger"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/utils"
+)
+
+const (
+	defaultBatchSize    = 50
+	defaultMaxAttempts  = 5
+	defaultPollInterval = 10 * time.Second
+	defaultRetryBackoff = 30 * time.Second
+	defaultLease        = time.Minute
+	maxRetryBackoff     = time.Hour
+)
+
+// Runner executes due scheduled transfers through the wallet use case as the
+// schedule owner. Every occurrence uses a deterministic ref_id, so an occurrence
+// retried after a crash or a transient error is a replay and cannot move funds twice.
+// An occurrence held for risk review is recorded as held and settles with the review.
+type Runner struct {
+	schedulesRepo schedules.Repository
+	walletUC      wallet.UseCase
+	batchSize     int
+	maxAttempts   int
+	pollInterval  time.Duration
+	retryBackoff  time.Duration
+	lease         time.Duration
+	logger        logger.Logger
+	now           func() time.Time
+}
+
+// Runner constructor, zero config values fall back to defaults
+func NewRunner(cfg *config.Config, schedulesRepo schedules.Repository, walletUC wallet.UseCase, log logger.Logger) *Runner {
+	r := &Runner{
+		schedulesRepo: schedulesRepo,
+		walletUC:      walletUC,
+		batchSize:     cfg.Schedules.BatchSize,
+		maxAttempts:   cfg.Schedules.MaxAttempts,
+		pollInterval:  time.Duration(cfg.Schedules.PollIntervalSeconds) * time.Second,
+		retryBackoff:  time.Duration(cfg.Schedules.RetryBackoffSeconds) * time.Second,
+		lease:         time.Duration(cfg.Schedules.LeaseSeconds) * time.Second,
+		logger:        log,
+		now:           time.Now,
+	}
+
+	if r.batchSize <= 0 {
+		r.batchSize = defaultBatchSize
+	}
+	if r.maxAttempts <= 0 {
+		r.maxAttempts = defaultMaxAttempts
+	}
+	if r.pollInterval <= 0 {
+		r.pollInterval = defaultPollInterval
+	}
+	if r.retryBackoff <= 0 {
+		r.retryBackoff = defaultRetryBackoff
+	}
+	if r.lease <= 0 {
+		r.lease = defaultLease
+	}
+
+	return r
+}
+
+// Poll for due schedules until ctx is done, a full batch is followed immediately by the next one
+func (r *Runner) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := r.SettleHeld(ctx); err != nil {
+			r.logger.Errorf("schedule runner: %s", err)
+		}
+
+		n, err := r.ProcessBatch(ctx)
+		if err != nil {
+			r.logger.Errorf("schedule runner: %s", err)
+		}
+
+		if err == nil && n == r.batchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Run one batch of due schedules, returns how many were claimed
+func (r *Runner) ProcessBatch(ctx context.Context) (int, error) {
+	due, err := r.schedulesRepo.ClaimDue(ctx, r.batchSize, r.lease)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, s := range due {
+		if err := r.execute(ctx, s); err != nil {
+			return len(due), err
+		}
+	}
+
+	return len(due), nil
+}
+
+// Settle one batch of held runs whose transfer was decided in risk review,
+// returns how many were settled
+func (r *Runner) SettleHeld(ctx context.Context) (int64, error) {
+	n, err := r.schedulesRepo.SettleHeldRuns(ctx, wallet.ErrTransactionDenied.Error(), r.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	if n > 0 {
+		r.logger.Infof("schedule runner: settled %d held runs", n)
+	}
+
+	return n, nil
+}
+
+// Run the due occurrence of s and move it to the next one. Older occurrences
+// that were missed while the runner was down are recorded as skipped, only the
+// latest due one runs. Only repository errors are returned.
+func (r *Runner) execute(ctx context.Context, s *models.ScheduledTransfer) error {
+	rec, err := schedules.NewRecurrence(s)
+	if err != nil {
+		// the rule was validated on create, a schedule that no longer parses is finished
+		r.logger.Errorf("schedule runner: schedule %d: %s", s.ID, err)
+		return r.schedulesRepo.CompleteOccurrence(ctx, s.ID, nil, s.Occurrences, nil)
+	}
+
+	now := r.now()
+	due := *s.NextRunAt
+	occurrence := s.Occurrences + 1
+	runs := make([]*models.ScheduledTransferRun, 0, 1)
+
+	for {
+		next := schedules.NextRun(s, rec, due, occurrence+1)
+		if next.IsZero() || next.After(now) {
+			break
+		}
+		runs = append(runs, newRun(s, occurrence, due, models.ScheduleRunSkipped, "missed, a later occurrence was due"))
+		occurrence++
+		due = next
+	}
+
+	transferErr := r.transfer(ctx, s, due)
+	switch {
+	case transferErr == nil:
+		runs = append(runs, newRun(s, occurrence, due, models.ScheduleRunSucceeded, ""))
+	case errors.Is(transferErr, wallet.ErrTransactionHeld):
+		// the schedule moves on, the run settles when the review is decided
+		r.logger.Infof("schedule runner: schedule %d occurrence %d held for review", s.ID, occurrence)
+		runs = append(runs, newRun(s, occurrence, due, models.ScheduleRunHeld, transferErr.Error()))
+	case rejected(transferErr) || s.Attempts+1 >= r.maxAttempts:
+		r.logger.Warnf("schedule runner: schedule %d occurrence %d failed: %s", s.ID, occurrence, transferErr)
+		runs = append(runs, newRun(s, occurrence, due, models.ScheduleRunFailed, transferErr.Error()))
+	default:
+		attempts := s.Attempts + 1
+		r.logger.Warnf("schedule runner: schedule %d occurrence %d, attempt %d: %s", s.ID, occurrence, attempts, transferErr)
+		return r.schedulesRepo.RetryOccurrence(ctx, s.ID, transferErr.Error(), now.Add(r.backoff(attempts)))
+	}
+
+	var nextRunAt *time.Time
+	if next := schedules.NextRun(s, rec, due, occurrence+1); !next.IsZero() {
+		nextRunAt = &next
+	}
+
+	return r.schedulesRepo.CompleteOccurrence(ctx, s.ID, runs, occurrence, nextRunAt)
+}
+
+// Transfer the occurrence due at t as the schedule owner
+func (r *Runner) transfer(ctx context.Context, s *models.ScheduledTransfer, t time.Time) error {
+	ownerCtx := context.WithValue(ctx, utils.UserCtxKey{}, &models.UserWithRole{User: models.User{ID: int(s.UserID)}})
+
+	_, err := r.walletUC.Transfer(ownerCtx, &dto.RequestTransfer{
+		FromWalletID: uint(s.FromWalletID),
+		ToWalletID:   uint(s.ToWalletID),
+		FromCurrency: s.FromCurrency,
+		ToCurrency:   s.ToCurrency,
+		Amount:       s.Amount,
+		Reference:    s.OccurrenceRef(t),
+	})
+
+	return err
+}
+
+// Delay before the next attempt, doubling per attempt
+func (r *Runner) backoff(attempts int) time.Duration {
+	delay := r.retryBackoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= maxRetryBackoff {
+			return maxRetryBackoff
+		}
+	}
+
+	return delay
+}
+
+// Business rejections such as insufficient funds or a frozen wallet fail the
+// occurrence, anything else is retried
+func rejected(err error) bool {
+	var restErr httpErrors.RestErr
+	if errors.As(err, &restErr) {
+		return restErr.Status() < http.StatusInternalServerError
+	}
+	return false
+}
+
+func newRun(s *models.ScheduledTransfer, occurrence int, t time.Time, status, reason string) *models.ScheduledTransferRun {
+	run := &models.ScheduledTransferRun{
+		ScheduleID:   s.ID,
+		Occurrence:   occurrence,
+		ScheduledFor: t,
+		RefID:        s.OccurrenceRef(t),
+		Status:       status,
+	}
+	if reason != "" {
+		run.Error = &reason
+	}
+
+	return run
+}
@@ -0,0 +1,165 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/config"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/dto"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/schedules/mock"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/wallet"
+	walletMock "github.com/aditwar-man/go-microservice-boilerplate/internal/wallet/mock"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/logger"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/utils"
+)
+
+var testNow = time.Date(2026, 3, 10, 9, 30, 0, 0, time.UTC)
+
+func newTestRunner(repo *mock.MockRepository, walletUC *walletMock.MockUseCase) *Runner {
+	cfg := &config.Config{
+		Logger:    config.Logger{Level: "error", Encoding: "console"},
+		Schedules: config.Schedules{BatchSize: 10, MaxAttempts: 3, RetryBackoffSeconds: 30},
+	}
+	l := logger.NewApiLogger(cfg)
+	l.InitLogger()
+
+	r := NewRunner(cfg, repo, walletUC, l)
+	r.now = func() time.Time { return testNow }
+	return r
+}
+
+// Daily at 09:00 UTC, last run three days ago
+func dailySchedule() *models.ScheduledTransfer {
+	next := time.Date(2026, 3, 8, 9, 0, 0, 0, time.UTC)
+	return &models.ScheduledTransfer{
+		ID:           7,
+		UserID:       3,
+		FromWalletID: 1,
+		ToWalletID:   2,
+		FromCurrency: "USD",
+		ToCurrency:   "USD",
+		Amount:       decimal.NewFromInt(10),
+		Recurrence:   models.RecurrenceRRule,
+		Rule:         "FREQ=DAILY",
+		Timezone:     "UTC",
+		StartAt:      time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC),
+		Status:       models.ScheduleStatusActive,
+		NextRunAt:    &next,
+		Occurrences:  6,
+	}
+}
+
+func TestRunner_ProcessBatch(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockRepository(ctrl)
+	walletUC := walletMock.NewMockUseCase(ctrl)
+	r := newTestRunner(repo, walletUC)
+
+	s := dailySchedule()
+	due := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+
+	repo.EXPECT().ClaimDue(gomock.Any(), 10, time.Minute).Return([]*models.ScheduledTransfer{s}, nil)
+	walletUC.EXPECT().Transfer(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, request *dto.RequestTransfer) (*models.Transfer, error) {
+			user, err := utils.GetUserFromCtx(ctx)
+			require.NoError(t, err)
+			require.Equal(t, 3, user.User.ID)
+			require.Equal(t, s.OccurrenceRef(due), request.Reference)
+			return &models.Transfer{}, nil
+		})
+	repo.EXPECT().CompleteOccurrence(gomock.Any(), int64(7), gomock.Any(), 9, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ int64, runs []*models.ScheduledTransferRun, _ int, nextRunAt *time.Time) error {
+			// the two occurrences missed while the runner was down are skipped
+			require.Len(t, runs, 3)
+			require.Equal(t, models.ScheduleRunSkipped, runs[0].Status)
+			require.Equal(t, models.ScheduleRunSkipped, runs[1].Status)
+			require.Equal(t, models.ScheduleRunSucceeded, runs[2].Status)
+			require.Equal(t, due, runs[2].ScheduledFor)
+			require.Equal(t, due.AddDate(0, 0, 1), *nextRunAt)
+			return nil
+		})
+
+	n, err := r.ProcessBatch(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+}
+
+func TestRunner_TransferErrors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("rejected", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mock.NewMockRepository(ctrl)
+		walletUC := walletMock.NewMockUseCase(ctrl)
+		r := newTestRunner(repo, walletUC)
+
+		s := dailySchedule()
+		s.NextRunAt = &testNow
+
+		walletUC.EXPECT().Transfer(gomock.Any(), gomock.Any()).Return(nil, wallet.ErrInsufficientFunds)
+		repo.EXPECT().CompleteOccurrence(gomock.Any(), int64(7), gomock.Any(), 7, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ int64, runs []*models.ScheduledTransferRun, _ int, _ *time.Time) error {
+				require.Len(t, runs, 1)
+				require.Equal(t, models.ScheduleRunFailed, runs[0].Status)
+				return nil
+			})
+
+		require.NoError(t, r.execute(context.Background(), s))
+	})
+
+	t.Run("held", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mock.NewMockRepository(ctrl)
+		walletUC := walletMock.NewMockUseCase(ctrl)
+		r := newTestRunner(repo, walletUC)
+
+		s := dailySchedule()
+		s.NextRunAt = &testNow
+
+		// a hold is not a rejection, the schedule moves on and the run waits for the review
+		walletUC.EXPECT().Transfer(gomock.Any(), gomock.Any()).Return(nil, wallet.ErrTransactionHeld)
+		repo.EXPECT().CompleteOccurrence(gomock.Any(), int64(7), gomock.Any(), 7, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ int64, runs []*models.ScheduledTransferRun, _ int, nextRunAt *time.Time) error {
+				require.Len(t, runs, 1)
+				require.Equal(t, models.ScheduleRunHeld, runs[0].Status)
+				require.Equal(t, s.OccurrenceRef(testNow), runs[0].RefID)
+				require.NotNil(t, nextRunAt)
+				return nil
+			})
+
+		require.NoError(t, r.execute(context.Background(), s))
+	})
+
+	t.Run("retried", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mock.NewMockRepository(ctrl)
+		walletUC := walletMock.NewMockUseCase(ctrl)
+		r := newTestRunner(repo, walletUC)
+
+		s := dailySchedule()
+		s.NextRunAt = &testNow
+		s.Attempts = 1
+
+		walletUC.EXPECT().Transfer(gomock.Any(), gomock.Any()).Return(nil, errors.New("connection reset"))
+		repo.EXPECT().RetryOccurrence(gomock.Any(), int64(7), "connection reset", testNow.Add(time.Minute)).Return(nil)
+
+		require.NoError(t, r.execute(context.Background(), s))
+	})
+}
@@ -0,0 +1,20 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase_mock.go -package mock
+package schedules
+
+import (
+	"context"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/dto"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+)
+
+// Schedules UseCase interface, schedules are scoped to the ctx user
+type UseCase interface {
+	Create(ctx context.Context, request *dto.RequestScheduledTransfer) (*models.ScheduledTransfer, error)
+	List(ctx context.Context) ([]*models.ScheduledTransfer, error)
+	Get(ctx context.Context, scheduleID int64) (*models.ScheduledTransfer, error)
+	Pause(ctx context.Context, scheduleID int64) (*models.ScheduledTransfer, error)
+	Resume(ctx context.Context, scheduleID int64) (*models.ScheduledTransfer, error)
+	Cancel(ctx context.Context, scheduleID int64) (*models.ScheduledTransfer, error)
+	ListRuns(ctx context.Context, scheduleID int64) ([]*models.ScheduledTransferRun, error)
+}
@@ -0,0 +1,233 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/shopspring/decimal"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/currency"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/dto"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/schedules"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/wallet"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/httpErrors"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/logger"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/utils"
+)
+
+const runLogSize = 100
+
+// Schedules UseCase
+type schedulesUC struct {
+	schedulesRepo schedules.Repository
+	walletRepo    wallet.Repository
+	currencyUC    currency.UseCase
+	logger        logger.Logger
+}
+
+// Schedules UseCase constructor
+func NewSchedulesUseCase(schedulesRepo schedules.Repository, walletRepo wallet.Repository, currencyUC currency.UseCase, log logger.Logger) schedules.UseCase {
+	return &schedulesUC{schedulesRepo: schedulesRepo, walletRepo: walletRepo, currencyUC: currencyUC, logger: log}
+}
+
+// Create a standing order from a wallet of the ctx user
+func (u *schedulesUC) Create(ctx context.Context, request *dto.RequestScheduledTransfer) (*models.ScheduledTransfer, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "schedulesUC.Create")
+	defer span.Finish()
+
+	user, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !request.Amount.IsPositive() {
+		return nil, httpErrors.NewBadRequestError("amount must be > 0")
+	}
+
+	if request.FromWalletID == request.ToWalletID && request.FromCurrency == request.ToCurrency {
+		return nil, httpErrors.NewBadRequestError("invalid transfer target")
+	}
+
+	if request.EndAt != nil && !request.EndAt.After(request.StartAt) {
+		return nil, httpErrors.NewBadRequestError("end_at must be after start_at")
+	}
+
+	if _, err := u.currencyUC.Validate(ctx, request.FromCurrency, request.Amount); err != nil {
+		return nil, err
+	}
+
+	if _, err := u.currencyUC.Validate(ctx, request.ToCurrency, decimal.Zero); err != nil {
+		return nil, err
+	}
+
+	// standing orders run as their owner, so only the owner may create them
+	from, err := u.walletRepo.GetByID(ctx, request.FromWalletID)
+	if err != nil {
+		return nil, err
+	}
+
+	if int(from.UserID) != user.User.ID {
+		return nil, wallet.ErrWalletAccessDenied
+	}
+
+	if _, err := u.walletRepo.GetByID(ctx, request.ToWalletID); err != nil {
+		return nil, err
+	}
+
+	timezone := request.Timezone
+	if timezone == "" {
+		timezone = "UTC"
+	}
+
+	s := &models.ScheduledTransfer{
+		UserID:         int64(user.User.ID),
+		FromWalletID:   request.FromWalletID,
+		ToWalletID:     request.ToWalletID,
+		FromCurrency:   request.FromCurrency,
+		ToCurrency:     request.ToCurrency,
+		Amount:         request.Amount,
+		Description:    request.Description,
+		Recurrence:     request.Recurrence,
+		Rule:           request.Rule,
+		Timezone:       timezone,
+		StartAt:        request.StartAt,
+		EndAt:          request.EndAt,
+		MaxOccurrences: request.MaxOccurrences,
+	}
+
+	if s.Recurrence == models.RecurrenceOnce && s.Rule != "" {
+		return nil, httpErrors.NewBadRequestError("rule is not used by one-off schedules")
+	}
+
+	rec, err := schedules.NewRecurrence(s)
+	if err != nil {
+		return nil, httpErrors.NewBadRequestError(err.Error())
+	}
+
+	first := schedules.FirstRun(s, rec)
+	if first.IsZero() {
+		return nil, schedules.ErrScheduleFinished
+	}
+	s.NextRunAt = &first
+
+	return u.schedulesRepo.Create(ctx, s)
+}
+
+// List the schedules of the ctx user
+func (u *schedulesUC) List(ctx context.Context) ([]*models.ScheduledTransfer, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "schedulesUC.List")
+	defer span.Finish()
+
+	user, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.schedulesRepo.List(ctx, int64(user.User.ID))
+}
+
+// Get a schedule of the ctx user
+func (u *schedulesUC) Get(ctx context.Context, scheduleID int64) (*models.ScheduledTransfer, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "schedulesUC.Get")
+	defer span.Finish()
+
+	return u.authorizeSchedule(ctx, scheduleID)
+}
+
+// Stop running an active schedule until it is resumed
+func (u *schedulesUC) Pause(ctx context.Context, scheduleID int64) (*models.ScheduledTransfer, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "schedulesUC.Pause")
+	defer span.Finish()
+
+	s, err := u.authorizeSchedule(ctx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.Status != models.ScheduleStatusActive {
+		return nil, schedules.ErrScheduleTransition
+	}
+
+	return u.schedulesRepo.UpdateStatus(ctx, s.ID, models.ScheduleStatusPaused, s.NextRunAt)
+}
+
+// Resume a paused schedule, occurrences that fell due while paused are not run
+func (u *schedulesUC) Resume(ctx context.Context, scheduleID int64) (*models.ScheduledTransfer, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "schedulesUC.Resume")
+	defer span.Finish()
+
+	s, err := u.authorizeSchedule(ctx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.Status != models.ScheduleStatusPaused {
+		return nil, schedules.ErrScheduleTransition
+	}
+
+	rec, err := schedules.NewRecurrence(s)
+	if err != nil {
+		return nil, err
+	}
+
+	next := s.NextRunAt
+	if rec != nil && next != nil && next.Before(time.Now()) {
+		t := schedules.NextRun(s, rec, time.Now(), s.Occurrences+1)
+		if t.IsZero() {
+			return nil, schedules.ErrScheduleFinished
+		}
+		next = &t
+	}
+
+	return u.schedulesRepo.UpdateStatus(ctx, s.ID, models.ScheduleStatusActive, next)
+}
+
+// Cancel an active or paused schedule for good
+func (u *schedulesUC) Cancel(ctx context.Context, scheduleID int64) (*models.ScheduledTransfer, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "schedulesUC.Cancel")
+	defer span.Finish()
+
+	s, err := u.authorizeSchedule(ctx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.Status != models.ScheduleStatusActive && s.Status != models.ScheduleStatusPaused {
+		return nil, schedules.ErrScheduleTransition
+	}
+
+	return u.schedulesRepo.UpdateStatus(ctx, s.ID, models.ScheduleStatusCancelled, nil)
+}
+
+// List the latest occurrences of a schedule with their outcome
+func (u *schedulesUC) ListRuns(ctx context.Context, scheduleID int64) ([]*models.ScheduledTransferRun, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "schedulesUC.ListRuns")
+	defer span.Finish()
+
+	if _, err := u.authorizeSchedule(ctx, scheduleID); err != nil {
+		return nil, err
+	}
+
+	return u.schedulesRepo.ListRuns(ctx, scheduleID, runLogSize)
+}
+
+// Load a schedule and require it to belong to the ctx user
+func (u *schedulesUC) authorizeSchedule(ctx context.Context, scheduleID int64) (*models.ScheduledTransfer, error) {
+	user, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := u.schedulesRepo.GetByID(ctx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.UserID != int64(user.User.ID) {
+		return nil, schedules.ErrScheduleAccessDenied
+	}
+
+	return s, nil
+}
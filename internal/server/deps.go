@@ -0,0 +1,92 @@
+package server
+
+import (
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/auth"
+	authRepository "github.com/aditwar-man/go-microservice-boilerplate/internal/auth/repository"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/currency"
+	currencyRepository "github.com/aditwar-man/go-microservice-boilerplate/internal/currency/repository"
+	currencyUseCase "github.com/aditwar-man/go-microservice-boilerplate/internal/currency/usecase"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/fees"
+	feesRepository "github.com/aditwar-man/go-microservice-boilerplate/internal/fees/repository"
+	feesUseCase "github.com/aditwar-man/go-microservice-boilerplate/internal/fees/usecase"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/fx"
+	fxProvider "github.com/aditwar-man/go-microservice-boilerplate/internal/fx/provider"
+	fxRepository "github.com/aditwar-man/go-microservice-boilerplate/internal/fx/repository"
+	fxUseCase "github.com/aditwar-man/go-microservice-boilerplate/internal/fx/usecase"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/ledger"
+	ledgerRepository "github.com/aditwar-man/go-microservice-boilerplate/internal/ledger/repository"
+	ledgerUseCase "github.com/aditwar-man/go-microservice-boilerplate/internal/ledger/usecase"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/limits"
+	limitsRepository "github.com/aditwar-man/go-microservice-boilerplate/internal/limits/repository"
+	limitsUseCase "github.com/aditwar-man/go-microservice-boilerplate/internal/limits/usecase"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/rbac"
+	rbac_service "github.com/aditwar-man/go-microservice-boilerplate/internal/rbac/service"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/reconciliation"
+	reconciliationRepository "github.com/aditwar-man/go-microservice-boilerplate/internal/reconciliation/repository"
+	reconciliationUseCase "github.com/aditwar-man/go-microservice-boilerplate/internal/reconciliation/usecase"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/risk"
+	riskRepository "github.com/aditwar-man/go-microservice-boilerplate/internal/risk/repository"
+	riskUseCase "github.com/aditwar-man/go-microservice-boilerplate/internal/risk/usecase"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/wallet"
+	wallet_repo "github.com/aditwar-man/go-microservice-boilerplate/internal/wallet/repository"
+	walletUsecase "github.com/aditwar-man/go-microservice-boilerplate/internal/wallet/usecase"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/metric"
+)
+
+// Repositories and use cases shared by the API handlers and the background
+// workers, so scheduled transfers, payouts and reconciliation run through the
+// same instances as the API
+type deps struct {
+	authRepo         auth.Repository
+	walletRepo       wallet.Repository
+	rbacService      rbac.RBACServiceInterface
+	rateProvider     fx.RateProvider
+	currencyUC       currency.UseCase
+	fxUC             fx.UseCase
+	limitsUC         limits.UseCase
+	feesUC           fees.UseCase
+	riskUC           risk.UseCase
+	walletUC         wallet.UseCase
+	reconciliationUC reconciliation.UseCase
+	ledgerUC         ledger.UseCase
+}
+
+// Shared dependencies, built on first use
+func (s *Server) sharedDeps() (*deps, error) {
+	if s.deps != nil {
+		return s.deps, nil
+	}
+
+	rateProvider, err := fxProvider.NewRateProvider(s.cfg, s.db)
+	if err != nil {
+		return nil, err
+	}
+
+	driftGauge, err := metric.CreateDriftGauge(s.cfg.Metrics.ServiceName)
+	if err != nil {
+		return nil, err
+	}
+
+	ledgerUC, err := ledgerUseCase.NewLedgerUseCase(s.cfg, ledgerRepository.NewLedgerRepository(s.db), s.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &deps{
+		authRepo:         authRepository.NewAuthRepository(s.db),
+		walletRepo:       wallet_repo.NewWalletRepository(s.db),
+		rbacService:      rbac_service.NewRBACService(s.db),
+		rateProvider:     rateProvider,
+		reconciliationUC: reconciliationUseCase.NewReconciliationUseCase(s.cfg, reconciliationRepository.NewReconciliationRepository(s.db), driftGauge, s.logger),
+		ledgerUC:         ledgerUC,
+	}
+	d.currencyUC = currencyUseCase.NewCurrencyUseCase(currencyRepository.NewCurrencyRepository(s.db), s.logger)
+	d.fxUC = fxUseCase.NewFXUseCase(s.cfg, fxRepository.NewFXRepository(s.db), rateProvider, d.currencyUC, s.logger)
+	d.limitsUC = limitsUseCase.NewLimitsUseCase(limitsRepository.NewLimitsRepository(s.db), d.rbacService, s.logger)
+	d.feesUC = feesUseCase.NewFeesUseCase(feesRepository.NewFeesRepository(s.db), d.rbacService, s.logger)
+	d.riskUC = riskUseCase.NewRiskUseCase(riskRepository.NewRiskRepository(s.db), s.logger)
+	d.walletUC = walletUsecase.NewWalletUseCase(s.cfg, d.walletRepo, d.authRepo, fxProvider.NewRateConverter(rateProvider), d.fxUC, d.currencyUC, d.limitsUC, d.feesUC, d.riskUC, d.rbacService, s.logger)
+
+	s.deps = d
+	return d, nil
+}
@@ -15,15 +15,36 @@ import (
 	authHttp "github.com/aditwar-man/go-microservice-boilerplate/internal/auth/delivery/http"
 	authRepository "github.com/aditwar-man/go-microservice-boilerplate/internal/auth/repository"
 	authUseCase "github.com/aditwar-man/go-microservice-boilerplate/internal/auth/usecase"
+	currencyHttp "github.com/aditwar-man/go-microservice-boilerplate/internal/currency/delivery/http"
+	feesHttp "github.com/aditwar-man/go-microservice-boilerplate/internal/fees/delivery/http"
+	fxHttp "github.com/aditwar-man/go-microservice-boilerplate/internal/fx/delivery/http"
+	idempotencyRepository "github.com/aditwar-man/go-microservice-boilerplate/internal/idempotency/repository"
+	ledgerHttp "github.com/aditwar-man/go-microservice-boilerplate/internal/ledger/delivery/http"
+	limitsHttp "github.com/aditwar-man/go-microservice-boilerplate/internal/limits/delivery/http"
 	apiMiddlewares "github.com/aditwar-man/go-microservice-boilerplate/internal/middleware"
+	paymentRequestsHttp "github.com/aditwar-man/go-microservice-boilerplate/internal/paymentrequests/delivery/http"
+	paymentRequestsRepository "github.com/aditwar-man/go-microservice-boilerplate/internal/paymentrequests/repository"
+	paymentRequestsUseCase "github.com/aditwar-man/go-microservice-boilerplate/internal/paymentrequests/usecase"
+	payoutsHttp "github.com/aditwar-man/go-microservice-boilerplate/internal/payouts/delivery/http"
+	payoutsRepository "github.com/aditwar-man/go-microservice-boilerplate/internal/payouts/repository"
+	payoutsUseCase "github.com/aditwar-man/go-microservice-boilerplate/internal/payouts/usecase"
 	rbacHttp "github.com/aditwar-man/go-microservice-boilerplate/internal/rbac/delivery/http"
-	rbac_service "github.com/aditwar-man/go-microservice-boilerplate/internal/rbac/service"
 	rbacUseCase "github.com/aditwar-man/go-microservice-boilerplate/internal/rbac/usecase"
+	reconciliationHttp "github.com/aditwar-man/go-microservice-boilerplate/internal/reconciliation/delivery/http"
+	riskHttp "github.com/aditwar-man/go-microservice-boilerplate/internal/risk/delivery/http"
+	schedulesHttp "github.com/aditwar-man/go-microservice-boilerplate/internal/schedules/delivery/http"
+	schedulesRepository "github.com/aditwar-man/go-microservice-boilerplate/internal/schedules/repository"
+	schedulesUseCase "github.com/aditwar-man/go-microservice-boilerplate/internal/schedules/usecase"
 	sessionRepository "github.com/aditwar-man/go-microservice-boilerplate/internal/session/repository"
 	sessUseCase "github.com/aditwar-man/go-microservice-boilerplate/internal/session/usecase"
+	statementsHttp "github.com/aditwar-man/go-microservice-boilerplate/internal/statements/delivery/http"
+	statementsGenerator "github.com/aditwar-man/go-microservice-boilerplate/internal/statements/generator"
+	statementsRepository "github.com/aditwar-man/go-microservice-boilerplate/internal/statements/repository"
+	statementsUseCase "github.com/aditwar-man/go-microservice-boilerplate/internal/statements/usecase"
 	walletHttp "github.com/aditwar-man/go-microservice-boilerplate/internal/wallet/delivery/http"
-	wallet_repo "github.com/aditwar-man/go-microservice-boilerplate/internal/wallet/repository"
-	walletUsecase "github.com/aditwar-man/go-microservice-boilerplate/internal/wallet/usecase"
+	webhooksHttp "github.com/aditwar-man/go-microservice-boilerplate/internal/webhooks/delivery/http"
+	webhooksRepository "github.com/aditwar-man/go-microservice-boilerplate/internal/webhooks/repository"
+	webhooksUseCase "github.com/aditwar-man/go-microservice-boilerplate/internal/webhooks/usecase"
 )
 
 func (s *Server) MapHandlers(e *echo.Echo) error {
@@ -38,29 +59,68 @@ func (s *Server) MapHandlers(e *echo.Echo) error {
 		s.cfg.Metrics.ServiceName,
 	)
 
+	// wallet, FX, limits, fees, risk, reconciliation and ledger are shared with the workers
+	d, err := s.sharedDeps()
+	if err != nil {
+		return err
+	}
+
 	// Initialize repositories
-	aRepo := authRepository.NewAuthRepository(s.db)
+	aRepo := d.authRepo
 	sRepo := sessionRepository.NewSessionRepository(s.redisClient, s.cfg)
 	authRedisRepo := authRepository.NewAuthRedisRepo(s.redisClient)
+	idempotencyRedisRepo := idempotencyRepository.NewIdempotencyRedisRepo(s.redisClient)
 
 	// Initialize RBAC service
-	rbacService := rbac_service.NewRBACService(s.db)
-	walletRepository := wallet_repo.NewWalletRepository(s.db)
+	rbacService := d.rbacService
+	walletRepository := d.walletRepo
+	webhooksRepo := webhooksRepository.NewWebhooksRepository(s.db)
+	schedulesRepo := schedulesRepository.NewSchedulesRepository(s.db)
+	payoutsRepo := payoutsRepository.NewPayoutsRepository(s.db)
+	paymentRequestsRepo := paymentRequestsRepository.NewPaymentRequestsRepository(s.db)
+	statementsRepo := statementsRepository.NewStatementsRepository(s.db)
+	statementsAWSRepo := statementsRepository.NewStatementsAWSRepository(s.awsClient)
 
 	// Init useCases
 	authUC := authUseCase.NewAuthUseCase(s.cfg, aRepo, authRedisRepo, s.logger)
 	sessUC := sessUseCase.NewSessionUseCase(sRepo, s.cfg)
 	rbacUc := rbacUseCase.NewRbacUsecase(s.cfg, rbacService, s.logger)
-	walletUC := walletUsecase.NewWalletUseCase(s.cfg, walletRepository, s.logger)
+	currencyUC := d.currencyUC
+	fxUC := d.fxUC
+	limitsUC := d.limitsUC
+	feesUC := d.feesUC
+	riskUC := d.riskUC
+	webhooksUC := webhooksUseCase.NewWebhooksUseCase(webhooksRepo, s.logger)
+	schedulesUC := schedulesUseCase.NewSchedulesUseCase(schedulesRepo, walletRepository, currencyUC, s.logger)
+	payoutsUC := payoutsUseCase.NewPayoutsUseCase(s.cfg, payoutsRepo, walletRepository, currencyUC, s.logger)
+	walletUC := d.walletUC
+	paymentRequestsUC := paymentRequestsUseCase.NewPaymentRequestsUseCase(s.cfg, paymentRequestsRepo, aRepo, walletRepository, walletUC, currencyUC, s.logger)
+	statementGenerator := statementsGenerator.NewGenerator(s.cfg, statementsRepo, walletRepository, statementsAWSRepo, currencyUC, s.logger)
+	statementsUC := statementsUseCase.NewStatementsUseCase(s.cfg, statementsRepo, walletRepository, statementGenerator, currencyUC, rbacService, s.logger)
+	reconciliationUC := d.reconciliationUC
+	ledgerUC := d.ledgerUC
 
 	// Init handlers
 	authHandlers := authHttp.NewAuthHandlers(s.cfg, authUC, sessUC, s.logger)
 	rbacHandlers := rbacHttp.NewRbacHandlers(s.cfg, rbacUc, s.logger)
 	walletHandlers := walletHttp.NewWalletHandlers(s.cfg, walletUC, s.logger)
+	fxHandlers := fxHttp.NewFXHandlers(s.cfg, fxUC, s.logger)
+	currencyHandlers := currencyHttp.NewCurrencyHandlers(currencyUC, s.logger)
+	limitsHandlers := limitsHttp.NewLimitsHandlers(limitsUC, s.logger)
+	feesHandlers := feesHttp.NewFeesHandlers(feesUC, s.logger)
+	webhooksHandlers := webhooksHttp.NewWebhooksHandlers(webhooksUC, s.logger)
+	schedulesHandlers := schedulesHttp.NewSchedulesHandlers(schedulesUC, s.logger)
+	payoutsHandlers := payoutsHttp.NewPayoutsHandlers(payoutsUC, s.logger)
+	paymentRequestsHandlers := paymentRequestsHttp.NewPaymentRequestsHandlers(paymentRequestsUC, s.logger)
+	statementsHandlers := statementsHttp.NewStatementsHandlers(statementsUC, s.logger)
+	reconciliationHandlers := reconciliationHttp.NewReconciliationHandlers(reconciliationUC, s.logger)
+	ledgerHandlers := ledgerHttp.NewLedgerHandlers(ledgerUC, s.logger)
+	riskHandlers := riskHttp.NewRiskHandlers(riskUC, s.logger)
 
 	// Initialize middleware
 	mw := apiMiddlewares.NewMiddlewareManager(sessUC, authUC, s.cfg, []string{"*"}, s.logger)
 	rbacMw := apiMiddlewares.NewRBACMiddleware(rbacService, s.logger)
+	idemMw := apiMiddlewares.NewIdempotencyMiddleware(idempotencyRedisRepo, s.logger)
 
 	e.Use(mw.RequestLoggerMiddleware)
 
@@ -73,7 +133,7 @@ func (s *Server) MapHandlers(e *echo.Echo) error {
 
 	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
 		AllowOrigins: []string{"*"},
-		AllowHeaders: []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderXRequestID, csrf.CSRFHeader},
+		AllowHeaders: []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderXRequestID, csrf.CSRFHeader, apiMiddlewares.IdempotencyKeyHeader},
 	}))
 
 	e.Use(middleware.RecoverWithConfig(middleware.RecoverConfig{
@@ -134,7 +194,44 @@ func (s *Server) MapHandlers(e *echo.Echo) error {
 
 	walletGroup := v1.Group("/wallets")
 	walletGroup.Use(mw.AuthJWTMiddleware(authUC, s.cfg))
-	walletHttp.MapWalletRoutes(walletGroup, walletHandlers, mw, authUC, walletUC, s.cfg)
+	walletHttp.MapWalletRoutes(walletGroup, walletHandlers, mw, rbacMw, idemMw, authUC, walletUC, s.cfg)
+
+	fxGroup := walletGroup.Group("/fx")
+	fxHttp.MapFXRoutes(fxGroup, fxHandlers)
+
+	currencyGroup := v1.Group("/currencies")
+	currencyGroup.Use(mw.AuthJWTMiddleware(authUC, s.cfg))
+	currencyHttp.MapCurrencyRoutes(currencyGroup, currencyHandlers)
+
+	limitsGroup := v1.Group("/limits")
+	limitsHttp.MapLimitsRoutes(limitsGroup, limitsHandlers, mw, rbacMw, authUC, s.cfg)
+
+	feesGroup := v1.Group("/fees")
+	feesHttp.MapFeesRoutes(feesGroup, feesHandlers, mw, rbacMw, authUC, s.cfg)
+
+	webhooksGroup := v1.Group("/webhooks")
+	webhooksHttp.MapWebhooksRoutes(webhooksGroup, webhooksHandlers, mw, authUC, s.cfg)
+
+	schedulesGroup := v1.Group("/schedules")
+	schedulesHttp.MapSchedulesRoutes(schedulesGroup, schedulesHandlers, mw, authUC, s.cfg)
+
+	payoutsGroup := v1.Group("/payouts")
+	payoutsHttp.MapPayoutsRoutes(payoutsGroup, payoutsHandlers, mw, rbacMw, authUC, s.cfg)
+
+	paymentRequestsGroup := v1.Group("/payment-requests")
+	paymentRequestsHttp.MapPaymentRequestsRoutes(paymentRequestsGroup, paymentRequestsHandlers, mw, idemMw, authUC, s.cfg)
+
+	statementsGroup := v1.Group("/statements")
+	statementsHttp.MapStatementsRoutes(statementsGroup, statementsHandlers, mw, authUC, s.cfg)
+
+	reconciliationGroup := v1.Group("/reconciliation")
+	reconciliationHttp.MapReconciliationRoutes(reconciliationGroup, reconciliationHandlers, mw, rbacMw, authUC, s.cfg)
+
+	ledgerGroup := v1.Group("/ledger")
+	ledgerHttp.MapLedgerRoutes(ledgerGroup, ledgerHandlers, mw, rbacMw, authUC, s.cfg)
+
+	riskGroup := v1.Group("/risk")
+	riskHttp.MapRiskRoutes(riskGroup, riskHandlers, mw, rbacMw, authUC, s.cfg)
 
 	s.logger.Info("Successfully mapped all handlers with RBAC support")
 	return nil
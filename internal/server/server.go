@@ -30,6 +30,7 @@ type Server struct {
 	redisClient *redis.Client
 	awsClient   *minio.Client
 	logger      logger.Logger
+	deps        *deps
 }
 
 func NewServer(
@@ -50,6 +51,13 @@ func NewServer(
 }
 
 func (s *Server) Run() error {
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+
+	if err := s.RunWorkers(workersCtx); err != nil {
+		return err
+	}
+
 	if s.cfg.Server.SSL {
 		if err := s.MapHandlers(s.echo); err != nil {
 			return err
@@ -0,0 +1,67 @@
+package server
+
+import (
+	"context"
+
+	ledgerCheckpointer "github.com/aditwar-man/go-microservice-boilerplate/internal/ledger/checkpointer"
+	outboxPublisher "github.com/aditwar-man/go-microservice-boilerplate/internal/outbox/publisher"
+	outboxRelay "github.com/aditwar-man/go-microservice-boilerplate/internal/outbox/relay"
+	outboxRepository "github.com/aditwar-man/go-microservice-boilerplate/internal/outbox/repository"
+	paymentRequestsExpirer "github.com/aditwar-man/go-microservice-boilerplate/internal/paymentrequests/expirer"
+	paymentRequestsRepository "github.com/aditwar-man/go-microservice-boilerplate/internal/paymentrequests/repository"
+	payoutsProcessor "github.com/aditwar-man/go-microservice-boilerplate/internal/payouts/processor"
+	payoutsRepository "github.com/aditwar-man/go-microservice-boilerplate/internal/payouts/repository"
+	reconciliationScheduler "github.com/aditwar-man/go-microservice-boilerplate/internal/reconciliation/scheduler"
+	schedulesRepository "github.com/aditwar-man/go-microservice-boilerplate/internal/schedules/repository"
+	schedulesRunner "github.com/aditwar-man/go-microservice-boilerplate/internal/schedules/runner"
+	statementsGenerator "github.com/aditwar-man/go-microservice-boilerplate/internal/statements/generator"
+	statementsRepository "github.com/aditwar-man/go-microservice-boilerplate/internal/statements/repository"
+	walletReleaser "github.com/aditwar-man/go-microservice-boilerplate/internal/wallet/releaser"
+	walletSnapshotter "github.com/aditwar-man/go-microservice-boilerplate/internal/wallet/snapshotter"
+	webhooksDispatcher "github.com/aditwar-man/go-microservice-boilerplate/internal/webhooks/dispatcher"
+	webhooksRepository "github.com/aditwar-man/go-microservice-boilerplate/internal/webhooks/repository"
+)
+
+// Background worker, Run blocks until ctx is cancelled
+type worker interface {
+	Run(ctx context.Context)
+}
+
+// Start the background workers, they stop when ctx is cancelled
+func (s *Server) RunWorkers(ctx context.Context) error {
+	eventPublisher, err := outboxPublisher.NewEventPublisher(s.cfg, s.redisClient)
+	if err != nil {
+		return err
+	}
+
+	webhooksRepo := webhooksRepository.NewWebhooksRepository(s.db)
+
+	// every outbox event goes to the stream and queues the matching webhooks
+	publisher := outboxPublisher.NewMultiPublisher(eventPublisher, webhooksDispatcher.NewFanoutPublisher(webhooksRepo))
+
+	// scheduled transfers, payouts and reconciliation share the API instances
+	d, err := s.sharedDeps()
+	if err != nil {
+		return err
+	}
+
+	workers := []worker{
+		outboxRelay.NewRelay(s.cfg, outboxRepository.NewOutboxRepository(s.db), publisher, s.logger),
+		webhooksDispatcher.NewDispatcher(s.cfg, webhooksRepo, s.logger),
+		schedulesRunner.NewRunner(s.cfg, schedulesRepository.NewSchedulesRepository(s.db), d.walletUC, s.logger),
+		walletReleaser.NewReleaser(s.cfg, d.walletRepo, s.logger),
+		payoutsProcessor.NewProcessor(s.cfg, payoutsRepository.NewPayoutsRepository(s.db), d.walletUC, s.logger),
+		paymentRequestsExpirer.NewExpirer(s.cfg, paymentRequestsRepository.NewPaymentRequestsRepository(s.db), s.logger),
+		statementsGenerator.NewGenerator(s.cfg, statementsRepository.NewStatementsRepository(s.db), d.walletRepo, statementsRepository.NewStatementsAWSRepository(s.awsClient), d.currencyUC, s.logger),
+		reconciliationScheduler.NewScheduler(s.cfg, d.reconciliationUC, s.logger),
+		ledgerCheckpointer.NewCheckpointer(s.cfg, d.ledgerUC, s.logger),
+		walletSnapshotter.NewSnapshotter(s.cfg, d.walletRepo, s.logger),
+	}
+
+	for _, w := range workers {
+		go w.Run(ctx)
+	}
+
+	s.logger.Infof("Started %d background workers", len(workers))
+	return nil
+}
@@ -0,0 +1,18 @@
+//go:generate mockgen -source aws_repository.go -destination mock/aws_repository_mock.go -package mock
+package statements
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+)
+
+// Statement files in object storage
+type AWSRepository interface {
+	PutObject(ctx context.Context, input models.UploadInput) (*minio.UploadInfo, error)
+	PresignedGetObject(ctx context.Context, bucket, key, fileName string, expiry time.Duration) (*url.URL, error)
+}
@@ -0,0 +1,10 @@
+package statements
+
+import "github.com/labstack/echo/v4"
+
+// Statements HTTP Handlers interface
+type Handlers interface {
+	Create() echo.HandlerFunc
+	List() echo.HandlerFunc
+	Get() echo.HandlerFunc
+}
@@ -0,0 +1,110 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/opentracing/opentracing-go"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/dto"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/statements"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/httpErrors"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/logger"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/utils"
+)
+
+type statementsHandlers struct {
+	statementsUC statements.UseCase
+	logger       logger.Logger
+}
+
+func NewStatementsHandlers(statementsUC statements.UseCase, log logger.Logger) statements.Handlers {
+	return &statementsHandlers{statementsUC: statementsUC, logger: log}
+}
+
+// Create godoc
+// @Summary Request statement
+// @Description Statement of a wallet in one currency over [from, to) as CSV or PDF. Short periods are returned completed with a download link, longer ones are accepted and generated in the background.
+// @Tags Statements
+// @Accept json
+// @Produce json
+// @Param body body dto.RequestStatement true "statement"
+// @Success 201 {object} models.Statement
+// @Success 202 {object} models.Statement
+// @Router /statements [post]
+func (h *statementsHandlers) Create() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "statements.Create")
+		defer span.Finish()
+
+		statementRequest := &dto.RequestStatement{}
+		if err := utils.ReadRequest(c, statementRequest); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		statement, err := h.statementsUC.Create(ctx, statementRequest)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		if statement.Status == models.StatementPending {
+			return c.JSON(http.StatusAccepted, statement)
+		}
+		return c.JSON(http.StatusCreated, statement)
+	}
+}
+
+// List godoc
+// @Summary List statements
+// @Description List the statements requested by the current user, newest first
+// @Tags Statements
+// @Produce json
+// @Success 200 {array} models.Statement
+// @Router /statements [get]
+func (h *statementsHandlers) List() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "statements.List")
+		defer span.Finish()
+
+		list, err := h.statementsUC.List(ctx)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, list)
+	}
+}
+
+// Get godoc
+// @Summary Get statement
+// @Description Get a statement, completed statements carry a short lived download link
+// @Tags Statements
+// @Produce json
+// @Param id path int true "statement_id"
+// @Success 200 {object} models.Statement
+// @Router /statements/{id} [get]
+func (h *statementsHandlers) Get() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "statements.Get")
+		defer span.Finish()
+
+		statementID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(httpErrors.NewBadRequestError(err.Error())))
+		}
+
+		statement, err := h.statementsUC.Get(ctx, statementID)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, statement)
+	}
+}
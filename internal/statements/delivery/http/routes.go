@@ -0,0 +1,20 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/config"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/auth"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/middleware"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/statements"
+)
+
+// Map statements routes, wallet owners and holders of read on statements may request them
+func MapStatementsRoutes(statementsGroup *echo.Group, h statements.Handlers, mw *middleware.MiddlewareManager, authUc auth.UseCase, cfg *config.Config) {
+	statementsGroup.Use(mw.AuthJWTMiddleware(authUc, cfg))
+	statementsGroup.Use(mw.AuthSessionMiddleware)
+
+	statementsGroup.GET("", h.List())
+	statementsGroup.POST("", h.Create())
+	statementsGroup.GET("/:id", h.Get())
+}
@@ -0,0 +1,13 @@
+package statements
+
+import (
+	"net/http"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/httpErrors"
+)
+
+// Statements domain errors
+var (
+	ErrStatementNotFound     = httpErrors.NewRestError(http.StatusNotFound, "Statement not found", nil)
+	ErrStatementAccessDenied = httpErrors.NewRestError(http.StatusForbidden, "Statement access denied", nil)
+)
@@ -0,0 +1,15 @@
+//go:generate mockgen -source generator.go -destination mock/generator_mock.go -package mock
+package statements
+
+import (
+	"context"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+)
+
+// Renders statements into object storage, used by the API for short ranges
+// and by the background generator for the rest
+type Generator interface {
+	Generate(ctx context.Context, statement *models.Statement) (*models.Statement, error)
+	DownloadURL(ctx context.Context, statement *models.Statement) (string, error)
+}
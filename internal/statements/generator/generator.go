@@ -0,0 +1,254 @@
+package generator
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/config"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/currency"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/statements"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/wallet"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/httpErrors"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/logger"
+)
+
+const (
+	defaultBucket       = "statements"
+	defaultURLExpiry    = 15 * time.Minute
+	defaultBatchSize    = 10
+	defaultMaxAttempts  = 5
+	defaultPollInterval = 10 * time.Second
+	defaultRetryBackoff = 30 * time.Second
+	defaultLease        = 5 * time.Minute
+	maxRetryBackoff     = time.Hour
+
+	// scale of ledger amounts, NUMERIC(36,18)
+	storedExponent = 18
+)
+
+// Generator renders statements from the txs ledger and stores them in the
+// statements bucket. As a worker it renders the pending statements that
+// were too long to render in the request.
+type Generator struct {
+	statementsRepo statements.Repository
+	walletRepo     wallet.Repository
+	awsRepo        statements.AWSRepository
+	currencyUC     currency.UseCase
+	bucket         string
+	urlExpiry      time.Duration
+	batchSize      int
+	maxAttempts    int
+	pollInterval   time.Duration
+	retryBackoff   time.Duration
+	lease          time.Duration
+	logger         logger.Logger
+	now            func() time.Time
+}
+
+// Generator constructor, zero config values fall back to defaults
+func NewGenerator(cfg *config.Config, statementsRepo statements.Repository, walletRepo wallet.Repository, awsRepo statements.AWSRepository, currencyUC currency.UseCase, log logger.Logger) *Generator {
+	g := &Generator{
+		statementsRepo: statementsRepo,
+		walletRepo:     walletRepo,
+		awsRepo:        awsRepo,
+		currencyUC:     currencyUC,
+		bucket:         cfg.Statements.Bucket,
+		urlExpiry:      time.Duration(cfg.Statements.URLExpiryMinutes) * time.Minute,
+		batchSize:      cfg.Statements.BatchSize,
+		maxAttempts:    cfg.Statements.MaxAttempts,
+		pollInterval:   time.Duration(cfg.Statements.PollIntervalSeconds) * time.Second,
+		retryBackoff:   time.Duration(cfg.Statements.RetryBackoffSeconds) * time.Second,
+		lease:          time.Duration(cfg.Statements.LeaseSeconds) * time.Second,
+		logger:         log,
+		now:            time.Now,
+	}
+
+	if g.bucket == "" {
+		g.bucket = defaultBucket
+	}
+	if g.urlExpiry <= 0 {
+		g.urlExpiry = defaultURLExpiry
+	}
+	if g.batchSize <= 0 {
+		g.batchSize = defaultBatchSize
+	}
+	if g.maxAttempts <= 0 {
+		g.maxAttempts = defaultMaxAttempts
+	}
+	if g.pollInterval <= 0 {
+		g.pollInterval = defaultPollInterval
+	}
+	if g.retryBackoff <= 0 {
+		g.retryBackoff = defaultRetryBackoff
+	}
+	if g.lease <= 0 {
+		g.lease = defaultLease
+	}
+
+	return g
+}
+
+// Render a statement, upload the file and mark the statement completed
+func (g *Generator) Generate(ctx context.Context, s *models.Statement) (*models.Statement, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "statementGenerator.Generate")
+	defer span.Finish()
+
+	w, err := g.walletRepo.GetByID(ctx, s.WalletID)
+	if err != nil {
+		return nil, err
+	}
+
+	opening, err := g.statementsRepo.GetOpeningBalance(ctx, s.WalletID, s.Currency, s.PeriodFrom)
+	if err != nil {
+		return nil, err
+	}
+
+	txs, err := g.statementsRepo.FindTransactions(ctx, s.WalletID, s.Currency, s.PeriodFrom, s.PeriodTo)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &document{
+		statement:    s,
+		walletName:   w.Name,
+		exponent:     g.exponent(ctx, s.Currency),
+		opening:      opening,
+		transactions: txs,
+		generatedAt:  g.now(),
+	}
+
+	buf := &bytes.Buffer{}
+	if err := render(buf, d); err != nil {
+		return nil, err
+	}
+
+	key := s.Key()
+	if _, err := g.awsRepo.PutObject(ctx, models.UploadInput{
+		File:        buf,
+		Name:        key,
+		Size:        int64(buf.Len()),
+		ContentType: s.ContentType(),
+		BucketName:  g.bucket,
+	}); err != nil {
+		return nil, err
+	}
+
+	closing := d.closing()
+	s.ObjectKey = &key
+	s.OpeningBalance = &opening
+	s.ClosingBalance = &closing
+	s.TxCount = len(txs)
+
+	return g.statementsRepo.Complete(ctx, s)
+}
+
+// Presigned link to the file of a completed statement
+func (g *Generator) DownloadURL(ctx context.Context, s *models.Statement) (string, error) {
+	if s.Status != models.StatementCompleted || s.ObjectKey == nil {
+		return "", nil
+	}
+
+	link, err := g.awsRepo.PresignedGetObject(ctx, g.bucket, *s.ObjectKey, s.FileName(), g.urlExpiry)
+	if err != nil {
+		return "", err
+	}
+
+	return link.String(), nil
+}
+
+// Poll for pending statements until ctx is done, a full batch is followed immediately by the next one
+func (g *Generator) Run(ctx context.Context) {
+	ticker := time.NewTicker(g.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		n, err := g.ProcessBatch(ctx)
+		if err != nil {
+			g.logger.Errorf("statement generator: %s", err)
+		}
+
+		if err == nil && n == g.batchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Generate one batch of pending statements, returns how many were claimed
+func (g *Generator) ProcessBatch(ctx context.Context) (int, error) {
+	claimed, err := g.statementsRepo.Claim(ctx, g.batchSize, g.lease)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, s := range claimed {
+		if err := g.execute(ctx, s); err != nil {
+			return len(claimed), err
+		}
+	}
+
+	return len(claimed), nil
+}
+
+// Generate a statement and record a failure, only repository errors are returned
+func (g *Generator) execute(ctx context.Context, s *models.Statement) error {
+	_, genErr := g.Generate(ctx, s)
+	switch {
+	case genErr == nil:
+		g.logger.Infof("Statement %d of wallet %d generated", s.ID, s.WalletID)
+		return nil
+	case rejected(genErr) || s.Attempts+1 >= g.maxAttempts:
+		g.logger.Warnf("statement generator: statement %d failed: %s", s.ID, genErr)
+		return g.statementsRepo.Fail(ctx, int64(s.ID), genErr.Error())
+	default:
+		attempts := s.Attempts + 1
+		g.logger.Warnf("statement generator: statement %d, attempt %d: %s", s.ID, attempts, genErr)
+		return g.statementsRepo.Retry(ctx, int64(s.ID), genErr.Error(), g.now().Add(g.backoff(attempts)))
+	}
+}
+
+// Minor unit digits of a currency, amounts of unknown currencies are printed as stored
+func (g *Generator) exponent(ctx context.Context, code string) int32 {
+	currencies, err := g.currencyUC.List(ctx)
+	if err == nil {
+		for _, c := range currencies {
+			if c.Code == code {
+				return c.Exponent
+			}
+		}
+	}
+	return storedExponent
+}
+
+// Delay before the next attempt, doubling per attempt
+func (g *Generator) backoff(attempts int) time.Duration {
+	delay := g.retryBackoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= maxRetryBackoff {
+			return maxRetryBackoff
+		}
+	}
+
+	return delay
+}
+
+// A missing wallet fails the statement, anything else is retried
+func rejected(err error) bool {
+	var restErr httpErrors.RestErr
+	if errors.As(err, &restErr) {
+		return restErr.Status() < http.StatusInternalServerError
+	}
+	return false
+}
@@ -0,0 +1,167 @@
+package generator
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/minio/minio-go/v7"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/config"
+	currencyMock "github.com/aditwar-man/go-microservice-boilerplate/internal/currency/mock"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/statements/mock"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/wallet"
+	walletMock "github.com/aditwar-man/go-microservice-boilerplate/internal/wallet/mock"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/logger"
+)
+
+var testNow = time.Date(2026, 4, 2, 8, 0, 0, 0, time.UTC)
+
+type testDeps struct {
+	repo       *mock.MockRepository
+	awsRepo    *mock.MockAWSRepository
+	walletRepo *walletMock.MockRepository
+	currencyUC *currencyMock.MockUseCase
+}
+
+func newTestGenerator(ctrl *gomock.Controller) (*Generator, testDeps) {
+	cfg := &config.Config{
+		Logger:     config.Logger{Level: "error", Encoding: "console"},
+		Statements: config.Statements{Bucket: "statements", BatchSize: 10, MaxAttempts: 3, RetryBackoffSeconds: 30},
+	}
+	l := logger.NewApiLogger(cfg)
+	l.InitLogger()
+
+	deps := testDeps{
+		repo:       mock.NewMockRepository(ctrl),
+		awsRepo:    mock.NewMockAWSRepository(ctrl),
+		walletRepo: walletMock.NewMockRepository(ctrl),
+		currencyUC: currencyMock.NewMockUseCase(ctrl),
+	}
+
+	g := NewGenerator(cfg, deps.repo, deps.walletRepo, deps.awsRepo, deps.currencyUC, l)
+	g.now = func() time.Time { return testNow }
+	return g, deps
+}
+
+func pendingStatement(id int64, attempts int) *models.Statement {
+	return &models.Statement{
+		ID:         models.ID(id),
+		UserID:     3,
+		WalletID:   7,
+		Currency:   "USD",
+		Format:     models.StatementFormatCSV,
+		PeriodFrom: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		PeriodTo:   time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+		Status:     models.StatementPending,
+		Attempts:   attempts,
+	}
+}
+
+func TestGenerator_Generate(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	g, deps := newTestGenerator(ctrl)
+	s := pendingStatement(5, 0)
+
+	ref := "invoice-12"
+	txs := []*models.Transaction{
+		{WalletID: 7, Type: models.TypeDeposit, Currency: "USD", Amount: decimal.RequireFromString("100.5"), RefID: &ref, CreatedAt: time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC)},
+		{WalletID: 7, Type: models.TypeWithdraw, Currency: "USD", Amount: decimal.RequireFromString("-30.25"), CreatedAt: time.Date(2026, 3, 9, 12, 0, 0, 0, time.UTC)},
+	}
+
+	deps.walletRepo.EXPECT().GetByID(gomock.Any(), int64(7)).Return(&models.Wallet{ID: 7, UserID: 3, Name: "main"}, nil)
+	deps.repo.EXPECT().GetOpeningBalance(gomock.Any(), int64(7), "USD", s.PeriodFrom).Return(decimal.NewFromInt(20), nil)
+	deps.repo.EXPECT().FindTransactions(gomock.Any(), int64(7), "USD", s.PeriodFrom, s.PeriodTo).Return(txs, nil)
+	deps.currencyUC.EXPECT().List(gomock.Any()).Return([]*models.Currency{{Code: "USD", Exponent: 2}}, nil)
+
+	var rows [][]string
+	deps.awsRepo.EXPECT().PutObject(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, input models.UploadInput) (*minio.UploadInfo, error) {
+			require.Equal(t, "statements", input.BucketName)
+			require.Equal(t, "statements/3/5/statement-7-USD-20260301-20260401.csv", input.Name)
+			require.Equal(t, "text/csv", input.ContentType)
+
+			body, err := io.ReadAll(input.File)
+			require.NoError(t, err)
+			require.Equal(t, int64(len(body)), input.Size)
+
+			rows, err = csv.NewReader(bytes.NewReader(body)).ReadAll()
+			require.NoError(t, err)
+			return &minio.UploadInfo{}, nil
+		})
+
+	deps.repo.EXPECT().Complete(gomock.Any(), s).
+		DoAndReturn(func(_ context.Context, s *models.Statement) (*models.Statement, error) {
+			require.Equal(t, "20.00", s.OpeningBalance.StringFixed(2))
+			require.Equal(t, "90.25", s.ClosingBalance.StringFixed(2))
+			require.Equal(t, 2, s.TxCount)
+			require.NotNil(t, s.ObjectKey)
+			s.Status = models.StatementCompleted
+			return s, nil
+		})
+
+	completed, err := g.Generate(context.Background(), s)
+	require.NoError(t, err)
+	require.Equal(t, models.StatementCompleted, completed.Status)
+
+	require.Equal(t, [][]string{
+		csvHeader,
+		{"2026-03-01T00:00:00Z", rowOpeningBalance, "", "", "20.00"},
+		{"2026-03-04T10:00:00Z", models.TypeDeposit, "invoice-12", "100.50", "120.50"},
+		{"2026-03-09T12:00:00Z", models.TypeWithdraw, "", "-30.25", "90.25"},
+		{"2026-04-01T00:00:00Z", rowClosingBalance, "", "", "90.25"},
+	}, rows)
+}
+
+func TestGenerator_ProcessBatch(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	g, deps := newTestGenerator(ctrl)
+	missing, flaky, exhausted := pendingStatement(1, 0), pendingStatement(2, 1), pendingStatement(3, 2)
+	missing.WalletID, flaky.WalletID, exhausted.WalletID = 11, 12, 13
+
+	deps.repo.EXPECT().Claim(gomock.Any(), 10, defaultLease).Return([]*models.Statement{missing, flaky, exhausted}, nil)
+
+	down := errors.New("connection refused")
+	deps.walletRepo.EXPECT().GetByID(gomock.Any(), int64(11)).Return(nil, wallet.ErrWalletNotFound)
+	deps.walletRepo.EXPECT().GetByID(gomock.Any(), int64(12)).Return(nil, down)
+	deps.walletRepo.EXPECT().GetByID(gomock.Any(), int64(13)).Return(nil, down)
+
+	// a missing wallet fails at once, a transient error is retried until attempts run out
+	deps.repo.EXPECT().Fail(gomock.Any(), int64(1), wallet.ErrWalletNotFound.Error()).Return(nil)
+	deps.repo.EXPECT().Retry(gomock.Any(), int64(2), down.Error(), testNow.Add(time.Minute)).Return(nil)
+	deps.repo.EXPECT().Fail(gomock.Any(), int64(3), down.Error()).Return(nil)
+
+	n, err := g.ProcessBatch(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 3, n)
+}
+
+func TestGenerator_DownloadURL(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	g, _ := newTestGenerator(ctrl)
+
+	// pending statements have no file yet, the AWS repository is not called
+	link, err := g.DownloadURL(context.Background(), pendingStatement(1, 0))
+	require.NoError(t, err)
+	require.Empty(t, link)
+}
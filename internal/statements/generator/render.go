@@ -0,0 +1,141 @@
+package generator
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/pdf"
+)
+
+const (
+	rowOpeningBalance = "opening_balance"
+	rowClosingBalance = "closing_balance"
+
+	pdfTimeLayout   = "2006-01-02 15:04"
+	referenceLength = 24
+)
+
+var csvHeader = []string{"date", "type", "reference", "amount", "balance"}
+
+// Everything a statement file shows, amounts are formatted to the currency exponent
+type document struct {
+	statement    *models.Statement
+	walletName   string
+	exponent     int32
+	opening      decimal.Decimal
+	transactions []*models.Transaction
+	generatedAt  time.Time
+}
+
+// Balance after the last transaction
+func (d *document) closing() decimal.Decimal {
+	balance := d.opening
+	for _, tx := range d.transactions {
+		balance = balance.Add(tx.Amount)
+	}
+	return balance
+}
+
+func (d *document) amount(v decimal.Decimal) string {
+	return v.StringFixed(d.exponent)
+}
+
+func render(w io.Writer, d *document) error {
+	if d.statement.Format == models.StatementFormatPDF {
+		return renderPDF(w, d)
+	}
+	return renderCSV(w, d)
+}
+
+// One row per transaction with the running balance, framed by the opening
+// and closing balance rows
+func renderCSV(w io.Writer, d *document) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvHeader); err != nil {
+		return err
+	}
+
+	s := d.statement
+	balance := d.opening
+	if err := writer.Write([]string{s.PeriodFrom.UTC().Format(time.RFC3339), rowOpeningBalance, "", "", d.amount(balance)}); err != nil {
+		return err
+	}
+
+	for _, tx := range d.transactions {
+		balance = balance.Add(tx.Amount)
+		if err := writer.Write([]string{
+			tx.CreatedAt.UTC().Format(time.RFC3339), tx.Type, reference(tx), d.amount(tx.Amount), d.amount(balance),
+		}); err != nil {
+			return err
+		}
+	}
+
+	if err := writer.Write([]string{s.PeriodTo.UTC().Format(time.RFC3339), rowClosingBalance, "", "", d.amount(balance)}); err != nil {
+		return err
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// Printable statement with a summary block and a fixed width transaction table
+func renderPDF(w io.Writer, d *document) error {
+	s := d.statement
+	doc := pdf.New()
+
+	moneyIn, moneyOut := decimal.Zero, decimal.Zero
+	for _, tx := range d.transactions {
+		if tx.Amount.IsPositive() {
+			moneyIn = moneyIn.Add(tx.Amount)
+		} else {
+			moneyOut = moneyOut.Add(tx.Amount.Neg())
+		}
+	}
+
+	doc.Line("ACCOUNT STATEMENT")
+	doc.Line("")
+	doc.Line(fmt.Sprintf("Wallet:    %d %s", s.WalletID, d.walletName))
+	doc.Line(fmt.Sprintf("Currency:  %s", s.Currency))
+	doc.Line(fmt.Sprintf("Period:    %s to %s UTC", s.PeriodFrom.UTC().Format(pdfTimeLayout), s.PeriodTo.UTC().Format(pdfTimeLayout)))
+	doc.Line(fmt.Sprintf("Generated: %s UTC", d.generatedAt.UTC().Format(pdfTimeLayout)))
+	doc.Line("")
+	doc.Line(fmt.Sprintf("Opening balance: %18s", d.amount(d.opening)))
+	doc.Line(fmt.Sprintf("Money in:        %18s", d.amount(moneyIn)))
+	doc.Line(fmt.Sprintf("Money out:       %18s", d.amount(moneyOut)))
+	doc.Line(fmt.Sprintf("Closing balance: %18s", d.amount(d.closing())))
+	doc.Line(fmt.Sprintf("Transactions:    %18d", len(d.transactions)))
+	doc.Line("")
+
+	row := "%-16s %-16s %-24s %16s %16s"
+	doc.Line(fmt.Sprintf(row, "Date", "Type", "Reference", "Amount", "Balance"))
+
+	balance := d.opening
+	doc.Line(fmt.Sprintf(row, s.PeriodFrom.UTC().Format(pdfTimeLayout), "Opening balance", "", "", d.amount(balance)))
+	for _, tx := range d.transactions {
+		balance = balance.Add(tx.Amount)
+		doc.Line(fmt.Sprintf(row, tx.CreatedAt.UTC().Format(pdfTimeLayout), tx.Type, truncate(reference(tx), referenceLength), d.amount(tx.Amount), d.amount(balance)))
+	}
+	doc.Line(fmt.Sprintf(row, s.PeriodTo.UTC().Format(pdfTimeLayout), "Closing balance", "", "", d.amount(balance)))
+
+	_, err := doc.WriteTo(w)
+	return err
+}
+
+func reference(tx *models.Transaction) string {
+	if tx.RefID == nil {
+		return ""
+	}
+	return *tx.RefID
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n-3] + "..."
+}
@@ -0,0 +1,69 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: aws_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	url "net/url"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	gomock "github.com/golang/mock/gomock"
+	minio "github.com/minio/minio-go/v7"
+)
+
+// MockAWSRepository is a mock of AWSRepository interface.
+type MockAWSRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAWSRepositoryMockRecorder
+}
+
+// MockAWSRepositoryMockRecorder is the mock recorder for MockAWSRepository.
+type MockAWSRepositoryMockRecorder struct {
+	mock *MockAWSRepository
+}
+
+// NewMockAWSRepository creates a new mock instance.
+func NewMockAWSRepository(ctrl *gomock.Controller) *MockAWSRepository {
+	mock := &MockAWSRepository{ctrl: ctrl}
+	mock.recorder = &MockAWSRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAWSRepository) EXPECT() *MockAWSRepositoryMockRecorder {
+	return m.recorder
+}
+
+// PresignedGetObject mocks base method.
+func (m *MockAWSRepository) PresignedGetObject(ctx context.Context, bucket, key, fileName string, expiry time.Duration) (*url.URL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PresignedGetObject", ctx, bucket, key, fileName, expiry)
+	ret0, _ := ret[0].(*url.URL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PresignedGetObject indicates an expected call of PresignedGetObject.
+func (mr *MockAWSRepositoryMockRecorder) PresignedGetObject(ctx, bucket, key, fileName, expiry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PresignedGetObject", reflect.TypeOf((*MockAWSRepository)(nil).PresignedGetObject), ctx, bucket, key, fileName, expiry)
+}
+
+// PutObject mocks base method.
+func (m *MockAWSRepository) PutObject(ctx context.Context, input models.UploadInput) (*minio.UploadInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutObject", ctx, input)
+	ret0, _ := ret[0].(*minio.UploadInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutObject indicates an expected call of PutObject.
+func (mr *MockAWSRepositoryMockRecorder) PutObject(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutObject", reflect.TypeOf((*MockAWSRepository)(nil).PutObject), ctx, input)
+}
@@ -0,0 +1,28 @@
+//go:generate mockgen -source pg_repository.go -destination mock/pg_repository_mock.go -package mock
+package statements
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+)
+
+// Statements repository interface
+type Repository interface {
+	Create(ctx context.Context, statement *models.Statement) (*models.Statement, error)
+	GetByID(ctx context.Context, statementID int64) (*models.Statement, error)
+	List(ctx context.Context, userID int64) ([]*models.Statement, error)
+
+	// Ledger of a wallet in one currency, transactions in [from, to) oldest first
+	GetOpeningBalance(ctx context.Context, walletID int64, currency string, before time.Time) (decimal.Decimal, error)
+	FindTransactions(ctx context.Context, walletID int64, currency string, from, to time.Time) ([]*models.Transaction, error)
+
+	// Generation, claimed statements are leased so concurrent generators skip them
+	Claim(ctx context.Context, limit int, lease time.Duration) ([]*models.Statement, error)
+	Complete(ctx context.Context, statement *models.Statement) (*models.Statement, error)
+	Retry(ctx context.Context, statementID int64, reason string, next time.Time) error
+	Fail(ctx context.Context, statementID int64, reason string) error
+}
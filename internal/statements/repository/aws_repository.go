@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/statements"
+)
+
+// Statements AWS S3 repository
+type statementsAWSRepository struct {
+	client *minio.Client
+}
+
+// Statements AWS S3 repository constructor
+func NewStatementsAWSRepository(awsClient *minio.Client) statements.AWSRepository {
+	return &statementsAWSRepository{client: awsClient}
+}
+
+// Upload a statement file, the object stays private and is shared by presigned links
+func (aws *statementsAWSRepository) PutObject(ctx context.Context, input models.UploadInput) (*minio.UploadInfo, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "statementsAWSRepository.PutObject")
+	defer span.Finish()
+
+	uploadInfo, err := aws.client.PutObject(ctx, input.BucketName, input.Name, input.File, input.Size, minio.PutObjectOptions{
+		ContentType: input.ContentType,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "statementsAWSRepository.PutObject")
+	}
+
+	return &uploadInfo, nil
+}
+
+// Time limited download link that saves the object as fileName
+func (aws *statementsAWSRepository) PresignedGetObject(ctx context.Context, bucket, key, fileName string, expiry time.Duration) (*url.URL, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "statementsAWSRepository.PresignedGetObject")
+	defer span.Finish()
+
+	params := url.Values{}
+	params.Set("response-content-disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+
+	link, err := aws.client.PresignedGetObject(ctx, bucket, key, expiry, params)
+	if err != nil {
+		return nil, errors.Wrap(err, "statementsAWSRepository.PresignedGetObject")
+	}
+
+	return link, nil
+}
@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/statements"
+)
+
+// Statements Repository
+type statementsRepo struct {
+	db *sqlx.DB
+}
+
+// Statements Repository constructor
+func NewStatementsRepository(db *sqlx.DB) statements.Repository {
+	return &statementsRepo{db: db}
+}
+
+// Create a pending statement, a set LockedUntil keeps generators off it
+func (r *statementsRepo) Create(ctx context.Context, s *models.Statement) (*models.Statement, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "statementsRepo.Create")
+	defer span.Finish()
+
+	created := &models.Statement{}
+	if err := r.db.QueryRowxContext(ctx, createStatementQuery,
+		s.UserID, s.WalletID, s.Currency, s.Format, s.PeriodFrom, s.PeriodTo, s.LockedUntil,
+	).StructScan(created); err != nil {
+		return nil, errors.Wrap(err, "statementsRepo.Create.StructScan")
+	}
+
+	return created, nil
+}
+
+func (r *statementsRepo) GetByID(ctx context.Context, statementID int64) (*models.Statement, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "statementsRepo.GetByID")
+	defer span.Finish()
+
+	s := &models.Statement{}
+	if err := r.db.GetContext(ctx, s, getStatementQuery, statementID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, statements.ErrStatementNotFound
+		}
+		return nil, errors.Wrap(err, "statementsRepo.GetByID.GetContext")
+	}
+
+	return s, nil
+}
+
+// List the statements requested by a user, newest first
+func (r *statementsRepo) List(ctx context.Context, userID int64) ([]*models.Statement, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "statementsRepo.List")
+	defer span.Finish()
+
+	list := make([]*models.Statement, 0)
+	if err := r.db.SelectContext(ctx, &list, listStatementsQuery, userID); err != nil {
+		return nil, errors.Wrap(err, "statementsRepo.List.SelectContext")
+	}
+
+	return list, nil
+}
+
+// Balance of a wallet in a currency just before a point in time
+func (r *statementsRepo) GetOpeningBalance(ctx context.Context, walletID int64, currency string, before time.Time) (decimal.Decimal, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "statementsRepo.GetOpeningBalance")
+	defer span.Finish()
+
+	var balance decimal.Decimal
+	if err := r.db.GetContext(ctx, &balance, getOpeningBalanceQuery, walletID, currency, before); err != nil {
+		return decimal.Zero, errors.Wrap(err, "statementsRepo.GetOpeningBalance.GetContext")
+	}
+
+	return balance, nil
+}
+
+func (r *statementsRepo) FindTransactions(ctx context.Context, walletID int64, currency string, from, to time.Time) ([]*models.Transaction, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "statementsRepo.FindTransactions")
+	defer span.Finish()
+
+	txs := make([]*models.Transaction, 0)
+	if err := r.db.SelectContext(ctx, &txs, findStatementTxsQuery, walletID, currency, from, to); err != nil {
+		return nil, errors.Wrap(err, "statementsRepo.FindTransactions.SelectContext")
+	}
+
+	return txs, nil
+}
+
+func (r *statementsRepo) Claim(ctx context.Context, limit int, lease time.Duration) ([]*models.Statement, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "statementsRepo.Claim")
+	defer span.Finish()
+
+	claimed := make([]*models.Statement, 0, limit)
+	if err := r.db.SelectContext(ctx, &claimed, claimStatementsQuery, limit, lease.Seconds()); err != nil {
+		return nil, errors.Wrap(err, "statementsRepo.Claim.SelectContext")
+	}
+
+	return claimed, nil
+}
+
+// Record the stored file and balances of a generated statement
+func (r *statementsRepo) Complete(ctx context.Context, s *models.Statement) (*models.Statement, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "statementsRepo.Complete")
+	defer span.Finish()
+
+	completed := &models.Statement{}
+	if err := r.db.QueryRowxContext(ctx, completeStatementQuery,
+		s.ID, s.ObjectKey, s.OpeningBalance, s.ClosingBalance, s.TxCount,
+	).StructScan(completed); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			// generated twice after a lease ran out, the first result stands
+			return r.GetByID(ctx, int64(s.ID))
+		}
+		return nil, errors.Wrap(err, "statementsRepo.Complete.StructScan")
+	}
+
+	return completed, nil
+}
+
+func (r *statementsRepo) Retry(ctx context.Context, statementID int64, reason string, next time.Time) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "statementsRepo.Retry")
+	defer span.Finish()
+
+	if _, err := r.db.ExecContext(ctx, retryStatementQuery, statementID, reason, next); err != nil {
+		return errors.Wrap(err, "statementsRepo.Retry.ExecContext")
+	}
+
+	return nil
+}
+
+func (r *statementsRepo) Fail(ctx context.Context, statementID int64, reason string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "statementsRepo.Fail")
+	defer span.Finish()
+
+	if _, err := r.db.ExecContext(ctx, failStatementQuery, statementID, reason); err != nil {
+		return errors.Wrap(err, "statementsRepo.Fail.ExecContext")
+	}
+
+	return nil
+}
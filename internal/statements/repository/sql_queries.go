@@ -0,0 +1,50 @@
+package repository
+
+const (
+	createStatementQuery = `INSERT INTO statements (user_id, wallet_id, currency, format, period_from, period_to, locked_until)
+						VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING *`
+
+	getStatementQuery = `SELECT * FROM statements WHERE id = $1`
+
+	listStatementsQuery = `SELECT * FROM statements WHERE user_id = $1 ORDER BY created_at DESC, id DESC`
+)
+
+const (
+	// posted txs rows are signed and sum to the balance, rows without an entry
+	// (withdrawal holds and releases, pre-ledger history) do not move it
+	getOpeningBalanceQuery = `SELECT COALESCE(SUM(amount), 0) FROM txs
+						WHERE wallet_id = $1 AND currency = $2 AND created_at < $3 AND entry_id IS NOT NULL`
+
+	findStatementTxsQuery = `SELECT id, wallet_id, type, currency, amount, ref_id, meta, created_at
+						FROM txs
+						WHERE wallet_id = $1 AND currency = $2 AND created_at >= $3 AND created_at < $4
+						AND entry_id IS NOT NULL
+						ORDER BY created_at, id`
+)
+
+const (
+	// lease pending statements so concurrent generators skip them until the lease expires
+	claimStatementsQuery = `UPDATE statements SET locked_until = now() + make_interval(secs => $2)
+						WHERE id IN (
+							SELECT id FROM statements
+							WHERE status = 'pending'
+							AND (locked_until IS NULL OR locked_until <= now())
+							ORDER BY id LIMIT $1
+							FOR UPDATE SKIP LOCKED
+						)
+						RETURNING *`
+
+	completeStatementQuery = `UPDATE statements
+						SET status = 'completed', object_key = $2, opening_balance = $3, closing_balance = $4, tx_count = $5,
+						error = NULL, locked_until = NULL, completed_at = now(), updated_at = now()
+						WHERE id = $1 AND status = 'pending'
+						RETURNING *`
+
+	retryStatementQuery = `UPDATE statements
+						SET attempts = attempts + 1, error = $2, locked_until = $3, updated_at = now()
+						WHERE id = $1 AND status = 'pending'`
+
+	failStatementQuery = `UPDATE statements
+						SET status = 'failed', attempts = attempts + 1, error = $2, locked_until = NULL, updated_at = now()
+						WHERE id = $1 AND status = 'pending'`
+)
@@ -0,0 +1,16 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase_mock.go -package mock
+package statements
+
+import (
+	"context"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/dto"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+)
+
+// Statements UseCase interface
+type UseCase interface {
+	Create(ctx context.Context, request *dto.RequestStatement) (*models.Statement, error)
+	List(ctx context.Context) ([]*models.Statement, error)
+	Get(ctx context.Context, statementID int64) (*models.Statement, error)
+}
@@ -0,0 +1,217 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/config"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/currency"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/dto"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/rbac"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/statements"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/wallet"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/httpErrors"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/logger"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/utils"
+)
+
+const (
+	defaultSyncMaxDays  = 31
+	defaultMaxRangeDays = 366
+
+	// keeps the background generator off a statement rendered in the request
+	syncLease = 5 * time.Minute
+
+	// auditors read statements of wallets they do not own
+	permissionRead     = "read"
+	resourceStatements = "statements"
+)
+
+// Statements UseCase
+type statementsUC struct {
+	statementsRepo statements.Repository
+	walletRepo     wallet.Repository
+	generator      statements.Generator
+	currencyUC     currency.UseCase
+	rbac           rbac.RBACServiceInterface
+	syncRange      time.Duration
+	maxRange       time.Duration
+	logger         logger.Logger
+}
+
+// Statements UseCase constructor
+func NewStatementsUseCase(cfg *config.Config, statementsRepo statements.Repository, walletRepo wallet.Repository, generator statements.Generator, currencyUC currency.UseCase, rbacService rbac.RBACServiceInterface, log logger.Logger) statements.UseCase {
+	syncMaxDays := cfg.Statements.SyncMaxDays
+	if syncMaxDays <= 0 {
+		syncMaxDays = defaultSyncMaxDays
+	}
+	maxRangeDays := cfg.Statements.MaxRangeDays
+	if maxRangeDays <= 0 {
+		maxRangeDays = defaultMaxRangeDays
+	}
+
+	return &statementsUC{
+		statementsRepo: statementsRepo,
+		walletRepo:     walletRepo,
+		generator:      generator,
+		currencyUC:     currencyUC,
+		rbac:           rbacService,
+		syncRange:      time.Duration(syncMaxDays) * 24 * time.Hour,
+		maxRange:       time.Duration(maxRangeDays) * 24 * time.Hour,
+		logger:         log,
+	}
+}
+
+// Request a statement. Short ranges are rendered right away, longer ones are
+// left pending for the background generator, as is a short range whose
+// rendering failed.
+func (u *statementsUC) Create(ctx context.Context, request *dto.RequestStatement) (*models.Statement, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "statementsUC.Create")
+	defer span.Finish()
+
+	user, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	from, to := request.From.UTC(), request.To.UTC()
+	switch {
+	case !to.After(from):
+		return nil, httpErrors.NewBadRequestError("to must be after from")
+	case to.Sub(from) > u.maxRange:
+		return nil, httpErrors.NewBadRequestError("statement period is too long")
+	case !from.Before(time.Now()):
+		return nil, httpErrors.NewBadRequestError("from must be in the past")
+	}
+
+	code := strings.ToUpper(strings.TrimSpace(request.Currency))
+	if err := u.knownCurrency(ctx, code); err != nil {
+		return nil, err
+	}
+
+	w, err := u.walletRepo.GetByID(ctx, request.WalletID)
+	if err != nil {
+		return nil, err
+	}
+
+	if int(w.UserID) != user.User.ID {
+		if err := u.requireRead(user.User.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	s := &models.Statement{
+		UserID:     int64(user.User.ID),
+		WalletID:   w.ID,
+		Currency:   code,
+		Format:     request.Format,
+		PeriodFrom: from,
+		PeriodTo:   to,
+	}
+
+	sync := to.Sub(from) <= u.syncRange
+	if sync {
+		lockedUntil := time.Now().Add(syncLease)
+		s.LockedUntil = &lockedUntil
+	}
+
+	created, err := u.statementsRepo.Create(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+
+	if !sync {
+		u.logger.Infof("Statement %d of wallet %d queued by user %d", created.ID, w.ID, user.User.ID)
+		return created, nil
+	}
+
+	generated, err := u.generator.Generate(ctx, created)
+	if err != nil {
+		u.logger.Warnf("Statement %d left to the generator: %s", created.ID, err)
+		return created, nil
+	}
+
+	return u.withDownloadURL(ctx, generated)
+}
+
+// List the statements requested by the ctx user, newest first
+func (u *statementsUC) List(ctx context.Context) ([]*models.Statement, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "statementsUC.List")
+	defer span.Finish()
+
+	user, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.statementsRepo.List(ctx, int64(user.User.ID))
+}
+
+// Get a statement with a fresh download link once it is completed
+func (u *statementsUC) Get(ctx context.Context, statementID int64) (*models.Statement, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "statementsUC.Get")
+	defer span.Finish()
+
+	user, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := u.statementsRepo.GetByID(ctx, statementID)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.UserID != int64(user.User.ID) {
+		if err := u.requireRead(user.User.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	return u.withDownloadURL(ctx, s)
+}
+
+func (u *statementsUC) withDownloadURL(ctx context.Context, s *models.Statement) (*models.Statement, error) {
+	link, err := u.generator.DownloadURL(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+
+	s.DownloadURL = link
+	return s, nil
+}
+
+// Statements are available for every registered currency, disabled ones included
+func (u *statementsUC) knownCurrency(ctx context.Context, code string) error {
+	currencies, err := u.currencyUC.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range currencies {
+		if c.Code == code {
+			return nil
+		}
+	}
+
+	return currency.ErrUnknownCurrency
+}
+
+func (u *statementsUC) requireRead(userID int) error {
+	allowed, err := u.rbac.HasPermission(userID, permissionRead, resourceStatements, nil)
+	if err != nil {
+		return httpErrors.NewInternalServerError(errors.Wrap(err, "statementsUC.requireRead.HasPermission"))
+	}
+
+	if !allowed {
+		u.logger.Warnf("User %d denied %s on %s", userID, permissionRead, resourceStatements)
+		return statements.ErrStatementAccessDenied
+	}
+
+	return nil
+}
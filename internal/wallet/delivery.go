@@ -8,4 +8,43 @@ type Handlers interface {
 	ListWallet() echo.HandlerFunc
 	Deposit() echo.HandlerFunc
 	Transfer() echo.HandlerFunc
+	PreviewTransfer() echo.HandlerFunc
+	SendP2P() echo.HandlerFunc
+	PreviewP2P() echo.HandlerFunc
+	Withdraw() echo.HandlerFunc
+	SettleWithdrawal() echo.HandlerFunc
+	ReleaseWithdrawal() echo.HandlerFunc
+	ListTransactions() echo.HandlerFunc
+	RebuildBalances() echo.HandlerFunc
+	Freeze() echo.HandlerFunc
+	Unfreeze() echo.HandlerFunc
+	Close() echo.HandlerFunc
+	Archive() echo.HandlerFunc
+	ListStatusEvents() echo.HandlerFunc
+	ReverseTransfer() echo.HandlerFunc
+	ListReversals() echo.HandlerFunc
+	CreateEscrow() echo.HandlerFunc
+	ListEscrows() echo.HandlerFunc
+	GetEscrow() echo.HandlerFunc
+	ListEscrowEvents() echo.HandlerFunc
+	ReleaseEscrow() echo.HandlerFunc
+	CancelEscrow() echo.HandlerFunc
+	DisputeEscrow() echo.HandlerFunc
+	ResolveEscrow() echo.HandlerFunc
+	CreatePocket() echo.HandlerFunc
+	ListPockets() echo.HandlerFunc
+	UpdatePocketTarget() echo.HandlerFunc
+	MovePocketFunds() echo.HandlerFunc
+	BalancesAt() echo.HandlerFunc
+	ExportBalancesAt() echo.HandlerFunc
+	RequestAdjustment() echo.HandlerFunc
+	ListAdjustments() echo.HandlerFunc
+	GetAdjustment() echo.HandlerFunc
+	ApproveAdjustment() echo.HandlerFunc
+	RejectAdjustment() echo.HandlerFunc
+	CancelAdjustment() echo.HandlerFunc
+	ListRiskReviews() echo.HandlerFunc
+	GetRiskReview() echo.HandlerFunc
+	ApproveRiskReview() echo.HandlerFunc
+	RejectRiskReview() echo.HandlerFunc
 }
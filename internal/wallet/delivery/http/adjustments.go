@@ -0,0 +1,246 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/opentracing/opentracing-go"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/dto"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/httpErrors"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/utils"
+)
+
+// RequestAdjustment godoc
+// @Summary Request balance adjustment
+// @Description Propose a manual credit or debit with a reason and supporting documents, posted only once another user approves it
+// @Tags Wallet
+// @Accept json
+// @Produce json
+// @Param id path int true "wallet_id"
+// @Param body body dto.RequestAdjustment true "adjustment"
+// @Success 201 {object} models.Adjustment
+// @Router /wallets/{id}/adjustments [post]
+func (h *walletHandlers) RequestAdjustment() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "wallet.RequestAdjustment")
+		defer span.Finish()
+
+		walletID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(httpErrors.NewBadRequestError(err.Error())))
+		}
+
+		adjustmentRequest := &dto.RequestAdjustment{}
+		if err := utils.ReadRequest(c, adjustmentRequest); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		adjustment, err := h.walletUC.RequestAdjustment(ctx, walletID, adjustmentRequest)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusCreated, adjustment)
+	}
+}
+
+// ListAdjustments godoc
+// @Summary List balance adjustments
+// @Description List adjustments newest first, for the approval queue and audits
+// @Tags Wallet
+// @Produce json
+// @Param status query string false "pending, approved, rejected or cancelled"
+// @Param wallet_id query int false "only adjustments of this wallet"
+// @Param requested_by query int false "only adjustments requested by this user"
+// @Param limit query int false "at most 200, defaults to 50"
+// @Success 200 {array} models.Adjustment
+// @Router /wallets/adjustments [get]
+func (h *walletHandlers) ListAdjustments() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "wallet.ListAdjustments")
+		defer span.Finish()
+
+		filter, err := getAdjustmentFilter(c)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		adjustments, err := h.walletUC.ListAdjustments(ctx, filter)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, adjustments)
+	}
+}
+
+// GetAdjustment godoc
+// @Summary Get balance adjustment
+// @Description Get an adjustment with its review and the journal entry it posted
+// @Tags Wallet
+// @Produce json
+// @Param adjustmentID path int true "adjustment_id"
+// @Success 200 {object} models.Adjustment
+// @Router /wallets/adjustments/{adjustmentID} [get]
+func (h *walletHandlers) GetAdjustment() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "wallet.GetAdjustment")
+		defer span.Finish()
+
+		adjustmentID, err := strconv.ParseInt(c.Param("adjustmentID"), 10, 64)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(httpErrors.NewBadRequestError(err.Error())))
+		}
+
+		adjustment, err := h.walletUC.GetAdjustment(ctx, adjustmentID)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, adjustment)
+	}
+}
+
+// ApproveAdjustment godoc
+// @Summary Approve balance adjustment
+// @Description Approve a pending adjustment requested by another user and post it to the ledger
+// @Tags Wallet
+// @Accept json
+// @Produce json
+// @Param adjustmentID path int true "adjustment_id"
+// @Param body body dto.RequestAdjustmentReview false "review"
+// @Success 200 {object} models.Adjustment
+// @Router /wallets/adjustments/{adjustmentID}/approve [post]
+func (h *walletHandlers) ApproveAdjustment() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "wallet.ApproveAdjustment")
+		defer span.Finish()
+
+		adjustmentID, err := strconv.ParseInt(c.Param("adjustmentID"), 10, 64)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(httpErrors.NewBadRequestError(err.Error())))
+		}
+
+		reviewRequest := &dto.RequestAdjustmentReview{}
+		if err := utils.ReadRequest(c, reviewRequest); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		adjustment, err := h.walletUC.ApproveAdjustment(ctx, adjustmentID, reviewRequest)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, adjustment)
+	}
+}
+
+// RejectAdjustment godoc
+// @Summary Reject balance adjustment
+// @Description Reject a pending adjustment requested by another user, the note says why
+// @Tags Wallet
+// @Accept json
+// @Produce json
+// @Param adjustmentID path int true "adjustment_id"
+// @Param body body dto.RequestAdjustmentReview true "review"
+// @Success 200 {object} models.Adjustment
+// @Router /wallets/adjustments/{adjustmentID}/reject [post]
+func (h *walletHandlers) RejectAdjustment() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "wallet.RejectAdjustment")
+		defer span.Finish()
+
+		adjustmentID, err := strconv.ParseInt(c.Param("adjustmentID"), 10, 64)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(httpErrors.NewBadRequestError(err.Error())))
+		}
+
+		reviewRequest := &dto.RequestAdjustmentReview{}
+		if err := utils.ReadRequest(c, reviewRequest); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		adjustment, err := h.walletUC.RejectAdjustment(ctx, adjustmentID, reviewRequest)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, adjustment)
+	}
+}
+
+// CancelAdjustment godoc
+// @Summary Cancel balance adjustment
+// @Description Withdraw a pending adjustment, only its requester may
+// @Tags Wallet
+// @Produce json
+// @Param adjustmentID path int true "adjustment_id"
+// @Success 200 {object} models.Adjustment
+// @Router /wallets/adjustments/{adjustmentID}/cancel [post]
+func (h *walletHandlers) CancelAdjustment() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "wallet.CancelAdjustment")
+		defer span.Finish()
+
+		adjustmentID, err := strconv.ParseInt(c.Param("adjustmentID"), 10, 64)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(httpErrors.NewBadRequestError(err.Error())))
+		}
+
+		adjustment, err := h.walletUC.CancelAdjustment(ctx, adjustmentID)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, adjustment)
+	}
+}
+
+// Read the optional adjustment list filters
+func getAdjustmentFilter(c echo.Context) (*models.AdjustmentFilter, error) {
+	filter := &models.AdjustmentFilter{Status: c.QueryParam("status")}
+
+	switch filter.Status {
+	case "", models.AdjustmentPending, models.AdjustmentApproved, models.AdjustmentRejected, models.AdjustmentCancelled:
+	default:
+		return nil, httpErrors.NewBadRequestError("status must be pending, approved, rejected or cancelled")
+	}
+
+	for param, dst := range map[string]*int64{"wallet_id": &filter.WalletID, "requested_by": &filter.RequestedBy} {
+		if value := c.QueryParam(param); value != "" {
+			id, err := strconv.ParseInt(value, 10, 64)
+			if err != nil || id <= 0 {
+				return nil, httpErrors.NewBadRequestError(param + " must be a positive integer")
+			}
+			*dst = id
+		}
+	}
+
+	if value := c.QueryParam("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit <= 0 {
+			return nil, httpErrors.NewBadRequestError("limit must be a positive integer")
+		}
+		filter.Limit = limit
+	}
+
+	return filter, nil
+}
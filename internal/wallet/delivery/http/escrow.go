@@ -0,0 +1,263 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/opentracing/opentracing-go"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/dto"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/httpErrors"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/utils"
+)
+
+// CreateEscrow godoc
+// @Summary Open escrow
+// @Description Move funds from a wallet of the caller into escrow for a seller wallet
+// @Tags Wallet
+// @Accept json
+// @Produce json
+// @Param body body dto.RequestEscrow true "escrow"
+// @Success 201 {object} models.Escrow
+// @Router /wallets/escrows [post]
+func (h *walletHandlers) CreateEscrow() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "wallet.CreateEscrow")
+		defer span.Finish()
+
+		escrowRequest := &dto.RequestEscrow{}
+		if err := utils.ReadRequest(c, escrowRequest); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		escrow, err := h.walletUC.CreateEscrow(ctx, escrowRequest)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusCreated, escrow)
+	}
+}
+
+// ListEscrows godoc
+// @Summary List escrows
+// @Description List the escrows the caller is buyer or seller in, newest first
+// @Tags Wallet
+// @Produce json
+// @Success 200 {array} models.Escrow
+// @Router /wallets/escrows [get]
+func (h *walletHandlers) ListEscrows() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "wallet.ListEscrows")
+		defer span.Finish()
+
+		escrows, err := h.walletUC.ListEscrows(ctx)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, escrows)
+	}
+}
+
+// GetEscrow godoc
+// @Summary Get escrow
+// @Description Get an escrow the caller is buyer or seller in
+// @Tags Wallet
+// @Produce json
+// @Param escrowID path int true "escrow_id"
+// @Success 200 {object} models.Escrow
+// @Router /wallets/escrows/{escrowID} [get]
+func (h *walletHandlers) GetEscrow() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "wallet.GetEscrow")
+		defer span.Finish()
+
+		escrowID, err := strconv.ParseInt(c.Param("escrowID"), 10, 64)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(httpErrors.NewBadRequestError(err.Error())))
+		}
+
+		escrow, err := h.walletUC.GetEscrow(ctx, escrowID)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, escrow)
+	}
+}
+
+// ListEscrowEvents godoc
+// @Summary Escrow history
+// @Description List the state changes of an escrow with the journal entries that moved the funds
+// @Tags Wallet
+// @Produce json
+// @Param escrowID path int true "escrow_id"
+// @Success 200 {array} models.EscrowEvent
+// @Router /wallets/escrows/{escrowID}/events [get]
+func (h *walletHandlers) ListEscrowEvents() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "wallet.ListEscrowEvents")
+		defer span.Finish()
+
+		escrowID, err := strconv.ParseInt(c.Param("escrowID"), 10, 64)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(httpErrors.NewBadRequestError(err.Error())))
+		}
+
+		events, err := h.walletUC.ListEscrowEvents(ctx, escrowID)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, events)
+	}
+}
+
+// ReleaseEscrow godoc
+// @Summary Release escrow
+// @Description Buyer confirms delivery and the held funds go to the seller
+// @Tags Wallet
+// @Produce json
+// @Param escrowID path int true "escrow_id"
+// @Success 200 {object} models.Escrow
+// @Router /wallets/escrows/{escrowID}/release [post]
+func (h *walletHandlers) ReleaseEscrow() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "wallet.ReleaseEscrow")
+		defer span.Finish()
+
+		escrowID, err := strconv.ParseInt(c.Param("escrowID"), 10, 64)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(httpErrors.NewBadRequestError(err.Error())))
+		}
+
+		escrow, err := h.walletUC.ReleaseEscrow(ctx, escrowID)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, escrow)
+	}
+}
+
+// CancelEscrow godoc
+// @Summary Cancel escrow
+// @Description Seller cancels the sale and the held funds are refunded to the buyer
+// @Tags Wallet
+// @Accept json
+// @Produce json
+// @Param escrowID path int true "escrow_id"
+// @Param body body dto.RequestEscrowAction true "reason"
+// @Success 200 {object} models.Escrow
+// @Router /wallets/escrows/{escrowID}/cancel [post]
+func (h *walletHandlers) CancelEscrow() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "wallet.CancelEscrow")
+		defer span.Finish()
+
+		escrowID, err := strconv.ParseInt(c.Param("escrowID"), 10, 64)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(httpErrors.NewBadRequestError(err.Error())))
+		}
+
+		actionRequest := &dto.RequestEscrowAction{}
+		if err := utils.ReadRequest(c, actionRequest); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		escrow, err := h.walletUC.CancelEscrow(ctx, escrowID, actionRequest)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, escrow)
+	}
+}
+
+// DisputeEscrow godoc
+// @Summary Dispute escrow
+// @Description Buyer or seller freezes a held escrow until an admin resolves it
+// @Tags Wallet
+// @Accept json
+// @Produce json
+// @Param escrowID path int true "escrow_id"
+// @Param body body dto.RequestEscrowAction true "reason"
+// @Success 200 {object} models.Escrow
+// @Router /wallets/escrows/{escrowID}/dispute [post]
+func (h *walletHandlers) DisputeEscrow() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "wallet.DisputeEscrow")
+		defer span.Finish()
+
+		escrowID, err := strconv.ParseInt(c.Param("escrowID"), 10, 64)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(httpErrors.NewBadRequestError(err.Error())))
+		}
+
+		actionRequest := &dto.RequestEscrowAction{}
+		if err := utils.ReadRequest(c, actionRequest); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		escrow, err := h.walletUC.DisputeEscrow(ctx, escrowID, actionRequest)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, escrow)
+	}
+}
+
+// ResolveEscrow godoc
+// @Summary Resolve escrow dispute
+// @Description Release a disputed escrow to the seller or refund it to the buyer
+// @Tags Wallet
+// @Accept json
+// @Produce json
+// @Param escrowID path int true "escrow_id"
+// @Param body body dto.RequestEscrowResolution true "resolution"
+// @Success 200 {object} models.Escrow
+// @Router /wallets/escrows/{escrowID}/resolve [post]
+func (h *walletHandlers) ResolveEscrow() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "wallet.ResolveEscrow")
+		defer span.Finish()
+
+		escrowID, err := strconv.ParseInt(c.Param("escrowID"), 10, 64)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(httpErrors.NewBadRequestError(err.Error())))
+		}
+
+		resolutionRequest := &dto.RequestEscrowResolution{}
+		if err := utils.ReadRequest(c, resolutionRequest); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		escrow, err := h.walletUC.ResolveEscrow(ctx, escrowID, resolutionRequest)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, escrow)
+	}
+}
@@ -1,7 +1,10 @@
 package http
 
 import (
+	"context"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/aditwar-man/go-microservice-boilerplate/config"
 	"github.com/aditwar-man/go-microservice-boilerplate/internal/dto"
@@ -74,13 +77,19 @@ func (h *walletHandlers) ListWallet() echo.HandlerFunc {
 		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "wallet.List")
 		defer span.Finish()
 
+		userID, err := strconv.Atoi(c.Param("userID"))
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(httpErrors.NewBadRequestError(err.Error())))
+		}
+
 		paginationQuery, err := utils.GetPaginationFromCtx(c)
 		if err != nil {
 			utils.LogResponseError(c, h.logger, err)
 			return c.JSON(httpErrors.ErrorResponse(err))
 		}
 
-		createdWallet, err := h.walletUC.ListWallet(ctx, paginationQuery)
+		createdWallet, err := h.walletUC.ListWallet(ctx, userID, paginationQuery)
 		if err != nil {
 			utils.LogResponseError(c, h.logger, err)
 			return c.JSON(httpErrors.ErrorResponse(err))
@@ -103,11 +112,18 @@ func (h *walletHandlers) Deposit() echo.HandlerFunc {
 		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "wallet.List")
 		defer span.Finish()
 
+		walletID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(httpErrors.NewBadRequestError(err.Error())))
+		}
+
 		depositRequest := &dto.RequestDeposit{}
 		if err := utils.ReadRequest(c, depositRequest); err != nil {
 			utils.LogResponseError(c, h.logger, err)
 			return c.JSON(httpErrors.ErrorResponse(err))
 		}
+		depositRequest.WalletID = uint(walletID)
 
 		createdDeposit, err := h.walletUC.Deposit(ctx, depositRequest)
 		if err != nil {
@@ -147,3 +163,428 @@ func (h *walletHandlers) Transfer() echo.HandlerFunc {
 		return c.JSON(http.StatusOK, createdTransfer)
 	}
 }
+
+// PreviewTransfer godoc
+// @Summary Preview transfer
+// @Description Price a transfer with its fee and conversion without moving funds
+// @Tags Wallet
+// @Accept json
+// @Produce json
+// @Param body body dto.RequestTransfer true "transfer"
+// @Success 200 {object} dto.TransferPreview
+// @Router /wallets/transfer/preview [post]
+func (h *walletHandlers) PreviewTransfer() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "wallet.PreviewTransfer")
+		defer span.Finish()
+
+		transferRequest := &dto.RequestTransfer{}
+		if err := utils.ReadRequest(c, transferRequest); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		preview, err := h.walletUC.PreviewTransfer(ctx, transferRequest)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, preview)
+	}
+}
+
+// Withdraw godoc
+// @Summary Create withdrawal
+// @Description Place a hold on the wallet balance, returns withdrawal
+// @Tags Wallet
+// @Accept json
+// @Produce json
+// @Param id path int true "wallet_id"
+// @Success 201 {object} models.Withdrawal
+// @Router /wallets/{id}/withdrawals [post]
+func (h *walletHandlers) Withdraw() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "wallet.Withdraw")
+		defer span.Finish()
+
+		walletID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(httpErrors.NewBadRequestError(err.Error())))
+		}
+
+		withdrawRequest := &dto.RequestWithdraw{}
+		if err := utils.ReadRequest(c, withdrawRequest); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+		withdrawRequest.WalletID = uint(walletID)
+
+		withdrawal, err := h.walletUC.Withdraw(ctx, withdrawRequest)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusCreated, withdrawal)
+	}
+}
+
+// SettleWithdrawal godoc
+// @Summary Settle withdrawal
+// @Description Settle a held withdrawal, debiting the wallet balance
+// @Tags Wallet
+// @Produce json
+// @Param id path int true "wallet_id"
+// @Param withdrawalID path int true "withdrawal_id"
+// @Success 200 {object} models.Withdrawal
+// @Router /wallets/{id}/withdrawals/{withdrawalID}/settle [post]
+func (h *walletHandlers) SettleWithdrawal() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "wallet.SettleWithdrawal")
+		defer span.Finish()
+
+		walletID, withdrawalID, err := getWithdrawalParams(c)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		withdrawal, err := h.walletUC.SettleWithdrawal(ctx, walletID, withdrawalID)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, withdrawal)
+	}
+}
+
+// ReleaseWithdrawal godoc
+// @Summary Release withdrawal
+// @Description Release a held withdrawal, returning funds to the available balance
+// @Tags Wallet
+// @Produce json
+// @Param id path int true "wallet_id"
+// @Param withdrawalID path int true "withdrawal_id"
+// @Success 200 {object} models.Withdrawal
+// @Router /wallets/{id}/withdrawals/{withdrawalID}/release [post]
+func (h *walletHandlers) ReleaseWithdrawal() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "wallet.ReleaseWithdrawal")
+		defer span.Finish()
+
+		walletID, withdrawalID, err := getWithdrawalParams(c)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		withdrawal, err := h.walletUC.ReleaseWithdrawal(ctx, walletID, withdrawalID)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, withdrawal)
+	}
+}
+
+// ListTransactions godoc
+// @Summary List wallet transactions
+// @Description Wallet ledger history, newest first, with cursor pagination
+// @Tags Wallet
+// @Produce json
+// @Param id path int true "wallet_id"
+// @Param type query string false "transaction type"
+// @Param currency query string false "currency"
+// @Param ref_id query string false "reference id"
+// @Param from query string false "RFC3339 start time (inclusive)"
+// @Param to query string false "RFC3339 end time (exclusive)"
+// @Param cursor query string false "next_cursor from previous page"
+// @Param size query int false "page size, max 100"
+// @Success 200 {object} dto.TransactionList
+// @Router /wallets/{id}/transactions [get]
+func (h *walletHandlers) ListTransactions() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "wallet.ListTransactions")
+		defer span.Finish()
+
+		filter, err := getTransactionFilter(c)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		transactions, err := h.walletUC.ListTransactions(ctx, filter)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, transactions)
+	}
+}
+
+// RebuildBalances godoc
+// @Summary Rebuild wallet balances
+// @Description Recompute wallet balances from ledger postings, returns balances
+// @Tags Wallet
+// @Produce json
+// @Param id path int true "wallet_id"
+// @Success 200 {array} models.WalletBalance
+// @Router /wallets/{id}/balances/rebuild [post]
+func (h *walletHandlers) RebuildBalances() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "wallet.RebuildBalances")
+		defer span.Finish()
+
+		walletID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(httpErrors.NewBadRequestError(err.Error())))
+		}
+
+		balances, err := h.walletUC.RebuildBalances(ctx, walletID)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, balances)
+	}
+}
+
+// Freeze godoc
+// @Summary Freeze wallet
+// @Description Freeze an active wallet so it cannot send or receive, returns wallet
+// @Tags Wallet
+// @Accept json
+// @Produce json
+// @Param id path int true "wallet_id"
+// @Param body body dto.RequestWalletStatus true "reason"
+// @Success 200 {object} models.Wallet
+// @Router /wallets/{id}/freeze [post]
+func (h *walletHandlers) Freeze() echo.HandlerFunc {
+	return h.changeStatus("wallet.Freeze", h.walletUC.Freeze)
+}
+
+// Unfreeze godoc
+// @Summary Unfreeze wallet
+// @Description Return a frozen wallet to active, returns wallet
+// @Tags Wallet
+// @Accept json
+// @Produce json
+// @Param id path int true "wallet_id"
+// @Param body body dto.RequestWalletStatus true "reason"
+// @Success 200 {object} models.Wallet
+// @Router /wallets/{id}/unfreeze [post]
+func (h *walletHandlers) Unfreeze() echo.HandlerFunc {
+	return h.changeStatus("wallet.Unfreeze", h.walletUC.Unfreeze)
+}
+
+// Close godoc
+// @Summary Close wallet
+// @Description Close a wallet with zero balances, returns wallet
+// @Tags Wallet
+// @Accept json
+// @Produce json
+// @Param id path int true "wallet_id"
+// @Param body body dto.RequestWalletStatus true "reason"
+// @Success 200 {object} models.Wallet
+// @Router /wallets/{id}/close [post]
+func (h *walletHandlers) Close() echo.HandlerFunc {
+	return h.changeStatus("wallet.Close", h.walletUC.Close)
+}
+
+// Archive godoc
+// @Summary Archive wallet
+// @Description Archive a closed wallet, returns wallet
+// @Tags Wallet
+// @Accept json
+// @Produce json
+// @Param id path int true "wallet_id"
+// @Param body body dto.RequestWalletStatus true "reason"
+// @Success 200 {object} models.Wallet
+// @Router /wallets/{id}/archive [post]
+func (h *walletHandlers) Archive() echo.HandlerFunc {
+	return h.changeStatus("wallet.Archive", h.walletUC.Archive)
+}
+
+// ListStatusEvents godoc
+// @Summary Wallet status history
+// @Description List wallet state changes with reason and actor, newest first
+// @Tags Wallet
+// @Produce json
+// @Param id path int true "wallet_id"
+// @Success 200 {array} models.WalletStatusEvent
+// @Router /wallets/{id}/status-events [get]
+func (h *walletHandlers) ListStatusEvents() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "wallet.ListStatusEvents")
+		defer span.Finish()
+
+		walletID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(httpErrors.NewBadRequestError(err.Error())))
+		}
+
+		events, err := h.walletUC.ListStatusEvents(ctx, walletID)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, events)
+	}
+}
+
+// ReverseTransfer godoc
+// @Summary Reverse transfer
+// @Description Reverse a posted transfer in full or in part at its original rate, requires approve on wallets
+// @Tags Wallet
+// @Accept json
+// @Produce json
+// @Param refID path string true "ref_id of the transfer"
+// @Param body body dto.RequestReversal true "amount, refund_fee, reason"
+// @Success 201 {object} models.Reversal
+// @Router /wallets/transfers/{refID}/reversals [post]
+func (h *walletHandlers) ReverseTransfer() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "wallet.ReverseTransfer")
+		defer span.Finish()
+
+		reversalRequest := &dto.RequestReversal{}
+		if err := utils.ReadRequest(c, reversalRequest); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		reversal, err := h.walletUC.ReverseTransfer(ctx, c.Param("refID"), reversalRequest)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusCreated, reversal)
+	}
+}
+
+// ListReversals godoc
+// @Summary Transfer reversals
+// @Description List the reversals of a transfer, oldest first
+// @Tags Wallet
+// @Produce json
+// @Param refID path string true "ref_id of the transfer"
+// @Success 200 {array} models.Reversal
+// @Router /wallets/transfers/{refID}/reversals [get]
+func (h *walletHandlers) ListReversals() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "wallet.ListReversals")
+		defer span.Finish()
+
+		reversals, err := h.walletUC.ListReversals(ctx, c.Param("refID"))
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, reversals)
+	}
+}
+
+// Shared handler for wallet state changes
+func (h *walletHandlers) changeStatus(
+	operation string,
+	change func(ctx context.Context, walletID int64, dto *dto.RequestWalletStatus) (*models.Wallet, error),
+) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), operation)
+		defer span.Finish()
+
+		walletID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(httpErrors.NewBadRequestError(err.Error())))
+		}
+
+		statusRequest := &dto.RequestWalletStatus{}
+		if err := utils.ReadRequest(c, statusRequest); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		updated, err := change(ctx, walletID, statusRequest)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, updated)
+	}
+}
+
+// Read transaction history filter from path and query params
+func getTransactionFilter(c echo.Context) (*models.TransactionFilter, error) {
+	walletID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return nil, httpErrors.NewBadRequestError(err.Error())
+	}
+
+	size, err := utils.GetCursorSize(c.QueryParam("size"))
+	if err != nil {
+		return nil, httpErrors.NewBadRequestError(err.Error())
+	}
+
+	filter := &models.TransactionFilter{
+		WalletID: walletID,
+		Type:     c.QueryParam("type"),
+		Currency: c.QueryParam("currency"),
+		RefID:    c.QueryParam("ref_id"),
+		Limit:    size,
+	}
+
+	if from := c.QueryParam("from"); from != "" {
+		t, err := time.Parse(time.RFC3339, from)
+		if err != nil {
+			return nil, httpErrors.NewBadRequestError(err.Error())
+		}
+		filter.From = &t
+	}
+
+	if to := c.QueryParam("to"); to != "" {
+		t, err := time.Parse(time.RFC3339, to)
+		if err != nil {
+			return nil, httpErrors.NewBadRequestError(err.Error())
+		}
+		filter.To = &t
+	}
+
+	cursor, err := utils.DecodeCursor(c.QueryParam("cursor"))
+	if err != nil {
+		return nil, httpErrors.NewBadRequestError(err.Error())
+	}
+	if cursor != nil {
+		filter.BeforeCreatedAt = &cursor.CreatedAt
+		filter.BeforeID = cursor.ID
+	}
+
+	return filter, nil
+}
+
+// Read wallet and withdrawal ids from path params
+func getWithdrawalParams(c echo.Context) (int64, int64, error) {
+	walletID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return 0, 0, httpErrors.NewBadRequestError(err.Error())
+	}
+
+	withdrawalID, err := strconv.ParseInt(c.Param("withdrawalID"), 10, 64)
+	if err != nil {
+		return 0, 0, httpErrors.NewBadRequestError(err.Error())
+	}
+
+	return walletID, withdrawalID, nil
+}
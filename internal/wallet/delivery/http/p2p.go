@@ -0,0 +1,72 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/opentracing/opentracing-go"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/dto"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/httpErrors"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/utils"
+)
+
+// SendP2P godoc
+// @Summary Send to user
+// @Description Transfer to the default wallet of a user found by username or email
+// @Tags Wallet
+// @Accept json
+// @Produce json
+// @Param body body dto.RequestP2PTransfer true "p2p transfer"
+// @Success 200 {object} dto.P2PTransfer
+// @Router /wallets/p2p [post]
+func (h *walletHandlers) SendP2P() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "wallet.SendP2P")
+		defer span.Finish()
+
+		p2pRequest := &dto.RequestP2PTransfer{}
+		if err := utils.ReadRequest(c, p2pRequest); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		sent, err := h.walletUC.SendP2P(ctx, p2pRequest)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, sent)
+	}
+}
+
+// PreviewP2P godoc
+// @Summary Preview send to user
+// @Description Price a transfer to a user found by username or email and show the masked recipient name for confirmation
+// @Tags Wallet
+// @Accept json
+// @Produce json
+// @Param body body dto.RequestP2PTransfer true "p2p transfer"
+// @Success 200 {object} dto.P2PTransfer
+// @Router /wallets/p2p/preview [post]
+func (h *walletHandlers) PreviewP2P() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "wallet.PreviewP2P")
+		defer span.Finish()
+
+		p2pRequest := &dto.RequestP2PTransfer{}
+		if err := utils.ReadRequest(c, p2pRequest); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		preview, err := h.walletUC.PreviewP2P(ctx, p2pRequest)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, preview)
+	}
+}
@@ -0,0 +1,160 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/opentracing/opentracing-go"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/dto"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/httpErrors"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/utils"
+)
+
+// CreatePocket godoc
+// @Summary Create pocket
+// @Description Create a pocket under a wallet, with an optional savings goal
+// @Tags Wallet
+// @Accept json
+// @Produce json
+// @Param id path int true "wallet_id"
+// @Param body body dto.RequestCreatePocket true "pocket"
+// @Success 201 {object} models.Pocket
+// @Router /wallets/{id}/pockets [post]
+func (h *walletHandlers) CreatePocket() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "wallet.CreatePocket")
+		defer span.Finish()
+
+		walletID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(httpErrors.NewBadRequestError(err.Error())))
+		}
+
+		pocketRequest := &dto.RequestCreatePocket{}
+		if err := utils.ReadRequest(c, pocketRequest); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		pocket, err := h.walletUC.CreatePocket(ctx, walletID, pocketRequest)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusCreated, pocket)
+	}
+}
+
+// ListPockets godoc
+// @Summary List pockets
+// @Description List the pockets of a wallet with their balance and progress towards the goal
+// @Tags Wallet
+// @Produce json
+// @Param id path int true "wallet_id"
+// @Success 200 {array} models.Pocket
+// @Router /wallets/{id}/pockets [get]
+func (h *walletHandlers) ListPockets() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "wallet.ListPockets")
+		defer span.Finish()
+
+		walletID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(httpErrors.NewBadRequestError(err.Error())))
+		}
+
+		pockets, err := h.walletUC.ListPockets(ctx, walletID)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, pockets)
+	}
+}
+
+// UpdatePocketTarget godoc
+// @Summary Update pocket goal
+// @Description Set the target amount and date of a pocket, omitted fields clear them
+// @Tags Wallet
+// @Accept json
+// @Produce json
+// @Param id path int true "wallet_id"
+// @Param pocketID path int true "pocket_id"
+// @Param body body dto.RequestPocketTarget true "goal"
+// @Success 200 {object} models.Pocket
+// @Router /wallets/{id}/pockets/{pocketID}/target [put]
+func (h *walletHandlers) UpdatePocketTarget() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "wallet.UpdatePocketTarget")
+		defer span.Finish()
+
+		walletID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(httpErrors.NewBadRequestError(err.Error())))
+		}
+
+		pocketID, err := strconv.ParseInt(c.Param("pocketID"), 10, 64)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(httpErrors.NewBadRequestError(err.Error())))
+		}
+
+		targetRequest := &dto.RequestPocketTarget{}
+		if err := utils.ReadRequest(c, targetRequest); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		pocket, err := h.walletUC.UpdatePocketTarget(ctx, walletID, pocketID, targetRequest)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, pocket)
+	}
+}
+
+// MovePocketFunds godoc
+// @Summary Move pocket funds
+// @Description Move funds between a wallet and its pockets without fees, returns the pockets
+// @Tags Wallet
+// @Accept json
+// @Produce json
+// @Param id path int true "wallet_id"
+// @Param body body dto.RequestPocketMove true "move"
+// @Success 200 {array} models.Pocket
+// @Router /wallets/{id}/pockets/moves [post]
+func (h *walletHandlers) MovePocketFunds() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "wallet.MovePocketFunds")
+		defer span.Finish()
+
+		walletID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(httpErrors.NewBadRequestError(err.Error())))
+		}
+
+		moveRequest := &dto.RequestPocketMove{}
+		if err := utils.ReadRequest(c, moveRequest); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		pockets, err := h.walletUC.MovePocketFunds(ctx, walletID, moveRequest)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, pockets)
+	}
+}
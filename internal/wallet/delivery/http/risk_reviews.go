@@ -0,0 +1,180 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/opentracing/opentracing-go"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/dto"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/httpErrors"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/utils"
+)
+
+// ListRiskReviews godoc
+// @Summary List risk reviews
+// @Description List deposits and transfers held by the risk rules oldest first, the admin review queue
+// @Tags Wallet
+// @Produce json
+// @Param status query string false "pending, approved or rejected"
+// @Param wallet_id query int false "only reviews of this sending or receiving wallet"
+// @Param user_id query int false "only reviews of this wallet owner"
+// @Param limit query int false "at most 200, defaults to 50"
+// @Success 200 {array} models.RiskReview
+// @Router /wallets/reviews [get]
+func (h *walletHandlers) ListRiskReviews() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "wallet.ListRiskReviews")
+		defer span.Finish()
+
+		filter, err := getRiskReviewFilter(c)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		reviews, err := h.walletUC.ListRiskReviews(ctx, filter)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, reviews)
+	}
+}
+
+// GetRiskReview godoc
+// @Summary Get risk review
+// @Description Get a held operation with the rules that flagged it and its decision
+// @Tags Wallet
+// @Produce json
+// @Param reviewID path int true "review_id"
+// @Success 200 {object} models.RiskReview
+// @Router /wallets/reviews/{reviewID} [get]
+func (h *walletHandlers) GetRiskReview() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "wallet.GetRiskReview")
+		defer span.Finish()
+
+		reviewID, err := strconv.ParseInt(c.Param("reviewID"), 10, 64)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(httpErrors.NewBadRequestError(err.Error())))
+		}
+
+		review, err := h.walletUC.GetRiskReview(ctx, reviewID)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, review)
+	}
+}
+
+// ApproveRiskReview godoc
+// @Summary Approve risk review
+// @Description Release a held deposit or transfer and post it, limits are checked again at approval
+// @Tags Wallet
+// @Accept json
+// @Produce json
+// @Param reviewID path int true "review_id"
+// @Param body body dto.RequestRiskReview false "review"
+// @Success 200 {object} models.RiskReview
+// @Router /wallets/reviews/{reviewID}/approve [post]
+func (h *walletHandlers) ApproveRiskReview() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "wallet.ApproveRiskReview")
+		defer span.Finish()
+
+		reviewID, err := strconv.ParseInt(c.Param("reviewID"), 10, 64)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(httpErrors.NewBadRequestError(err.Error())))
+		}
+
+		reviewRequest := &dto.RequestRiskReview{}
+		if err := utils.ReadRequest(c, reviewRequest); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		review, err := h.walletUC.ApproveRiskReview(ctx, reviewID, reviewRequest)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, review)
+	}
+}
+
+// RejectRiskReview godoc
+// @Summary Reject risk review
+// @Description Cancel a held deposit or transfer and release its hold, the note says why
+// @Tags Wallet
+// @Accept json
+// @Produce json
+// @Param reviewID path int true "review_id"
+// @Param body body dto.RequestRiskReview true "review"
+// @Success 200 {object} models.RiskReview
+// @Router /wallets/reviews/{reviewID}/reject [post]
+func (h *walletHandlers) RejectRiskReview() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "wallet.RejectRiskReview")
+		defer span.Finish()
+
+		reviewID, err := strconv.ParseInt(c.Param("reviewID"), 10, 64)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(httpErrors.NewBadRequestError(err.Error())))
+		}
+
+		reviewRequest := &dto.RequestRiskReview{}
+		if err := utils.ReadRequest(c, reviewRequest); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		review, err := h.walletUC.RejectRiskReview(ctx, reviewID, reviewRequest)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, review)
+	}
+}
+
+// Read the optional risk review list filters
+func getRiskReviewFilter(c echo.Context) (*models.RiskReviewFilter, error) {
+	filter := &models.RiskReviewFilter{Status: c.QueryParam("status")}
+
+	switch filter.Status {
+	case "", models.RiskReviewPending, models.RiskReviewApproved, models.RiskReviewRejected:
+	default:
+		return nil, httpErrors.NewBadRequestError("status must be pending, approved or rejected")
+	}
+
+	for param, dst := range map[string]*int64{"wallet_id": &filter.WalletID, "user_id": &filter.UserID} {
+		if value := c.QueryParam(param); value != "" {
+			id, err := strconv.ParseInt(value, 10, 64)
+			if err != nil || id <= 0 {
+				return nil, httpErrors.NewBadRequestError(param + " must be a positive integer")
+			}
+			*dst = id
+		}
+	}
+
+	if value := c.QueryParam("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit <= 0 {
+			return nil, httpErrors.NewBadRequestError("limit must be a positive integer")
+		}
+		filter.Limit = limit
+	}
+
+	return filter, nil
+}
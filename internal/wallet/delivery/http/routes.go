@@ -10,13 +10,72 @@ import (
 )
 
 // Map auth routes
-func MapWalletRoutes(walletGroup *echo.Group, h wallet.Handlers, mw *middleware.MiddlewareManager, authUc auth.UseCase, walletUC wallet.UseCase, cfg *config.Config) {
+func MapWalletRoutes(walletGroup *echo.Group, h wallet.Handlers, mw *middleware.MiddlewareManager, rbacMw *middleware.RBACMiddleware, idemMw *middleware.IdempotencyMiddleware, authUc auth.UseCase, walletUC wallet.UseCase, cfg *config.Config) {
 	walletGroup.Use(mw.AuthJWTMiddleware(authUc, cfg))
 	walletGroup.Use(mw.AuthSessionMiddleware)
 
 	// wallets
 	walletGroup.POST("/", h.Create())
 	walletGroup.GET("/:userID", h.ListWallet())
-	walletGroup.POST("/:id/deposit", h.Deposit())
-	walletGroup.POST("/transfer", h.Transfer())
+	walletGroup.POST("/:id/deposit", h.Deposit(), idemMw.Idempotent)
+	walletGroup.POST("/transfer", h.Transfer(), idemMw.Idempotent)
+	walletGroup.POST("/transfer/preview", h.PreviewTransfer())
+
+	// peer-to-peer by username or email
+	walletGroup.POST("/p2p", h.SendP2P(), idemMw.Idempotent)
+	walletGroup.POST("/p2p/preview", h.PreviewP2P())
+
+	// ledger
+	walletGroup.GET("/:id/transactions", h.ListTransactions())
+	walletGroup.POST("/:id/balances/rebuild", h.RebuildBalances(), rbacMw.RequirePermission("manage", "wallets", nil))
+	walletGroup.GET("/:id/balances", h.BalancesAt())
+	walletGroup.GET("/balances/export", h.ExportBalancesAt(), rbacMw.RequirePermission("manage", "wallets", nil))
+
+	// lifecycle, compliance only
+	manageWallets := rbacMw.RequirePermission("manage", "wallets", nil)
+	walletGroup.POST("/:id/freeze", h.Freeze(), manageWallets)
+	walletGroup.POST("/:id/unfreeze", h.Unfreeze(), manageWallets)
+	walletGroup.POST("/:id/close", h.Close(), manageWallets)
+	walletGroup.POST("/:id/archive", h.Archive(), manageWallets)
+	walletGroup.GET("/:id/status-events", h.ListStatusEvents(), manageWallets)
+
+	// reversals, approvers only
+	approveWallets := rbacMw.RequirePermission("approve", "wallets", nil)
+	walletGroup.POST("/transfers/:refID/reversals", h.ReverseTransfer(), approveWallets, idemMw.Idempotent)
+	walletGroup.GET("/transfers/:refID/reversals", h.ListReversals(), approveWallets)
+
+	// manual balance adjustments, proposed by admins and posted once a different approver accepts them
+	walletGroup.POST("/:id/adjustments", h.RequestAdjustment(), manageWallets)
+	walletGroup.GET("/adjustments", h.ListAdjustments(), manageWallets)
+	walletGroup.GET("/adjustments/:adjustmentID", h.GetAdjustment(), manageWallets)
+	walletGroup.POST("/adjustments/:adjustmentID/approve", h.ApproveAdjustment(), approveWallets)
+	walletGroup.POST("/adjustments/:adjustmentID/reject", h.RejectAdjustment(), approveWallets)
+	walletGroup.POST("/adjustments/:adjustmentID/cancel", h.CancelAdjustment(), manageWallets)
+
+	// deposits and transfers held by the risk rules, released or cancelled by approvers
+	walletGroup.GET("/reviews", h.ListRiskReviews(), manageWallets)
+	walletGroup.GET("/reviews/:reviewID", h.GetRiskReview(), manageWallets)
+	walletGroup.POST("/reviews/:reviewID/approve", h.ApproveRiskReview(), approveWallets)
+	walletGroup.POST("/reviews/:reviewID/reject", h.RejectRiskReview(), approveWallets)
+
+	// escrow between two wallets, disputes are resolved by approvers
+	walletGroup.GET("/escrows", h.ListEscrows())
+	walletGroup.POST("/escrows", h.CreateEscrow(), idemMw.Idempotent)
+	walletGroup.GET("/escrows/:escrowID", h.GetEscrow())
+	walletGroup.GET("/escrows/:escrowID/events", h.ListEscrowEvents())
+	walletGroup.POST("/escrows/:escrowID/release", h.ReleaseEscrow(), idemMw.Idempotent)
+	walletGroup.POST("/escrows/:escrowID/cancel", h.CancelEscrow(), idemMw.Idempotent)
+	walletGroup.POST("/escrows/:escrowID/dispute", h.DisputeEscrow())
+	walletGroup.POST("/escrows/:escrowID/resolve", h.ResolveEscrow(), approveWallets, idemMw.Idempotent)
+
+	// pockets and savings goals, moves are free and skip limits
+	walletGroup.GET("/:id/pockets", h.ListPockets())
+	walletGroup.POST("/:id/pockets", h.CreatePocket())
+	walletGroup.PUT("/:id/pockets/:pocketID/target", h.UpdatePocketTarget())
+	walletGroup.POST("/:id/pockets/moves", h.MovePocketFunds(), idemMw.Idempotent)
+
+	// withdrawals
+	walletGroup.POST("/:id/withdrawals", h.Withdraw(), idemMw.Idempotent)
+	walletGroup.POST("/:id/withdrawals/:withdrawalID/settle", h.SettleWithdrawal(), rbacMw.RequirePermission("manage", "wallets", nil), idemMw.Idempotent)
+	walletGroup.POST("/:id/withdrawals/:withdrawalID/release", h.ReleaseWithdrawal(), idemMw.Idempotent)
 }
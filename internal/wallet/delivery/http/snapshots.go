@@ -0,0 +1,130 @@
+package http
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/opentracing/opentracing-go"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/httpErrors"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/utils"
+)
+
+var balanceExportColumns = []string{"wallet_id", "user_id", "currency", "amount"}
+
+// BalancesAt godoc
+// @Summary Wallet balances at a point in time
+// @Description Balances of a wallet as of a timestamp, replayed from the nearest daily snapshot
+// @Tags Wallet
+// @Produce json
+// @Param id path int true "wallet_id"
+// @Param at query string true "RFC3339 time, ledger rows created at or before it are counted"
+// @Success 200 {object} dto.BalancesAt
+// @Router /wallets/{id}/balances [get]
+func (h *walletHandlers) BalancesAt() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "wallet.BalancesAt")
+		defer span.Finish()
+
+		walletID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(httpErrors.NewBadRequestError(err.Error())))
+		}
+
+		at, err := getBalancesTime(c)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		balances, err := h.walletUC.BalancesAt(ctx, walletID, at)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, balances)
+	}
+}
+
+// ExportBalancesAt godoc
+// @Summary Export all balances at a point in time
+// @Description Download the balance of every wallet and currency as of a timestamp as CSV, for month-end close
+// @Tags Wallet
+// @Produce text/csv
+// @Param at query string true "RFC3339 time, ledger rows created at or before it are counted"
+// @Success 200 {file} file
+// @Router /wallets/balances/export [get]
+func (h *walletHandlers) ExportBalancesAt() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "wallet.ExportBalancesAt")
+		defer span.Finish()
+
+		at, err := getBalancesTime(c)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		balances, err := h.walletUC.ExportBalancesAt(ctx, at)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		export, err := writeBalanceExport(balances)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		filename := "balances-" + at.UTC().Format("20060102T150405Z") + ".csv"
+		c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename))
+		return c.Blob(http.StatusOK, "text/csv", export)
+	}
+}
+
+// Read the required at query param
+func getBalancesTime(c echo.Context) (time.Time, error) {
+	param := c.QueryParam("at")
+	if param == "" {
+		return time.Time{}, httpErrors.NewBadRequestError("at is required")
+	}
+
+	at, err := time.Parse(time.RFC3339, param)
+	if err != nil {
+		return time.Time{}, httpErrors.NewBadRequestError(err.Error())
+	}
+
+	return at, nil
+}
+
+func writeBalanceExport(balances []*models.HistoricalBalance) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	if err := w.Write(balanceExportColumns); err != nil {
+		return nil, err
+	}
+
+	for _, b := range balances {
+		if err := w.Write([]string{
+			strconv.FormatInt(b.WalletID, 10),
+			strconv.FormatInt(b.UserID, 10),
+			b.Currency,
+			b.Amount.String(),
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
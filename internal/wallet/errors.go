@@ -0,0 +1,55 @@
+package wallet
+
+import (
+	"net/http"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/httpErrors"
+)
+
+// Wallet domain errors
+var (
+	ErrWalletNotFound     = httpErrors.NewRestError(http.StatusNotFound, "Wallet not found", nil)
+	ErrWalletAccessDenied = httpErrors.NewRestError(http.StatusForbidden, "Wallet access denied", nil)
+	ErrWalletFrozen       = httpErrors.NewRestError(http.StatusUnprocessableEntity, "Wallet is frozen", nil)
+	ErrWalletClosed       = httpErrors.NewRestError(http.StatusUnprocessableEntity, "Wallet is closed", nil)
+	ErrWalletNotEmpty     = httpErrors.NewRestError(http.StatusConflict, "Wallet balance must be zero to close", nil)
+	ErrWalletTransition   = httpErrors.NewRestError(http.StatusConflict, "Wallet status change not allowed", nil)
+	ErrInsufficientFunds  = httpErrors.NewRestError(http.StatusUnprocessableEntity, "Insufficient funds", nil)
+	ErrWithdrawalNotFound = httpErrors.NewRestError(http.StatusNotFound, "Withdrawal not found", nil)
+	ErrWithdrawalNotHeld  = httpErrors.NewRestError(http.StatusConflict, "Withdrawal is not held", nil)
+	ErrTransferNotFound   = httpErrors.NewRestError(http.StatusNotFound, "Transfer not found", nil)
+	ErrReferenceInUse     = httpErrors.NewRestError(http.StatusConflict, "Reference already used", nil)
+	ErrRecipientNotFound  = httpErrors.NewRestError(http.StatusNotFound, "Recipient not found", nil)
+	ErrRecipientFrozen    = httpErrors.NewRestError(http.StatusUnprocessableEntity, "Recipient wallet is frozen", nil)
+	ErrEscrowNotFound     = httpErrors.NewRestError(http.StatusNotFound, "Escrow not found", nil)
+	ErrEscrowAccessDenied = httpErrors.NewRestError(http.StatusForbidden, "Escrow access denied", nil)
+	ErrEscrowTransition   = httpErrors.NewRestError(http.StatusConflict, "Escrow status change not allowed", nil)
+	ErrPocketNotFound     = httpErrors.NewRestError(http.StatusNotFound, "Pocket not found", nil)
+	ErrBalanceAtFuture    = httpErrors.NewRestError(http.StatusBadRequest, "Balance time must not be in the future", nil)
+
+	ErrAdjustmentNotFound     = httpErrors.NewRestError(http.StatusNotFound, "Adjustment not found", nil)
+	ErrAdjustmentNotPending   = httpErrors.NewRestError(http.StatusConflict, "Adjustment is no longer pending", nil)
+	ErrAdjustmentSelfReview   = httpErrors.NewRestError(http.StatusForbidden, "Adjustments must be reviewed by a different user", nil)
+	ErrAdjustmentAccessDenied = httpErrors.NewRestError(http.StatusForbidden, "Only the requester can cancel an adjustment", nil)
+
+	ErrTransactionHeld      = httpErrors.NewRestError(http.StatusAccepted, "Transaction held for review", nil)
+	ErrTransactionDenied    = httpErrors.NewRestError(http.StatusForbidden, "Transaction declined by risk rules", nil)
+	ErrRiskReviewNotFound   = httpErrors.NewRestError(http.StatusNotFound, "Risk review not found", nil)
+	ErrRiskReviewNotPending = httpErrors.NewRestError(http.StatusConflict, "Risk review is no longer pending", nil)
+	ErrRiskReviewSelfReview = httpErrors.NewRestError(http.StatusForbidden, "Transactions must be reviewed by someone other than the requester or owner", nil)
+
+	ErrReversalExceedsOriginal = httpErrors.NewRestError(http.StatusUnprocessableEntity, "Reversal exceeds the original transfer", nil)
+)
+
+// Error for a wallet that cannot send or receive in status, nil when active
+func StatusError(status string) error {
+	switch status {
+	case models.WalletStatusActive:
+		return nil
+	case models.WalletStatusFrozen:
+		return ErrWalletFrozen
+	default:
+		return ErrWalletClosed
+	}
+}
@@ -0,0 +1,697 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pg_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	utils "github.com/aditwar-man/go-microservice-boilerplate/pkg/utils"
+	gomock "github.com/golang/mock/gomock"
+	decimal "github.com/shopspring/decimal"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ApproveRiskReviewTx mocks base method.
+func (m *MockRepository) ApproveRiskReviewTx(ctx context.Context, reviewID, reviewerID int64, note *string, check *models.LimitCheck) (*models.RiskReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveRiskReviewTx", ctx, reviewID, reviewerID, note, check)
+	ret0, _ := ret[0].(*models.RiskReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApproveRiskReviewTx indicates an expected call of ApproveRiskReviewTx.
+func (mr *MockRepositoryMockRecorder) ApproveRiskReviewTx(ctx, reviewID, reviewerID, note, check interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveRiskReviewTx", reflect.TypeOf((*MockRepository)(nil).ApproveRiskReviewTx), ctx, reviewID, reviewerID, note, check)
+}
+
+// Create mocks base method.
+func (m *MockRepository) Create(ctx context.Context, user *models.Wallet) (*models.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, user)
+	ret0, _ := ret[0].(*models.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRepositoryMockRecorder) Create(ctx, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepository)(nil).Create), ctx, user)
+}
+
+// CreateAdjustment mocks base method.
+func (m *MockRepository) CreateAdjustment(ctx context.Context, adjustment *models.Adjustment) (*models.Adjustment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAdjustment", ctx, adjustment)
+	ret0, _ := ret[0].(*models.Adjustment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAdjustment indicates an expected call of CreateAdjustment.
+func (mr *MockRepositoryMockRecorder) CreateAdjustment(ctx, adjustment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAdjustment", reflect.TypeOf((*MockRepository)(nil).CreateAdjustment), ctx, adjustment)
+}
+
+// CreateEscrowTx mocks base method.
+func (m *MockRepository) CreateEscrowTx(ctx context.Context, escrow *models.Escrow, check *models.LimitCheck) (*models.Escrow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEscrowTx", ctx, escrow, check)
+	ret0, _ := ret[0].(*models.Escrow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateEscrowTx indicates an expected call of CreateEscrowTx.
+func (mr *MockRepositoryMockRecorder) CreateEscrowTx(ctx, escrow, check interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEscrowTx", reflect.TypeOf((*MockRepository)(nil).CreateEscrowTx), ctx, escrow, check)
+}
+
+// CreatePocketTx mocks base method.
+func (m *MockRepository) CreatePocketTx(ctx context.Context, pocket *models.Pocket) (*models.Pocket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePocketTx", ctx, pocket)
+	ret0, _ := ret[0].(*models.Pocket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePocketTx indicates an expected call of CreatePocketTx.
+func (mr *MockRepositoryMockRecorder) CreatePocketTx(ctx, pocket interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePocketTx", reflect.TypeOf((*MockRepository)(nil).CreatePocketTx), ctx, pocket)
+}
+
+// CreateSnapshotTx mocks base method.
+func (m *MockRepository) CreateSnapshotTx(ctx context.Context, asOf time.Time) (*models.BalanceSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSnapshotTx", ctx, asOf)
+	ret0, _ := ret[0].(*models.BalanceSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSnapshotTx indicates an expected call of CreateSnapshotTx.
+func (mr *MockRepositoryMockRecorder) CreateSnapshotTx(ctx, asOf interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSnapshotTx", reflect.TypeOf((*MockRepository)(nil).CreateSnapshotTx), ctx, asOf)
+}
+
+// DepositTx mocks base method.
+func (m *MockRepository) DepositTx(ctx context.Context, walletID int64, currency string, amount decimal.Decimal, refID string, check *models.LimitCheck) (*models.WalletBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DepositTx", ctx, walletID, currency, amount, refID, check)
+	ret0, _ := ret[0].(*models.WalletBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DepositTx indicates an expected call of DepositTx.
+func (mr *MockRepositoryMockRecorder) DepositTx(ctx, walletID, currency, amount, refID, check interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DepositTx", reflect.TypeOf((*MockRepository)(nil).DepositTx), ctx, walletID, currency, amount, refID, check)
+}
+
+// EntryPosted mocks base method.
+func (m *MockRepository) EntryPosted(ctx context.Context, refID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EntryPosted", ctx, refID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EntryPosted indicates an expected call of EntryPosted.
+func (mr *MockRepositoryMockRecorder) EntryPosted(ctx, refID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EntryPosted", reflect.TypeOf((*MockRepository)(nil).EntryPosted), ctx, refID)
+}
+
+// FindAdjustments mocks base method.
+func (m *MockRepository) FindAdjustments(ctx context.Context, filter *models.AdjustmentFilter) ([]*models.Adjustment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAdjustments", ctx, filter)
+	ret0, _ := ret[0].([]*models.Adjustment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAdjustments indicates an expected call of FindAdjustments.
+func (mr *MockRepositoryMockRecorder) FindAdjustments(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAdjustments", reflect.TypeOf((*MockRepository)(nil).FindAdjustments), ctx, filter)
+}
+
+// FindAll mocks base method.
+func (m *MockRepository) FindAll(ctx context.Context, userID int, pq *utils.PaginationQuery) (*models.WalletList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, userID, pq)
+	ret0, _ := ret[0].(*models.WalletList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockRepositoryMockRecorder) FindAll(ctx, userID, pq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockRepository)(nil).FindAll), ctx, userID, pq)
+}
+
+// FindAllBalancesAt mocks base method.
+func (m *MockRepository) FindAllBalancesAt(ctx context.Context, snapshotAt *time.Time, at time.Time) ([]*models.HistoricalBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllBalancesAt", ctx, snapshotAt, at)
+	ret0, _ := ret[0].([]*models.HistoricalBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllBalancesAt indicates an expected call of FindAllBalancesAt.
+func (mr *MockRepositoryMockRecorder) FindAllBalancesAt(ctx, snapshotAt, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllBalancesAt", reflect.TypeOf((*MockRepository)(nil).FindAllBalancesAt), ctx, snapshotAt, at)
+}
+
+// FindBalancesAt mocks base method.
+func (m *MockRepository) FindBalancesAt(ctx context.Context, walletID int64, snapshotAt *time.Time, at time.Time) ([]*models.HistoricalBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBalancesAt", ctx, walletID, snapshotAt, at)
+	ret0, _ := ret[0].([]*models.HistoricalBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBalancesAt indicates an expected call of FindBalancesAt.
+func (mr *MockRepositoryMockRecorder) FindBalancesAt(ctx, walletID, snapshotAt, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBalancesAt", reflect.TypeOf((*MockRepository)(nil).FindBalancesAt), ctx, walletID, snapshotAt, at)
+}
+
+// FindDefaultWallet mocks base method.
+func (m *MockRepository) FindDefaultWallet(ctx context.Context, userID int64, currency string) (*models.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDefaultWallet", ctx, userID, currency)
+	ret0, _ := ret[0].(*models.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDefaultWallet indicates an expected call of FindDefaultWallet.
+func (mr *MockRepositoryMockRecorder) FindDefaultWallet(ctx, userID, currency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDefaultWallet", reflect.TypeOf((*MockRepository)(nil).FindDefaultWallet), ctx, userID, currency)
+}
+
+// FindDueEscrows mocks base method.
+func (m *MockRepository) FindDueEscrows(ctx context.Context, now time.Time, limit int) ([]*models.Escrow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDueEscrows", ctx, now, limit)
+	ret0, _ := ret[0].([]*models.Escrow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDueEscrows indicates an expected call of FindDueEscrows.
+func (mr *MockRepositoryMockRecorder) FindDueEscrows(ctx, now, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDueEscrows", reflect.TypeOf((*MockRepository)(nil).FindDueEscrows), ctx, now, limit)
+}
+
+// FindEscrowEvents mocks base method.
+func (m *MockRepository) FindEscrowEvents(ctx context.Context, escrowID int64) ([]*models.EscrowEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindEscrowEvents", ctx, escrowID)
+	ret0, _ := ret[0].([]*models.EscrowEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindEscrowEvents indicates an expected call of FindEscrowEvents.
+func (mr *MockRepositoryMockRecorder) FindEscrowEvents(ctx, escrowID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEscrowEvents", reflect.TypeOf((*MockRepository)(nil).FindEscrowEvents), ctx, escrowID)
+}
+
+// FindEscrows mocks base method.
+func (m *MockRepository) FindEscrows(ctx context.Context, userID int64) ([]*models.Escrow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindEscrows", ctx, userID)
+	ret0, _ := ret[0].([]*models.Escrow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindEscrows indicates an expected call of FindEscrows.
+func (mr *MockRepositoryMockRecorder) FindEscrows(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEscrows", reflect.TypeOf((*MockRepository)(nil).FindEscrows), ctx, userID)
+}
+
+// FindPockets mocks base method.
+func (m *MockRepository) FindPockets(ctx context.Context, parentWalletID int64) ([]*models.Pocket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPockets", ctx, parentWalletID)
+	ret0, _ := ret[0].([]*models.Pocket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPockets indicates an expected call of FindPockets.
+func (mr *MockRepositoryMockRecorder) FindPockets(ctx, parentWalletID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPockets", reflect.TypeOf((*MockRepository)(nil).FindPockets), ctx, parentWalletID)
+}
+
+// FindReversals mocks base method.
+func (m *MockRepository) FindReversals(ctx context.Context, refID string) ([]*models.Reversal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindReversals", ctx, refID)
+	ret0, _ := ret[0].([]*models.Reversal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindReversals indicates an expected call of FindReversals.
+func (mr *MockRepositoryMockRecorder) FindReversals(ctx, refID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindReversals", reflect.TypeOf((*MockRepository)(nil).FindReversals), ctx, refID)
+}
+
+// FindRiskReviews mocks base method.
+func (m *MockRepository) FindRiskReviews(ctx context.Context, filter *models.RiskReviewFilter) ([]*models.RiskReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRiskReviews", ctx, filter)
+	ret0, _ := ret[0].([]*models.RiskReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRiskReviews indicates an expected call of FindRiskReviews.
+func (mr *MockRepositoryMockRecorder) FindRiskReviews(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRiskReviews", reflect.TypeOf((*MockRepository)(nil).FindRiskReviews), ctx, filter)
+}
+
+// FindStatusEvents mocks base method.
+func (m *MockRepository) FindStatusEvents(ctx context.Context, walletID int64) ([]*models.WalletStatusEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindStatusEvents", ctx, walletID)
+	ret0, _ := ret[0].([]*models.WalletStatusEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindStatusEvents indicates an expected call of FindStatusEvents.
+func (mr *MockRepositoryMockRecorder) FindStatusEvents(ctx, walletID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindStatusEvents", reflect.TypeOf((*MockRepository)(nil).FindStatusEvents), ctx, walletID)
+}
+
+// FindTransactions mocks base method.
+func (m *MockRepository) FindTransactions(ctx context.Context, filter *models.TransactionFilter) ([]*models.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTransactions", ctx, filter)
+	ret0, _ := ret[0].([]*models.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindTransactions indicates an expected call of FindTransactions.
+func (mr *MockRepositoryMockRecorder) FindTransactions(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTransactions", reflect.TypeOf((*MockRepository)(nil).FindTransactions), ctx, filter)
+}
+
+// FindTransferEntry mocks base method.
+func (m *MockRepository) FindTransferEntry(ctx context.Context, refID string) (*models.TransferEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTransferEntry", ctx, refID)
+	ret0, _ := ret[0].(*models.TransferEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindTransferEntry indicates an expected call of FindTransferEntry.
+func (mr *MockRepositoryMockRecorder) FindTransferEntry(ctx, refID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTransferEntry", reflect.TypeOf((*MockRepository)(nil).FindTransferEntry), ctx, refID)
+}
+
+// GetAdjustment mocks base method.
+func (m *MockRepository) GetAdjustment(ctx context.Context, adjustmentID int64) (*models.Adjustment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAdjustment", ctx, adjustmentID)
+	ret0, _ := ret[0].(*models.Adjustment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAdjustment indicates an expected call of GetAdjustment.
+func (mr *MockRepositoryMockRecorder) GetAdjustment(ctx, adjustmentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAdjustment", reflect.TypeOf((*MockRepository)(nil).GetAdjustment), ctx, adjustmentID)
+}
+
+// GetBalance mocks base method.
+func (m *MockRepository) GetBalance(ctx context.Context, walletID int64, currency string) (*models.WalletBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalance", ctx, walletID, currency)
+	ret0, _ := ret[0].(*models.WalletBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalance indicates an expected call of GetBalance.
+func (mr *MockRepositoryMockRecorder) GetBalance(ctx, walletID, currency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalance", reflect.TypeOf((*MockRepository)(nil).GetBalance), ctx, walletID, currency)
+}
+
+// GetByID mocks base method.
+func (m *MockRepository) GetByID(ctx context.Context, walletID int64) (*models.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, walletID)
+	ret0, _ := ret[0].(*models.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockRepositoryMockRecorder) GetByID(ctx, walletID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockRepository)(nil).GetByID), ctx, walletID)
+}
+
+// GetEscrow mocks base method.
+func (m *MockRepository) GetEscrow(ctx context.Context, escrowID int64) (*models.Escrow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEscrow", ctx, escrowID)
+	ret0, _ := ret[0].(*models.Escrow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEscrow indicates an expected call of GetEscrow.
+func (mr *MockRepositoryMockRecorder) GetEscrow(ctx, escrowID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEscrow", reflect.TypeOf((*MockRepository)(nil).GetEscrow), ctx, escrowID)
+}
+
+// GetOrCreateDefaultWalletTx mocks base method.
+func (m *MockRepository) GetOrCreateDefaultWalletTx(ctx context.Context, userID int64, currency, name string) (*models.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrCreateDefaultWalletTx", ctx, userID, currency, name)
+	ret0, _ := ret[0].(*models.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrCreateDefaultWalletTx indicates an expected call of GetOrCreateDefaultWalletTx.
+func (mr *MockRepositoryMockRecorder) GetOrCreateDefaultWalletTx(ctx, userID, currency, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrCreateDefaultWalletTx", reflect.TypeOf((*MockRepository)(nil).GetOrCreateDefaultWalletTx), ctx, userID, currency, name)
+}
+
+// GetPocket mocks base method.
+func (m *MockRepository) GetPocket(ctx context.Context, pocketID int64) (*models.Pocket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPocket", ctx, pocketID)
+	ret0, _ := ret[0].(*models.Pocket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPocket indicates an expected call of GetPocket.
+func (mr *MockRepositoryMockRecorder) GetPocket(ctx, pocketID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPocket", reflect.TypeOf((*MockRepository)(nil).GetPocket), ctx, pocketID)
+}
+
+// GetRiskReview mocks base method.
+func (m *MockRepository) GetRiskReview(ctx context.Context, reviewID int64) (*models.RiskReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRiskReview", ctx, reviewID)
+	ret0, _ := ret[0].(*models.RiskReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRiskReview indicates an expected call of GetRiskReview.
+func (mr *MockRepositoryMockRecorder) GetRiskReview(ctx, reviewID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRiskReview", reflect.TypeOf((*MockRepository)(nil).GetRiskReview), ctx, reviewID)
+}
+
+// GetRiskReviewByRef mocks base method.
+func (m *MockRepository) GetRiskReviewByRef(ctx context.Context, operation, refID string) (*models.RiskReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRiskReviewByRef", ctx, operation, refID)
+	ret0, _ := ret[0].(*models.RiskReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRiskReviewByRef indicates an expected call of GetRiskReviewByRef.
+func (mr *MockRepositoryMockRecorder) GetRiskReviewByRef(ctx, operation, refID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRiskReviewByRef", reflect.TypeOf((*MockRepository)(nil).GetRiskReviewByRef), ctx, operation, refID)
+}
+
+// HoldForReviewTx mocks base method.
+func (m *MockRepository) HoldForReviewTx(ctx context.Context, review *models.RiskReview) (*models.RiskReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HoldForReviewTx", ctx, review)
+	ret0, _ := ret[0].(*models.RiskReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HoldForReviewTx indicates an expected call of HoldForReviewTx.
+func (mr *MockRepositoryMockRecorder) HoldForReviewTx(ctx, review interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HoldForReviewTx", reflect.TypeOf((*MockRepository)(nil).HoldForReviewTx), ctx, review)
+}
+
+// HoldWithdrawalTx mocks base method.
+func (m *MockRepository) HoldWithdrawalTx(ctx context.Context, withdrawal *models.Withdrawal, check *models.LimitCheck) (*models.Withdrawal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HoldWithdrawalTx", ctx, withdrawal, check)
+	ret0, _ := ret[0].(*models.Withdrawal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HoldWithdrawalTx indicates an expected call of HoldWithdrawalTx.
+func (mr *MockRepositoryMockRecorder) HoldWithdrawalTx(ctx, withdrawal, check interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HoldWithdrawalTx", reflect.TypeOf((*MockRepository)(nil).HoldWithdrawalTx), ctx, withdrawal, check)
+}
+
+// LatestSnapshot mocks base method.
+func (m *MockRepository) LatestSnapshot(ctx context.Context, notAfter time.Time) (*models.BalanceSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LatestSnapshot", ctx, notAfter)
+	ret0, _ := ret[0].(*models.BalanceSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LatestSnapshot indicates an expected call of LatestSnapshot.
+func (mr *MockRepositoryMockRecorder) LatestSnapshot(ctx, notAfter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LatestSnapshot", reflect.TypeOf((*MockRepository)(nil).LatestSnapshot), ctx, notAfter)
+}
+
+// MovePocketFundsTx mocks base method.
+func (m *MockRepository) MovePocketFundsTx(ctx context.Context, move *models.PocketMove) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MovePocketFundsTx", ctx, move)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MovePocketFundsTx indicates an expected call of MovePocketFundsTx.
+func (mr *MockRepositoryMockRecorder) MovePocketFundsTx(ctx, move interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MovePocketFundsTx", reflect.TypeOf((*MockRepository)(nil).MovePocketFundsTx), ctx, move)
+}
+
+// RebuildBalancesTx mocks base method.
+func (m *MockRepository) RebuildBalancesTx(ctx context.Context, walletID int64) ([]models.WalletBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RebuildBalancesTx", ctx, walletID)
+	ret0, _ := ret[0].([]models.WalletBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RebuildBalancesTx indicates an expected call of RebuildBalancesTx.
+func (mr *MockRepositoryMockRecorder) RebuildBalancesTx(ctx, walletID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RebuildBalancesTx", reflect.TypeOf((*MockRepository)(nil).RebuildBalancesTx), ctx, walletID)
+}
+
+// RejectRiskReviewTx mocks base method.
+func (m *MockRepository) RejectRiskReviewTx(ctx context.Context, reviewID, reviewerID int64, note *string) (*models.RiskReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RejectRiskReviewTx", ctx, reviewID, reviewerID, note)
+	ret0, _ := ret[0].(*models.RiskReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RejectRiskReviewTx indicates an expected call of RejectRiskReviewTx.
+func (mr *MockRepositoryMockRecorder) RejectRiskReviewTx(ctx, reviewID, reviewerID, note interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectRiskReviewTx", reflect.TypeOf((*MockRepository)(nil).RejectRiskReviewTx), ctx, reviewID, reviewerID, note)
+}
+
+// ReleaseWithdrawalTx mocks base method.
+func (m *MockRepository) ReleaseWithdrawalTx(ctx context.Context, walletID, withdrawalID int64) (*models.Withdrawal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseWithdrawalTx", ctx, walletID, withdrawalID)
+	ret0, _ := ret[0].(*models.Withdrawal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseWithdrawalTx indicates an expected call of ReleaseWithdrawalTx.
+func (mr *MockRepositoryMockRecorder) ReleaseWithdrawalTx(ctx, walletID, withdrawalID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseWithdrawalTx", reflect.TypeOf((*MockRepository)(nil).ReleaseWithdrawalTx), ctx, walletID, withdrawalID)
+}
+
+// ReverseTransferTx mocks base method.
+func (m *MockRepository) ReverseTransferTx(ctx context.Context, reversal *models.Reversal) (*models.Reversal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReverseTransferTx", ctx, reversal)
+	ret0, _ := ret[0].(*models.Reversal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReverseTransferTx indicates an expected call of ReverseTransferTx.
+func (mr *MockRepositoryMockRecorder) ReverseTransferTx(ctx, reversal interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReverseTransferTx", reflect.TypeOf((*MockRepository)(nil).ReverseTransferTx), ctx, reversal)
+}
+
+// SettleWithdrawalTx mocks base method.
+func (m *MockRepository) SettleWithdrawalTx(ctx context.Context, walletID, withdrawalID int64) (*models.Withdrawal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SettleWithdrawalTx", ctx, walletID, withdrawalID)
+	ret0, _ := ret[0].(*models.Withdrawal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SettleWithdrawalTx indicates an expected call of SettleWithdrawalTx.
+func (mr *MockRepositoryMockRecorder) SettleWithdrawalTx(ctx, walletID, withdrawalID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SettleWithdrawalTx", reflect.TypeOf((*MockRepository)(nil).SettleWithdrawalTx), ctx, walletID, withdrawalID)
+}
+
+// TransferTx mocks base method.
+func (m *MockRepository) TransferTx(ctx context.Context, transfer *models.Transfer, check *models.LimitCheck) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransferTx", ctx, transfer, check)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TransferTx indicates an expected call of TransferTx.
+func (mr *MockRepositoryMockRecorder) TransferTx(ctx, transfer, check interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferTx", reflect.TypeOf((*MockRepository)(nil).TransferTx), ctx, transfer, check)
+}
+
+// UpdateAdjustmentTx mocks base method.
+func (m *MockRepository) UpdateAdjustmentTx(ctx context.Context, adjustmentID int64, status string, actorID int64, note *string) (*models.Adjustment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAdjustmentTx", ctx, adjustmentID, status, actorID, note)
+	ret0, _ := ret[0].(*models.Adjustment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAdjustmentTx indicates an expected call of UpdateAdjustmentTx.
+func (mr *MockRepositoryMockRecorder) UpdateAdjustmentTx(ctx, adjustmentID, status, actorID, note interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAdjustmentTx", reflect.TypeOf((*MockRepository)(nil).UpdateAdjustmentTx), ctx, adjustmentID, status, actorID, note)
+}
+
+// UpdateEscrowTx mocks base method.
+func (m *MockRepository) UpdateEscrowTx(ctx context.Context, escrowID int64, from, to, reason string, actorID *int64) (*models.Escrow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEscrowTx", ctx, escrowID, from, to, reason, actorID)
+	ret0, _ := ret[0].(*models.Escrow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateEscrowTx indicates an expected call of UpdateEscrowTx.
+func (mr *MockRepositoryMockRecorder) UpdateEscrowTx(ctx, escrowID, from, to, reason, actorID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEscrowTx", reflect.TypeOf((*MockRepository)(nil).UpdateEscrowTx), ctx, escrowID, from, to, reason, actorID)
+}
+
+// UpdatePocketTarget mocks base method.
+func (m *MockRepository) UpdatePocketTarget(ctx context.Context, pocketID int64, amount *decimal.Decimal, date *time.Time) (*models.Pocket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePocketTarget", ctx, pocketID, amount, date)
+	ret0, _ := ret[0].(*models.Pocket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePocketTarget indicates an expected call of UpdatePocketTarget.
+func (mr *MockRepositoryMockRecorder) UpdatePocketTarget(ctx, pocketID, amount, date interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePocketTarget", reflect.TypeOf((*MockRepository)(nil).UpdatePocketTarget), ctx, pocketID, amount, date)
+}
+
+// UpdateStatusTx mocks base method.
+func (m *MockRepository) UpdateStatusTx(ctx context.Context, walletID int64, status, reason string, actorID int64) (*models.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatusTx", ctx, walletID, status, reason, actorID)
+	ret0, _ := ret[0].(*models.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStatusTx indicates an expected call of UpdateStatusTx.
+func (mr *MockRepositoryMockRecorder) UpdateStatusTx(ctx, walletID, status, reason, actorID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatusTx", reflect.TypeOf((*MockRepository)(nil).UpdateStatusTx), ctx, walletID, status, reason, actorID)
+}
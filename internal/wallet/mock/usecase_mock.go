@@ -0,0 +1,684 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	dto "github.com/aditwar-man/go-microservice-boilerplate/internal/dto"
+	models "github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	utils "github.com/aditwar-man/go-microservice-boilerplate/pkg/utils"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUseCase is a mock of UseCase interface.
+type MockUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockUseCaseMockRecorder
+}
+
+// MockUseCaseMockRecorder is the mock recorder for MockUseCase.
+type MockUseCaseMockRecorder struct {
+	mock *MockUseCase
+}
+
+// NewMockUseCase creates a new mock instance.
+func NewMockUseCase(ctrl *gomock.Controller) *MockUseCase {
+	mock := &MockUseCase{ctrl: ctrl}
+	mock.recorder = &MockUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUseCase) EXPECT() *MockUseCaseMockRecorder {
+	return m.recorder
+}
+
+// ApproveAdjustment mocks base method.
+func (m *MockUseCase) ApproveAdjustment(ctx context.Context, adjustmentID int64, request *dto.RequestAdjustmentReview) (*models.Adjustment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveAdjustment", ctx, adjustmentID, request)
+	ret0, _ := ret[0].(*models.Adjustment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApproveAdjustment indicates an expected call of ApproveAdjustment.
+func (mr *MockUseCaseMockRecorder) ApproveAdjustment(ctx, adjustmentID, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveAdjustment", reflect.TypeOf((*MockUseCase)(nil).ApproveAdjustment), ctx, adjustmentID, request)
+}
+
+// ApproveRiskReview mocks base method.
+func (m *MockUseCase) ApproveRiskReview(ctx context.Context, reviewID int64, request *dto.RequestRiskReview) (*models.RiskReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveRiskReview", ctx, reviewID, request)
+	ret0, _ := ret[0].(*models.RiskReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApproveRiskReview indicates an expected call of ApproveRiskReview.
+func (mr *MockUseCaseMockRecorder) ApproveRiskReview(ctx, reviewID, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveRiskReview", reflect.TypeOf((*MockUseCase)(nil).ApproveRiskReview), ctx, reviewID, request)
+}
+
+// Archive mocks base method.
+func (m *MockUseCase) Archive(ctx context.Context, walletID int64, dto *dto.RequestWalletStatus) (*models.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Archive", ctx, walletID, dto)
+	ret0, _ := ret[0].(*models.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Archive indicates an expected call of Archive.
+func (mr *MockUseCaseMockRecorder) Archive(ctx, walletID, dto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Archive", reflect.TypeOf((*MockUseCase)(nil).Archive), ctx, walletID, dto)
+}
+
+// BalancesAt mocks base method.
+func (m *MockUseCase) BalancesAt(ctx context.Context, walletID int64, at time.Time) (*dto.BalancesAt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BalancesAt", ctx, walletID, at)
+	ret0, _ := ret[0].(*dto.BalancesAt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BalancesAt indicates an expected call of BalancesAt.
+func (mr *MockUseCaseMockRecorder) BalancesAt(ctx, walletID, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BalancesAt", reflect.TypeOf((*MockUseCase)(nil).BalancesAt), ctx, walletID, at)
+}
+
+// CancelAdjustment mocks base method.
+func (m *MockUseCase) CancelAdjustment(ctx context.Context, adjustmentID int64) (*models.Adjustment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelAdjustment", ctx, adjustmentID)
+	ret0, _ := ret[0].(*models.Adjustment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelAdjustment indicates an expected call of CancelAdjustment.
+func (mr *MockUseCaseMockRecorder) CancelAdjustment(ctx, adjustmentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelAdjustment", reflect.TypeOf((*MockUseCase)(nil).CancelAdjustment), ctx, adjustmentID)
+}
+
+// CancelEscrow mocks base method.
+func (m *MockUseCase) CancelEscrow(ctx context.Context, escrowID int64, request *dto.RequestEscrowAction) (*models.Escrow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelEscrow", ctx, escrowID, request)
+	ret0, _ := ret[0].(*models.Escrow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelEscrow indicates an expected call of CancelEscrow.
+func (mr *MockUseCaseMockRecorder) CancelEscrow(ctx, escrowID, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelEscrow", reflect.TypeOf((*MockUseCase)(nil).CancelEscrow), ctx, escrowID, request)
+}
+
+// Close mocks base method.
+func (m *MockUseCase) Close(ctx context.Context, walletID int64, dto *dto.RequestWalletStatus) (*models.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close", ctx, walletID, dto)
+	ret0, _ := ret[0].(*models.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockUseCaseMockRecorder) Close(ctx, walletID, dto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockUseCase)(nil).Close), ctx, walletID, dto)
+}
+
+// Create mocks base method.
+func (m *MockUseCase) Create(ctx context.Context, dto *dto.RequestCreateWallet) (*models.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, dto)
+	ret0, _ := ret[0].(*models.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockUseCaseMockRecorder) Create(ctx, dto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUseCase)(nil).Create), ctx, dto)
+}
+
+// CreateEscrow mocks base method.
+func (m *MockUseCase) CreateEscrow(ctx context.Context, request *dto.RequestEscrow) (*models.Escrow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEscrow", ctx, request)
+	ret0, _ := ret[0].(*models.Escrow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateEscrow indicates an expected call of CreateEscrow.
+func (mr *MockUseCaseMockRecorder) CreateEscrow(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEscrow", reflect.TypeOf((*MockUseCase)(nil).CreateEscrow), ctx, request)
+}
+
+// CreatePocket mocks base method.
+func (m *MockUseCase) CreatePocket(ctx context.Context, walletID int64, request *dto.RequestCreatePocket) (*models.Pocket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePocket", ctx, walletID, request)
+	ret0, _ := ret[0].(*models.Pocket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePocket indicates an expected call of CreatePocket.
+func (mr *MockUseCaseMockRecorder) CreatePocket(ctx, walletID, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePocket", reflect.TypeOf((*MockUseCase)(nil).CreatePocket), ctx, walletID, request)
+}
+
+// Deposit mocks base method.
+func (m *MockUseCase) Deposit(ctx context.Context, dto *dto.RequestDeposit) (*models.WalletBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Deposit", ctx, dto)
+	ret0, _ := ret[0].(*models.WalletBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Deposit indicates an expected call of Deposit.
+func (mr *MockUseCaseMockRecorder) Deposit(ctx, dto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deposit", reflect.TypeOf((*MockUseCase)(nil).Deposit), ctx, dto)
+}
+
+// DisputeEscrow mocks base method.
+func (m *MockUseCase) DisputeEscrow(ctx context.Context, escrowID int64, request *dto.RequestEscrowAction) (*models.Escrow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisputeEscrow", ctx, escrowID, request)
+	ret0, _ := ret[0].(*models.Escrow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DisputeEscrow indicates an expected call of DisputeEscrow.
+func (mr *MockUseCaseMockRecorder) DisputeEscrow(ctx, escrowID, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisputeEscrow", reflect.TypeOf((*MockUseCase)(nil).DisputeEscrow), ctx, escrowID, request)
+}
+
+// ExportBalancesAt mocks base method.
+func (m *MockUseCase) ExportBalancesAt(ctx context.Context, at time.Time) ([]*models.HistoricalBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportBalancesAt", ctx, at)
+	ret0, _ := ret[0].([]*models.HistoricalBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportBalancesAt indicates an expected call of ExportBalancesAt.
+func (mr *MockUseCaseMockRecorder) ExportBalancesAt(ctx, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportBalancesAt", reflect.TypeOf((*MockUseCase)(nil).ExportBalancesAt), ctx, at)
+}
+
+// Freeze mocks base method.
+func (m *MockUseCase) Freeze(ctx context.Context, walletID int64, dto *dto.RequestWalletStatus) (*models.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Freeze", ctx, walletID, dto)
+	ret0, _ := ret[0].(*models.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Freeze indicates an expected call of Freeze.
+func (mr *MockUseCaseMockRecorder) Freeze(ctx, walletID, dto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Freeze", reflect.TypeOf((*MockUseCase)(nil).Freeze), ctx, walletID, dto)
+}
+
+// GetAdjustment mocks base method.
+func (m *MockUseCase) GetAdjustment(ctx context.Context, adjustmentID int64) (*models.Adjustment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAdjustment", ctx, adjustmentID)
+	ret0, _ := ret[0].(*models.Adjustment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAdjustment indicates an expected call of GetAdjustment.
+func (mr *MockUseCaseMockRecorder) GetAdjustment(ctx, adjustmentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAdjustment", reflect.TypeOf((*MockUseCase)(nil).GetAdjustment), ctx, adjustmentID)
+}
+
+// GetEscrow mocks base method.
+func (m *MockUseCase) GetEscrow(ctx context.Context, escrowID int64) (*models.Escrow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEscrow", ctx, escrowID)
+	ret0, _ := ret[0].(*models.Escrow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEscrow indicates an expected call of GetEscrow.
+func (mr *MockUseCaseMockRecorder) GetEscrow(ctx, escrowID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEscrow", reflect.TypeOf((*MockUseCase)(nil).GetEscrow), ctx, escrowID)
+}
+
+// GetRiskReview mocks base method.
+func (m *MockUseCase) GetRiskReview(ctx context.Context, reviewID int64) (*models.RiskReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRiskReview", ctx, reviewID)
+	ret0, _ := ret[0].(*models.RiskReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRiskReview indicates an expected call of GetRiskReview.
+func (mr *MockUseCaseMockRecorder) GetRiskReview(ctx, reviewID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRiskReview", reflect.TypeOf((*MockUseCase)(nil).GetRiskReview), ctx, reviewID)
+}
+
+// ListAdjustments mocks base method.
+func (m *MockUseCase) ListAdjustments(ctx context.Context, filter *models.AdjustmentFilter) ([]*models.Adjustment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAdjustments", ctx, filter)
+	ret0, _ := ret[0].([]*models.Adjustment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAdjustments indicates an expected call of ListAdjustments.
+func (mr *MockUseCaseMockRecorder) ListAdjustments(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAdjustments", reflect.TypeOf((*MockUseCase)(nil).ListAdjustments), ctx, filter)
+}
+
+// ListEscrowEvents mocks base method.
+func (m *MockUseCase) ListEscrowEvents(ctx context.Context, escrowID int64) ([]*models.EscrowEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEscrowEvents", ctx, escrowID)
+	ret0, _ := ret[0].([]*models.EscrowEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEscrowEvents indicates an expected call of ListEscrowEvents.
+func (mr *MockUseCaseMockRecorder) ListEscrowEvents(ctx, escrowID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEscrowEvents", reflect.TypeOf((*MockUseCase)(nil).ListEscrowEvents), ctx, escrowID)
+}
+
+// ListEscrows mocks base method.
+func (m *MockUseCase) ListEscrows(ctx context.Context) ([]*models.Escrow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEscrows", ctx)
+	ret0, _ := ret[0].([]*models.Escrow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEscrows indicates an expected call of ListEscrows.
+func (mr *MockUseCaseMockRecorder) ListEscrows(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEscrows", reflect.TypeOf((*MockUseCase)(nil).ListEscrows), ctx)
+}
+
+// ListPockets mocks base method.
+func (m *MockUseCase) ListPockets(ctx context.Context, walletID int64) ([]*models.Pocket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPockets", ctx, walletID)
+	ret0, _ := ret[0].([]*models.Pocket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPockets indicates an expected call of ListPockets.
+func (mr *MockUseCaseMockRecorder) ListPockets(ctx, walletID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPockets", reflect.TypeOf((*MockUseCase)(nil).ListPockets), ctx, walletID)
+}
+
+// ListReversals mocks base method.
+func (m *MockUseCase) ListReversals(ctx context.Context, refID string) ([]*models.Reversal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReversals", ctx, refID)
+	ret0, _ := ret[0].([]*models.Reversal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReversals indicates an expected call of ListReversals.
+func (mr *MockUseCaseMockRecorder) ListReversals(ctx, refID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReversals", reflect.TypeOf((*MockUseCase)(nil).ListReversals), ctx, refID)
+}
+
+// ListRiskReviews mocks base method.
+func (m *MockUseCase) ListRiskReviews(ctx context.Context, filter *models.RiskReviewFilter) ([]*models.RiskReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRiskReviews", ctx, filter)
+	ret0, _ := ret[0].([]*models.RiskReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRiskReviews indicates an expected call of ListRiskReviews.
+func (mr *MockUseCaseMockRecorder) ListRiskReviews(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRiskReviews", reflect.TypeOf((*MockUseCase)(nil).ListRiskReviews), ctx, filter)
+}
+
+// ListStatusEvents mocks base method.
+func (m *MockUseCase) ListStatusEvents(ctx context.Context, walletID int64) ([]*models.WalletStatusEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStatusEvents", ctx, walletID)
+	ret0, _ := ret[0].([]*models.WalletStatusEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStatusEvents indicates an expected call of ListStatusEvents.
+func (mr *MockUseCaseMockRecorder) ListStatusEvents(ctx, walletID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStatusEvents", reflect.TypeOf((*MockUseCase)(nil).ListStatusEvents), ctx, walletID)
+}
+
+// ListTransactions mocks base method.
+func (m *MockUseCase) ListTransactions(ctx context.Context, filter *models.TransactionFilter) (*dto.TransactionList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransactions", ctx, filter)
+	ret0, _ := ret[0].(*dto.TransactionList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransactions indicates an expected call of ListTransactions.
+func (mr *MockUseCaseMockRecorder) ListTransactions(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransactions", reflect.TypeOf((*MockUseCase)(nil).ListTransactions), ctx, filter)
+}
+
+// ListWallet mocks base method.
+func (m *MockUseCase) ListWallet(ctx context.Context, userID int, pq *utils.PaginationQuery) (*models.WalletList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWallet", ctx, userID, pq)
+	ret0, _ := ret[0].(*models.WalletList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWallet indicates an expected call of ListWallet.
+func (mr *MockUseCaseMockRecorder) ListWallet(ctx, userID, pq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWallet", reflect.TypeOf((*MockUseCase)(nil).ListWallet), ctx, userID, pq)
+}
+
+// MovePocketFunds mocks base method.
+func (m *MockUseCase) MovePocketFunds(ctx context.Context, walletID int64, request *dto.RequestPocketMove) ([]*models.Pocket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MovePocketFunds", ctx, walletID, request)
+	ret0, _ := ret[0].([]*models.Pocket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MovePocketFunds indicates an expected call of MovePocketFunds.
+func (mr *MockUseCaseMockRecorder) MovePocketFunds(ctx, walletID, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MovePocketFunds", reflect.TypeOf((*MockUseCase)(nil).MovePocketFunds), ctx, walletID, request)
+}
+
+// PreviewP2P mocks base method.
+func (m *MockUseCase) PreviewP2P(ctx context.Context, request *dto.RequestP2PTransfer) (*dto.P2PTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreviewP2P", ctx, request)
+	ret0, _ := ret[0].(*dto.P2PTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PreviewP2P indicates an expected call of PreviewP2P.
+func (mr *MockUseCaseMockRecorder) PreviewP2P(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewP2P", reflect.TypeOf((*MockUseCase)(nil).PreviewP2P), ctx, request)
+}
+
+// PreviewTransfer mocks base method.
+func (m *MockUseCase) PreviewTransfer(ctx context.Context, request *dto.RequestTransfer) (*dto.TransferPreview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreviewTransfer", ctx, request)
+	ret0, _ := ret[0].(*dto.TransferPreview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PreviewTransfer indicates an expected call of PreviewTransfer.
+func (mr *MockUseCaseMockRecorder) PreviewTransfer(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewTransfer", reflect.TypeOf((*MockUseCase)(nil).PreviewTransfer), ctx, request)
+}
+
+// RebuildBalances mocks base method.
+func (m *MockUseCase) RebuildBalances(ctx context.Context, walletID int64) ([]models.WalletBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RebuildBalances", ctx, walletID)
+	ret0, _ := ret[0].([]models.WalletBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RebuildBalances indicates an expected call of RebuildBalances.
+func (mr *MockUseCaseMockRecorder) RebuildBalances(ctx, walletID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RebuildBalances", reflect.TypeOf((*MockUseCase)(nil).RebuildBalances), ctx, walletID)
+}
+
+// RejectAdjustment mocks base method.
+func (m *MockUseCase) RejectAdjustment(ctx context.Context, adjustmentID int64, request *dto.RequestAdjustmentReview) (*models.Adjustment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RejectAdjustment", ctx, adjustmentID, request)
+	ret0, _ := ret[0].(*models.Adjustment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RejectAdjustment indicates an expected call of RejectAdjustment.
+func (mr *MockUseCaseMockRecorder) RejectAdjustment(ctx, adjustmentID, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectAdjustment", reflect.TypeOf((*MockUseCase)(nil).RejectAdjustment), ctx, adjustmentID, request)
+}
+
+// RejectRiskReview mocks base method.
+func (m *MockUseCase) RejectRiskReview(ctx context.Context, reviewID int64, request *dto.RequestRiskReview) (*models.RiskReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RejectRiskReview", ctx, reviewID, request)
+	ret0, _ := ret[0].(*models.RiskReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RejectRiskReview indicates an expected call of RejectRiskReview.
+func (mr *MockUseCaseMockRecorder) RejectRiskReview(ctx, reviewID, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectRiskReview", reflect.TypeOf((*MockUseCase)(nil).RejectRiskReview), ctx, reviewID, request)
+}
+
+// ReleaseEscrow mocks base method.
+func (m *MockUseCase) ReleaseEscrow(ctx context.Context, escrowID int64) (*models.Escrow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseEscrow", ctx, escrowID)
+	ret0, _ := ret[0].(*models.Escrow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseEscrow indicates an expected call of ReleaseEscrow.
+func (mr *MockUseCaseMockRecorder) ReleaseEscrow(ctx, escrowID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseEscrow", reflect.TypeOf((*MockUseCase)(nil).ReleaseEscrow), ctx, escrowID)
+}
+
+// ReleaseWithdrawal mocks base method.
+func (m *MockUseCase) ReleaseWithdrawal(ctx context.Context, walletID, withdrawalID int64) (*models.Withdrawal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseWithdrawal", ctx, walletID, withdrawalID)
+	ret0, _ := ret[0].(*models.Withdrawal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseWithdrawal indicates an expected call of ReleaseWithdrawal.
+func (mr *MockUseCaseMockRecorder) ReleaseWithdrawal(ctx, walletID, withdrawalID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseWithdrawal", reflect.TypeOf((*MockUseCase)(nil).ReleaseWithdrawal), ctx, walletID, withdrawalID)
+}
+
+// RequestAdjustment mocks base method.
+func (m *MockUseCase) RequestAdjustment(ctx context.Context, walletID int64, request *dto.RequestAdjustment) (*models.Adjustment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestAdjustment", ctx, walletID, request)
+	ret0, _ := ret[0].(*models.Adjustment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestAdjustment indicates an expected call of RequestAdjustment.
+func (mr *MockUseCaseMockRecorder) RequestAdjustment(ctx, walletID, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestAdjustment", reflect.TypeOf((*MockUseCase)(nil).RequestAdjustment), ctx, walletID, request)
+}
+
+// ResolveEscrow mocks base method.
+func (m *MockUseCase) ResolveEscrow(ctx context.Context, escrowID int64, request *dto.RequestEscrowResolution) (*models.Escrow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveEscrow", ctx, escrowID, request)
+	ret0, _ := ret[0].(*models.Escrow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveEscrow indicates an expected call of ResolveEscrow.
+func (mr *MockUseCaseMockRecorder) ResolveEscrow(ctx, escrowID, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveEscrow", reflect.TypeOf((*MockUseCase)(nil).ResolveEscrow), ctx, escrowID, request)
+}
+
+// ReverseTransfer mocks base method.
+func (m *MockUseCase) ReverseTransfer(ctx context.Context, refID string, request *dto.RequestReversal) (*models.Reversal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReverseTransfer", ctx, refID, request)
+	ret0, _ := ret[0].(*models.Reversal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReverseTransfer indicates an expected call of ReverseTransfer.
+func (mr *MockUseCaseMockRecorder) ReverseTransfer(ctx, refID, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReverseTransfer", reflect.TypeOf((*MockUseCase)(nil).ReverseTransfer), ctx, refID, request)
+}
+
+// SendP2P mocks base method.
+func (m *MockUseCase) SendP2P(ctx context.Context, request *dto.RequestP2PTransfer) (*dto.P2PTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendP2P", ctx, request)
+	ret0, _ := ret[0].(*dto.P2PTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendP2P indicates an expected call of SendP2P.
+func (mr *MockUseCaseMockRecorder) SendP2P(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendP2P", reflect.TypeOf((*MockUseCase)(nil).SendP2P), ctx, request)
+}
+
+// SettleWithdrawal mocks base method.
+func (m *MockUseCase) SettleWithdrawal(ctx context.Context, walletID, withdrawalID int64) (*models.Withdrawal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SettleWithdrawal", ctx, walletID, withdrawalID)
+	ret0, _ := ret[0].(*models.Withdrawal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SettleWithdrawal indicates an expected call of SettleWithdrawal.
+func (mr *MockUseCaseMockRecorder) SettleWithdrawal(ctx, walletID, withdrawalID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SettleWithdrawal", reflect.TypeOf((*MockUseCase)(nil).SettleWithdrawal), ctx, walletID, withdrawalID)
+}
+
+// Transfer mocks base method.
+func (m *MockUseCase) Transfer(ctx context.Context, dto *dto.RequestTransfer) (*models.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transfer", ctx, dto)
+	ret0, _ := ret[0].(*models.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Transfer indicates an expected call of Transfer.
+func (mr *MockUseCaseMockRecorder) Transfer(ctx, dto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transfer", reflect.TypeOf((*MockUseCase)(nil).Transfer), ctx, dto)
+}
+
+// Unfreeze mocks base method.
+func (m *MockUseCase) Unfreeze(ctx context.Context, walletID int64, dto *dto.RequestWalletStatus) (*models.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unfreeze", ctx, walletID, dto)
+	ret0, _ := ret[0].(*models.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Unfreeze indicates an expected call of Unfreeze.
+func (mr *MockUseCaseMockRecorder) Unfreeze(ctx, walletID, dto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unfreeze", reflect.TypeOf((*MockUseCase)(nil).Unfreeze), ctx, walletID, dto)
+}
+
+// UpdatePocketTarget mocks base method.
+func (m *MockUseCase) UpdatePocketTarget(ctx context.Context, walletID, pocketID int64, request *dto.RequestPocketTarget) (*models.Pocket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePocketTarget", ctx, walletID, pocketID, request)
+	ret0, _ := ret[0].(*models.Pocket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePocketTarget indicates an expected call of UpdatePocketTarget.
+func (mr *MockUseCaseMockRecorder) UpdatePocketTarget(ctx, walletID, pocketID, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePocketTarget", reflect.TypeOf((*MockUseCase)(nil).UpdatePocketTarget), ctx, walletID, pocketID, request)
+}
+
+// Withdraw mocks base method.
+func (m *MockUseCase) Withdraw(ctx context.Context, dto *dto.RequestWithdraw) (*models.Withdrawal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Withdraw", ctx, dto)
+	ret0, _ := ret[0].(*models.Withdrawal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Withdraw indicates an expected call of Withdraw.
+func (mr *MockUseCaseMockRecorder) Withdraw(ctx, dto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Withdraw", reflect.TypeOf((*MockUseCase)(nil).Withdraw), ctx, dto)
+}
@@ -3,6 +3,9 @@ package wallet
 
 import (
 	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
 
 	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
 	"github.com/aditwar-man/go-microservice-boilerplate/pkg/utils"
@@ -11,11 +14,67 @@ import (
 // Wallet repository interface
 type Repository interface {
 	Create(ctx context.Context, user *models.Wallet) (*models.Wallet, error)
-	FindAll(ctx context.Context, pq *utils.PaginationQuery) (*models.WalletList, error)
-	GetByID(ctx context.Context, userID int) (*models.Wallet, error)
+	FindAll(ctx context.Context, userID int, pq *utils.PaginationQuery) (*models.WalletList, error)
+	GetByID(ctx context.Context, walletID int64) (*models.Wallet, error)
+	FindDefaultWallet(ctx context.Context, userID int64, currency string) (*models.Wallet, error)
+	GetOrCreateDefaultWalletTx(ctx context.Context, userID int64, currency, name string) (*models.Wallet, error)
+
+	// Lifecycle
+	UpdateStatusTx(ctx context.Context, walletID int64, status, reason string, actorID int64) (*models.Wallet, error)
+	FindStatusEvents(ctx context.Context, walletID int64) ([]*models.WalletStatusEvent, error)
+
+	// Transaction, a nil limit check skips velocity limits
+	GetBalance(ctx context.Context, walletID int64, currency string) (*models.WalletBalance, error)
+	DepositTx(ctx context.Context, walletID int64, currency string, amount decimal.Decimal, refID string, check *models.LimitCheck) (*models.WalletBalance, error)
+	TransferTx(ctx context.Context, transfer *models.Transfer, check *models.LimitCheck) error
+	EntryPosted(ctx context.Context, refID string) (bool, error)
+	RebuildBalancesTx(ctx context.Context, walletID int64) ([]models.WalletBalance, error)
+
+	// Withdrawal
+	HoldWithdrawalTx(ctx context.Context, withdrawal *models.Withdrawal, check *models.LimitCheck) (*models.Withdrawal, error)
+	SettleWithdrawalTx(ctx context.Context, walletID, withdrawalID int64) (*models.Withdrawal, error)
+	ReleaseWithdrawalTx(ctx context.Context, walletID, withdrawalID int64) (*models.Withdrawal, error)
+
+	// Ledger
+	FindTransactions(ctx context.Context, filter *models.TransactionFilter) ([]*models.Transaction, error)
+
+	// Reversal
+	FindTransferEntry(ctx context.Context, refID string) (*models.TransferEntry, error)
+	ReverseTransferTx(ctx context.Context, reversal *models.Reversal) (*models.Reversal, error)
+	FindReversals(ctx context.Context, refID string) ([]*models.Reversal, error)
+
+	// Escrow, a transition only applies while the escrow is still in the from status
+	CreateEscrowTx(ctx context.Context, escrow *models.Escrow, check *models.LimitCheck) (*models.Escrow, error)
+	GetEscrow(ctx context.Context, escrowID int64) (*models.Escrow, error)
+	FindEscrows(ctx context.Context, userID int64) ([]*models.Escrow, error)
+	FindEscrowEvents(ctx context.Context, escrowID int64) ([]*models.EscrowEvent, error)
+	UpdateEscrowTx(ctx context.Context, escrowID int64, from, to, reason string, actorID *int64) (*models.Escrow, error)
+	FindDueEscrows(ctx context.Context, now time.Time, limit int) ([]*models.Escrow, error)
+
+	// Pockets, wallets kept under a parent wallet
+	CreatePocketTx(ctx context.Context, pocket *models.Pocket) (*models.Pocket, error)
+	GetPocket(ctx context.Context, pocketID int64) (*models.Pocket, error)
+	FindPockets(ctx context.Context, parentWalletID int64) ([]*models.Pocket, error)
+	UpdatePocketTarget(ctx context.Context, pocketID int64, amount *decimal.Decimal, date *time.Time) (*models.Pocket, error)
+	MovePocketFundsTx(ctx context.Context, move *models.PocketMove) error
+
+	// Balance snapshots, a nil snapshotAt replays from the first ledger row
+	CreateSnapshotTx(ctx context.Context, asOf time.Time) (*models.BalanceSnapshot, error)
+	LatestSnapshot(ctx context.Context, notAfter time.Time) (*models.BalanceSnapshot, error)
+	FindBalancesAt(ctx context.Context, walletID int64, snapshotAt *time.Time, at time.Time) ([]*models.HistoricalBalance, error)
+	FindAllBalancesAt(ctx context.Context, snapshotAt *time.Time, at time.Time) ([]*models.HistoricalBalance, error)
+
+	// Adjustments, a transition only applies while the adjustment is pending
+	CreateAdjustment(ctx context.Context, adjustment *models.Adjustment) (*models.Adjustment, error)
+	GetAdjustment(ctx context.Context, adjustmentID int64) (*models.Adjustment, error)
+	FindAdjustments(ctx context.Context, filter *models.AdjustmentFilter) ([]*models.Adjustment, error)
+	UpdateAdjustmentTx(ctx context.Context, adjustmentID int64, status string, actorID int64, note *string) (*models.Adjustment, error)
 
-	// Transaction
-	GetBalanceForUpdate(ctx context.Context, walletId int64, currency string) (*models.WalletBalance, error)
-	UpsertBalance(ctx context.Context, walletBalance *models.WalletBalance) error
-	TransferTx(ctx context.Context, fromID, toID int64, curFrom, curTo string, amount int64, refID string) error
+	// Risk reviews, a review is approved or rejected only while pending
+	HoldForReviewTx(ctx context.Context, review *models.RiskReview) (*models.RiskReview, error)
+	GetRiskReview(ctx context.Context, reviewID int64) (*models.RiskReview, error)
+	GetRiskReviewByRef(ctx context.Context, operation, refID string) (*models.RiskReview, error)
+	FindRiskReviews(ctx context.Context, filter *models.RiskReviewFilter) ([]*models.RiskReview, error)
+	ApproveRiskReviewTx(ctx context.Context, reviewID, reviewerID int64, note *string, check *models.LimitCheck) (*models.RiskReview, error)
+	RejectRiskReviewTx(ctx context.Context, reviewID, reviewerID int64, note *string) (*models.RiskReview, error)
 }
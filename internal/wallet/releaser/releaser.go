@@ -0,0 +1,91 @@
+package releaser
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/config"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/wallet"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/logger"
+)
+
+const (
+	defaultBatchSize    = 50
+	defaultPollInterval = 30 * time.Second
+
+	timeoutReason = "release timeout"
+)
+
+// Releaser releases held escrows to the seller once their release time has
+// passed. The transition is guarded by the escrow row lock, so an escrow
+// disputed or confirmed in the meantime is left alone.
+type Releaser struct {
+	walletRepo   wallet.Repository
+	batchSize    int
+	pollInterval time.Duration
+	logger       logger.Logger
+	now          func() time.Time
+}
+
+// Releaser constructor, zero config values fall back to defaults
+func NewReleaser(cfg *config.Config, walletRepo wallet.Repository, log logger.Logger) *Releaser {
+	r := &Releaser{
+		walletRepo:   walletRepo,
+		batchSize:    cfg.Escrow.BatchSize,
+		pollInterval: time.Duration(cfg.Escrow.PollIntervalSeconds) * time.Second,
+		logger:       log,
+		now:          time.Now,
+	}
+
+	if r.batchSize <= 0 {
+		r.batchSize = defaultBatchSize
+	}
+	if r.pollInterval <= 0 {
+		r.pollInterval = defaultPollInterval
+	}
+
+	return r
+}
+
+// Poll for due escrows until ctx is done
+func (r *Releaser) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := r.ProcessBatch(ctx); err != nil {
+			r.logger.Errorf("escrow releaser: %s", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Release one batch of due escrows, returns how many were released
+func (r *Releaser) ProcessBatch(ctx context.Context) (int, error) {
+	due, err := r.walletRepo.FindDueEscrows(ctx, r.now(), r.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	released := 0
+	for _, e := range due {
+		_, err := r.walletRepo.UpdateEscrowTx(ctx, e.ID, models.EscrowStatusHeld, models.EscrowStatusReleased, timeoutReason, nil)
+		switch {
+		case err == nil:
+			released++
+		case errors.Is(err, wallet.ErrEscrowTransition):
+			// disputed or settled since it was listed
+		default:
+			r.logger.Warnf("escrow releaser: escrow %d: %s", e.ID, err)
+		}
+	}
+
+	return released, nil
+}
@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/wallet"
+)
+
+// Record a pending adjustment, nothing is posted until it is approved
+func (r *walletRepo) CreateAdjustment(ctx context.Context, a *models.Adjustment) (*models.Adjustment, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletRepo.CreateAdjustment")
+	defer span.Finish()
+
+	created := &models.Adjustment{}
+	if err := r.db.QueryRowxContext(ctx, createAdjustmentQuery,
+		a.WalletID, a.Currency, a.Direction, a.Amount, a.Reason, a.Attachments, a.RequestedBy,
+	).StructScan(created); err != nil {
+		return nil, errors.Wrap(err, "walletRepo.CreateAdjustment.StructScan")
+	}
+
+	return created, nil
+}
+
+func (r *walletRepo) GetAdjustment(ctx context.Context, adjustmentID int64) (*models.Adjustment, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletRepo.GetAdjustment")
+	defer span.Finish()
+
+	a := &models.Adjustment{}
+	if err := r.db.GetContext(ctx, a, getAdjustmentQuery, adjustmentID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, wallet.ErrAdjustmentNotFound
+		}
+		return nil, errors.Wrap(err, "walletRepo.GetAdjustment.GetContext")
+	}
+
+	return a, nil
+}
+
+// Find adjustments matching filter, newest first
+func (r *walletRepo) FindAdjustments(ctx context.Context, filter *models.AdjustmentFilter) ([]*models.Adjustment, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletRepo.FindAdjustments")
+	defer span.Finish()
+
+	adjustments := make([]*models.Adjustment, 0)
+	if err := r.db.SelectContext(ctx, &adjustments, findAdjustmentsQuery,
+		filter.Status, filter.WalletID, filter.RequestedBy, filter.Limit,
+	); err != nil {
+		return nil, errors.Wrap(err, "walletRepo.FindAdjustments.SelectContext")
+	}
+
+	return adjustments, nil
+}
+
+// Move a pending adjustment to status under a row lock. Approving posts the
+// adjustment against the adjustments account in the same transaction, a debit
+// fails when the wallet cannot cover it.
+func (r *walletRepo) UpdateAdjustmentTx(ctx context.Context, adjustmentID int64, status string, actorID int64, note *string) (*models.Adjustment, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletRepo.UpdateAdjustmentTx")
+	defer span.Finish()
+
+	updated := &models.Adjustment{}
+	err := r.execTx(ctx, func(tx *sqlx.Tx) error {
+		a := &models.Adjustment{}
+		if err := tx.GetContext(ctx, a, getAdjustmentForUpdateQuery, adjustmentID); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return wallet.ErrAdjustmentNotFound
+			}
+			return errors.Wrap(err, "walletRepo.UpdateAdjustmentTx.GetContext")
+		}
+
+		if a.Status != models.AdjustmentPending {
+			return wallet.ErrAdjustmentNotPending
+		}
+
+		var entryID *int64
+		if status == models.AdjustmentApproved {
+			entry, err := adjustmentEntry(a, actorID)
+			if err != nil {
+				return err
+			}
+
+			// the adjustment is pending under the row lock, so its reference
+			// can only have been taken by another entry
+			if err := r.postEntryTx(ctx, tx, entry); err != nil {
+				if errors.Is(err, errDuplicateEntry) {
+					return wallet.ErrReferenceInUse
+				}
+				return err
+			}
+			entryID = &entry.ID
+
+			if err := r.writeEventTx(ctx, tx, a.WalletID, models.EventWalletAdjusted, map[string]interface{}{
+				"adjustment_id": a.ID,
+				"entry_id":      entry.ID,
+				"wallet_id":     a.WalletID,
+				"currency":      a.Currency,
+				"amount":        a.SignedAmount(),
+			}); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.QueryRowxContext(ctx, updateAdjustmentQuery, a.ID, status, actorID, note, entryID).StructScan(updated); err != nil {
+			return errors.Wrap(err, "walletRepo.UpdateAdjustmentTx.StructScan")
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// Journal entry of an approved adjustment
+func adjustmentEntry(a *models.Adjustment, approvedBy int64) (*models.JournalEntry, error) {
+	meta, err := json.Marshal(map[string]interface{}{
+		"adjustment_id": a.ID,
+		"requested_by":  a.RequestedBy,
+		"approved_by":   approvedBy,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "walletRepo.adjustmentEntry.Marshal")
+	}
+
+	amount := a.SignedAmount()
+	return &models.JournalEntry{
+		Type:        models.EntryTypeAdjustment,
+		RefID:       a.RefID(),
+		Description: "Adjustment: " + a.Reason,
+		Meta:        meta,
+		Postings: []models.Posting{
+			models.WalletPosting(a.WalletID, models.TypeAdjustment, a.Currency, amount),
+			models.SystemPosting(models.AccountAdjustments, models.TypeAdjustment, a.Currency, amount.Neg()),
+		},
+	}, nil
+}
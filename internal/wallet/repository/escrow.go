@@ -0,0 +1,237 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/wallet"
+)
+
+// Outbox event published for each escrow state
+var escrowEvents = map[string]string{
+	models.EscrowStatusHeld:     models.EventEscrowHeld,
+	models.EscrowStatusReleased: models.EventEscrowReleased,
+	models.EscrowStatusRefunded: models.EventEscrowRefunded,
+	models.EscrowStatusDisputed: models.EventEscrowDisputed,
+}
+
+// Open an escrow, moving the amount from the buyer to the escrow account.
+// The buyer balance is locked by postEntryTx. A repeated ref_id returns the
+// escrow already opened when it is the same escrow, ErrReferenceInUse otherwise.
+func (r *walletRepo) CreateEscrowTx(ctx context.Context, e *models.Escrow, check *models.LimitCheck) (*models.Escrow, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletRepo.CreateEscrowTx")
+	defer span.Finish()
+
+	created := &models.Escrow{}
+	err := r.execTx(ctx, func(tx *sqlx.Tx) error {
+		if err := tx.GetContext(ctx, created, getEscrowByRefQuery, e.RefID); err == nil {
+			if !created.SameTerms(e) {
+				return wallet.ErrReferenceInUse
+			}
+			return nil
+		} else if !errors.Is(err, sql.ErrNoRows) {
+			return errors.Wrap(err, "walletRepo.CreateEscrowTx.GetContext.escrow")
+		}
+
+		if err := tx.QueryRowxContext(ctx, createEscrowQuery,
+			e.RefID, e.BuyerWalletID, e.SellerWalletID, e.Currency, e.Amount, e.Description, e.ReleaseAt, e.CreatedBy,
+		).StructScan(created); err != nil {
+			return errors.Wrap(err, "walletRepo.CreateEscrowTx.StructScan")
+		}
+
+		entry, err := escrowEntry(created, models.EscrowStatusHeld)
+		if err != nil {
+			return err
+		}
+
+		if err := r.postEntryTx(ctx, tx, entry); err != nil {
+			if errors.Is(err, errDuplicateEntry) {
+				return wallet.ErrReferenceInUse
+			}
+			return err
+		}
+
+		if err := r.applyLimitsTx(ctx, tx, check, e.RefID); err != nil {
+			return err
+		}
+
+		return r.recordEscrowTx(ctx, tx, created, "", "opened", e.CreatedBy, &entry.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (r *walletRepo) GetEscrow(ctx context.Context, escrowID int64) (*models.Escrow, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletRepo.GetEscrow")
+	defer span.Finish()
+
+	e := &models.Escrow{}
+	if err := r.db.GetContext(ctx, e, getEscrowQuery, escrowID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, wallet.ErrEscrowNotFound
+		}
+		return nil, errors.Wrap(err, "walletRepo.GetEscrow.GetContext")
+	}
+
+	return e, nil
+}
+
+// Find the escrows a user is buyer or seller in, newest first
+func (r *walletRepo) FindEscrows(ctx context.Context, userID int64) ([]*models.Escrow, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletRepo.FindEscrows")
+	defer span.Finish()
+
+	escrows := make([]*models.Escrow, 0)
+	if err := r.db.SelectContext(ctx, &escrows, findUserEscrowsQuery, userID); err != nil {
+		return nil, errors.Wrap(err, "walletRepo.FindEscrows.SelectContext")
+	}
+
+	return escrows, nil
+}
+
+// Find the state changes of an escrow, oldest first
+func (r *walletRepo) FindEscrowEvents(ctx context.Context, escrowID int64) ([]*models.EscrowEvent, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletRepo.FindEscrowEvents")
+	defer span.Finish()
+
+	events := make([]*models.EscrowEvent, 0)
+	if err := r.db.SelectContext(ctx, &events, findEscrowEventsQuery, escrowID); err != nil {
+		return nil, errors.Wrap(err, "walletRepo.FindEscrowEvents.SelectContext")
+	}
+
+	return events, nil
+}
+
+// Move an escrow from one state to another under a row lock. Releasing and
+// refunding post the funds out of the escrow account, a dispute only freezes it.
+func (r *walletRepo) UpdateEscrowTx(ctx context.Context, escrowID int64, from, to, reason string, actorID *int64) (*models.Escrow, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletRepo.UpdateEscrowTx")
+	defer span.Finish()
+
+	updated := &models.Escrow{}
+	err := r.execTx(ctx, func(tx *sqlx.Tx) error {
+		e := &models.Escrow{}
+		if err := tx.GetContext(ctx, e, getEscrowForUpdateQuery, escrowID); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return wallet.ErrEscrowNotFound
+			}
+			return errors.Wrap(err, "walletRepo.UpdateEscrowTx.GetContext")
+		}
+
+		if e.Status != from || !e.CanTransition(to) {
+			return wallet.ErrEscrowTransition
+		}
+
+		var entryID *int64
+		if to != models.EscrowStatusDisputed {
+			entry, err := escrowEntry(e, to)
+			if err != nil {
+				return err
+			}
+			if err := r.postEntryTx(ctx, tx, entry); err != nil {
+				return err
+			}
+			entryID = &entry.ID
+		}
+
+		var disputeReason *string
+		if to == models.EscrowStatusDisputed {
+			disputeReason = &reason
+		}
+
+		if err := tx.QueryRowxContext(ctx, updateEscrowStatusQuery, to, disputeReason, e.ID).StructScan(updated); err != nil {
+			return errors.Wrap(err, "walletRepo.UpdateEscrowTx.StructScan")
+		}
+
+		return r.recordEscrowTx(ctx, tx, updated, from, reason, actorID, entryID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// Find held escrows whose release time has passed
+func (r *walletRepo) FindDueEscrows(ctx context.Context, now time.Time, limit int) ([]*models.Escrow, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletRepo.FindDueEscrows")
+	defer span.Finish()
+
+	escrows := make([]*models.Escrow, 0, limit)
+	if err := r.db.SelectContext(ctx, &escrows, findDueEscrowsQuery, now, limit); err != nil {
+		return nil, errors.Wrap(err, "walletRepo.FindDueEscrows.SelectContext")
+	}
+
+	return escrows, nil
+}
+
+// Write the audit row and the outbox event of an escrow state change
+func (r *walletRepo) recordEscrowTx(ctx context.Context, tx *sqlx.Tx, e *models.Escrow, from, reason string, actorID, entryID *int64) error {
+	if _, err := tx.ExecContext(ctx, createEscrowEventQuery, e.ID, from, e.Status, reason, actorID, entryID); err != nil {
+		return errors.Wrap(err, "walletRepo.recordEscrowTx.ExecContext")
+	}
+
+	// both parties are named so webhooks reach the buyer and the seller
+	return r.writeEventTx(ctx, tx, e.BuyerWalletID, escrowEvents[e.Status], map[string]interface{}{
+		"escrow_id":      e.ID,
+		"ref_id":         e.RefID,
+		"from_wallet_id": e.BuyerWalletID,
+		"to_wallet_id":   e.SellerWalletID,
+		"currency":       e.Currency,
+		"amount":         e.Amount,
+		"status":         e.Status,
+		"entry_id":       entryID,
+		"reason":         reason,
+	})
+}
+
+// Journal entry moving the escrow funds for the escrow entering status, its
+// ref_id is a system reference derived from the escrow ref_id
+func escrowEntry(e *models.Escrow, status string) (*models.JournalEntry, error) {
+	account := models.EscrowAccount(e.ID)
+
+	entry := &models.JournalEntry{Type: models.EntryTypeEscrow}
+	switch status {
+	case models.EscrowStatusHeld:
+		entry.RefID = models.SystemRef(e.RefID, "hold")
+		entry.Description = "Escrow hold"
+		entry.Postings = []models.Posting{
+			models.WalletPosting(e.BuyerWalletID, models.TypeEscrowHold, e.Currency, e.Amount.Neg()),
+			models.SystemPosting(account, models.TypeEscrowHold, e.Currency, e.Amount),
+		}
+	case models.EscrowStatusReleased:
+		entry.RefID = models.SystemRef(e.RefID, "release")
+		entry.Description = "Escrow release"
+		entry.Postings = []models.Posting{
+			models.SystemPosting(account, models.TypeEscrowRelease, e.Currency, e.Amount.Neg()),
+			models.WalletPosting(e.SellerWalletID, models.TypeEscrowRelease, e.Currency, e.Amount),
+		}
+	case models.EscrowStatusRefunded:
+		entry.RefID = models.SystemRef(e.RefID, "refund")
+		entry.Description = "Escrow refund"
+		entry.Postings = []models.Posting{
+			models.SystemPosting(account, models.TypeEscrowRefund, e.Currency, e.Amount.Neg()),
+			models.WalletPosting(e.BuyerWalletID, models.TypeEscrowRefund, e.Currency, e.Amount),
+		}
+	default:
+		return nil, errors.Errorf("walletRepo.escrowEntry: no funds movement for %s", status)
+	}
+
+	meta, err := json.Marshal(map[string]interface{}{"escrow_id": e.ID})
+	if err != nil {
+		return nil, errors.Wrap(err, "walletRepo.escrowEntry.json.Marshal")
+	}
+	entry.Meta = meta
+
+	return entry, nil
+}
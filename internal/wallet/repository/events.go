@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	outboxRepository "github.com/aditwar-man/go-microservice-boilerplate/internal/outbox/repository"
+)
+
+// Append a wallet event to the outbox inside tx, it is only relayed if tx commits
+func (r *walletRepo) writeEventTx(ctx context.Context, tx *sqlx.Tx, walletID int64, eventType string, payload interface{}) error {
+	event, err := models.NewOutboxEvent(models.AggregateWallet, strconv.FormatInt(walletID, 10), eventType, payload)
+	if err != nil {
+		return errors.Wrap(err, "walletRepo.writeEventTx.NewOutboxEvent")
+	}
+
+	return outboxRepository.WriteEventsTx(ctx, tx, event)
+}
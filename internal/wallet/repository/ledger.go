@@ -0,0 +1,224 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/wallet"
+)
+
+// Returned by postEntryTx when the entry ref_id was already posted
+var errDuplicateEntry = errors.New("journal entry already posted")
+
+type balanceKey struct {
+	walletID int64
+	currency string
+}
+
+// Post a balanced journal entry inside tx. Wallet postings are applied to the
+// wallet_balances projection and mirrored as txs rows, balances are locked in
+// deterministic (wallet_id, currency) order to avoid deadlocks.
+func (r *walletRepo) postEntryTx(ctx context.Context, tx *sqlx.Tx, entry *models.JournalEntry) error {
+	return r.writeEntryTx(ctx, tx, entry, true)
+}
+
+// Post a repair entry inside a transaction of another repository. The entry
+// takes the same wallet status checks, lock order and ledger rows as any
+// other, but wallet_balances already holds it and is left as it is. A ref_id
+// already posted is ErrReferenceInUse.
+func PostRepairEntryTx(ctx context.Context, tx *sqlx.Tx, entry *models.JournalEntry) error {
+	r := &walletRepo{}
+	if err := r.writeEntryTx(ctx, tx, entry, false); err != nil {
+		if errors.Is(err, errDuplicateEntry) {
+			return wallet.ErrReferenceInUse
+		}
+		return err
+	}
+	return nil
+}
+
+// Write entry, its postings and txs rows, applying wallet postings to the
+// balances when applyBalances
+func (r *walletRepo) writeEntryTx(ctx context.Context, tx *sqlx.Tx, entry *models.JournalEntry, applyBalances bool) error {
+	if err := entry.Validate(); err != nil {
+		return err
+	}
+
+	if err := tx.QueryRowxContext(ctx, createJournalEntryQuery,
+		entry.Type, entry.RefID, entry.Description, entry.Meta, entry.ReversesEntryID,
+	).Scan(&entry.ID, &entry.CreatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errDuplicateEntry
+		}
+		return errors.Wrap(err, "walletRepo.writeEntryTx.Scan.entry")
+	}
+
+	walletIDs := make([]int64, 0, len(entry.Postings))
+	for _, p := range entry.Postings {
+		if p.WalletID != nil {
+			walletIDs = append(walletIDs, *p.WalletID)
+		}
+	}
+	if err := r.lockWalletsTx(ctx, tx, walletIDs); err != nil {
+		return err
+	}
+
+	balances, err := r.lockBalancesTx(ctx, tx, entry.Postings)
+	if err != nil {
+		return err
+	}
+
+	if err := r.lockChainHeadsTx(ctx, tx, walletIDs); err != nil {
+		return err
+	}
+
+	for i := range entry.Postings {
+		p := &entry.Postings[i]
+		p.EntryID = entry.ID
+
+		if err := tx.QueryRowxContext(ctx, createPostingQuery,
+			p.EntryID, p.Account, p.WalletID, p.Type, p.Currency, p.Amount,
+		).Scan(&p.ID, &p.CreatedAt); err != nil {
+			return errors.Wrap(err, "walletRepo.writeEntryTx.Scan.posting")
+		}
+
+		if p.WalletID == nil {
+			continue
+		}
+
+		if applyBalances {
+			b := balances[balanceKey{*p.WalletID, p.Currency}]
+			if p.Amount.IsNegative() && b.Available().Add(p.Amount).IsNegative() {
+				return wallet.ErrInsufficientFunds
+			}
+			b.Amount = b.Amount.Add(p.Amount)
+
+			if _, err := tx.ExecContext(ctx, applyBalanceQuery, p.Amount, *p.WalletID, p.Currency); err != nil {
+				return errors.Wrap(err, "walletRepo.writeEntryTx.ExecContext.balance")
+			}
+		}
+
+		if _, err := tx.ExecContext(ctx, insertEntryTxQuery,
+			*p.WalletID, p.Type, p.Currency, p.Amount, entry.RefID+"-"+p.Type, entry.ID,
+		); err != nil {
+			return errors.Wrap(err, "walletRepo.writeEntryTx.ExecContext.ledger")
+		}
+	}
+
+	return nil
+}
+
+// A ref_id already posted is only a replay when it posted entry itself, the
+// same type and postings. A reference used for anything else is refused.
+func (r *walletRepo) checkEntryReplay(ctx context.Context, q sqlx.QueryerContext, entry *models.JournalEntry) error {
+	var entryID int64
+	if err := sqlx.GetContext(ctx, q, &entryID, getEntryByRefQuery, entry.RefID, entry.Type); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return wallet.ErrReferenceInUse
+		}
+		return errors.Wrap(err, "walletRepo.checkEntryReplay.GetContext")
+	}
+
+	postings := make([]models.Posting, 0, len(entry.Postings))
+	if err := sqlx.SelectContext(ctx, q, &postings, findEntryPostingsQuery, entryID); err != nil {
+		return errors.Wrap(err, "walletRepo.checkEntryReplay.SelectContext")
+	}
+
+	if !entry.SamePostings(postings) {
+		return wallet.ErrReferenceInUse
+	}
+
+	return nil
+}
+
+// Lock (creating when missing) the balances touched by wallet postings
+func (r *walletRepo) lockBalancesTx(ctx context.Context, tx *sqlx.Tx, postings []models.Posting) (map[balanceKey]*models.WalletBalance, error) {
+	keys := make([]balanceKey, 0, len(postings))
+	balances := make(map[balanceKey]*models.WalletBalance, len(postings))
+	for _, p := range postings {
+		if p.WalletID == nil {
+			continue
+		}
+		k := balanceKey{*p.WalletID, p.Currency}
+		if _, ok := balances[k]; !ok {
+			balances[k] = nil
+			keys = append(keys, k)
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].walletID != keys[j].walletID {
+			return keys[i].walletID < keys[j].walletID
+		}
+		return keys[i].currency < keys[j].currency
+	})
+
+	for _, k := range keys {
+		if _, err := tx.ExecContext(ctx, ensureBalanceQuery, k.walletID, k.currency); err != nil {
+			return nil, errors.Wrap(err, "walletRepo.lockBalancesTx.ExecContext")
+		}
+
+		b := &models.WalletBalance{}
+		if err := tx.GetContext(ctx, b, getBalanceForUpdateQuery, k.walletID, k.currency); err != nil {
+			return nil, errors.Wrap(err, "walletRepo.lockBalancesTx.GetContext")
+		}
+		balances[k] = b
+	}
+
+	return balances, nil
+}
+
+// Share lock the wallets in id order and require them to be active
+func (r *walletRepo) lockWalletsTx(ctx context.Context, tx *sqlx.Tx, walletIDs []int64) error {
+	for _, id := range sortedIDs(walletIDs) {
+		var status string
+		if err := tx.GetContext(ctx, &status, lockWalletStatusQuery, id); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return wallet.ErrWalletNotFound
+			}
+			return errors.Wrap(err, "walletRepo.lockWalletsTx.GetContext")
+		}
+
+		if err := wallet.StatusError(status); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Lock the hash chain heads of the wallets in id order. The txs insert trigger
+// locks the head of each row it chains, taking them here first keeps entries
+// touching the same wallets from locking them in opposite orders.
+func (r *walletRepo) lockChainHeadsTx(ctx context.Context, tx *sqlx.Tx, walletIDs []int64) error {
+	for _, id := range sortedIDs(walletIDs) {
+		if _, err := tx.ExecContext(ctx, ensureChainHeadQuery, id); err != nil {
+			return errors.Wrap(err, "walletRepo.lockChainHeadsTx.ExecContext.ensure")
+		}
+		if _, err := tx.ExecContext(ctx, lockChainHeadQuery, id); err != nil {
+			return errors.Wrap(err, "walletRepo.lockChainHeadsTx.ExecContext.lock")
+		}
+	}
+
+	return nil
+}
+
+// Distinct ids in ascending order
+func sortedIDs(walletIDs []int64) []int64 {
+	ids := make([]int64, 0, len(walletIDs))
+	seen := make(map[int64]struct{}, len(walletIDs))
+	for _, id := range walletIDs {
+		if _, ok := seen[id]; !ok {
+			seen[id] = struct{}{}
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	return ids
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/httpErrors"
+)
+
+// Enforce the velocity limits of check inside tx and record its usage under refID.
+// Must run after the ledger entry was posted so replays never count twice.
+func (r *walletRepo) applyLimitsTx(ctx context.Context, tx *sqlx.Tx, check *models.LimitCheck, refID string) error {
+	if check == nil || len(check.Rules) == 0 {
+		return nil
+	}
+
+	if _, err := tx.ExecContext(ctx, lockLimitUserQuery, check.UserID); err != nil {
+		return errors.Wrap(err, "walletRepo.applyLimitsTx.ExecContext.lock")
+	}
+
+	for i := range check.Rules {
+		rule := &check.Rules[i]
+
+		if rule.Window() == 0 {
+			if check.Amount.GreaterThan(rule.MaxAmount) {
+				return httpErrors.NewLimitError(rule.Name(), check.Currency,
+					rule.MaxAmount.String(), decimal.Zero.String(), check.Amount.String(), nil)
+			}
+			continue
+		}
+
+		query, scope := findUserLimitUsageQuery, check.UserID
+		if rule.Aggregate == models.LimitAggregateWallet {
+			query, scope = findWalletLimitUsageQuery, check.WalletID
+		}
+
+		usage := make([]models.LimitUsage, 0)
+		if err := tx.SelectContext(ctx, &usage, query,
+			scope, check.Operation, check.Currency, rule.Window().Seconds(),
+		); err != nil {
+			return errors.Wrap(err, "walletRepo.applyLimitsTx.SelectContext.usage")
+		}
+
+		used := decimal.Zero
+		for _, u := range usage {
+			used = used.Add(u.Amount)
+		}
+
+		if used.Add(check.Amount).GreaterThan(rule.MaxAmount) {
+			return httpErrors.NewLimitError(rule.Name(), check.Currency,
+				rule.MaxAmount.String(), used.String(), check.Amount.String(),
+				limitResetsAt(usage, used, check.Amount, rule))
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, createLimitUsageQuery,
+		check.UserID, check.WalletID, check.Operation, check.Currency, check.Amount, refID,
+	); err != nil {
+		return errors.Wrap(err, "walletRepo.applyLimitsTx.ExecContext.usage")
+	}
+
+	return nil
+}
+
+// Earliest time enough usage rolls out of the window for amount to fit,
+// nil when amount alone exceeds the rule
+func limitResetsAt(usage []models.LimitUsage, used, amount decimal.Decimal, rule *models.LimitRule) *time.Time {
+	if amount.GreaterThan(rule.MaxAmount) {
+		return nil
+	}
+
+	for _, u := range usage {
+		used = used.Sub(u.Amount)
+		if !used.Add(amount).GreaterThan(rule.MaxAmount) {
+			resetsAt := u.CreatedAt.Add(rule.Window())
+			return &resetsAt
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+)
+
+func TestLimitResetsAt(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	rule := &models.LimitRule{Period: models.LimitPeriodDay, MaxAmount: decimal.NewFromInt(100)}
+	usage := []models.LimitUsage{
+		{Amount: decimal.NewFromInt(40), CreatedAt: start},
+		{Amount: decimal.NewFromInt(50), CreatedAt: start.Add(time.Hour)},
+	}
+	used := decimal.NewFromInt(90)
+
+	// 30 fits once the first 40 rolls out of the window
+	resetsAt := limitResetsAt(usage, used, decimal.NewFromInt(30), rule)
+	require.NotNil(t, resetsAt)
+	require.Equal(t, start.Add(24*time.Hour), *resetsAt)
+
+	// 60 needs both rows gone
+	resetsAt = limitResetsAt(usage, used, decimal.NewFromInt(60), rule)
+	require.NotNil(t, resetsAt)
+	require.Equal(t, start.Add(25*time.Hour), *resetsAt)
+
+	// never fits
+	require.Nil(t, limitResetsAt(usage, used, decimal.NewFromInt(101), rule))
+}
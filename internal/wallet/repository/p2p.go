@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/wallet"
+)
+
+// Default wallet of a user for a currency, the oldest active wallet that
+// already holds the currency or else the oldest active wallet. A user with
+// any frozen wallet has none.
+func (r *walletRepo) FindDefaultWallet(ctx context.Context, userID int64, currency string) (*models.Wallet, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletRepo.FindDefaultWallet")
+	defer span.Finish()
+
+	return findDefaultWallet(ctx, r.db, userID, currency)
+}
+
+// Default wallet of a user for a currency, a wallet named name is created
+// when the user has no active wallet and none is frozen
+func (r *walletRepo) GetOrCreateDefaultWalletTx(ctx context.Context, userID int64, currency, name string) (*models.Wallet, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletRepo.GetOrCreateDefaultWalletTx")
+	defer span.Finish()
+
+	found := &models.Wallet{}
+	err := r.execTx(ctx, func(tx *sqlx.Tx) error {
+		if _, err := tx.ExecContext(ctx, lockUserWalletsQuery, userID); err != nil {
+			return errors.Wrap(err, "walletRepo.GetOrCreateDefaultWalletTx.ExecContext.lock")
+		}
+
+		w, err := findDefaultWallet(ctx, tx, userID, currency)
+		if err == nil {
+			found = w
+			return nil
+		}
+		if !errors.Is(err, wallet.ErrWalletNotFound) {
+			return err
+		}
+
+		if err := tx.QueryRowxContext(ctx, createWalletQuery, userID, name).StructScan(found); err != nil {
+			return errors.Wrap(err, "walletRepo.GetOrCreateDefaultWalletTx.StructScan")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return found, nil
+}
+
+func findDefaultWallet(ctx context.Context, q sqlx.QueryerContext, userID int64, currency string) (*models.Wallet, error) {
+	// a frozen user is not paid into another wallet of theirs
+	var frozen bool
+	if err := sqlx.GetContext(ctx, q, &frozen, hasFrozenWalletQuery, userID); err != nil {
+		return nil, errors.Wrap(err, "walletRepo.findDefaultWallet.GetContext.frozen")
+	}
+	if frozen {
+		return nil, wallet.ErrRecipientFrozen
+	}
+
+	w := &models.Wallet{}
+	if err := q.QueryRowxContext(ctx, findDefaultWalletQuery, userID, currency).StructScan(w); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, wallet.ErrWalletNotFound
+		}
+		return nil, errors.Wrap(err, "walletRepo.findDefaultWallet.QueryRowxContext")
+	}
+
+	return w, nil
+}
@@ -3,12 +3,17 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/opentracing/opentracing-go"
 	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
 
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/fx"
 	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
 	"github.com/aditwar-man/go-microservice-boilerplate/internal/wallet"
 	"github.com/aditwar-man/go-microservice-boilerplate/pkg/utils"
@@ -36,12 +41,12 @@ func (r *walletRepo) Create(ctx context.Context, wallet *models.Wallet) (*models
 	return w, nil
 }
 
-func (r *walletRepo) FindAll(ctx context.Context, pq *utils.PaginationQuery) (*models.WalletList, error) {
+func (r *walletRepo) FindAll(ctx context.Context, userID int, pq *utils.PaginationQuery) (*models.WalletList, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "walletRepo.FindAll")
 	defer span.Finish()
 
 	var totalCount int
-	if err := r.db.GetContext(ctx, &totalCount, getTotal); err != nil {
+	if err := r.db.GetContext(ctx, &totalCount, getTotal, userID); err != nil {
 		return nil, errors.Wrap(err, "walletRepo.FindAll.GetContext.totalCount")
 	}
 
@@ -56,62 +61,59 @@ func (r *walletRepo) FindAll(ctx context.Context, pq *utils.PaginationQuery) (*m
 		}, nil
 	}
 
-	// join wallets + balances
-	rows, err := r.db.QueryxContext(ctx, `
-		SELECT
-			w.id, w.user_id, w.name, w.created_at,
-			b.wallet_id, b.currency, b.amount
-		FROM wallets w
-		LEFT JOIN wallet_balances b ON w.id = b.wallet_id
-		ORDER BY w.id
-		LIMIT $1 OFFSET $2
-	`, pq.GetLimit(), pq.GetOffset())
+	// page over the user's wallets, then join their balances
+	rows, err := r.db.QueryxContext(ctx, findUserWalletsQuery, userID, pq.GetLimit(), pq.GetOffset())
 	if err != nil {
 		return nil, errors.Wrap(err, "walletRepo.FindAll.QueryxContext")
 	}
 	defer rows.Close()
 
+	wallets := make([]*models.Wallet, 0, pq.GetSize())
 	walletMap := make(map[int64]*models.Wallet)
 
 	for rows.Next() {
 		var (
 			wID      int64
-			userID   uint
+			uID      uint
 			name     string
+			status   string
 			created  time.Time
 			bWallet  *int64
 			currency *string
-			amount   *int64
+			amount   decimal.NullDecimal
 		)
 
-		if err := rows.Scan(&wID, &userID, &name, &created, &bWallet, &currency, &amount); err != nil {
+		if err := rows.Scan(&wID, &uID, &name, &status, &created, &bWallet, &currency, &amount); err != nil {
 			return nil, errors.Wrap(err, "walletRepo.FindAll.Scan")
 		}
 
 		if _, ok := walletMap[wID]; !ok {
 			walletMap[wID] = &models.Wallet{
 				ID:        wID,
-				UserID:    userID,
+				UserID:    uID,
 				Name:      name,
+				Status:    status,
 				CreatedAt: created,
 				Balances:  []models.WalletBalance{},
 			}
+			wallets = append(wallets, walletMap[wID])
 		}
 
 		// kalau ada balance, tambahkan
-		if bWallet != nil && currency != nil && amount != nil {
+		if bWallet != nil && currency != nil && amount.Valid {
 			walletMap[wID].Balances = append(walletMap[wID].Balances, models.WalletBalance{
 				WalletID: *bWallet,
 				Currency: *currency,
-				Amount:   *amount,
+				Amount:   amount.Decimal,
 			})
 		}
 	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "walletRepo.FindAll.rows.Err")
+	}
 
-	// convert map ke slice
-	wallets := make([]*models.Wallet, 0, len(walletMap))
-	for _, w := range walletMap {
-		wallets = append(wallets, w)
+	if err := r.addPocketTotals(ctx, wallets); err != nil {
+		return nil, err
 	}
 
 	return &models.WalletList{
@@ -124,209 +126,536 @@ func (r *walletRepo) FindAll(ctx context.Context, pq *utils.PaginationQuery) (*m
 	}, nil
 }
 
-func (r *walletRepo) GetByID(ctx context.Context, walletID int) (*models.Wallet, error) {
+func (r *walletRepo) GetByID(ctx context.Context, walletID int64) (*models.Wallet, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "walletRepo.GetByID")
 	defer span.Finish()
 
 	foundWallet := &models.Wallet{}
 	if err := r.db.QueryRowxContext(ctx, getWalletByID, walletID).StructScan(foundWallet); err != nil {
-		return nil, errors.Wrap(err, "walletRepo.FindByUsername.QueryRowxContext")
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, wallet.ErrWalletNotFound
+		}
+		return nil, errors.Wrap(err, "walletRepo.GetByID.QueryRowxContext")
 	}
 
 	return foundWallet, nil
 }
 
-func (r *walletRepo) GetBalanceForUpdate(ctx context.Context, walletID int64, currency string) (*models.WalletBalance, error) {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "WalletRepo.GetBalanceForUpdate")
+// Move a wallet to status, recording reason and acting user. Closing requires
+// every balance of the wallet to be zero with nothing held.
+func (r *walletRepo) UpdateStatusTx(ctx context.Context, walletID int64, status, reason string, actorID int64) (*models.Wallet, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletRepo.UpdateStatusTx")
 	defer span.Finish()
 
-	const getBalanceForUpdateQuery = `
-		SELECT wallet_id, currency, amount
-		FROM wallet_balances
-		WHERE wallet_id = $1 AND currency = $2
-		FOR UPDATE
-	`
+	updated := &models.Wallet{}
+	err := r.execTx(ctx, func(tx *sqlx.Tx) error {
+		current := &models.Wallet{}
+		if err := tx.GetContext(ctx, current, getWalletForUpdateQuery, walletID); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return wallet.ErrWalletNotFound
+			}
+			return errors.Wrap(err, "walletRepo.UpdateStatusTx.GetContext.wallet")
+		}
 
-	b := &models.WalletBalance{}
-	if err := r.db.QueryRowxContext(ctx, getBalanceForUpdateQuery, walletID, currency).StructScan(b); err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, nil
+		if !current.CanTransition(status) {
+			return wallet.ErrWalletTransition
+		}
+
+		if status == models.WalletStatusClosed {
+			var open int
+			if err := tx.GetContext(ctx, &open, countOpenBalancesQuery, walletID); err != nil {
+				return errors.Wrap(err, "walletRepo.UpdateStatusTx.GetContext.balances")
+			}
+			if open > 0 {
+				return wallet.ErrWalletNotEmpty
+			}
+		}
+
+		if err := tx.QueryRowxContext(ctx, updateWalletStatusQuery, status, reason, actorID, walletID).StructScan(updated); err != nil {
+			return errors.Wrap(err, "walletRepo.UpdateStatusTx.StructScan")
 		}
-		return nil, errors.Wrap(err, "WalletRepo.GetBalanceForUpdate.StructScan")
+
+		if _, err := tx.ExecContext(ctx, createWalletStatusEventQuery, walletID, current.Status, status, reason, actorID); err != nil {
+			return errors.Wrap(err, "walletRepo.UpdateStatusTx.ExecContext.event")
+		}
+
+		return r.writeEventTx(ctx, tx, walletID, models.EventWalletStatusChanged, map[string]interface{}{
+			"wallet_id":   walletID,
+			"from_status": current.Status,
+			"to_status":   status,
+			"reason":      reason,
+			"actor_id":    actorID,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// Find the state change history of a wallet, newest first
+func (r *walletRepo) FindStatusEvents(ctx context.Context, walletID int64) ([]*models.WalletStatusEvent, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletRepo.FindStatusEvents")
+	defer span.Finish()
+
+	events := make([]*models.WalletStatusEvent, 0)
+	if err := r.db.SelectContext(ctx, &events, findWalletStatusEventsQuery, walletID); err != nil {
+		return nil, errors.Wrap(err, "walletRepo.FindStatusEvents.SelectContext")
+	}
+
+	return events, nil
+}
+
+func (r *walletRepo) GetBalance(ctx context.Context, walletID int64, currency string) (*models.WalletBalance, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletRepo.GetBalance")
+	defer span.Finish()
+
+	b := &models.WalletBalance{}
+	if err := r.db.GetContext(ctx, b, getBalanceQuery, walletID, currency); err != nil {
+		return nil, errors.Wrap(err, "walletRepo.GetBalance.GetContext")
 	}
 
 	return b, nil
 }
 
-func (r *walletRepo) UpsertBalance(ctx context.Context, b *models.WalletBalance) error {
-	const upsertBalanceQuery = `
-		INSERT INTO wallet_balances (wallet_id, currency, amount)
-		VALUES ($1, $2, $3)
-		ON CONFLICT (wallet_id, currency)
-		DO UPDATE SET amount = EXCLUDED.amount
-	`
+// Credit a deposit. A repeated ref_id returns the balance when it posted this
+// same deposit, a reference posted for anything else is ErrReferenceInUse.
+func (r *walletRepo) DepositTx(ctx context.Context, walletID int64, currency string, amount decimal.Decimal, refID string, check *models.LimitCheck) (*models.WalletBalance, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletRepo.DepositTx")
+	defer span.Finish()
 
-	_, err := r.db.ExecContext(ctx, upsertBalanceQuery, b.WalletID, b.Currency, b.Amount)
+	err := r.execTx(ctx, func(tx *sqlx.Tx) error {
+		_, err := r.postDepositTx(ctx, tx, walletID, currency, amount, refID, check)
+		return err
+	})
+	if errors.Is(err, errDuplicateEntry) {
+		err = r.checkEntryReplay(ctx, r.db, depositEntry(walletID, currency, amount, refID))
+	}
 	if err != nil {
-		return errors.Wrap(err, "WalletRepo.UpsertBalance.ExecContext")
+		return nil, err
 	}
 
-	return nil
+	return r.GetBalance(ctx, walletID, currency)
 }
 
-func (r *walletRepo) TransferTx(ctx context.Context, fromID, toID int64, curFrom, curTo string, amount int64, refID string) error {
-	tx, err := r.db.BeginTxx(ctx, nil)
+// Cash-in entry crediting amount to a wallet
+func depositEntry(walletID int64, currency string, amount decimal.Decimal, refID string) *models.JournalEntry {
+	return &models.JournalEntry{
+		Type:        models.EntryTypeDeposit,
+		RefID:       refID,
+		Description: "Deposit",
+		Postings: []models.Posting{
+			models.SystemPosting(models.AccountCashIn, models.TypeDeposit, currency, amount.Neg()),
+			models.WalletPosting(walletID, models.TypeDeposit, currency, amount),
+		},
+	}
+}
+
+// Post a cash-in entry with its limit usage and event, returns the entry id
+func (r *walletRepo) postDepositTx(ctx context.Context, tx *sqlx.Tx, walletID int64, currency string, amount decimal.Decimal, refID string, check *models.LimitCheck) (int64, error) {
+	entry := depositEntry(walletID, currency, amount, refID)
+	if err := r.postEntryTx(ctx, tx, entry); err != nil {
+		return 0, err
+	}
+
+	if err := r.applyLimitsTx(ctx, tx, check, refID); err != nil {
+		return 0, err
+	}
+
+	return entry.ID, r.writeEventTx(ctx, tx, walletID, models.EventWalletDeposited, map[string]interface{}{
+		"entry_id":  entry.ID,
+		"ref_id":    refID,
+		"wallet_id": walletID,
+		"currency":  currency,
+		"amount":    amount,
+	})
+}
+
+func (r *walletRepo) TransferTx(ctx context.Context, t *models.Transfer, check *models.LimitCheck) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletRepo.TransferTx")
+	defer span.Finish()
+
+	err := r.execTx(ctx, func(tx *sqlx.Tx) error {
+		_, err := r.postTransferTx(ctx, tx, t, check)
+		return err
+	})
+	if errors.Is(err, errDuplicateEntry) {
+		return r.checkTransferReplay(ctx, r.db, t)
+	}
+
+	return err
+}
+
+// Whether a journal entry was posted under refID
+func (r *walletRepo) EntryPosted(ctx context.Context, refID string) (bool, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletRepo.EntryPosted")
+	defer span.Finish()
+
+	var posted bool
+	if err := r.db.GetContext(ctx, &posted, entryPostedQuery, refID); err != nil {
+		return false, errors.Wrap(err, "walletRepo.EntryPosted.GetContext")
+	}
+
+	return posted, nil
+}
+
+// A posted reference is only a replay when it posted this same transfer, a
+// reference reused for anything else is refused
+func (r *walletRepo) checkTransferReplay(ctx context.Context, q sqlx.QueryerContext, t *models.Transfer) error {
+	posted, err := r.getTransferEntry(ctx, q, getTransferEntryQuery, t.RefID)
+	if errors.Is(err, wallet.ErrTransferNotFound) {
+		return wallet.ErrReferenceInUse
+	}
 	if err != nil {
 		return err
 	}
-	defer func() {
-		if p := recover(); p != nil {
-			tx.Rollback()
-			panic(p)
-		} else if err != nil {
-			tx.Rollback()
+
+	if !posted.SameMovement(t) {
+		return wallet.ErrReferenceInUse
+	}
+
+	return nil
+}
+
+// Post a priced transfer with its fee, limit usage and event, claiming its
+// quote, returns the entry id
+func (r *walletRepo) postTransferTx(ctx context.Context, tx *sqlx.Tx, t *models.Transfer, check *models.LimitCheck) (int64, error) {
+	entry := &models.JournalEntry{
+		Type:        models.EntryTypeTransfer,
+		RefID:       t.RefID,
+		Description: "Transfer",
+		Postings: []models.Posting{
+			models.WalletPosting(t.FromWalletID, models.TypeTransferOut, t.FromCurrency, t.Amount.Neg()),
+		},
+	}
+
+	if t.FromCurrency == t.ToCurrency {
+		entry.Postings = append(entry.Postings,
+			models.WalletPosting(t.ToWalletID, models.TypeTransferIn, t.ToCurrency, t.Amount),
+		)
+	} else {
+		// the fx account buys FromCurrency and sells ToCurrency at the converted amount
+		entry.Postings = append(entry.Postings,
+			models.SystemPosting(models.AccountFX, models.TypeFXIn, t.FromCurrency, t.Amount),
+			models.SystemPosting(models.AccountFX, models.TypeFXOut, t.ToCurrency, t.ConvertedAmount.Neg()),
+			models.WalletPosting(t.ToWalletID, models.TypeTransferIn, t.ToCurrency, t.ConvertedAmount),
+		)
+	}
+
+	// the fee is its own pair of postings so it shows as separate ledger rows
+	if t.Fee.Amount.IsPositive() {
+		entry.Postings = append(entry.Postings,
+			models.WalletPosting(t.FromWalletID, models.TypeFee, t.FromCurrency, t.Fee.Amount.Neg()),
+		)
+		if t.FeeWalletID != 0 {
+			entry.Postings = append(entry.Postings,
+				models.WalletPosting(t.FeeWalletID, models.TypeFeeIncome, t.FromCurrency, t.Fee.Amount),
+			)
 		} else {
-			err = tx.Commit()
+			entry.Postings = append(entry.Postings,
+				models.SystemPosting(models.AccountFees, models.TypeFeeIncome, t.FromCurrency, t.Fee.Amount),
+			)
 		}
-	}()
+	}
 
-	// Idempotency check
-	var count int
-	if err = tx.GetContext(ctx, &count, `SELECT COUNT(*) FROM txs WHERE ref_id = $1`, refID); err != nil {
-		return err
+	meta := make(map[string]interface{})
+	if t.FromCurrency != t.ToCurrency {
+		meta["rate"] = t.Rate
+		meta["quote_id"] = t.QuoteID
+	}
+	if t.Fee.Amount.IsPositive() {
+		meta["fee"] = t.Fee.Amount
+		meta["fee_schedule_id"] = t.Fee.ScheduleID
+	}
+	if len(meta) > 0 {
+		raw, err := json.Marshal(meta)
+		if err != nil {
+			return 0, errors.Wrap(err, "walletRepo.postTransferTx.json.Marshal")
+		}
+		entry.Meta = raw
+	}
+
+	if err := r.postEntryTx(ctx, tx, entry); err != nil {
+		return 0, err
+	}
+
+	if err := r.applyLimitsTx(ctx, tx, check, t.RefID); err != nil {
+		return 0, err
+	}
+
+	if err := r.writeEventTx(ctx, tx, t.FromWalletID, models.EventWalletTransferred, map[string]interface{}{
+		"entry_id":         entry.ID,
+		"ref_id":           t.RefID,
+		"from_wallet_id":   t.FromWalletID,
+		"to_wallet_id":     t.ToWalletID,
+		"from_currency":    t.FromCurrency,
+		"to_currency":      t.ToCurrency,
+		"amount":           t.Amount,
+		"converted_amount": t.ConvertedAmount,
+		"rate":             t.Rate,
+		"fee":              t.Fee.Amount,
+	}); err != nil {
+		return 0, err
+	}
+
+	if t.QuoteID == "" {
+		return entry.ID, nil
+	}
+
+	// a quote is single use, claim it in the same transaction as the postings
+	res, err := tx.ExecContext(ctx, useFXQuoteQuery, t.QuoteID, t.RefID)
+	if err != nil {
+		return 0, errors.Wrap(err, "walletRepo.postTransferTx.ExecContext.quote")
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "walletRepo.postTransferTx.RowsAffected.quote")
+	}
+	if rows == 0 {
+		return 0, fx.ErrQuoteExpired
 	}
-	if count > 0 {
+
+	return entry.ID, nil
+}
+
+// Recompute the wallet_balances projection of a wallet from its postings,
+// balances of currencies the wallet never posted in are zeroed
+func (r *walletRepo) RebuildBalancesTx(ctx context.Context, walletID int64) ([]models.WalletBalance, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletRepo.RebuildBalancesTx")
+	defer span.Finish()
+
+	balances := make([]models.WalletBalance, 0)
+	err := r.execTx(ctx, func(tx *sqlx.Tx) error {
+		if _, err := tx.ExecContext(ctx, lockWalletBalancesQuery, walletID); err != nil {
+			return errors.Wrap(err, "walletRepo.RebuildBalancesTx.ExecContext.lock")
+		}
+
+		if _, err := tx.ExecContext(ctx, zeroUnpostedBalancesQuery, walletID); err != nil {
+			return errors.Wrap(err, "walletRepo.RebuildBalancesTx.ExecContext.zero")
+		}
+
+		if _, err := tx.ExecContext(ctx, rebuildBalancesQuery, walletID); err != nil {
+			return errors.Wrap(err, "walletRepo.RebuildBalancesTx.ExecContext.rebuild")
+		}
+
+		if err := tx.SelectContext(ctx, &balances, getBalancesQuery, walletID); err != nil {
+			return errors.Wrap(err, "walletRepo.RebuildBalancesTx.SelectContext")
+		}
+
 		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// Deterministic lock order
-	type key struct {
-		walletID int64
-		cur      string
-	}
-	k1, k2 := key{fromID, curFrom}, key{toID, curTo}
-	first, second := k1, k2
-	if (k2.walletID < k1.walletID) || (k2.walletID == k1.walletID && k2.cur < k1.cur) {
-		first, second = k2, k1
-	}
-
-	// Lock first balance
-	var b1 models.WalletBalance
-	err = tx.GetContext(ctx, &b1, `
-        SELECT wallet_id, currency, amount
-        FROM wallet_balances
-        WHERE wallet_id = $1 AND currency = $2
-        FOR UPDATE
-    `, first.walletID, first.cur)
-	if errors.Is(err, sql.ErrNoRows) {
-		_, err = tx.ExecContext(ctx, `
-            INSERT INTO wallet_balances (wallet_id, currency, amount)
-            VALUES ($1, $2, 0)
-        `, first.walletID, first.cur)
-		if err != nil {
+	return balances, nil
+}
+
+func (r *walletRepo) HoldWithdrawalTx(ctx context.Context, w *models.Withdrawal, check *models.LimitCheck) (*models.Withdrawal, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletRepo.HoldWithdrawalTx")
+	defer span.Finish()
+
+	created := &models.Withdrawal{}
+	err := r.execTx(ctx, func(tx *sqlx.Tx) error {
+		// a retry of this withdrawal gets it back, a reference held for any
+		// other wallet or amount is refused
+		if err := tx.GetContext(ctx, created, getWithdrawalByRefQuery, w.RefID); err == nil {
+			if !created.SameHold(w) {
+				return wallet.ErrReferenceInUse
+			}
+			return nil
+		} else if !errors.Is(err, sql.ErrNoRows) {
+			return errors.Wrap(err, "walletRepo.HoldWithdrawalTx.GetContext.withdrawal")
+		}
+
+		if err := r.lockWalletsTx(ctx, tx, []int64{w.WalletID}); err != nil {
 			return err
 		}
-		err = tx.GetContext(ctx, &b1, `
-            SELECT wallet_id, currency, amount
-            FROM wallet_balances
-            WHERE wallet_id = $1 AND currency = $2
-            FOR UPDATE
-        `, first.walletID, first.cur)
-		if err != nil {
+
+		balance := &models.WalletBalance{}
+		if err := tx.GetContext(ctx, balance, getBalanceForUpdateQuery, w.WalletID, w.Currency); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return wallet.ErrInsufficientFunds
+			}
+			return errors.Wrap(err, "walletRepo.HoldWithdrawalTx.GetContext.balance")
+		}
+
+		if balance.Available().LessThan(w.Amount) {
+			return wallet.ErrInsufficientFunds
+		}
+
+		if _, err := tx.ExecContext(ctx, holdBalanceQuery, w.Amount, w.WalletID, w.Currency); err != nil {
+			return errors.Wrap(err, "walletRepo.HoldWithdrawalTx.ExecContext.hold")
+		}
+
+		if err := tx.QueryRowxContext(ctx, createWithdrawalQuery,
+			w.WalletID, w.Currency, w.Amount, models.WithdrawalStatusHeld, w.RefID,
+		).StructScan(created); err != nil {
+			return errors.Wrap(err, "walletRepo.HoldWithdrawalTx.StructScan")
+		}
+
+		if _, err := tx.ExecContext(ctx, insertTxQuery,
+			w.WalletID, models.TypeWithdrawHold, w.Currency, w.Amount, models.SystemRef(w.RefID, "hold"),
+		); err != nil {
+			return errors.Wrap(err, "walletRepo.HoldWithdrawalTx.ExecContext.ledger")
+		}
+
+		if err := r.applyLimitsTx(ctx, tx, check, w.RefID); err != nil {
 			return err
 		}
-	} else if err != nil {
-		return err
+
+		return r.writeEventTx(ctx, tx, w.WalletID, models.EventWithdrawalHeld, created)
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// Lock second balance
-	var b2 models.WalletBalance
-	err = tx.GetContext(ctx, &b2, `
-        SELECT wallet_id, currency, amount
-        FROM wallet_balances
-        WHERE wallet_id = $1 AND currency = $2
-        FOR UPDATE
-    `, second.walletID, second.cur)
-	if errors.Is(err, sql.ErrNoRows) {
-		_, err = tx.ExecContext(ctx, `
-            INSERT INTO wallet_balances (wallet_id, currency, amount)
-            VALUES ($1, $2, 0)
-        `, second.walletID, second.cur)
-		if err != nil {
-			return err
+	return created, nil
+}
+
+func (r *walletRepo) SettleWithdrawalTx(ctx context.Context, walletID, withdrawalID int64) (*models.Withdrawal, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletRepo.SettleWithdrawalTx")
+	defer span.Finish()
+
+	return r.finishWithdrawalTx(ctx, walletID, withdrawalID, models.WithdrawalStatusSettled)
+}
+
+func (r *walletRepo) ReleaseWithdrawalTx(ctx context.Context, walletID, withdrawalID int64) (*models.Withdrawal, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletRepo.ReleaseWithdrawalTx")
+	defer span.Finish()
+
+	return r.finishWithdrawalTx(ctx, walletID, withdrawalID, models.WithdrawalStatusReleased)
+}
+
+// Move a held withdrawal to settled (funds leave the wallet) or released (hold is lifted)
+func (r *walletRepo) finishWithdrawalTx(ctx context.Context, walletID, withdrawalID int64, status string) (*models.Withdrawal, error) {
+	updated := &models.Withdrawal{}
+	err := r.execTx(ctx, func(tx *sqlx.Tx) error {
+		w := &models.Withdrawal{}
+		if err := tx.GetContext(ctx, w, getWithdrawalForUpdateQuery, withdrawalID, walletID); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return wallet.ErrWithdrawalNotFound
+			}
+			return errors.Wrap(err, "walletRepo.finishWithdrawalTx.GetContext.withdrawal")
 		}
-		err = tx.GetContext(ctx, &b2, `
-            SELECT wallet_id, currency, amount
-            FROM wallet_balances
-            WHERE wallet_id = $1 AND currency = $2
-            FOR UPDATE
-        `, second.walletID, second.cur)
-		if err != nil {
+
+		if w.Status != models.WithdrawalStatusHeld {
+			return wallet.ErrWithdrawalNotHeld
+		}
+
+		balance := &models.WalletBalance{}
+		if err := tx.GetContext(ctx, balance, getBalanceForUpdateQuery, w.WalletID, w.Currency); err != nil {
+			return errors.Wrap(err, "walletRepo.finishWithdrawalTx.GetContext.balance")
+		}
+
+		if _, err := tx.ExecContext(ctx, releaseHeldBalanceQuery, w.Amount, w.WalletID, w.Currency); err != nil {
+			return errors.Wrap(err, "walletRepo.finishWithdrawalTx.ExecContext.balance")
+		}
+
+		if err := tx.QueryRowxContext(ctx, updateWithdrawalStatusQuery, status, w.ID).StructScan(updated); err != nil {
+			return errors.Wrap(err, "walletRepo.finishWithdrawalTx.StructScan")
+		}
+
+		if status == models.WithdrawalStatusReleased {
+			if _, err := tx.ExecContext(ctx, insertTxQuery,
+				w.WalletID, models.TypeWithdrawRelease, w.Currency, w.Amount, models.SystemRef(w.RefID, "release"),
+			); err != nil {
+				return errors.Wrap(err, "walletRepo.finishWithdrawalTx.ExecContext.ledger")
+			}
+
+			// a released withdrawal no longer counts against withdrawal limits
+			if _, err := tx.ExecContext(ctx, deleteLimitUsageQuery, w.RefID); err != nil {
+				return errors.Wrap(err, "walletRepo.finishWithdrawalTx.ExecContext.usage")
+			}
+
+			return r.writeEventTx(ctx, tx, w.WalletID, models.EventWithdrawalReleased, updated)
+		}
+
+		if err := r.postEntryTx(ctx, tx, &models.JournalEntry{
+			Type:        models.EntryTypeWithdrawal,
+			RefID:       models.SystemRef(w.RefID, "settle"),
+			Description: "Withdrawal settlement",
+			Postings: []models.Posting{
+				models.WalletPosting(w.WalletID, models.TypeWithdraw, w.Currency, w.Amount.Neg()),
+				models.SystemPosting(models.AccountCashOut, models.TypeWithdraw, w.Currency, w.Amount),
+			},
+		}); err != nil {
 			return err
 		}
-	} else if err != nil {
-		return err
-	}
 
-	// Map balances
-	pFrom := &b1
-	if b1.WalletID != fromID || b1.Currency != curFrom {
-		pFrom = &b2
+		return r.writeEventTx(ctx, tx, w.WalletID, models.EventWithdrawalSettled, updated)
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	if pFrom.Amount < int64(amount) {
-		return errors.New("insufficient funds")
+	return updated, nil
+}
+
+// Run fn inside a database transaction, rolling back on error or panic
+func (r *walletRepo) execTx(ctx context.Context, fn func(tx *sqlx.Tx) error) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "walletRepo.execTx.BeginTxx")
 	}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
 
-	// Apply debit
-	if _, err = tx.ExecContext(ctx, `
-        UPDATE wallet_balances
-        SET amount = amount - $1
-        WHERE wallet_id = $2 AND currency = $3
-    `, amount, fromID, curFrom); err != nil {
+	if err = fn(tx); err != nil {
+		tx.Rollback()
 		return err
 	}
 
-	// Convert amount kalau beda currency
-	convertedAmount := float64(amount)
-	if curFrom != curTo {
-		convertedAmount, err = utils.NewFixedRateConverter().Convert(curFrom, curTo, float64(amount))
-		if err != nil {
-			return err
-		}
-	}
+	return errors.Wrap(tx.Commit(), "walletRepo.execTx.Commit")
+}
 
-	// Apply credit (upsert)
-	if _, err = tx.ExecContext(ctx, `
-        INSERT INTO wallet_balances (wallet_id, currency, amount)
-        VALUES ($1, $2, $3)
-        ON CONFLICT (wallet_id, currency)
-        DO UPDATE SET amount = wallet_balances.amount + EXCLUDED.amount
-    `, toID, curTo, int64(convertedAmount)); err != nil {
-		return err
+// Find ledger rows of a wallet, newest first, using keyset pagination over idx_tx_wallet_created
+func (r *walletRepo) FindTransactions(ctx context.Context, filter *models.TransactionFilter) ([]*models.Transaction, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletRepo.FindTransactions")
+	defer span.Finish()
+
+	var query strings.Builder
+	query.WriteString(findTransactionsQuery)
+	args := []interface{}{filter.WalletID}
+
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return "$" + strconv.Itoa(len(args))
 	}
 
-	// Ledger entries
-	outRef := refID + "-out"
-	inRef := refID + "-in"
-	if _, err = tx.ExecContext(ctx, `
-        INSERT INTO txs (wallet_id, type, currency, amount, ref_id)
-        VALUES ($1, 'TRANSFER_OUT', $2, $3, $4)
-    `, fromID, curFrom, amount, outRef); err != nil {
-		return err
+	if filter.Type != "" {
+		query.WriteString(" AND type = " + arg(filter.Type))
 	}
-	if _, err = tx.ExecContext(ctx, `
-        INSERT INTO txs (wallet_id, type, currency, amount, ref_id)
-        VALUES ($1, 'TRANSFER_IN', $2, $3, $4)
-    `, toID, curTo, convertedAmount, inRef); err != nil {
-		return err
+	if filter.Currency != "" {
+		query.WriteString(" AND currency = " + arg(filter.Currency))
 	}
-	if _, err = tx.ExecContext(ctx, `
-        INSERT INTO txs (wallet_id, type, currency, amount, ref_id)
-        VALUES ($1, 'META', $2, 0, $3)
-    `, fromID, curFrom, refID); err != nil {
-		return err
+	if filter.RefID != "" {
+		// entry rows are stored as <ref>-<type> and the rows the service derives
+		// as <ref>:<step>, matched with starts_with so % and _ in the ref are not
+		// LIKE wildcards
+		p := arg(filter.RefID)
+		query.WriteString(" AND (ref_id = " + p + " OR starts_with(ref_id, " + p + " || '-') OR starts_with(ref_id, " + p + " || '" + models.SystemRefSeparator + "'))")
 	}
+	if filter.From != nil {
+		query.WriteString(" AND created_at >= " + arg(*filter.From))
+	}
+	if filter.To != nil {
+		query.WriteString(" AND created_at < " + arg(*filter.To))
+	}
+	if filter.BeforeCreatedAt != nil {
+		query.WriteString(" AND (created_at, id) < (" + arg(*filter.BeforeCreatedAt) + ", " + arg(filter.BeforeID) + ")")
+	}
+	query.WriteString(" ORDER BY created_at DESC, id DESC LIMIT " + arg(filter.Limit))
 
-	return nil
+	transactions := make([]*models.Transaction, 0, filter.Limit)
+	if err := r.db.SelectContext(ctx, &transactions, query.String(), args...); err != nil {
+		return nil, errors.Wrap(err, "walletRepo.FindTransactions.SelectContext")
+	}
+
+	return transactions, nil
 }
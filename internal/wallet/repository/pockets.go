@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/wallet"
+)
+
+// Create a pocket wallet under a parent wallet, owned by the parent's owner
+func (r *walletRepo) CreatePocketTx(ctx context.Context, p *models.Pocket) (*models.Pocket, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletRepo.CreatePocketTx")
+	defer span.Finish()
+
+	created := &models.Pocket{}
+	err := r.execTx(ctx, func(tx *sqlx.Tx) error {
+		w := &models.Wallet{}
+		if err := tx.QueryRowxContext(ctx, createPocketWalletQuery, p.ParentWalletID, p.Name).StructScan(w); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return wallet.ErrWalletNotFound
+			}
+			return errors.Wrap(err, "walletRepo.CreatePocketTx.StructScan.wallet")
+		}
+
+		if _, err := tx.ExecContext(ctx, createPocketQuery, w.ID, p.ParentWalletID, p.Currency, p.TargetAmount, p.TargetDate); err != nil {
+			return errors.Wrap(err, "walletRepo.CreatePocketTx.ExecContext")
+		}
+
+		found, err := getPocket(ctx, tx, w.ID)
+		if err != nil {
+			return err
+		}
+		created = found
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (r *walletRepo) GetPocket(ctx context.Context, pocketID int64) (*models.Pocket, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletRepo.GetPocket")
+	defer span.Finish()
+
+	return getPocket(ctx, r.db, pocketID)
+}
+
+// Find the pockets of a wallet with their balance in the pocket currency
+func (r *walletRepo) FindPockets(ctx context.Context, parentWalletID int64) ([]*models.Pocket, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletRepo.FindPockets")
+	defer span.Finish()
+
+	pockets := make([]*models.Pocket, 0)
+	if err := r.db.SelectContext(ctx, &pockets, findPocketsQuery, parentWalletID); err != nil {
+		return nil, errors.Wrap(err, "walletRepo.FindPockets.SelectContext")
+	}
+
+	return pockets, nil
+}
+
+// Replace the savings goal of a pocket, nil values clear it
+func (r *walletRepo) UpdatePocketTarget(ctx context.Context, pocketID int64, amount *decimal.Decimal, date *time.Time) (*models.Pocket, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletRepo.UpdatePocketTarget")
+	defer span.Finish()
+
+	result, err := r.db.ExecContext(ctx, updatePocketTargetQuery, amount, date, pocketID)
+	if err != nil {
+		return nil, errors.Wrap(err, "walletRepo.UpdatePocketTarget.ExecContext")
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return nil, errors.Wrap(err, "walletRepo.UpdatePocketTarget.RowsAffected")
+	}
+	if affected == 0 {
+		return nil, wallet.ErrPocketNotFound
+	}
+
+	return getPocket(ctx, r.db, pocketID)
+}
+
+// Move funds between a wallet and its pockets. The move is a plain pair of
+// postings without fees or limits. A repeated ref_id is a no-op when it posted
+// this same move, ErrReferenceInUse otherwise.
+func (r *walletRepo) MovePocketFundsTx(ctx context.Context, m *models.PocketMove) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletRepo.MovePocketFundsTx")
+	defer span.Finish()
+
+	meta, err := json.Marshal(map[string]interface{}{"parent_wallet_id": m.ParentWalletID})
+	if err != nil {
+		return errors.Wrap(err, "walletRepo.MovePocketFundsTx.json.Marshal")
+	}
+
+	entry := &models.JournalEntry{
+		Type:        models.EntryTypePocket,
+		RefID:       m.RefID,
+		Description: "Pocket move",
+		Meta:        meta,
+		Postings: []models.Posting{
+			models.WalletPosting(m.FromWalletID, models.TypePocketOut, m.Currency, m.Amount.Neg()),
+			models.WalletPosting(m.ToWalletID, models.TypePocketIn, m.Currency, m.Amount),
+		},
+	}
+
+	return r.execTx(ctx, func(tx *sqlx.Tx) error {
+		if err := r.postEntryTx(ctx, tx, entry); err != nil {
+			if errors.Is(err, errDuplicateEntry) {
+				return r.checkEntryReplay(ctx, tx, entry)
+			}
+			return err
+		}
+		return nil
+	})
+}
+
+// Fill Totals of each wallet with its own balances plus those of its pockets
+func (r *walletRepo) addPocketTotals(ctx context.Context, wallets []*models.Wallet) error {
+	if len(wallets) == 0 {
+		return nil
+	}
+
+	walletIDs := make([]int64, 0, len(wallets))
+	totals := make(map[int64]map[string]decimal.Decimal, len(wallets))
+	for _, w := range wallets {
+		walletIDs = append(walletIDs, w.ID)
+		totals[w.ID] = make(map[string]decimal.Decimal, len(w.Balances))
+		for _, b := range w.Balances {
+			totals[w.ID][b.Currency] = totals[w.ID][b.Currency].Add(b.Amount)
+		}
+	}
+
+	ids, err := json.Marshal(walletIDs)
+	if err != nil {
+		return errors.Wrap(err, "walletRepo.addPocketTotals.json.Marshal")
+	}
+
+	rows, err := r.db.QueryxContext(ctx, findPocketTotalsQuery, ids)
+	if err != nil {
+		return errors.Wrap(err, "walletRepo.addPocketTotals.QueryxContext")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			parentID int64
+			currency string
+			amount   decimal.Decimal
+		)
+		if err := rows.Scan(&parentID, &currency, &amount); err != nil {
+			return errors.Wrap(err, "walletRepo.addPocketTotals.Scan")
+		}
+		totals[parentID][currency] = totals[parentID][currency].Add(amount)
+	}
+	if err := rows.Err(); err != nil {
+		return errors.Wrap(err, "walletRepo.addPocketTotals.rows.Err")
+	}
+
+	for _, w := range wallets {
+		w.Totals = make([]models.WalletBalance, 0, len(totals[w.ID]))
+		for currency, amount := range totals[w.ID] {
+			w.Totals = append(w.Totals, models.WalletBalance{WalletID: w.ID, Currency: currency, Amount: amount})
+		}
+		sort.Slice(w.Totals, func(i, j int) bool { return w.Totals[i].Currency < w.Totals[j].Currency })
+	}
+
+	return nil
+}
+
+func getPocket(ctx context.Context, q sqlx.QueryerContext, pocketID int64) (*models.Pocket, error) {
+	p := &models.Pocket{}
+	if err := q.QueryRowxContext(ctx, getPocketQuery, pocketID).StructScan(p); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, wallet.ErrPocketNotFound
+		}
+		return nil, errors.Wrap(err, "walletRepo.getPocket.QueryRowxContext")
+	}
+
+	return p, nil
+}
@@ -0,0 +1,232 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/wallet"
+)
+
+// Find a posted transfer by its ref_id together with what was already reversed
+func (r *walletRepo) FindTransferEntry(ctx context.Context, refID string) (*models.TransferEntry, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletRepo.FindTransferEntry")
+	defer span.Finish()
+
+	return r.getTransferEntry(ctx, r.db, getTransferEntryQuery, refID)
+}
+
+// Post the compensating entry of a reversal. The original entry is locked so
+// concurrent reversals of one transfer cannot together exceed it. A reversal
+// ref_id repeated for the same transfer returns the reversal already posted,
+// one already used elsewhere is ErrReferenceInUse.
+func (r *walletRepo) ReverseTransferTx(ctx context.Context, rv *models.Reversal) (*models.Reversal, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletRepo.ReverseTransferTx")
+	defer span.Finish()
+
+	created := &models.Reversal{}
+	err := r.execTx(ctx, func(tx *sqlx.Tx) error {
+		if err := tx.GetContext(ctx, created, getReversalByRefQuery, rv.RefID, rv.OriginalRefID); err == nil {
+			return nil
+		} else if !errors.Is(err, sql.ErrNoRows) {
+			return errors.Wrap(err, "walletRepo.ReverseTransferTx.GetContext.reversal")
+		}
+
+		var taken bool
+		if err := tx.GetContext(ctx, &taken, entryPostedQuery, rv.RefID); err != nil {
+			return errors.Wrap(err, "walletRepo.ReverseTransferTx.GetContext.entryPosted")
+		}
+		if taken {
+			return wallet.ErrReferenceInUse
+		}
+
+		original, err := r.getTransferEntry(ctx, tx, getTransferEntryForUpdateQuery, rv.OriginalRefID)
+		if err != nil {
+			return err
+		}
+
+		if rv.Amount.GreaterThan(original.Remaining()) ||
+			rv.ConvertedAmount.GreaterThan(original.RemainingConverted()) ||
+			rv.FeeAmount.GreaterThan(original.RemainingFee()) {
+			return wallet.ErrReversalExceedsOriginal
+		}
+
+		entry, err := reversalEntry(original, rv)
+		if err != nil {
+			return err
+		}
+
+		if err := r.postEntryTx(ctx, tx, entry); err != nil {
+			if errors.Is(err, errDuplicateEntry) {
+				return wallet.ErrReferenceInUse
+			}
+			return err
+		}
+
+		if err := tx.QueryRowxContext(ctx, createReversalQuery,
+			rv.RefID, entry.ID, original.EntryID, rv.OriginalRefID,
+			rv.Amount, rv.ConvertedAmount, rv.FeeAmount, rv.Rate, rv.Reason, rv.ActorID,
+		).StructScan(created); err != nil {
+			return errors.Wrap(err, "walletRepo.ReverseTransferTx.StructScan")
+		}
+
+		return r.writeEventTx(ctx, tx, original.FromWalletID, models.EventWalletTransferReversed, map[string]interface{}{
+			"entry_id":         entry.ID,
+			"ref_id":           rv.RefID,
+			"original_ref_id":  rv.OriginalRefID,
+			"from_wallet_id":   original.FromWalletID,
+			"to_wallet_id":     original.ToWalletID,
+			"from_currency":    original.FromCurrency,
+			"to_currency":      original.ToCurrency,
+			"amount":           rv.Amount,
+			"converted_amount": rv.ConvertedAmount,
+			"fee":              rv.FeeAmount,
+			"rate":             rv.Rate,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// Find the reversals of a transfer, oldest first
+func (r *walletRepo) FindReversals(ctx context.Context, refID string) ([]*models.Reversal, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletRepo.FindReversals")
+	defer span.Finish()
+
+	reversals := make([]*models.Reversal, 0)
+	if err := r.db.SelectContext(ctx, &reversals, findReversalsQuery, refID); err != nil {
+		return nil, errors.Wrap(err, "walletRepo.FindReversals.SelectContext")
+	}
+
+	return reversals, nil
+}
+
+// Rebuild a transfer from the postings of its journal entry
+func (r *walletRepo) getTransferEntry(ctx context.Context, q sqlx.QueryerContext, query, refID string) (*models.TransferEntry, error) {
+	var head struct {
+		ID   int64  `db:"id"`
+		Meta []byte `db:"meta"`
+	}
+	if err := sqlx.GetContext(ctx, q, &head, query, refID, models.EntryTypeTransfer); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, wallet.ErrTransferNotFound
+		}
+		return nil, errors.Wrap(err, "walletRepo.getTransferEntry.GetContext")
+	}
+
+	postings := make([]models.Posting, 0)
+	if err := sqlx.SelectContext(ctx, q, &postings, findEntryPostingsQuery, head.ID); err != nil {
+		return nil, errors.Wrap(err, "walletRepo.getTransferEntry.SelectContext")
+	}
+
+	entry := &models.TransferEntry{EntryID: head.ID}
+	entry.RefID = refID
+	entry.Rate = decimal.NewFromInt(1)
+
+	for _, p := range postings {
+		switch p.Type {
+		case models.TypeTransferOut:
+			entry.FromWalletID = *p.WalletID
+			entry.FromCurrency = p.Currency
+			entry.Amount = p.Amount.Neg()
+		case models.TypeTransferIn:
+			entry.ToWalletID = *p.WalletID
+			entry.ToCurrency = p.Currency
+			entry.ConvertedAmount = p.Amount
+		case models.TypeFee:
+			entry.Fee.Currency = p.Currency
+			entry.Fee.Amount = p.Amount.Neg()
+		case models.TypeFeeIncome:
+			if p.WalletID != nil {
+				entry.FeeWalletID = *p.WalletID
+			}
+		}
+	}
+
+	if len(head.Meta) > 0 {
+		var meta struct {
+			Rate          decimal.NullDecimal `json:"rate"`
+			QuoteID       string              `json:"quote_id"`
+			FeeScheduleID *int64              `json:"fee_schedule_id"`
+		}
+		if err := json.Unmarshal(head.Meta, &meta); err != nil {
+			return nil, errors.Wrap(err, "walletRepo.getTransferEntry.json.Unmarshal")
+		}
+		if meta.Rate.Valid {
+			entry.Rate = meta.Rate.Decimal
+		}
+		entry.QuoteID = meta.QuoteID
+		entry.Fee.ScheduleID = meta.FeeScheduleID
+	}
+
+	if err := q.QueryRowxContext(ctx, sumReversalsQuery, head.ID).Scan(
+		&entry.Reversed, &entry.ReversedConverted, &entry.ReversedFee,
+	); err != nil {
+		return nil, errors.Wrap(err, "walletRepo.getTransferEntry.Scan.reversed")
+	}
+
+	return entry, nil
+}
+
+// Compensating entry of a reversal, the transfer postings mirrored at the reversed amounts
+func reversalEntry(original *models.TransferEntry, rv *models.Reversal) (*models.JournalEntry, error) {
+	postings := []models.Posting{
+		models.WalletPosting(original.ToWalletID, models.TypeReversalOut, original.ToCurrency, rv.ConvertedAmount.Neg()),
+	}
+
+	if original.FromCurrency == original.ToCurrency {
+		postings = append(postings,
+			models.WalletPosting(original.FromWalletID, models.TypeReversalIn, original.FromCurrency, rv.Amount),
+		)
+	} else {
+		// the fx account buys back ToCurrency and returns FromCurrency at the original rate
+		postings = append(postings,
+			models.SystemPosting(models.AccountFX, models.TypeFXIn, original.ToCurrency, rv.ConvertedAmount),
+			models.SystemPosting(models.AccountFX, models.TypeFXOut, original.FromCurrency, rv.Amount.Neg()),
+			models.WalletPosting(original.FromWalletID, models.TypeReversalIn, original.FromCurrency, rv.Amount),
+		)
+	}
+
+	if rv.FeeAmount.IsPositive() {
+		if original.FeeWalletID != 0 {
+			postings = append(postings,
+				models.WalletPosting(original.FeeWalletID, models.TypeFeeReversal, original.FromCurrency, rv.FeeAmount.Neg()),
+			)
+		} else {
+			postings = append(postings,
+				models.SystemPosting(models.AccountFees, models.TypeFeeReversal, original.FromCurrency, rv.FeeAmount.Neg()),
+			)
+		}
+		postings = append(postings,
+			models.WalletPosting(original.FromWalletID, models.TypeFeeRefund, original.FromCurrency, rv.FeeAmount),
+		)
+	}
+
+	meta, err := json.Marshal(map[string]interface{}{
+		"original_ref_id": rv.OriginalRefID,
+		"rate":            rv.Rate,
+		"reason":          rv.Reason,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "walletRepo.reversalEntry.json.Marshal")
+	}
+
+	originalID := original.EntryID
+	return &models.JournalEntry{
+		Type:            models.EntryTypeReversal,
+		RefID:           rv.RefID,
+		Description:     "Transfer reversal",
+		Meta:            meta,
+		ReversesEntryID: &originalID,
+		Postings:        postings,
+	}, nil
+}
@@ -0,0 +1,263 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/wallet"
+)
+
+// Queue an operation for review, holding what a transfer would debit. A
+// retried operation gets its existing review back as it stands.
+func (r *walletRepo) HoldForReviewTx(ctx context.Context, review *models.RiskReview) (*models.RiskReview, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletRepo.HoldForReviewTx")
+	defer span.Finish()
+
+	created := &models.RiskReview{}
+	err := r.execTx(ctx, func(tx *sqlx.Tx) error {
+		err := tx.QueryRowxContext(ctx, createRiskReviewQuery,
+			review.Operation, review.WalletID, review.UserID, review.Currency, review.Amount, review.Held,
+			review.ToWalletID, review.ToCurrency, review.ConvertedAmount, review.Rate, review.QuoteID,
+			review.Fee, review.FeeScheduleID, review.FeeWalletID, review.RefID, review.Hits, review.RequestedBy,
+		).StructScan(created)
+		if errors.Is(err, sql.ErrNoRows) {
+			if err := tx.GetContext(ctx, created, getRiskReviewByRefQuery, review.Operation, review.RefID); err != nil {
+				return errors.Wrap(err, "walletRepo.HoldForReviewTx.GetContext.review")
+			}
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, "walletRepo.HoldForReviewTx.StructScan")
+		}
+
+		if created.Held.IsPositive() {
+			if err := r.lockWalletsTx(ctx, tx, []int64{created.WalletID}); err != nil {
+				return err
+			}
+
+			balance := &models.WalletBalance{}
+			if err := tx.GetContext(ctx, balance, getBalanceForUpdateQuery, created.WalletID, created.Currency); err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return wallet.ErrInsufficientFunds
+				}
+				return errors.Wrap(err, "walletRepo.HoldForReviewTx.GetContext.balance")
+			}
+
+			if balance.Available().LessThan(created.Held) {
+				return wallet.ErrInsufficientFunds
+			}
+
+			if _, err := tx.ExecContext(ctx, holdBalanceQuery, created.Held, created.WalletID, created.Currency); err != nil {
+				return errors.Wrap(err, "walletRepo.HoldForReviewTx.ExecContext.hold")
+			}
+
+			if _, err := tx.ExecContext(ctx, insertTxQuery,
+				created.WalletID, models.TypeRiskHold, created.Currency, created.Held, models.SystemRef(created.RefID, models.TypeRiskHold),
+			); err != nil {
+				return errors.Wrap(err, "walletRepo.HoldForReviewTx.ExecContext.ledger")
+			}
+		}
+
+		return r.writeEventTx(ctx, tx, created.WalletID, models.EventTransactionHeld, riskReviewEvent(created))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (r *walletRepo) GetRiskReview(ctx context.Context, reviewID int64) (*models.RiskReview, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletRepo.GetRiskReview")
+	defer span.Finish()
+
+	review := &models.RiskReview{}
+	if err := r.db.GetContext(ctx, review, getRiskReviewQuery, reviewID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, wallet.ErrRiskReviewNotFound
+		}
+		return nil, errors.Wrap(err, "walletRepo.GetRiskReview.GetContext")
+	}
+
+	return review, nil
+}
+
+// Review of the operation held under refID
+func (r *walletRepo) GetRiskReviewByRef(ctx context.Context, operation, refID string) (*models.RiskReview, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletRepo.GetRiskReviewByRef")
+	defer span.Finish()
+
+	review := &models.RiskReview{}
+	if err := r.db.GetContext(ctx, review, getRiskReviewByRefQuery, operation, refID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, wallet.ErrRiskReviewNotFound
+		}
+		return nil, errors.Wrap(err, "walletRepo.GetRiskReviewByRef.GetContext")
+	}
+
+	return review, nil
+}
+
+// Find reviews matching filter, oldest first
+func (r *walletRepo) FindRiskReviews(ctx context.Context, filter *models.RiskReviewFilter) ([]*models.RiskReview, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletRepo.FindRiskReviews")
+	defer span.Finish()
+
+	reviews := make([]*models.RiskReview, 0)
+	if err := r.db.SelectContext(ctx, &reviews, findRiskReviewsQuery,
+		filter.Status, filter.WalletID, filter.UserID, filter.Limit,
+	); err != nil {
+		return nil, errors.Wrap(err, "walletRepo.FindRiskReviews.SelectContext")
+	}
+
+	return reviews, nil
+}
+
+// Lift the hold of a pending review and post the held operation in the same
+// transaction, check is the limits resolved at approval time. A reference
+// already posted for anything but the held operation is ErrReferenceInUse.
+func (r *walletRepo) ApproveRiskReviewTx(ctx context.Context, reviewID, reviewerID int64, note *string, check *models.LimitCheck) (*models.RiskReview, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletRepo.ApproveRiskReviewTx")
+	defer span.Finish()
+
+	updated := &models.RiskReview{}
+	err := r.execTx(ctx, func(tx *sqlx.Tx) error {
+		review, err := r.lockPendingReviewTx(ctx, tx, reviewID)
+		if err != nil {
+			return err
+		}
+
+		if err := r.releaseReviewHoldTx(ctx, tx, review); err != nil {
+			return err
+		}
+
+		var entryID int64
+		if review.Operation == models.RiskOperationDeposit {
+			entryID, err = r.postDepositTx(ctx, tx, review.WalletID, review.Currency, review.Amount, review.RefID, check)
+			if errors.Is(err, errDuplicateEntry) {
+				err = r.checkEntryReplay(ctx, tx, depositEntry(review.WalletID, review.Currency, review.Amount, review.RefID))
+			}
+		} else {
+			transfer := review.Transfer()
+			entryID, err = r.postTransferTx(ctx, tx, transfer, check)
+			if errors.Is(err, errDuplicateEntry) {
+				err = r.checkTransferReplay(ctx, tx, transfer)
+			}
+		}
+		if err != nil {
+			return err
+		}
+
+		// a retry that already posted this operation only has the hold left to lift
+		var postedEntryID *int64
+		if entryID != 0 {
+			postedEntryID = &entryID
+		}
+
+		if err := tx.QueryRowxContext(ctx, updateRiskReviewQuery,
+			review.ID, models.RiskReviewApproved, reviewerID, note, postedEntryID,
+		).StructScan(updated); err != nil {
+			return errors.Wrap(err, "walletRepo.ApproveRiskReviewTx.StructScan")
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// Cancel a pending review, a held transfer gets its funds back
+func (r *walletRepo) RejectRiskReviewTx(ctx context.Context, reviewID, reviewerID int64, note *string) (*models.RiskReview, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletRepo.RejectRiskReviewTx")
+	defer span.Finish()
+
+	updated := &models.RiskReview{}
+	err := r.execTx(ctx, func(tx *sqlx.Tx) error {
+		review, err := r.lockPendingReviewTx(ctx, tx, reviewID)
+		if err != nil {
+			return err
+		}
+
+		if err := r.releaseReviewHoldTx(ctx, tx, review); err != nil {
+			return err
+		}
+
+		if review.Held.IsPositive() {
+			if _, err := tx.ExecContext(ctx, insertTxQuery,
+				review.WalletID, models.TypeRiskRelease, review.Currency, review.Held, models.SystemRef(review.RefID, models.TypeRiskRelease),
+			); err != nil {
+				return errors.Wrap(err, "walletRepo.RejectRiskReviewTx.ExecContext.ledger")
+			}
+		}
+
+		if err := tx.QueryRowxContext(ctx, updateRiskReviewQuery,
+			review.ID, models.RiskReviewRejected, reviewerID, note, nil,
+		).StructScan(updated); err != nil {
+			return errors.Wrap(err, "walletRepo.RejectRiskReviewTx.StructScan")
+		}
+
+		return r.writeEventTx(ctx, tx, updated.WalletID, models.EventTransactionRejected, riskReviewEvent(updated))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// Lock a review row, it must still be pending
+func (r *walletRepo) lockPendingReviewTx(ctx context.Context, tx *sqlx.Tx, reviewID int64) (*models.RiskReview, error) {
+	review := &models.RiskReview{}
+	if err := tx.GetContext(ctx, review, getRiskReviewForUpdateQuery, reviewID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, wallet.ErrRiskReviewNotFound
+		}
+		return nil, errors.Wrap(err, "walletRepo.lockPendingReviewTx.GetContext")
+	}
+
+	if review.Status != models.RiskReviewPending {
+		return nil, wallet.ErrRiskReviewNotPending
+	}
+
+	return review, nil
+}
+
+// Give back the amount a review holds on the sender balance
+func (r *walletRepo) releaseReviewHoldTx(ctx context.Context, tx *sqlx.Tx, review *models.RiskReview) error {
+	if !review.Held.IsPositive() {
+		return nil
+	}
+
+	balance := &models.WalletBalance{}
+	if err := tx.GetContext(ctx, balance, getBalanceForUpdateQuery, review.WalletID, review.Currency); err != nil {
+		return errors.Wrap(err, "walletRepo.releaseReviewHoldTx.GetContext")
+	}
+
+	if _, err := tx.ExecContext(ctx, releaseHeldBalanceQuery, review.Held, review.WalletID, review.Currency); err != nil {
+		return errors.Wrap(err, "walletRepo.releaseReviewHoldTx.ExecContext")
+	}
+
+	return nil
+}
+
+// Event payload of a review, the rules that fired stay internal
+func riskReviewEvent(review *models.RiskReview) map[string]interface{} {
+	return map[string]interface{}{
+		"review_id":    review.ID,
+		"operation":    review.Operation,
+		"ref_id":       review.RefID,
+		"wallet_id":    review.WalletID,
+		"to_wallet_id": review.ToWalletID,
+		"currency":     review.Currency,
+		"amount":       review.Amount,
+		"status":       review.Status,
+	}
+}
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+)
+
+// Snapshot every wallet balance as of asOf, built from the latest earlier
+// snapshot. A snapshot that already exists is returned as is.
+func (r *walletRepo) CreateSnapshotTx(ctx context.Context, asOf time.Time) (*models.BalanceSnapshot, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletRepo.CreateSnapshotTx")
+	defer span.Finish()
+
+	snapshot := &models.BalanceSnapshot{}
+	err := r.execTx(ctx, func(tx *sqlx.Tx) error {
+		if _, err := tx.ExecContext(ctx, lockSnapshotsQuery); err != nil {
+			return errors.Wrap(err, "walletRepo.CreateSnapshotTx.ExecContext.lock")
+		}
+
+		err := tx.GetContext(ctx, snapshot, getSnapshotQuery, asOf)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return errors.Wrap(err, "walletRepo.CreateSnapshotTx.GetContext.existing")
+		}
+
+		var base *time.Time
+		previous := &models.BalanceSnapshot{}
+		if err := tx.GetContext(ctx, previous, latestSnapshotQuery, asOf.Add(-time.Microsecond)); err == nil {
+			base = &previous.AsOf
+		} else if !errors.Is(err, sql.ErrNoRows) {
+			return errors.Wrap(err, "walletRepo.CreateSnapshotTx.GetContext.previous")
+		}
+
+		if _, err := tx.ExecContext(ctx, createSnapshotQuery, asOf); err != nil {
+			return errors.Wrap(err, "walletRepo.CreateSnapshotTx.ExecContext.snapshot")
+		}
+		if _, err := tx.ExecContext(ctx, createSnapshotLinesQuery, asOf, base); err != nil {
+			return errors.Wrap(err, "walletRepo.CreateSnapshotTx.ExecContext.lines")
+		}
+
+		return errors.Wrap(tx.GetContext(ctx, snapshot, countSnapshotQuery, asOf), "walletRepo.CreateSnapshotTx.GetContext.count")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshot, nil
+}
+
+// Latest snapshot taken as of notAfter or earlier, nil when there is none
+func (r *walletRepo) LatestSnapshot(ctx context.Context, notAfter time.Time) (*models.BalanceSnapshot, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletRepo.LatestSnapshot")
+	defer span.Finish()
+
+	snapshot := &models.BalanceSnapshot{}
+	if err := r.db.GetContext(ctx, snapshot, latestSnapshotQuery, notAfter); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "walletRepo.LatestSnapshot.GetContext")
+	}
+
+	return snapshot, nil
+}
+
+// Balances of a wallet at a point in time, replayed from the snapshot at
+// snapshotAt or from the first ledger row when it is nil
+func (r *walletRepo) FindBalancesAt(ctx context.Context, walletID int64, snapshotAt *time.Time, at time.Time) ([]*models.HistoricalBalance, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletRepo.FindBalancesAt")
+	defer span.Finish()
+
+	balances := make([]*models.HistoricalBalance, 0)
+	if err := r.db.SelectContext(ctx, &balances, findBalancesAtQuery, walletID, snapshotAt, at); err != nil {
+		return nil, errors.Wrap(err, "walletRepo.FindBalancesAt.SelectContext")
+	}
+
+	return balances, nil
+}
+
+// Balances of every wallet at a point in time, in wallet and currency order
+func (r *walletRepo) FindAllBalancesAt(ctx context.Context, snapshotAt *time.Time, at time.Time) ([]*models.HistoricalBalance, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletRepo.FindAllBalancesAt")
+	defer span.Finish()
+
+	balances := make([]*models.HistoricalBalance, 0)
+	if err := r.db.SelectContext(ctx, &balances, findAllBalancesAtQuery, snapshotAt, at); err != nil {
+		return nil, errors.Wrap(err, "walletRepo.FindAllBalancesAt.SelectContext")
+	}
+
+	return balances, nil
+}
@@ -3,7 +3,345 @@ package repository
 const (
 	createWalletQuery = `INSERT INTO public.wallets (user_id, name, created_at)
 						VALUES ($1, $2, now()) RETURNING *`
-	getWallet     = `SELECT * FROM public.wallets ORDER BY COALESCE(NULLIF($1, ''), name) OFFSET $2 LIMIT $3`
-	getTotal      = `SELECT COUNT(id) FROM public.wallets`
-	getWalletByID = `SELECT * FROM public.wallets w JOIN public.wallet_balances wb ON wb.wallet_id = w.id`
+	getWallet = `SELECT * FROM public.wallets ORDER BY COALESCE(NULLIF($1, ''), name) OFFSET $2 LIMIT $3`
+	getTotal  = `SELECT COUNT(id) FROM public.wallets WHERE user_id = $1
+						AND NOT EXISTS (SELECT 1 FROM wallet_pockets p WHERE p.wallet_id = wallets.id)`
+	getWalletByID = `SELECT * FROM public.wallets WHERE id = $1`
+
+	findUserWalletsQuery = `SELECT w.id, w.user_id, w.name, w.status, w.created_at, b.wallet_id, b.currency, b.amount
+						FROM (
+							SELECT * FROM public.wallets WHERE user_id = $1
+							AND NOT EXISTS (SELECT 1 FROM wallet_pockets p WHERE p.wallet_id = wallets.id)
+							ORDER BY id LIMIT $2 OFFSET $3
+						) w
+						LEFT JOIN wallet_balances b ON w.id = b.wallet_id
+						ORDER BY w.id, b.currency`
+)
+
+const (
+	getBalanceForUpdateQuery = `SELECT wallet_id, currency, amount, held
+						FROM wallet_balances
+						WHERE wallet_id = $1 AND currency = $2
+						FOR UPDATE`
+
+	holdBalanceQuery = `UPDATE wallet_balances SET held = held + $1
+						WHERE wallet_id = $2 AND currency = $3`
+
+	releaseHeldBalanceQuery = `UPDATE wallet_balances SET held = held - $1
+						WHERE wallet_id = $2 AND currency = $3`
+
+	createWithdrawalQuery = `INSERT INTO withdrawals (wallet_id, currency, amount, status, ref_id)
+						VALUES ($1, $2, $3, $4, $5) RETURNING *`
+
+	getWithdrawalByRefQuery = `SELECT * FROM withdrawals WHERE ref_id = $1`
+
+	getWithdrawalForUpdateQuery = `SELECT * FROM withdrawals WHERE id = $1 AND wallet_id = $2 FOR UPDATE`
+
+	updateWithdrawalStatusQuery = `UPDATE withdrawals SET status = $1, updated_at = now()
+						WHERE id = $2 RETURNING *`
+
+	insertTxQuery = `INSERT INTO txs (wallet_id, type, currency, amount, ref_id)
+						VALUES ($1, $2, $3, $4, $5)`
+)
+
+const (
+	findTransactionsQuery = `SELECT id, wallet_id, type, currency, amount, ref_id, meta, created_at
+						FROM txs
+						WHERE wallet_id = $1`
+)
+
+const (
+	getBalanceQuery = `SELECT wallet_id, currency, amount, held
+						FROM wallet_balances
+						WHERE wallet_id = $1 AND currency = $2`
+
+	getBalancesQuery = `SELECT wallet_id, currency, amount, held
+						FROM wallet_balances
+						WHERE wallet_id = $1
+						ORDER BY currency`
+
+	lockWalletBalancesQuery = `SELECT 1 FROM wallet_balances WHERE wallet_id = $1 FOR UPDATE`
+
+	ensureBalanceQuery = `INSERT INTO wallet_balances (wallet_id, currency, amount)
+						VALUES ($1, $2, 0)
+						ON CONFLICT (wallet_id, currency) DO NOTHING`
+
+	applyBalanceQuery = `UPDATE wallet_balances SET amount = amount + $1
+						WHERE wallet_id = $2 AND currency = $3`
+
+	createJournalEntryQuery = `INSERT INTO journal_entries (type, ref_id, description, meta, reverses_entry_id)
+						VALUES ($1, $2, $3, $4, $5)
+						ON CONFLICT (ref_id) DO NOTHING
+						RETURNING id, created_at`
+
+	entryPostedQuery = `SELECT EXISTS (SELECT 1 FROM journal_entries WHERE ref_id = $1)`
+
+	getEntryByRefQuery = `SELECT id FROM journal_entries WHERE ref_id = $1 AND type = $2`
+
+	createPostingQuery = `INSERT INTO postings (entry_id, account, wallet_id, type, currency, amount)
+						VALUES ($1, $2, $3, $4, $5, $6)
+						RETURNING id, created_at`
+
+	insertEntryTxQuery = `INSERT INTO txs (wallet_id, type, currency, amount, ref_id, entry_id)
+						VALUES ($1, $2, $3, $4, $5, $6)`
+
+	ensureChainHeadQuery = `INSERT INTO ledger_chain_heads (wallet_id) VALUES ($1)
+						ON CONFLICT (wallet_id) DO NOTHING`
+
+	lockChainHeadQuery = `SELECT 1 FROM ledger_chain_heads WHERE wallet_id = $1 FOR UPDATE`
+
+	// a currency without postings has nothing to rebuild from, it is zero
+	zeroUnpostedBalancesQuery = `UPDATE wallet_balances wb SET amount = 0
+						WHERE wb.wallet_id = $1
+						  AND NOT EXISTS (SELECT 1 FROM postings p WHERE p.wallet_id = $1 AND p.currency = wb.currency)`
+
+	rebuildBalancesQuery = `INSERT INTO wallet_balances (wallet_id, currency, amount)
+						SELECT $1, p.currency, SUM(p.amount)
+						FROM postings p
+						WHERE p.wallet_id = $1
+						GROUP BY p.currency
+						ON CONFLICT (wallet_id, currency)
+						DO UPDATE SET amount = EXCLUDED.amount`
+)
+
+const (
+	useFXQuoteQuery = `UPDATE fx_quotes SET used_at = now(), used_ref_id = $2
+						WHERE id = $1 AND used_at IS NULL AND expires_at > now()`
+)
+
+const (
+	// share lock blocks status changes while funds move, status changes take FOR UPDATE
+	lockWalletStatusQuery = `SELECT status FROM wallets WHERE id = $1 FOR SHARE`
+
+	getWalletForUpdateQuery = `SELECT * FROM wallets WHERE id = $1 FOR UPDATE`
+
+	countOpenBalancesQuery = `SELECT COUNT(*) FROM wallet_balances
+						WHERE wallet_id = $1 AND (amount <> 0 OR held <> 0)`
+
+	updateWalletStatusQuery = `UPDATE wallets
+						SET status = $1, status_reason = $2, status_changed_by = $3, status_changed_at = now()
+						WHERE id = $4 RETURNING *`
+
+	createWalletStatusEventQuery = `INSERT INTO wallet_status_events (wallet_id, from_status, to_status, reason, actor_id)
+						VALUES ($1, $2, $3, $4, $5)`
+
+	findWalletStatusEventsQuery = `SELECT * FROM wallet_status_events
+						WHERE wallet_id = $1 ORDER BY created_at DESC, id DESC`
+)
+
+const (
+	// serialise limit checks of one user so concurrent movements cannot both fit
+	lockLimitUserQuery = `SELECT pg_advisory_xact_lock(hashtext('limit_usage'), $1::int)`
+
+	findUserLimitUsageQuery = `SELECT amount, created_at FROM limit_usage
+						WHERE user_id = $1 AND operation = $2 AND currency = $3
+						AND created_at > now() - make_interval(secs => $4)
+						ORDER BY created_at ASC, id ASC`
+
+	findWalletLimitUsageQuery = `SELECT amount, created_at FROM limit_usage
+						WHERE wallet_id = $1 AND operation = $2 AND currency = $3
+						AND created_at > now() - make_interval(secs => $4)
+						ORDER BY created_at ASC, id ASC`
+
+	createLimitUsageQuery = `INSERT INTO limit_usage (user_id, wallet_id, operation, currency, amount, ref_id)
+						VALUES ($1, $2, $3, $4, $5, $6)`
+
+	deleteLimitUsageQuery = `DELETE FROM limit_usage WHERE ref_id = $1`
+)
+
+const (
+	getTransferEntryQuery = `SELECT id, meta FROM journal_entries WHERE ref_id = $1 AND type = $2`
+
+	// serialises reversals of one transfer
+	getTransferEntryForUpdateQuery = `SELECT id, meta FROM journal_entries WHERE ref_id = $1 AND type = $2 FOR UPDATE`
+
+	findEntryPostingsQuery = `SELECT * FROM postings WHERE entry_id = $1 ORDER BY id`
+
+	sumReversalsQuery = `SELECT COALESCE(SUM(amount), 0), COALESCE(SUM(converted_amount), 0), COALESCE(SUM(fee_amount), 0)
+						FROM reversals
+						WHERE original_entry_id = $1`
+
+	getReversalByRefQuery = `SELECT * FROM reversals WHERE ref_id = $1 AND original_ref_id = $2`
+
+	createReversalQuery = `INSERT INTO reversals (ref_id, entry_id, original_entry_id, original_ref_id, amount, converted_amount, fee_amount, rate, reason, actor_id)
+						VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING *`
+
+	findReversalsQuery = `SELECT * FROM reversals WHERE original_ref_id = $1 ORDER BY created_at, id`
+)
+
+const (
+	createEscrowQuery = `INSERT INTO escrows (ref_id, buyer_wallet_id, seller_wallet_id, currency, amount, description, release_at, created_by)
+						VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING *`
+
+	getEscrowQuery = `SELECT * FROM escrows WHERE id = $1`
+
+	getEscrowByRefQuery = `SELECT * FROM escrows WHERE ref_id = $1`
+
+	getEscrowForUpdateQuery = `SELECT * FROM escrows WHERE id = $1 FOR UPDATE`
+
+	updateEscrowStatusQuery = `UPDATE escrows SET status = $1, dispute_reason = COALESCE($2, dispute_reason), updated_at = now()
+						WHERE id = $3 RETURNING *`
+
+	findUserEscrowsQuery = `SELECT e.* FROM escrows e
+						WHERE e.buyer_wallet_id IN (SELECT id FROM wallets WHERE user_id = $1)
+						OR e.seller_wallet_id IN (SELECT id FROM wallets WHERE user_id = $1)
+						ORDER BY e.created_at DESC, e.id DESC`
+
+	// escrows whose seller cannot receive are left until the wallet is active again
+	findDueEscrowsQuery = `SELECT e.* FROM escrows e
+						JOIN wallets w ON w.id = e.seller_wallet_id AND w.status = 'active'
+						WHERE e.status = 'held' AND e.release_at <= $1
+						ORDER BY e.release_at, e.id
+						LIMIT $2`
+
+	createEscrowEventQuery = `INSERT INTO escrow_events (escrow_id, from_status, to_status, reason, actor_id, entry_id)
+						VALUES ($1, $2, $3, $4, $5, $6)`
+
+	findEscrowEventsQuery = `SELECT * FROM escrow_events WHERE escrow_id = $1 ORDER BY created_at, id`
+)
+
+const (
+	// serialise default wallet creation per user
+	lockUserWalletsQuery = `SELECT pg_advisory_xact_lock(hashtext('default_wallet'), $1::int)`
+
+	hasFrozenWalletQuery = `SELECT EXISTS (SELECT 1 FROM wallets WHERE user_id = $1 AND status = 'frozen')`
+
+	// oldest active wallet already holding the currency, else the oldest active wallet, pockets never receive
+	findDefaultWalletQuery = `SELECT w.* FROM wallets w
+						LEFT JOIN wallet_balances b ON b.wallet_id = w.id AND b.currency = $2
+						WHERE w.user_id = $1 AND w.status = 'active'
+						AND NOT EXISTS (SELECT 1 FROM wallet_pockets p WHERE p.wallet_id = w.id)
+						ORDER BY (b.wallet_id IS NOT NULL) DESC, w.id ASC
+						LIMIT 1`
+)
+
+const (
+	// a pocket wallet belongs to the owner of its parent
+	createPocketWalletQuery = `INSERT INTO wallets (user_id, name, created_at)
+						SELECT user_id, $2, now() FROM wallets WHERE id = $1
+						RETURNING *`
+
+	createPocketQuery = `INSERT INTO wallet_pockets (wallet_id, parent_wallet_id, currency, target_amount, target_date)
+						VALUES ($1, $2, $3, $4, $5)`
+
+	getPocketQuery = `SELECT p.wallet_id, p.parent_wallet_id, w.name, w.status, p.currency, COALESCE(b.amount, 0) AS balance,
+						p.target_amount, p.target_date, p.created_at, p.updated_at
+						FROM wallet_pockets p
+						JOIN wallets w ON w.id = p.wallet_id
+						LEFT JOIN wallet_balances b ON b.wallet_id = p.wallet_id AND b.currency = p.currency
+						WHERE p.wallet_id = $1`
+
+	findPocketsQuery = `SELECT p.wallet_id, p.parent_wallet_id, w.name, w.status, p.currency, COALESCE(b.amount, 0) AS balance,
+						p.target_amount, p.target_date, p.created_at, p.updated_at
+						FROM wallet_pockets p
+						JOIN wallets w ON w.id = p.wallet_id
+						LEFT JOIN wallet_balances b ON b.wallet_id = p.wallet_id AND b.currency = p.currency
+						WHERE p.parent_wallet_id = $1
+						ORDER BY p.wallet_id`
+
+	updatePocketTargetQuery = `UPDATE wallet_pockets SET target_amount = $1, target_date = $2, updated_at = now()
+						WHERE wallet_id = $3`
+
+	// pocket balances per parent wallet and currency
+	findPocketTotalsQuery = `SELECT p.parent_wallet_id, b.currency, SUM(b.amount) AS amount
+						FROM wallet_pockets p
+						JOIN wallet_balances b ON b.wallet_id = p.wallet_id
+						WHERE p.parent_wallet_id IN (SELECT value::bigint FROM jsonb_array_elements_text($1::jsonb))
+						GROUP BY p.parent_wallet_id, b.currency`
+)
+
+const (
+	// serializes snapshot creation across instances
+	lockSnapshotsQuery = `SELECT pg_advisory_xact_lock(hashtext('balance_snapshots'))`
+
+	getSnapshotQuery = `SELECT * FROM balance_snapshots WHERE as_of = $1`
+
+	latestSnapshotQuery = `SELECT * FROM balance_snapshots WHERE as_of <= $1 ORDER BY as_of DESC LIMIT 1`
+
+	createSnapshotQuery = `INSERT INTO balance_snapshots (as_of, wallet_count, line_count) VALUES ($1, 0, 0)`
+
+	// the previous snapshot plus the posted rows since, or every posted row
+	// when there is no previous snapshot
+	createSnapshotLinesQuery = `INSERT INTO balance_snapshot_lines (as_of, wallet_id, currency, amount)
+						SELECT $1, wallet_id, currency, SUM(amount) FROM (
+							SELECT wallet_id, currency, amount FROM balance_snapshot_lines WHERE as_of = $2
+							UNION ALL
+							SELECT wallet_id, currency, amount FROM txs
+							WHERE entry_id IS NOT NULL AND created_at < $1
+							AND ($2::timestamptz IS NULL OR created_at >= $2)
+						) b
+						GROUP BY wallet_id, currency`
+
+	countSnapshotQuery = `UPDATE balance_snapshots s SET wallet_count = c.wallets, line_count = c.lines
+						FROM (
+							SELECT COUNT(DISTINCT wallet_id) AS wallets, COUNT(*) AS lines
+							FROM balance_snapshot_lines WHERE as_of = $1
+						) c
+						WHERE s.as_of = $1 RETURNING s.*`
+
+	// balances at $3: the snapshot at $2 plus the posted rows from $2 to $3
+	findBalancesAtQuery = `SELECT $1::bigint AS wallet_id, currency, SUM(amount) AS amount FROM (
+							SELECT currency, amount FROM balance_snapshot_lines WHERE as_of = $2 AND wallet_id = $1
+							UNION ALL
+							SELECT currency, amount FROM txs
+							WHERE wallet_id = $1 AND entry_id IS NOT NULL AND created_at <= $3
+							AND ($2::timestamptz IS NULL OR created_at >= $2)
+						) b
+						GROUP BY currency ORDER BY currency`
+
+	findAllBalancesAtQuery = `SELECT b.wallet_id, w.user_id, b.currency, SUM(b.amount) AS amount FROM (
+							SELECT wallet_id, currency, amount FROM balance_snapshot_lines WHERE as_of = $1
+							UNION ALL
+							SELECT wallet_id, currency, amount FROM txs
+							WHERE entry_id IS NOT NULL AND created_at <= $2
+							AND ($1::timestamptz IS NULL OR created_at >= $1)
+						) b
+						JOIN wallets w ON w.id = b.wallet_id
+						GROUP BY b.wallet_id, w.user_id, b.currency
+						ORDER BY b.wallet_id, b.currency`
+)
+
+const (
+	createAdjustmentQuery = `INSERT INTO balance_adjustments (wallet_id, currency, direction, amount, reason, attachments, requested_by)
+						VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING *`
+
+	getAdjustmentQuery = `SELECT * FROM balance_adjustments WHERE id = $1`
+
+	getAdjustmentForUpdateQuery = `SELECT * FROM balance_adjustments WHERE id = $1 FOR UPDATE`
+
+	findAdjustmentsQuery = `SELECT * FROM balance_adjustments
+						WHERE ($1::text = '' OR status = $1::text)
+						AND ($2::bigint = 0 OR wallet_id = $2::bigint)
+						AND ($3::bigint = 0 OR requested_by = $3::bigint)
+						ORDER BY created_at DESC, id DESC LIMIT $4`
+
+	updateAdjustmentQuery = `UPDATE balance_adjustments
+						SET status = $2, reviewed_by = $3, review_note = $4, entry_id = $5, reviewed_at = now()
+						WHERE id = $1 RETURNING *`
+)
+
+const (
+	createRiskReviewQuery = `INSERT INTO risk_reviews (operation, wallet_id, user_id, currency, amount, held,
+							to_wallet_id, to_currency, converted_amount, rate, quote_id,
+							fee, fee_schedule_id, fee_wallet_id, ref_id, hits, requested_by)
+						VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
+						ON CONFLICT (operation, ref_id) DO NOTHING
+						RETURNING *`
+
+	getRiskReviewByRefQuery = `SELECT * FROM risk_reviews WHERE operation = $1 AND ref_id = $2`
+
+	getRiskReviewQuery = `SELECT * FROM risk_reviews WHERE id = $1`
+
+	getRiskReviewForUpdateQuery = `SELECT * FROM risk_reviews WHERE id = $1 FOR UPDATE`
+
+	// the queue is worked oldest first
+	findRiskReviewsQuery = `SELECT * FROM risk_reviews
+						WHERE ($1::text = '' OR status = $1::text)
+						AND ($2::bigint = 0 OR wallet_id = $2::bigint OR to_wallet_id = $2::bigint)
+						AND ($3::bigint = 0 OR user_id = $3::bigint)
+						ORDER BY created_at, id LIMIT $4`
+
+	updateRiskReviewQuery = `UPDATE risk_reviews
+						SET status = $2, reviewed_by = $3, review_note = $4, entry_id = $5, reviewed_at = now()
+						WHERE id = $1 RETURNING *`
 )
@@ -0,0 +1,95 @@
+package snapshotter
+
+import (
+	"context"
+	"time"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/config"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/wallet"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/logger"
+)
+
+const (
+	defaultPollInterval = 15 * time.Minute
+	defaultSettle       = 10 * time.Minute
+
+	day = 24 * time.Hour
+)
+
+// Snapshotter takes a balance snapshot at every UTC midnight once the day has
+// settled, catching up on days missed while it was not running
+type Snapshotter struct {
+	walletRepo   wallet.Repository
+	pollInterval time.Duration
+	settle       time.Duration
+	logger       logger.Logger
+	now          func() time.Time
+}
+
+// Snapshotter constructor, zero config values fall back to defaults
+func NewSnapshotter(cfg *config.Config, walletRepo wallet.Repository, log logger.Logger) *Snapshotter {
+	s := &Snapshotter{
+		walletRepo:   walletRepo,
+		pollInterval: time.Duration(cfg.Snapshots.PollIntervalMinutes) * time.Minute,
+		settle:       time.Duration(cfg.Snapshots.SettleMinutes) * time.Minute,
+		logger:       log,
+		now:          time.Now,
+	}
+
+	if s.pollInterval <= 0 {
+		s.pollInterval = defaultPollInterval
+	}
+	if s.settle <= 0 {
+		s.settle = defaultSettle
+	}
+
+	return s
+}
+
+// Poll for settled days until ctx is done
+func (s *Snapshotter) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.ProcessDue(ctx); err != nil {
+			s.logger.Errorf("balance snapshotter: %s", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Snapshot every settled midnight after the latest snapshot, returns how many
+// were taken. The first snapshot is the latest settled midnight.
+func (s *Snapshotter) ProcessDue(ctx context.Context) (int, error) {
+	last := s.now().Add(-s.settle).UTC().Truncate(day)
+
+	latest, err := s.walletRepo.LatestSnapshot(ctx, last)
+	if err != nil {
+		return 0, err
+	}
+
+	next := last
+	if latest != nil {
+		next = latest.AsOf.UTC().Add(day)
+	}
+
+	taken := 0
+	for ; !next.After(last); next = next.Add(day) {
+		snapshot, err := s.walletRepo.CreateSnapshotTx(ctx, next)
+		if err != nil {
+			return taken, err
+		}
+
+		taken++
+		s.logger.Infof("Balance snapshot as of %s: %d wallets, %d lines",
+			snapshot.AsOf.UTC().Format(time.RFC3339), snapshot.WalletCount, snapshot.LineCount)
+	}
+
+	return taken, nil
+}
@@ -0,0 +1,79 @@
+package snapshotter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/config"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/wallet/mock"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/logger"
+)
+
+func newTestSnapshotter(repo *mock.MockRepository, now time.Time) *Snapshotter {
+	cfg := &config.Config{
+		Logger:    config.Logger{Level: "error", Encoding: "console"},
+		Snapshots: config.Snapshots{SettleMinutes: 10},
+	}
+	l := logger.NewApiLogger(cfg)
+	l.InitLogger()
+
+	s := NewSnapshotter(cfg, repo, l)
+	s.now = func() time.Time { return now }
+	return s
+}
+
+func TestSnapshotter_ProcessDue(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockRepository(ctrl)
+	midnight := time.Date(2026, 7, 3, 0, 0, 0, 0, time.UTC)
+
+	// the day has not settled yet, the latest settled midnight is the day before
+	early := newTestSnapshotter(repo, midnight.Add(5*time.Minute))
+	repo.EXPECT().LatestSnapshot(gomock.Any(), midnight.Add(-24*time.Hour)).
+		Return(&models.BalanceSnapshot{AsOf: midnight.Add(-24 * time.Hour)}, nil)
+
+	n, err := early.ProcessDue(context.Background())
+	require.NoError(t, err)
+	require.Zero(t, n)
+
+	// missed days are caught up in order
+	late := newTestSnapshotter(repo, midnight.Add(30*time.Minute))
+	repo.EXPECT().LatestSnapshot(gomock.Any(), midnight).
+		Return(&models.BalanceSnapshot{AsOf: midnight.Add(-48 * time.Hour)}, nil)
+	gomock.InOrder(
+		repo.EXPECT().CreateSnapshotTx(gomock.Any(), midnight.Add(-24*time.Hour)).Return(&models.BalanceSnapshot{AsOf: midnight.Add(-24 * time.Hour)}, nil),
+		repo.EXPECT().CreateSnapshotTx(gomock.Any(), midnight).Return(&models.BalanceSnapshot{AsOf: midnight}, nil),
+	)
+
+	n, err = late.ProcessDue(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+}
+
+func TestSnapshotter_ProcessDue_First(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockRepository(ctrl)
+	midnight := time.Date(2026, 7, 3, 0, 0, 0, 0, time.UTC)
+	s := newTestSnapshotter(repo, midnight.Add(9*time.Hour))
+
+	// without snapshots only the latest settled midnight is taken
+	repo.EXPECT().LatestSnapshot(gomock.Any(), midnight).Return(nil, nil)
+	repo.EXPECT().CreateSnapshotTx(gomock.Any(), midnight).Return(&models.BalanceSnapshot{AsOf: midnight}, nil)
+
+	n, err := s.ProcessDue(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+}
@@ -3,6 +3,7 @@ package wallet
 
 import (
 	"context"
+	"time"
 
 	"github.com/aditwar-man/go-microservice-boilerplate/internal/dto"
 	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
@@ -12,7 +13,46 @@ import (
 // Auth repository interface
 type UseCase interface {
 	Create(ctx context.Context, dto *dto.RequestCreateWallet) (*models.Wallet, error)
-	ListWallet(ctx context.Context, pq *utils.PaginationQuery) (*models.WalletList, error)
+	ListWallet(ctx context.Context, userID int, pq *utils.PaginationQuery) (*models.WalletList, error)
 	Deposit(ctx context.Context, dto *dto.RequestDeposit) (*models.WalletBalance, error)
 	Transfer(ctx context.Context, dto *dto.RequestTransfer) (*models.Transaction, error)
+	PreviewTransfer(ctx context.Context, request *dto.RequestTransfer) (*dto.TransferPreview, error)
+	SendP2P(ctx context.Context, request *dto.RequestP2PTransfer) (*dto.P2PTransfer, error)
+	PreviewP2P(ctx context.Context, request *dto.RequestP2PTransfer) (*dto.P2PTransfer, error)
+	Withdraw(ctx context.Context, dto *dto.RequestWithdraw) (*models.Withdrawal, error)
+	SettleWithdrawal(ctx context.Context, walletID, withdrawalID int64) (*models.Withdrawal, error)
+	ReleaseWithdrawal(ctx context.Context, walletID, withdrawalID int64) (*models.Withdrawal, error)
+	ListTransactions(ctx context.Context, filter *models.TransactionFilter) (*dto.TransactionList, error)
+	RebuildBalances(ctx context.Context, walletID int64) ([]models.WalletBalance, error)
+	Freeze(ctx context.Context, walletID int64, dto *dto.RequestWalletStatus) (*models.Wallet, error)
+	Unfreeze(ctx context.Context, walletID int64, dto *dto.RequestWalletStatus) (*models.Wallet, error)
+	Close(ctx context.Context, walletID int64, dto *dto.RequestWalletStatus) (*models.Wallet, error)
+	Archive(ctx context.Context, walletID int64, dto *dto.RequestWalletStatus) (*models.Wallet, error)
+	ListStatusEvents(ctx context.Context, walletID int64) ([]*models.WalletStatusEvent, error)
+	ReverseTransfer(ctx context.Context, refID string, request *dto.RequestReversal) (*models.Reversal, error)
+	ListReversals(ctx context.Context, refID string) ([]*models.Reversal, error)
+	CreateEscrow(ctx context.Context, request *dto.RequestEscrow) (*models.Escrow, error)
+	ListEscrows(ctx context.Context) ([]*models.Escrow, error)
+	GetEscrow(ctx context.Context, escrowID int64) (*models.Escrow, error)
+	ListEscrowEvents(ctx context.Context, escrowID int64) ([]*models.EscrowEvent, error)
+	ReleaseEscrow(ctx context.Context, escrowID int64) (*models.Escrow, error)
+	CancelEscrow(ctx context.Context, escrowID int64, request *dto.RequestEscrowAction) (*models.Escrow, error)
+	DisputeEscrow(ctx context.Context, escrowID int64, request *dto.RequestEscrowAction) (*models.Escrow, error)
+	ResolveEscrow(ctx context.Context, escrowID int64, request *dto.RequestEscrowResolution) (*models.Escrow, error)
+	CreatePocket(ctx context.Context, walletID int64, request *dto.RequestCreatePocket) (*models.Pocket, error)
+	ListPockets(ctx context.Context, walletID int64) ([]*models.Pocket, error)
+	UpdatePocketTarget(ctx context.Context, walletID, pocketID int64, request *dto.RequestPocketTarget) (*models.Pocket, error)
+	MovePocketFunds(ctx context.Context, walletID int64, request *dto.RequestPocketMove) ([]*models.Pocket, error)
+	BalancesAt(ctx context.Context, walletID int64, at time.Time) (*dto.BalancesAt, error)
+	ExportBalancesAt(ctx context.Context, at time.Time) ([]*models.HistoricalBalance, error)
+	RequestAdjustment(ctx context.Context, walletID int64, request *dto.RequestAdjustment) (*models.Adjustment, error)
+	ListAdjustments(ctx context.Context, filter *models.AdjustmentFilter) ([]*models.Adjustment, error)
+	GetAdjustment(ctx context.Context, adjustmentID int64) (*models.Adjustment, error)
+	ApproveAdjustment(ctx context.Context, adjustmentID int64, request *dto.RequestAdjustmentReview) (*models.Adjustment, error)
+	RejectAdjustment(ctx context.Context, adjustmentID int64, request *dto.RequestAdjustmentReview) (*models.Adjustment, error)
+	CancelAdjustment(ctx context.Context, adjustmentID int64) (*models.Adjustment, error)
+	ListRiskReviews(ctx context.Context, filter *models.RiskReviewFilter) ([]*models.RiskReview, error)
+	GetRiskReview(ctx context.Context, reviewID int64) (*models.RiskReview, error)
+	ApproveRiskReview(ctx context.Context, reviewID int64, request *dto.RequestRiskReview) (*models.RiskReview, error)
+	RejectRiskReview(ctx context.Context, reviewID int64, request *dto.RequestRiskReview) (*models.RiskReview, error)
 }
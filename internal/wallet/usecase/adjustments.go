@@ -0,0 +1,173 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+
+	"github.com/opentracing/opentracing-go"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/dto"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/wallet"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/httpErrors"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/utils"
+)
+
+const (
+	defaultAdjustmentsLimit = 50
+	maxAdjustmentsLimit     = 200
+)
+
+// Propose a manual credit or debit on a wallet. Nothing moves until a
+// different user approves it.
+func (u *walletUC) RequestAdjustment(ctx context.Context, walletID int64, request *dto.RequestAdjustment) (*models.Adjustment, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletUC.RequestAdjustment")
+	defer span.Finish()
+
+	user, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !request.Amount.IsPositive() {
+		return nil, httpErrors.NewBadRequestError("amount must be > 0")
+	}
+
+	reason := strings.TrimSpace(request.Reason)
+	if reason == "" {
+		return nil, httpErrors.NewBadRequestError("reason is required")
+	}
+
+	if _, err := u.currencyUC.Validate(ctx, request.Currency, request.Amount); err != nil {
+		return nil, err
+	}
+
+	w, err := u.walletRepo.GetByID(ctx, walletID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := wallet.StatusError(w.Status); err != nil {
+		return nil, err
+	}
+
+	created, err := u.walletRepo.CreateAdjustment(ctx, &models.Adjustment{
+		WalletID:    models.ID(walletID),
+		Currency:    request.Currency,
+		Direction:   request.Direction,
+		Amount:      request.Amount,
+		Reason:      reason,
+		Attachments: request.Attachments,
+		RequestedBy: int64(user.User.ID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	u.logger.Infof("Adjustment %d requested by user %d: %s %s %s on wallet %d",
+		created.ID, user.User.ID, created.Direction, created.Amount, created.Currency, walletID)
+
+	return created, nil
+}
+
+// List adjustments, newest first
+func (u *walletUC) ListAdjustments(ctx context.Context, filter *models.AdjustmentFilter) ([]*models.Adjustment, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletUC.ListAdjustments")
+	defer span.Finish()
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultAdjustmentsLimit
+	}
+	if filter.Limit > maxAdjustmentsLimit {
+		filter.Limit = maxAdjustmentsLimit
+	}
+
+	return u.walletRepo.FindAdjustments(ctx, filter)
+}
+
+func (u *walletUC) GetAdjustment(ctx context.Context, adjustmentID int64) (*models.Adjustment, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletUC.GetAdjustment")
+	defer span.Finish()
+
+	return u.walletRepo.GetAdjustment(ctx, adjustmentID)
+}
+
+// Approve a pending adjustment of another user and post it
+func (u *walletUC) ApproveAdjustment(ctx context.Context, adjustmentID int64, request *dto.RequestAdjustmentReview) (*models.Adjustment, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletUC.ApproveAdjustment")
+	defer span.Finish()
+
+	return u.reviewAdjustment(ctx, adjustmentID, models.AdjustmentApproved, strings.TrimSpace(request.Note))
+}
+
+// Reject a pending adjustment of another user, the note says why
+func (u *walletUC) RejectAdjustment(ctx context.Context, adjustmentID int64, request *dto.RequestAdjustmentReview) (*models.Adjustment, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletUC.RejectAdjustment")
+	defer span.Finish()
+
+	note := strings.TrimSpace(request.Note)
+	if note == "" {
+		return nil, httpErrors.NewBadRequestError("note is required to reject")
+	}
+
+	return u.reviewAdjustment(ctx, adjustmentID, models.AdjustmentRejected, note)
+}
+
+// Withdraw a pending adjustment, only its requester may
+func (u *walletUC) CancelAdjustment(ctx context.Context, adjustmentID int64) (*models.Adjustment, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletUC.CancelAdjustment")
+	defer span.Finish()
+
+	user, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := u.walletRepo.GetAdjustment(ctx, adjustmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if a.RequestedBy != int64(user.User.ID) {
+		return nil, wallet.ErrAdjustmentAccessDenied
+	}
+
+	cancelled, err := u.walletRepo.UpdateAdjustmentTx(ctx, adjustmentID, models.AdjustmentCancelled, int64(user.User.ID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	u.logger.Infof("Adjustment %d cancelled by user %d", adjustmentID, user.User.ID)
+	return cancelled, nil
+}
+
+// Approve or reject an adjustment, the requester can never review their own
+func (u *walletUC) reviewAdjustment(ctx context.Context, adjustmentID int64, status, note string) (*models.Adjustment, error) {
+	reviewer, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := u.walletRepo.GetAdjustment(ctx, adjustmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if a.RequestedBy == int64(reviewer.User.ID) {
+		u.logger.Warnf("User %d denied review of own adjustment %d", reviewer.User.ID, adjustmentID)
+		return nil, wallet.ErrAdjustmentSelfReview
+	}
+
+	var reviewNote *string
+	if note != "" {
+		reviewNote = &note
+	}
+
+	reviewed, err := u.walletRepo.UpdateAdjustmentTx(ctx, adjustmentID, status, int64(reviewer.User.ID), reviewNote)
+	if err != nil {
+		return nil, err
+	}
+
+	u.logger.Infof("Adjustment %d %s by user %d", adjustmentID, status, reviewer.User.ID)
+	return reviewed, nil
+}
@@ -0,0 +1,271 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/opentracing/opentracing-go"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/dto"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/wallet"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/httpErrors"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/utils"
+)
+
+// Open an escrow from a wallet of the caller. The amount counts against the
+// buyer's transfer limits and leaves the buyer balance until the escrow ends.
+func (u *walletUC) CreateEscrow(ctx context.Context, request *dto.RequestEscrow) (*models.Escrow, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletUC.CreateEscrow")
+	defer span.Finish()
+
+	user, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !request.Amount.IsPositive() {
+		return nil, httpErrors.NewBadRequestError("amount must be > 0")
+	}
+
+	if request.BuyerWalletID == request.SellerWalletID {
+		return nil, httpErrors.NewBadRequestError("buyer and seller wallets must differ")
+	}
+
+	if request.ReleaseAt != nil && !request.ReleaseAt.After(time.Now()) {
+		return nil, httpErrors.NewBadRequestError("release_at must be in the future")
+	}
+
+	if _, err := u.currencyUC.Validate(ctx, request.Currency, request.Amount); err != nil {
+		return nil, err
+	}
+
+	buyer, err := u.walletRepo.GetByID(ctx, request.BuyerWalletID)
+	if err != nil {
+		return nil, err
+	}
+
+	if int(buyer.UserID) != user.User.ID {
+		return nil, wallet.ErrWalletAccessDenied
+	}
+
+	seller, err := u.walletRepo.GetByID(ctx, request.SellerWalletID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := wallet.StatusError(seller.Status); err != nil {
+		return nil, err
+	}
+
+	check, err := u.limitsUC.Resolve(ctx, int64(buyer.UserID), buyer.ID, models.LimitOperationTransfer, request.Currency, request.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	refID := request.Reference
+	if refID == "" {
+		refID = uuid.New().String()
+	}
+
+	createdBy := int64(user.User.ID)
+	return u.walletRepo.CreateEscrowTx(ctx, &models.Escrow{
+		RefID:          refID,
+		BuyerWalletID:  buyer.ID,
+		SellerWalletID: seller.ID,
+		Currency:       request.Currency,
+		Amount:         request.Amount,
+		Description:    request.Description,
+		ReleaseAt:      request.ReleaseAt,
+		CreatedBy:      &createdBy,
+	}, check)
+}
+
+// List the escrows the caller is buyer or seller in
+func (u *walletUC) ListEscrows(ctx context.Context) ([]*models.Escrow, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletUC.ListEscrows")
+	defer span.Finish()
+
+	user, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.walletRepo.FindEscrows(ctx, int64(user.User.ID))
+}
+
+// Get an escrow of the caller
+func (u *walletUC) GetEscrow(ctx context.Context, escrowID int64) (*models.Escrow, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletUC.GetEscrow")
+	defer span.Finish()
+
+	e, _, err := u.authorizeEscrow(ctx, escrowID)
+	return e, err
+}
+
+// Get the state change history of an escrow
+func (u *walletUC) ListEscrowEvents(ctx context.Context, escrowID int64) ([]*models.EscrowEvent, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletUC.ListEscrowEvents")
+	defer span.Finish()
+
+	if _, _, err := u.authorizeEscrow(ctx, escrowID); err != nil {
+		return nil, err
+	}
+
+	return u.walletRepo.FindEscrowEvents(ctx, escrowID)
+}
+
+// Buyer confirms delivery, the funds go to the seller
+func (u *walletUC) ReleaseEscrow(ctx context.Context, escrowID int64) (*models.Escrow, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletUC.ReleaseEscrow")
+	defer span.Finish()
+
+	e, party, err := u.authorizeEscrow(ctx, escrowID)
+	if err != nil {
+		return nil, err
+	}
+
+	if party != escrowBuyer {
+		return nil, wallet.ErrEscrowAccessDenied
+	}
+
+	return u.updateEscrow(ctx, e, models.EscrowStatusHeld, models.EscrowStatusReleased, "confirmed by buyer")
+}
+
+// Seller cancels the sale, the funds go back to the buyer. A buyer who wants
+// their money back without the seller's consent opens a dispute instead.
+func (u *walletUC) CancelEscrow(ctx context.Context, escrowID int64, request *dto.RequestEscrowAction) (*models.Escrow, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletUC.CancelEscrow")
+	defer span.Finish()
+
+	if request.Reason == "" {
+		return nil, httpErrors.NewBadRequestError("reason is required")
+	}
+
+	e, party, err := u.authorizeEscrow(ctx, escrowID)
+	if err != nil {
+		return nil, err
+	}
+
+	if party != escrowSeller {
+		return nil, wallet.ErrEscrowAccessDenied
+	}
+
+	return u.updateEscrow(ctx, e, models.EscrowStatusHeld, models.EscrowStatusRefunded, request.Reason)
+}
+
+// Either party freezes a held escrow until an admin resolves it, this also
+// stops the automatic release
+func (u *walletUC) DisputeEscrow(ctx context.Context, escrowID int64, request *dto.RequestEscrowAction) (*models.Escrow, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletUC.DisputeEscrow")
+	defer span.Finish()
+
+	if request.Reason == "" {
+		return nil, httpErrors.NewBadRequestError("reason is required")
+	}
+
+	e, party, err := u.authorizeEscrow(ctx, escrowID)
+	if err != nil {
+		return nil, err
+	}
+
+	if party == escrowAdmin {
+		return nil, wallet.ErrEscrowAccessDenied
+	}
+
+	return u.updateEscrow(ctx, e, models.EscrowStatusHeld, models.EscrowStatusDisputed, request.Reason)
+}
+
+// Admin settles a dispute by releasing to the seller or refunding the buyer
+func (u *walletUC) ResolveEscrow(ctx context.Context, escrowID int64, request *dto.RequestEscrowResolution) (*models.Escrow, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletUC.ResolveEscrow")
+	defer span.Finish()
+
+	if request.Reason == "" {
+		return nil, httpErrors.NewBadRequestError("reason is required")
+	}
+
+	var to string
+	switch request.Resolution {
+	case "release":
+		to = models.EscrowStatusReleased
+	case "refund":
+		to = models.EscrowStatusRefunded
+	default:
+		return nil, httpErrors.NewBadRequestError("resolution must be release or refund")
+	}
+
+	e, err := u.walletRepo.GetEscrow(ctx, escrowID)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.updateEscrow(ctx, e, models.EscrowStatusDisputed, to, request.Reason)
+}
+
+func (u *walletUC) updateEscrow(ctx context.Context, e *models.Escrow, from, to, reason string) (*models.Escrow, error) {
+	actor, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if e.Status != from {
+		return nil, wallet.ErrEscrowTransition
+	}
+
+	actorID := int64(actor.User.ID)
+	updated, err := u.walletRepo.UpdateEscrowTx(ctx, e.ID, from, to, reason, &actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	u.logger.Infof("Escrow %d moved to %s by user %d: %s", e.ID, to, actor.User.ID, reason)
+	return updated, nil
+}
+
+// Role of the caller in an escrow
+type escrowParty int
+
+const (
+	escrowBuyer escrowParty = iota
+	escrowSeller
+	// not a party, but holds manage on wallets
+	escrowAdmin
+)
+
+// Load an escrow and the role of the caller in it, callers that are neither
+// party need manage on wallets
+func (u *walletUC) authorizeEscrow(ctx context.Context, escrowID int64) (*models.Escrow, escrowParty, error) {
+	user, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	e, err := u.walletRepo.GetEscrow(ctx, escrowID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	buyer, err := u.walletRepo.GetByID(ctx, e.BuyerWalletID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if int(buyer.UserID) == user.User.ID {
+		return e, escrowBuyer, nil
+	}
+
+	seller, err := u.walletRepo.GetByID(ctx, e.SellerWalletID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if int(seller.UserID) == user.User.ID {
+		return e, escrowSeller, nil
+	}
+
+	if err := u.requireManage(user.User.ID); err != nil {
+		return nil, 0, wallet.ErrEscrowAccessDenied
+	}
+
+	return e, escrowAdmin, nil
+}
@@ -0,0 +1,161 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/dto"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/wallet"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/httpErrors"
+)
+
+// Name of the wallet created for a recipient without an active wallet
+const defaultWalletName = "Default"
+
+// Send to a user by username or email through the regular transfer path,
+// the funds go to the recipient's default wallet for the currency
+func (u *walletUC) SendP2P(ctx context.Context, request *dto.RequestP2PTransfer) (*dto.P2PTransfer, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletUC.SendP2P")
+	defer span.Finish()
+
+	transferRequest, recipient, err := u.prepareP2P(ctx, request, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if transferRequest.Reference == "" {
+		transferRequest.Reference = uuid.New().String()
+	}
+
+	transfer, err := u.executeTransfer(ctx, transferRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.P2PTransfer{
+		Recipient:       maskName(recipient.Username),
+		Reference:       transfer.RefID,
+		TransferPreview: *newTransferPreview(transfer),
+	}, nil
+}
+
+// Price a transfer to a user by username or email, the masked recipient name
+// lets the sender confirm the person before sending. Nothing is created, a
+// recipient without a wallet yet is priced without one.
+func (u *walletUC) PreviewP2P(ctx context.Context, request *dto.RequestP2PTransfer) (*dto.P2PTransfer, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletUC.PreviewP2P")
+	defer span.Finish()
+
+	transferRequest, recipient, err := u.prepareP2P(ctx, request, false)
+	if err != nil {
+		return nil, err
+	}
+
+	transfer, _, err := u.prepareTransfer(ctx, transferRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.P2PTransfer{
+		Recipient:       maskName(recipient.Username),
+		TransferPreview: *newTransferPreview(transfer),
+	}, nil
+}
+
+// Resolve the recipient and their default wallet into a same-currency
+// transfer request. With create a recipient without an active wallet gets
+// one, otherwise the request has no recipient wallet. A recipient with a
+// frozen wallet is refused.
+func (u *walletUC) prepareP2P(ctx context.Context, request *dto.RequestP2PTransfer, create bool) (*dto.RequestTransfer, *models.User, error) {
+	if !request.Amount.IsPositive() {
+		return nil, nil, httpErrors.NewBadRequestError("amount must be > 0")
+	}
+
+	if _, err := u.currencyUC.Validate(ctx, request.Currency, request.Amount); err != nil {
+		return nil, nil, err
+	}
+
+	from, err := u.authorizeWallet(ctx, int64(request.FromWalletID))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	recipient, err := u.findRecipient(ctx, request.Recipient)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if recipient.ID == int(from.UserID) {
+		return nil, nil, httpErrors.NewBadRequestError("recipient owns the source wallet")
+	}
+
+	var to *models.Wallet
+	if create {
+		to, err = u.walletRepo.GetOrCreateDefaultWalletTx(ctx, int64(recipient.ID), request.Currency, defaultWalletName)
+	} else {
+		to, err = u.walletRepo.FindDefaultWallet(ctx, int64(recipient.ID), request.Currency)
+		if errors.Is(err, wallet.ErrWalletNotFound) {
+			to, err = &models.Wallet{}, nil
+		}
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &dto.RequestTransfer{
+		FromWalletID: request.FromWalletID,
+		ToWalletID:   uint(to.ID),
+		FromCurrency: request.Currency,
+		ToCurrency:   request.Currency,
+		Amount:       request.Amount,
+		Reference:    request.Reference,
+	}, recipient, nil
+}
+
+// Find a user by email when the handle contains @, by username otherwise
+func (u *walletUC) findRecipient(ctx context.Context, handle string) (*models.User, error) {
+	handle = strings.TrimSpace(handle)
+	if handle == "" {
+		return nil, httpErrors.NewBadRequestError("recipient is required")
+	}
+
+	var (
+		recipient *models.User
+		err       error
+	)
+	if strings.Contains(handle, "@") {
+		recipient, err = u.authRepo.FindByEmail(ctx, strings.ToLower(handle))
+	} else {
+		var found *models.UserWithRole
+		if found, err = u.authRepo.FindByUsername(ctx, handle); err == nil {
+			recipient = &found.User
+		}
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, wallet.ErrRecipientNotFound
+		}
+		return nil, err
+	}
+
+	return recipient, nil
+}
+
+// Keep the first and last letter of a name, "alice" becomes "a***e"
+func maskName(name string) string {
+	runes := []rune(name)
+	switch len(runes) {
+	case 0:
+		return ""
+	case 1, 2:
+		return string(runes[0]) + "***"
+	default:
+		return string(runes[0]) + "***" + string(runes[len(runes)-1])
+	}
+}
@@ -0,0 +1,218 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/dto"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/wallet"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/httpErrors"
+)
+
+// Create a pocket under a wallet of the caller. Pockets hold one currency and
+// cannot have pockets of their own.
+func (u *walletUC) CreatePocket(ctx context.Context, walletID int64, request *dto.RequestCreatePocket) (*models.Pocket, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletUC.CreatePocket")
+	defer span.Finish()
+
+	parent, err := u.authorizeParent(ctx, walletID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.validatePocketTarget(ctx, request.Currency, request.TargetAmount, request.TargetDate); err != nil {
+		return nil, err
+	}
+
+	pocket, err := u.walletRepo.CreatePocketTx(ctx, &models.Pocket{
+		ParentWalletID: parent.ID,
+		Name:           request.Name,
+		Currency:       request.Currency,
+		TargetAmount:   request.TargetAmount,
+		TargetDate:     request.TargetDate,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	u.logger.Infof("Pocket %d created under wallet %d in %s", pocket.ID, parent.ID, pocket.Currency)
+	pocket.Progress = pocket.ComputeProgress(time.Now())
+	return pocket, nil
+}
+
+// List the pockets of a wallet with their progress
+func (u *walletUC) ListPockets(ctx context.Context, walletID int64) ([]*models.Pocket, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletUC.ListPockets")
+	defer span.Finish()
+
+	if _, err := u.authorizeWallet(ctx, walletID); err != nil {
+		return nil, err
+	}
+
+	return u.findPockets(ctx, walletID)
+}
+
+// Set or clear the savings goal of a pocket
+func (u *walletUC) UpdatePocketTarget(ctx context.Context, walletID, pocketID int64, request *dto.RequestPocketTarget) (*models.Pocket, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletUC.UpdatePocketTarget")
+	defer span.Finish()
+
+	if _, err := u.authorizeWallet(ctx, walletID); err != nil {
+		return nil, err
+	}
+
+	pocket, err := u.walletRepo.GetPocket(ctx, pocketID)
+	if err != nil {
+		return nil, err
+	}
+
+	if pocket.ParentWalletID != walletID {
+		return nil, wallet.ErrPocketNotFound
+	}
+
+	if err := u.validatePocketTarget(ctx, pocket.Currency, request.TargetAmount, request.TargetDate); err != nil {
+		return nil, err
+	}
+
+	updated, err := u.walletRepo.UpdatePocketTarget(ctx, pocket.ID, request.TargetAmount, request.TargetDate)
+	if err != nil {
+		return nil, err
+	}
+
+	updated.Progress = updated.ComputeProgress(time.Now())
+	return updated, nil
+}
+
+// Move funds between a wallet and its pockets, or between two of its pockets.
+// Moves are instant and free, they skip fees and transfer limits.
+func (u *walletUC) MovePocketFunds(ctx context.Context, walletID int64, request *dto.RequestPocketMove) ([]*models.Pocket, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletUC.MovePocketFunds")
+	defer span.Finish()
+
+	if !request.Amount.IsPositive() {
+		return nil, httpErrors.NewBadRequestError("amount must be > 0")
+	}
+
+	if request.FromWalletID == request.ToWalletID {
+		return nil, httpErrors.NewBadRequestError("from and to wallets must differ")
+	}
+
+	// a frozen or closed parent also stops moves between its pockets
+	parent, err := u.authorizeWallet(ctx, walletID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := wallet.StatusError(parent.Status); err != nil {
+		return nil, err
+	}
+
+	pockets, err := u.walletRepo.FindPockets(ctx, parent.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	pocketByID := make(map[int64]*models.Pocket, len(pockets))
+	for _, p := range pockets {
+		pocketByID[p.ID] = p
+	}
+
+	var currency string
+	for _, id := range []int64{request.FromWalletID, request.ToWalletID} {
+		if id == parent.ID {
+			continue
+		}
+		p, ok := pocketByID[id]
+		if !ok {
+			return nil, wallet.ErrPocketNotFound
+		}
+		if currency != "" && currency != p.Currency {
+			return nil, httpErrors.NewBadRequestError("pockets hold different currencies")
+		}
+		currency = p.Currency
+	}
+
+	if _, err := u.currencyUC.Validate(ctx, currency, request.Amount); err != nil {
+		return nil, err
+	}
+
+	refID := request.Reference
+	if refID == "" {
+		refID = uuid.New().String()
+	}
+
+	if err := u.walletRepo.MovePocketFundsTx(ctx, &models.PocketMove{
+		ParentWalletID: parent.ID,
+		FromWalletID:   request.FromWalletID,
+		ToWalletID:     request.ToWalletID,
+		Currency:       currency,
+		Amount:         request.Amount,
+		RefID:          refID,
+	}); err != nil {
+		return nil, err
+	}
+
+	return u.findPockets(ctx, parent.ID)
+}
+
+// Load a wallet of the caller that may hold pockets
+func (u *walletUC) authorizeParent(ctx context.Context, walletID int64) (*models.Wallet, error) {
+	parent, err := u.authorizeWallet(ctx, walletID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := wallet.StatusError(parent.Status); err != nil {
+		return nil, err
+	}
+
+	if _, err := u.walletRepo.GetPocket(ctx, parent.ID); err == nil {
+		return nil, httpErrors.NewBadRequestError("a pocket cannot hold pockets")
+	} else if !errors.Is(err, wallet.ErrPocketNotFound) {
+		return nil, err
+	}
+
+	return parent, nil
+}
+
+func (u *walletUC) validatePocketTarget(ctx context.Context, currency string, amount *decimal.Decimal, date *time.Time) error {
+	target := decimal.Zero
+	if amount != nil {
+		if !amount.IsPositive() {
+			return httpErrors.NewBadRequestError("target_amount must be > 0")
+		}
+		target = *amount
+	}
+
+	if date != nil {
+		if amount == nil {
+			return httpErrors.NewBadRequestError("target_date needs a target_amount")
+		}
+		if !date.After(time.Now()) {
+			return httpErrors.NewBadRequestError("target_date must be in the future")
+		}
+	}
+
+	_, err := u.currencyUC.Validate(ctx, currency, target)
+	return err
+}
+
+func (u *walletUC) findPockets(ctx context.Context, walletID int64) ([]*models.Pocket, error) {
+	pockets, err := u.walletRepo.FindPockets(ctx, walletID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for _, p := range pockets {
+		p.Progress = p.ComputeProgress(now)
+	}
+
+	return pockets, nil
+}
@@ -0,0 +1,184 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/dto"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/wallet"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/httpErrors"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/utils"
+)
+
+const (
+	defaultRiskReviewsLimit = 50
+	maxRiskReviewsLimit     = 200
+)
+
+// Act on a risk assessment, nil when the operation may post now. A flagged
+// operation is queued with its hold, a retry of one already approved posts as
+// a repeated reference.
+func (u *walletUC) applyRisk(ctx context.Context, assessment *models.RiskAssessment, review *models.RiskReview) error {
+	switch assessment.Decision {
+	case models.RiskDecisionDeny:
+		u.logger.Warnf("%s %s denied by risk rules on wallet %d", review.Operation, review.RefID, review.WalletID)
+		return wallet.ErrTransactionDenied
+
+	case models.RiskDecisionReview:
+		review.Hits = assessment.Hits
+		if user, err := utils.GetUserFromCtx(ctx); err == nil {
+			requestedBy := int64(user.User.ID)
+			review.RequestedBy = &requestedBy
+		}
+
+		held, err := u.walletRepo.HoldForReviewTx(ctx, review)
+		if err != nil {
+			return err
+		}
+
+		if held.Status == models.RiskReviewPending {
+			u.logger.Infof("%s %s held for review %d on wallet %d", held.Operation, held.RefID, held.ID, held.WalletID)
+		}
+		return reviewOutcome(held)
+	}
+
+	return nil
+}
+
+// Whether the request's reference was already used for the operation. A held
+// or rejected operation fails as it did the first time, a posted one replays.
+// A review held for a different operation refuses the reference.
+func (u *walletUC) replayedRef(ctx context.Context, request *models.RiskReview) (bool, error) {
+	review, err := u.walletRepo.GetRiskReviewByRef(ctx, request.Operation, request.RefID)
+	switch {
+	case err == nil:
+		if !review.SameOperation(request) {
+			return false, wallet.ErrReferenceInUse
+		}
+		return true, reviewOutcome(review)
+	case !errors.Is(err, wallet.ErrRiskReviewNotFound):
+		return false, err
+	}
+
+	return u.walletRepo.EntryPosted(ctx, request.RefID)
+}
+
+// Outcome of an operation under review, nil once it was approved and posted
+func reviewOutcome(review *models.RiskReview) error {
+	switch review.Status {
+	case models.RiskReviewPending:
+		return wallet.ErrTransactionHeld
+	case models.RiskReviewRejected:
+		return wallet.ErrTransactionDenied
+	}
+	return nil
+}
+
+// List held operations, oldest first
+func (u *walletUC) ListRiskReviews(ctx context.Context, filter *models.RiskReviewFilter) ([]*models.RiskReview, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletUC.ListRiskReviews")
+	defer span.Finish()
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultRiskReviewsLimit
+	}
+	if filter.Limit > maxRiskReviewsLimit {
+		filter.Limit = maxRiskReviewsLimit
+	}
+
+	return u.walletRepo.FindRiskReviews(ctx, filter)
+}
+
+func (u *walletUC) GetRiskReview(ctx context.Context, reviewID int64) (*models.RiskReview, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletUC.GetRiskReview")
+	defer span.Finish()
+
+	return u.walletRepo.GetRiskReview(ctx, reviewID)
+}
+
+// Release a held operation and post it, limits are checked as of now
+func (u *walletUC) ApproveRiskReview(ctx context.Context, reviewID int64, request *dto.RequestRiskReview) (*models.RiskReview, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletUC.ApproveRiskReview")
+	defer span.Finish()
+
+	reviewer, review, err := u.authorizeRiskReview(ctx, reviewID)
+	if err != nil {
+		return nil, err
+	}
+
+	operation := models.LimitOperationTransfer
+	if review.Operation == models.RiskOperationDeposit {
+		operation = models.LimitOperationDeposit
+	}
+
+	check, err := u.limitsUC.Resolve(ctx, review.UserID, review.WalletID, operation, review.Currency, review.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	approved, err := u.walletRepo.ApproveRiskReviewTx(ctx, reviewID, reviewer, reviewNote(request.Note), check)
+	if err != nil {
+		return nil, err
+	}
+
+	u.logger.Infof("Risk review %d approved by user %d", reviewID, reviewer)
+	return approved, nil
+}
+
+// Cancel a held operation and release its hold, the note says why
+func (u *walletUC) RejectRiskReview(ctx context.Context, reviewID int64, request *dto.RequestRiskReview) (*models.RiskReview, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletUC.RejectRiskReview")
+	defer span.Finish()
+
+	note := reviewNote(request.Note)
+	if note == nil {
+		return nil, httpErrors.NewBadRequestError("note is required to reject")
+	}
+
+	reviewer, _, err := u.authorizeRiskReview(ctx, reviewID)
+	if err != nil {
+		return nil, err
+	}
+
+	rejected, err := u.walletRepo.RejectRiskReviewTx(ctx, reviewID, reviewer, note)
+	if err != nil {
+		return nil, err
+	}
+
+	u.logger.Infof("Risk review %d rejected by user %d", reviewID, reviewer)
+	return rejected, nil
+}
+
+// Load a review for the caller to decide, neither the requester nor the
+// wallet owner may decide their own
+func (u *walletUC) authorizeRiskReview(ctx context.Context, reviewID int64) (int64, *models.RiskReview, error) {
+	user, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return 0, nil, err
+	}
+	reviewer := int64(user.User.ID)
+
+	review, err := u.walletRepo.GetRiskReview(ctx, reviewID)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if review.UserID == reviewer || (review.RequestedBy != nil && *review.RequestedBy == reviewer) {
+		u.logger.Warnf("User %d denied decision on own risk review %d", reviewer, reviewID)
+		return 0, nil, wallet.ErrRiskReviewSelfReview
+	}
+
+	return reviewer, review, nil
+}
+
+func reviewNote(note string) *string {
+	note = strings.TrimSpace(note)
+	if note == "" {
+		return nil
+	}
+	return &note
+}
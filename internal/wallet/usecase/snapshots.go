@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/dto"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/wallet"
+)
+
+// Balances of a wallet at a point in time, the nearest daily snapshot is
+// replayed forward with the ledger rows up to at
+func (u *walletUC) BalancesAt(ctx context.Context, walletID int64, at time.Time) (*dto.BalancesAt, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletUC.BalancesAt")
+	defer span.Finish()
+
+	if at.After(time.Now()) {
+		return nil, wallet.ErrBalanceAtFuture
+	}
+
+	if _, err := u.authorizeWallet(ctx, walletID); err != nil {
+		return nil, err
+	}
+
+	snapshotAt, err := u.snapshotBefore(ctx, at)
+	if err != nil {
+		return nil, err
+	}
+
+	balances, err := u.walletRepo.FindBalancesAt(ctx, walletID, snapshotAt, at)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.BalancesAt{WalletID: walletID, At: at.UTC(), SnapshotAt: snapshotAt, Balances: balances}, nil
+}
+
+// Balances of every wallet at a point in time, for month-end close
+func (u *walletUC) ExportBalancesAt(ctx context.Context, at time.Time) ([]*models.HistoricalBalance, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletUC.ExportBalancesAt")
+	defer span.Finish()
+
+	if at.After(time.Now()) {
+		return nil, wallet.ErrBalanceAtFuture
+	}
+
+	snapshotAt, err := u.snapshotBefore(ctx, at)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.walletRepo.FindAllBalancesAt(ctx, snapshotAt, at)
+}
+
+// Time of the latest snapshot at or before at, nil when there is none
+func (u *walletUC) snapshotBefore(ctx context.Context, at time.Time) (*time.Time, error) {
+	snapshot, err := u.walletRepo.LatestSnapshot(ctx, at)
+	if err != nil || snapshot == nil {
+		return nil, err
+	}
+
+	snapshotAt := snapshot.AsOf.UTC()
+	return &snapshotAt, nil
+}
@@ -2,15 +2,25 @@ package usecase
 
 import (
 	"context"
-	"encoding/json"
-	"errors"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
 
 	"github.com/aditwar-man/go-microservice-boilerplate/config"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/auth"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/currency"
 	"github.com/aditwar-man/go-microservice-boilerplate/internal/dto"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/fees"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/fx"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/limits"
 	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/rbac"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/risk"
 	"github.com/aditwar-man/go-microservice-boilerplate/internal/wallet"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/httpErrors"
 	"github.com/aditwar-man/go-microservice-boilerplate/pkg/logger"
 	"github.com/aditwar-man/go-microservice-boilerplate/pkg/utils"
 )
@@ -18,18 +28,30 @@ import (
 const (
 	basePrefix    = "api-auth:"
 	cacheDuration = 3600
+
+	// holders of manage on wallets may act on wallets they do not own
+	permissionManage = "manage"
+	resourceWallets  = "wallets"
 )
 
 // Auth UseCase
 type walletUC struct {
 	cfg        *config.Config
 	walletRepo wallet.Repository
+	authRepo   auth.Repository
+	converter  utils.CurrencyConverter
+	fxUC       fx.UseCase
+	currencyUC currency.UseCase
+	limitsUC   limits.UseCase
+	feesUC     fees.UseCase
+	riskUC     risk.UseCase
+	rbac       rbac.RBACServiceInterface
 	logger     logger.Logger
 }
 
 // Auth UseCase constructor
-func NewWalletUseCase(cfg *config.Config, walletRepo wallet.Repository, log logger.Logger) wallet.UseCase {
-	return &walletUC{cfg: cfg, walletRepo: walletRepo, logger: log}
+func NewWalletUseCase(cfg *config.Config, walletRepo wallet.Repository, authRepo auth.Repository, converter utils.CurrencyConverter, fxUC fx.UseCase, currencyUC currency.UseCase, limitsUC limits.UseCase, feesUC fees.UseCase, riskUC risk.UseCase, rbacService rbac.RBACServiceInterface, log logger.Logger) wallet.UseCase {
+	return &walletUC{cfg: cfg, walletRepo: walletRepo, authRepo: authRepo, converter: converter, fxUC: fxUC, currencyUC: currencyUC, limitsUC: limitsUC, feesUC: feesUC, riskUC: riskUC, rbac: rbacService, logger: log}
 }
 
 // Create new user
@@ -50,69 +72,582 @@ func (u *walletUC) Create(ctx context.Context, dto *dto.RequestCreateWallet) (*m
 	return createdWallet, nil
 }
 
-// Get wallets with pagination
-func (u *walletUC) ListWallet(ctx context.Context, pq *utils.PaginationQuery) (*models.WalletList, error) {
+// Get wallets of a user with pagination
+func (u *walletUC) ListWallet(ctx context.Context, userID int, pq *utils.PaginationQuery) (*models.WalletList, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "walletUC.GetWallets")
 	defer span.Finish()
 
-	return u.walletRepo.FindAll(ctx, pq)
+	user, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.User.ID != userID {
+		if err = u.requireManage(user.User.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	return u.walletRepo.FindAll(ctx, userID, pq)
 }
 
 func (u *walletUC) Deposit(ctx context.Context, dto *dto.RequestDeposit) (*models.WalletBalance, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "walletUC.Deposit")
 	defer span.Finish()
 
-	if dto.Amount <= 0 {
+	if !dto.Amount.IsPositive() {
 		return nil, errors.New("amount must be > 0")
 	}
 
-	// lock balance row
-	balance, err := u.walletRepo.GetBalanceForUpdate(ctx, int64(dto.WalletID), dto.Currency)
+	if _, err := u.currencyUC.Validate(ctx, dto.Currency, dto.Amount); err != nil {
+		return nil, err
+	}
+
+	w, err := u.authorizeWallet(ctx, int64(dto.WalletID))
 	if err != nil {
 		return nil, err
 	}
 
-	// if not exist, init new
-	if balance == nil {
-		balance = &models.WalletBalance{
-			WalletID: models.ID(dto.WalletID),
-			Currency: dto.Currency,
-			Amount:   0,
-		}
+	if err = wallet.StatusError(w.Status); err != nil {
+		return nil, err
+	}
+
+	refID := dto.Reference
+	if refID == "" {
+		refID = uuid.New().String()
 	}
 
-	// add deposit
-	balance.Amount += int64(dto.Amount)
-	slo, _ := json.Marshal(balance)
-	sli, _ := json.Marshal(dto)
-	u.logger.Info("DataAmount: " + string(slo))
-	u.logger.Info("DataAmount2: " + string(sli))
+	review := &models.RiskReview{
+		Operation: models.RiskOperationDeposit,
+		WalletID:  w.ID,
+		UserID:    int64(w.UserID),
+		Currency:  dto.Currency,
+		Amount:    dto.Amount,
+		RefID:     refID,
+	}
 
-	// upsert
-	err = u.walletRepo.UpsertBalance(ctx, balance)
+	// a repeated reference gets its original outcome without being checked
+	// again, the repository refuses it when it posted anything but this deposit
+	replay, err := u.replayedRef(ctx, review)
 	if err != nil {
 		return nil, err
 	}
 
-	return balance, nil
+	var check *models.LimitCheck
+	if !replay {
+		// limits are counted against the wallet owner, not the caller
+		check, err = u.limitsUC.Resolve(ctx, int64(w.UserID), w.ID, models.LimitOperationDeposit, dto.Currency, dto.Amount)
+		if err != nil {
+			return nil, err
+		}
+
+		assessment, err := u.riskUC.Assess(ctx, &models.RiskRequest{
+			Operation: models.RiskOperationDeposit,
+			UserID:    int64(w.UserID),
+			WalletID:  w.ID,
+			Currency:  dto.Currency,
+			Amount:    dto.Amount,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if err := u.applyRisk(ctx, assessment, review); err != nil {
+			return nil, err
+		}
+	}
+
+	// post cash-in entry, a repeated reference returns the current balance
+	return u.walletRepo.DepositTx(ctx, int64(dto.WalletID), dto.Currency, dto.Amount, refID, check)
 }
 
 func (u *walletUC) Transfer(ctx context.Context, dto *dto.RequestTransfer) (*models.Transaction, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "walletUC.Transfer")
 	defer span.Finish()
 
-	if dto.Amount <= 0 {
-		return nil, errors.New("amount must be > 0")
+	if _, err := u.executeTransfer(ctx, dto); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// Validate, price and post a transfer, returns the posted transfer
+func (u *walletUC) executeTransfer(ctx context.Context, request *dto.RequestTransfer) (*models.Transfer, error) {
+	transfer, from, err := u.prepareTransfer(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	review := models.NewTransferReview(transfer, int64(from.UserID), nil)
+
+	// a repeated reference gets its original outcome without being checked again
+	replay, err := u.replayedRef(ctx, review)
+	if err != nil {
+		return nil, err
+	}
+
+	var check *models.LimitCheck
+	if !replay {
+		check, err = u.limitsUC.Resolve(ctx, int64(from.UserID), from.ID, models.LimitOperationTransfer, request.FromCurrency, request.Amount)
+		if err != nil {
+			return nil, err
+		}
+
+		assessment, err := u.riskUC.Assess(ctx, &models.RiskRequest{
+			Operation:            models.RiskOperationTransfer,
+			UserID:               int64(from.UserID),
+			WalletID:             from.ID,
+			CounterpartyWalletID: transfer.ToWalletID,
+			Currency:             transfer.FromCurrency,
+			Amount:               transfer.Amount,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if err := u.applyRisk(ctx, assessment, review); err != nil {
+			return nil, err
+		}
+	}
+
+	// execute transaction in repo, a replay only checks the reference posted this transfer
+	if err := u.walletRepo.TransferTx(ctx, transfer, check); err != nil {
+		return nil, err
+	}
+
+	return transfer, nil
+}
+
+// Price a transfer without executing it
+func (u *walletUC) PreviewTransfer(ctx context.Context, request *dto.RequestTransfer) (*dto.TransferPreview, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletUC.PreviewTransfer")
+	defer span.Finish()
+
+	transfer, _, err := u.prepareTransfer(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return newTransferPreview(transfer), nil
+}
+
+// Validate, authorize and price a transfer including its fee, returns the source wallet
+func (u *walletUC) prepareTransfer(ctx context.Context, dto *dto.RequestTransfer) (*models.Transfer, *models.Wallet, error) {
+	if !dto.Amount.IsPositive() {
+		return nil, nil, errors.New("amount must be > 0")
 	}
 
 	if dto.FromWalletID == dto.ToWalletID && dto.FromCurrency == dto.ToCurrency {
-		return nil, errors.New("invalid transfer target")
+		return nil, nil, errors.New("invalid transfer target")
+	}
+
+	fromCurrency, err := u.currencyUC.Validate(ctx, dto.FromCurrency, dto.Amount)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	to, err := u.currencyUC.Validate(ctx, dto.ToCurrency, decimal.Zero)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	from, err := u.authorizeWallet(ctx, int64(dto.FromWalletID))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err = wallet.StatusError(from.Status); err != nil {
+		return nil, nil, err
+	}
+
+	// the recipient only has to exist and be able to receive, a P2P preview
+	// to a user without a wallet yet has none to check
+	if dto.ToWalletID != 0 {
+		recipient, err := u.walletRepo.GetByID(ctx, int64(dto.ToWalletID))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if err = wallet.StatusError(recipient.Status); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	refID := dto.Reference
+	if refID == "" {
+		refID = uuid.New().String()
+	}
+
+	transfer := &models.Transfer{
+		FromWalletID:    int64(dto.FromWalletID),
+		ToWalletID:      int64(dto.ToWalletID),
+		FromCurrency:    dto.FromCurrency,
+		ToCurrency:      dto.ToCurrency,
+		Amount:          dto.Amount,
+		ConvertedAmount: dto.Amount,
+		Rate:            decimal.NewFromInt(1),
+		RefID:           refID,
+		FeeWalletID:     u.cfg.Fees.HouseWalletID,
+	}
+
+	if err := u.priceTransfer(ctx, transfer, to, dto.QuoteID); err != nil {
+		return nil, nil, err
+	}
+
+	// the sender pays the fee, rounded to the source currency minor unit
+	fee, err := u.feesUC.Calculate(ctx, int64(from.UserID), dto.FromCurrency, dto.ToCurrency, dto.Amount)
+	if err != nil {
+		return nil, nil, err
+	}
+	fee.Amount = fromCurrency.Round(fee.Amount)
+	transfer.Fee = *fee
+
+	return transfer, from, nil
+}
+
+func newTransferPreview(t *models.Transfer) *dto.TransferPreview {
+	return &dto.TransferPreview{
+		FromCurrency:    t.FromCurrency,
+		ToCurrency:      t.ToCurrency,
+		Amount:          t.Amount,
+		Fee:             t.Fee,
+		TotalDebit:      t.Amount.Add(t.Fee.Amount),
+		Rate:            t.Rate,
+		ConvertedAmount: t.ConvertedAmount,
+		QuoteID:         t.QuoteID,
+	}
+}
+
+// Fill the converted amount of a cross-currency transfer, from the locked
+// quote when one is referenced, otherwise from the current rate rounded to
+// the target currency minor unit
+func (u *walletUC) priceTransfer(ctx context.Context, transfer *models.Transfer, to *models.Currency, quoteID string) error {
+	if quoteID != "" {
+		quote, err := u.fxUC.GetQuote(ctx, quoteID)
+		if err != nil {
+			return err
+		}
+
+		user, err := utils.GetUserFromCtx(ctx)
+		if err != nil {
+			return err
+		}
+
+		if quote.UserID != user.User.ID ||
+			quote.FromCurrency != transfer.FromCurrency ||
+			quote.ToCurrency != transfer.ToCurrency ||
+			!quote.Amount.Equal(transfer.Amount) {
+			return fx.ErrQuoteMismatch
+		}
+
+		if quote.UsedAt != nil || time.Now().After(quote.ExpiresAt) {
+			return fx.ErrQuoteExpired
+		}
+
+		transfer.QuoteID = quote.ID
+		transfer.Rate = quote.Rate
+		transfer.ConvertedAmount = quote.ConvertedAmount
+		return nil
+	}
+
+	if transfer.FromCurrency == transfer.ToCurrency {
+		return nil
+	}
+
+	converted, err := u.converter.Convert(ctx, transfer.FromCurrency, transfer.ToCurrency, transfer.Amount)
+	if err != nil {
+		return err
+	}
+
+	transfer.ConvertedAmount = to.Round(converted)
+	if !transfer.ConvertedAmount.IsPositive() {
+		return errors.New("converted amount rounds to zero")
+	}
+	transfer.Rate = converted.Div(transfer.Amount)
+	return nil
+}
+
+// Place a hold on the wallet balance for a pending withdrawal
+func (u *walletUC) Withdraw(ctx context.Context, dto *dto.RequestWithdraw) (*models.Withdrawal, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletUC.Withdraw")
+	defer span.Finish()
+
+	if !dto.Amount.IsPositive() {
+		return nil, errors.New("amount must be > 0")
 	}
 
-	// execute transaction in repo
-	if err := u.walletRepo.TransferTx(ctx, int64(dto.FromWalletID), int64(dto.ToWalletID), dto.FromCurrency, dto.ToCurrency, int64(dto.Amount), dto.Reference); err != nil {
+	if _, err := u.currencyUC.Validate(ctx, dto.Currency, dto.Amount); err != nil {
 		return nil, err
 	}
 
-	return nil, nil
+	w, err := u.authorizeWallet(ctx, int64(dto.WalletID))
+	if err != nil {
+		return nil, err
+	}
+
+	if err = wallet.StatusError(w.Status); err != nil {
+		return nil, err
+	}
+
+	check, err := u.limitsUC.Resolve(ctx, int64(w.UserID), w.ID, models.LimitOperationWithdrawal, dto.Currency, dto.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	refID := dto.Reference
+	if refID == "" {
+		refID = uuid.New().String()
+	}
+
+	return u.walletRepo.HoldWithdrawalTx(ctx, &models.Withdrawal{
+		WalletID: models.ID(dto.WalletID),
+		Currency: dto.Currency,
+		Amount:   dto.Amount,
+		RefID:    refID,
+	}, check)
+}
+
+// Settle a held withdrawal, debiting the wallet balance
+func (u *walletUC) SettleWithdrawal(ctx context.Context, walletID, withdrawalID int64) (*models.Withdrawal, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletUC.SettleWithdrawal")
+	defer span.Finish()
+
+	return u.walletRepo.SettleWithdrawalTx(ctx, walletID, withdrawalID)
+}
+
+// Release a held withdrawal, returning the funds to the available balance
+func (u *walletUC) ReleaseWithdrawal(ctx context.Context, walletID, withdrawalID int64) (*models.Withdrawal, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletUC.ReleaseWithdrawal")
+	defer span.Finish()
+
+	if _, err := u.authorizeWallet(ctx, walletID); err != nil {
+		return nil, err
+	}
+
+	return u.walletRepo.ReleaseWithdrawalTx(ctx, walletID, withdrawalID)
+}
+
+// Get wallet transaction history page, fetching one extra row to detect the next page
+func (u *walletUC) ListTransactions(ctx context.Context, filter *models.TransactionFilter) (*dto.TransactionList, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletUC.ListTransactions")
+	defer span.Finish()
+
+	if _, err := u.authorizeWallet(ctx, filter.WalletID); err != nil {
+		return nil, err
+	}
+
+	size := filter.Limit
+	filter.Limit = size + 1
+
+	transactions, err := u.walletRepo.FindTransactions(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &dto.TransactionList{
+		Size:         size,
+		Transactions: make([]*dto.TransactionResponse, 0, len(transactions)),
+	}
+
+	if len(transactions) > size {
+		transactions = transactions[:size]
+		last := transactions[size-1]
+		list.HasMore = true
+		list.NextCursor = (&utils.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}).Encode()
+	}
+
+	for _, t := range transactions {
+		list.Transactions = append(list.Transactions, dto.NewTransactionResponse(t))
+	}
+
+	return list, nil
+}
+
+// Rebuild the wallet_balances projection of a wallet from ledger postings
+func (u *walletUC) RebuildBalances(ctx context.Context, walletID int64) ([]models.WalletBalance, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletUC.RebuildBalances")
+	defer span.Finish()
+
+	return u.walletRepo.RebuildBalancesTx(ctx, walletID)
+}
+
+// Freeze an active wallet, it can no longer send or receive
+func (u *walletUC) Freeze(ctx context.Context, walletID int64, dto *dto.RequestWalletStatus) (*models.Wallet, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletUC.Freeze")
+	defer span.Finish()
+
+	return u.changeStatus(ctx, walletID, models.WalletStatusFrozen, dto.Reason)
+}
+
+// Unfreeze a frozen wallet back to active
+func (u *walletUC) Unfreeze(ctx context.Context, walletID int64, dto *dto.RequestWalletStatus) (*models.Wallet, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletUC.Unfreeze")
+	defer span.Finish()
+
+	return u.changeStatus(ctx, walletID, models.WalletStatusActive, dto.Reason)
+}
+
+// Close a wallet whose balances are all zero
+func (u *walletUC) Close(ctx context.Context, walletID int64, dto *dto.RequestWalletStatus) (*models.Wallet, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletUC.Close")
+	defer span.Finish()
+
+	return u.changeStatus(ctx, walletID, models.WalletStatusClosed, dto.Reason)
+}
+
+// Archive a closed wallet
+func (u *walletUC) Archive(ctx context.Context, walletID int64, dto *dto.RequestWalletStatus) (*models.Wallet, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletUC.Archive")
+	defer span.Finish()
+
+	return u.changeStatus(ctx, walletID, models.WalletStatusArchived, dto.Reason)
+}
+
+// Get the state change history of a wallet
+func (u *walletUC) ListStatusEvents(ctx context.Context, walletID int64) ([]*models.WalletStatusEvent, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletUC.ListStatusEvents")
+	defer span.Finish()
+
+	return u.walletRepo.FindStatusEvents(ctx, walletID)
+}
+
+// Reverse a posted transfer in full or in part. The recipient is debited at
+// the original rate and the sender credited, optionally with a pro rata fee refund.
+func (u *walletUC) ReverseTransfer(ctx context.Context, refID string, request *dto.RequestReversal) (*models.Reversal, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletUC.ReverseTransfer")
+	defer span.Finish()
+
+	actor, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Reason == "" {
+		return nil, httpErrors.NewBadRequestError("reason is required")
+	}
+
+	if request.Amount.IsNegative() {
+		return nil, httpErrors.NewBadRequestError("amount must be > 0")
+	}
+
+	original, err := u.walletRepo.FindTransferEntry(ctx, refID)
+	if err != nil {
+		return nil, err
+	}
+
+	amount := original.Remaining()
+	if !request.Amount.IsZero() {
+		amount = request.Amount
+	}
+
+	if !amount.IsPositive() || amount.GreaterThan(original.Remaining()) {
+		return nil, wallet.ErrReversalExceedsOriginal
+	}
+
+	from, err := u.currencyUC.Validate(ctx, original.FromCurrency, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := u.currencyUC.Validate(ctx, original.ToCurrency, decimal.Zero)
+	if err != nil {
+		return nil, err
+	}
+
+	converted, fee := original.ReversalAmounts(amount, request.RefundFee, from, to)
+	if !converted.IsPositive() {
+		return nil, errors.New("converted amount rounds to zero")
+	}
+
+	reversalRef := request.Reference
+	if reversalRef == "" {
+		reversalRef = uuid.New().String()
+	}
+
+	actorID := int64(actor.User.ID)
+	reversal, err := u.walletRepo.ReverseTransferTx(ctx, &models.Reversal{
+		RefID:           reversalRef,
+		OriginalRefID:   refID,
+		Amount:          amount,
+		ConvertedAmount: converted,
+		FeeAmount:       fee,
+		Rate:            original.Rate,
+		Reason:          request.Reason,
+		ActorID:         &actorID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	u.logger.Infof("Transfer %s reversed by user %d: %s %s (%s)", refID, actor.User.ID, amount, original.FromCurrency, request.Reason)
+	return reversal, nil
+}
+
+// Get the reversals of a transfer
+func (u *walletUC) ListReversals(ctx context.Context, refID string) ([]*models.Reversal, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "walletUC.ListReversals")
+	defer span.Finish()
+
+	if _, err := u.walletRepo.FindTransferEntry(ctx, refID); err != nil {
+		return nil, err
+	}
+
+	return u.walletRepo.FindReversals(ctx, refID)
+}
+
+func (u *walletUC) changeStatus(ctx context.Context, walletID int64, status, reason string) (*models.Wallet, error) {
+	actor, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if reason == "" {
+		return nil, httpErrors.NewBadRequestError("reason is required")
+	}
+
+	updated, err := u.walletRepo.UpdateStatusTx(ctx, walletID, status, reason, int64(actor.User.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	u.logger.Infof("Wallet %d moved to %s by user %d: %s", walletID, status, actor.User.ID, reason)
+	return updated, nil
+}
+
+// Allow the wallet owner, or a caller holding manage on wallets
+func (u *walletUC) authorizeWallet(ctx context.Context, walletID int64) (*models.Wallet, error) {
+	user, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	w, err := u.walletRepo.GetByID(ctx, walletID)
+	if err != nil {
+		return nil, err
+	}
+
+	if int(w.UserID) == user.User.ID {
+		return w, nil
+	}
+
+	if err = u.requireManage(user.User.ID); err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+func (u *walletUC) requireManage(userID int) error {
+	allowed, err := u.rbac.HasPermission(userID, permissionManage, resourceWallets, nil)
+	if err != nil {
+		return httpErrors.NewInternalServerError(errors.Wrap(err, "walletUC.requireManage.HasPermission"))
+	}
+
+	if !allowed {
+		u.logger.Warnf("User %d denied %s on %s", userID, permissionManage, resourceWallets)
+		return wallet.ErrWalletAccessDenied
+	}
+
+	return nil
 }
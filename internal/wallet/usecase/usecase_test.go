@@ -0,0 +1,671 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/config"
+	authMock "github.com/aditwar-man/go-microservice-boilerplate/internal/auth/mock"
+	currencyMock "github.com/aditwar-man/go-microservice-boilerplate/internal/currency/mock"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/dto"
+	feesMock "github.com/aditwar-man/go-microservice-boilerplate/internal/fees/mock"
+	fxProvider "github.com/aditwar-man/go-microservice-boilerplate/internal/fx/provider"
+	limitsMock "github.com/aditwar-man/go-microservice-boilerplate/internal/limits/mock"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	rbacMock "github.com/aditwar-man/go-microservice-boilerplate/internal/rbac/mock"
+	riskMock "github.com/aditwar-man/go-microservice-boilerplate/internal/risk/mock"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/wallet"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/wallet/mock"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/logger"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/utils"
+)
+
+func newTestLogger() logger.Logger {
+	l := logger.NewApiLogger(&config.Config{Logger: config.Logger{Level: "error", Encoding: "console"}})
+	l.InitLogger()
+	return l
+}
+
+// Converter at a fixed USD to IDR rate
+func newTestConverter() utils.CurrencyConverter {
+	return fxProvider.NewRateConverter(fxProvider.NewStaticProvider(map[string]decimal.Decimal{"USD:IDR": decimal.NewFromInt(16000)}))
+}
+
+func userCtx(userID int) context.Context {
+	return context.WithValue(context.Background(), utils.UserCtxKey{}, &models.UserWithRole{User: models.User{ID: userID}})
+}
+
+func TestWalletUC_Transfer_RequiresOwnership(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWalletRepo := mock.NewMockRepository(ctrl)
+	mockCurrencyUC := currencyMock.NewMockUseCase(ctrl)
+	mockRbac := rbacMock.NewMockRBACServiceInterface(ctrl)
+	walletUC := NewWalletUseCase(&config.Config{}, mockWalletRepo, nil, newTestConverter(), nil, mockCurrencyUC, nil, nil, nil, mockRbac, newTestLogger())
+
+	usd := &models.Currency{Code: "USD", Exponent: 2, Enabled: true}
+	mockCurrencyUC.EXPECT().Validate(gomock.Any(), "USD", gomock.Any()).Return(usd, nil).Times(2)
+	mockWalletRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(&models.Wallet{ID: 1, UserID: 7, Status: models.WalletStatusActive}, nil)
+	mockRbac.EXPECT().HasPermission(8, "manage", "wallets", nil).Return(false, nil)
+
+	_, err := walletUC.Transfer(userCtx(8), &dto.RequestTransfer{
+		FromWalletID: 1,
+		ToWalletID:   2,
+		FromCurrency: "USD",
+		ToCurrency:   "USD",
+		Amount:       decimal.NewFromInt(10),
+	})
+	require.ErrorIs(t, err, wallet.ErrWalletAccessDenied)
+}
+
+func TestWalletUC_Transfer_Owner(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWalletRepo := mock.NewMockRepository(ctrl)
+	mockCurrencyUC := currencyMock.NewMockUseCase(ctrl)
+	mockLimitsUC := limitsMock.NewMockUseCase(ctrl)
+	mockFeesUC := feesMock.NewMockUseCase(ctrl)
+	mockRiskUC := riskMock.NewMockUseCase(ctrl)
+	mockRbac := rbacMock.NewMockRBACServiceInterface(ctrl)
+	walletUC := NewWalletUseCase(&config.Config{}, mockWalletRepo, nil, newTestConverter(), nil, mockCurrencyUC, mockLimitsUC, mockFeesUC, mockRiskUC, mockRbac, newTestLogger())
+
+	usd := &models.Currency{Code: "USD", Exponent: 2, Enabled: true}
+	idr := &models.Currency{Code: "IDR", Exponent: 2, Enabled: true}
+	mockCurrencyUC.EXPECT().Validate(gomock.Any(), "USD", gomock.Any()).Return(usd, nil)
+	mockCurrencyUC.EXPECT().Validate(gomock.Any(), "IDR", gomock.Any()).Return(idr, nil)
+	mockWalletRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(&models.Wallet{ID: 1, UserID: 7, Status: models.WalletStatusActive}, nil)
+	mockWalletRepo.EXPECT().GetByID(gomock.Any(), int64(2)).Return(&models.Wallet{ID: 2, UserID: 9, Status: models.WalletStatusActive}, nil)
+	mockFeesUC.EXPECT().Calculate(gomock.Any(), int64(7), "USD", "IDR", gomock.Any()).Return(&models.Fee{Currency: "USD", Amount: decimal.Zero}, nil)
+	mockWalletRepo.EXPECT().GetRiskReviewByRef(gomock.Any(), models.RiskOperationTransfer, gomock.Any()).Return(nil, wallet.ErrRiskReviewNotFound)
+	mockWalletRepo.EXPECT().EntryPosted(gomock.Any(), gomock.Any()).Return(false, nil)
+	mockLimitsUC.EXPECT().Resolve(gomock.Any(), int64(7), int64(1), models.LimitOperationTransfer, "USD", gomock.Any()).Return(nil, nil)
+	mockRiskUC.EXPECT().Assess(gomock.Any(), gomock.Any()).Return(&models.RiskAssessment{Decision: models.RiskDecisionAllow}, nil)
+	mockWalletRepo.EXPECT().TransferTx(gomock.Any(), gomock.Any(), gomock.Nil()).DoAndReturn(func(_ context.Context, transfer *models.Transfer, _ *models.LimitCheck) error {
+		require.Equal(t, "160000", transfer.ConvertedAmount.String())
+		return nil
+	})
+
+	_, err := walletUC.Transfer(userCtx(7), &dto.RequestTransfer{
+		FromWalletID: 1,
+		ToWalletID:   2,
+		FromCurrency: "USD",
+		ToCurrency:   "IDR",
+		Amount:       decimal.NewFromInt(10),
+	})
+	require.NoError(t, err)
+}
+
+func TestWalletUC_Transfer_RiskDecision(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		decision string
+		err      error
+	}{
+		{"held for review", models.RiskDecisionReview, wallet.ErrTransactionHeld},
+		{"denied", models.RiskDecisionDeny, wallet.ErrTransactionDenied},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockWalletRepo := mock.NewMockRepository(ctrl)
+			mockCurrencyUC := currencyMock.NewMockUseCase(ctrl)
+			mockLimitsUC := limitsMock.NewMockUseCase(ctrl)
+			mockFeesUC := feesMock.NewMockUseCase(ctrl)
+			mockRiskUC := riskMock.NewMockUseCase(ctrl)
+			walletUC := NewWalletUseCase(&config.Config{}, mockWalletRepo, nil, nil, nil, mockCurrencyUC, mockLimitsUC, mockFeesUC, mockRiskUC, nil, newTestLogger())
+
+			usd := &models.Currency{Code: "USD", Exponent: 2, Enabled: true}
+			mockCurrencyUC.EXPECT().Validate(gomock.Any(), "USD", gomock.Any()).Return(usd, nil).Times(2)
+			mockWalletRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(&models.Wallet{ID: 1, UserID: 7, Status: models.WalletStatusActive}, nil)
+			mockWalletRepo.EXPECT().GetByID(gomock.Any(), int64(2)).Return(&models.Wallet{ID: 2, UserID: 9, Status: models.WalletStatusActive}, nil)
+			mockFeesUC.EXPECT().Calculate(gomock.Any(), int64(7), "USD", "USD", gomock.Any()).Return(&models.Fee{Currency: "USD", Amount: decimal.NewFromInt(1)}, nil)
+			mockWalletRepo.EXPECT().GetRiskReviewByRef(gomock.Any(), models.RiskOperationTransfer, "ref-1").Return(nil, wallet.ErrRiskReviewNotFound)
+			mockWalletRepo.EXPECT().EntryPosted(gomock.Any(), "ref-1").Return(false, nil)
+			mockLimitsUC.EXPECT().Resolve(gomock.Any(), int64(7), int64(1), models.LimitOperationTransfer, "USD", gomock.Any()).Return(nil, nil)
+			mockRiskUC.EXPECT().Assess(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, request *models.RiskRequest) (*models.RiskAssessment, error) {
+				require.Equal(t, int64(2), request.CounterpartyWalletID)
+				return &models.RiskAssessment{Decision: tt.decision, Hits: models.RiskHits{{RuleID: 3, Action: tt.decision}}}, nil
+			})
+			if tt.decision == models.RiskDecisionReview {
+				// amount plus fee is held, nothing is posted
+				mockWalletRepo.EXPECT().HoldForReviewTx(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, review *models.RiskReview) (*models.RiskReview, error) {
+					require.Equal(t, "11", review.Held.String())
+					require.Equal(t, int64(7), *review.RequestedBy)
+					require.Len(t, review.Hits, 1)
+					review.Status = models.RiskReviewPending
+					return review, nil
+				})
+			}
+
+			_, err := walletUC.Transfer(userCtx(7), &dto.RequestTransfer{
+				FromWalletID: 1,
+				ToWalletID:   2,
+				FromCurrency: "USD",
+				ToCurrency:   "USD",
+				Amount:       decimal.NewFromInt(10),
+				Reference:    "ref-1",
+			})
+			require.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestWalletUC_Transfer_RepeatedReference(t *testing.T) {
+	t.Parallel()
+
+	// review of the transfer below, 10 USD from wallet 1 to wallet 2
+	review := func(status string, toWalletID int64) *models.RiskReview {
+		toCurrency := "USD"
+		return &models.RiskReview{
+			Operation:  models.RiskOperationTransfer,
+			WalletID:   1,
+			Currency:   "USD",
+			Amount:     decimal.NewFromInt(10),
+			ToWalletID: &toWalletID,
+			ToCurrency: &toCurrency,
+			RefID:      "ref-1",
+			Status:     status,
+		}
+	}
+
+	tests := []struct {
+		name   string
+		review *models.RiskReview
+		posted bool
+		err    error
+	}{
+		{"posted", nil, true, nil},
+		{"held for review", review(models.RiskReviewPending, 2), false, wallet.ErrTransactionHeld},
+		{"rejected in review", review(models.RiskReviewRejected, 2), false, wallet.ErrTransactionDenied},
+		{"approved in review", review(models.RiskReviewApproved, 2), false, nil},
+		{"held for another transfer", review(models.RiskReviewPending, 3), false, wallet.ErrReferenceInUse},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// limits and risk mocks carry no expectations, a retry is not checked again
+			mockWalletRepo := mock.NewMockRepository(ctrl)
+			mockCurrencyUC := currencyMock.NewMockUseCase(ctrl)
+			mockFeesUC := feesMock.NewMockUseCase(ctrl)
+			walletUC := NewWalletUseCase(&config.Config{}, mockWalletRepo, nil, nil, nil, mockCurrencyUC, limitsMock.NewMockUseCase(ctrl), mockFeesUC, riskMock.NewMockUseCase(ctrl), nil, newTestLogger())
+
+			usd := &models.Currency{Code: "USD", Exponent: 2, Enabled: true}
+			mockCurrencyUC.EXPECT().Validate(gomock.Any(), "USD", gomock.Any()).Return(usd, nil).Times(2)
+			mockWalletRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(&models.Wallet{ID: 1, UserID: 7, Status: models.WalletStatusActive}, nil)
+			mockWalletRepo.EXPECT().GetByID(gomock.Any(), int64(2)).Return(&models.Wallet{ID: 2, UserID: 9, Status: models.WalletStatusActive}, nil)
+			mockFeesUC.EXPECT().Calculate(gomock.Any(), int64(7), "USD", "USD", gomock.Any()).Return(&models.Fee{Currency: "USD", Amount: decimal.Zero}, nil)
+			if tt.review != nil {
+				mockWalletRepo.EXPECT().GetRiskReviewByRef(gomock.Any(), models.RiskOperationTransfer, "ref-1").Return(tt.review, nil)
+			} else {
+				mockWalletRepo.EXPECT().GetRiskReviewByRef(gomock.Any(), models.RiskOperationTransfer, "ref-1").Return(nil, wallet.ErrRiskReviewNotFound)
+				mockWalletRepo.EXPECT().EntryPosted(gomock.Any(), "ref-1").Return(tt.posted, nil)
+			}
+			if tt.err == nil {
+				mockWalletRepo.EXPECT().TransferTx(gomock.Any(), gomock.Any(), gomock.Nil()).Return(nil)
+			}
+
+			_, err := walletUC.Transfer(userCtx(7), &dto.RequestTransfer{
+				FromWalletID: 1,
+				ToWalletID:   2,
+				FromCurrency: "USD",
+				ToCurrency:   "USD",
+				Amount:       decimal.NewFromInt(10),
+				Reference:    "ref-1",
+			})
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestWalletUC_PreviewTransfer_Fee(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWalletRepo := mock.NewMockRepository(ctrl)
+	mockCurrencyUC := currencyMock.NewMockUseCase(ctrl)
+	mockFeesUC := feesMock.NewMockUseCase(ctrl)
+	cfg := &config.Config{Fees: config.Fees{HouseWalletID: 99}}
+	walletUC := NewWalletUseCase(cfg, mockWalletRepo, nil, nil, nil, mockCurrencyUC, nil, mockFeesUC, nil, nil, newTestLogger())
+
+	usd := &models.Currency{Code: "USD", Exponent: 2, Enabled: true, RoundingMode: models.RoundingHalfEven}
+	scheduleID := int64(5)
+	mockCurrencyUC.EXPECT().Validate(gomock.Any(), "USD", gomock.Any()).Return(usd, nil).Times(2)
+	mockWalletRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(&models.Wallet{ID: 1, UserID: 7, Status: models.WalletStatusActive}, nil)
+	mockWalletRepo.EXPECT().GetByID(gomock.Any(), int64(2)).Return(&models.Wallet{ID: 2, UserID: 9, Status: models.WalletStatusActive}, nil)
+	mockFeesUC.EXPECT().Calculate(gomock.Any(), int64(7), "USD", "USD", gomock.Any()).
+		Return(&models.Fee{ScheduleID: &scheduleID, Currency: "USD", Amount: decimal.RequireFromString("0.3725")}, nil)
+
+	preview, err := walletUC.PreviewTransfer(userCtx(7), &dto.RequestTransfer{
+		FromWalletID: 1,
+		ToWalletID:   2,
+		FromCurrency: "USD",
+		ToCurrency:   "USD",
+		Amount:       decimal.NewFromInt(25),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "0.37", preview.Fee.Amount.String())
+	require.Equal(t, "25.37", preview.TotalDebit.String())
+	require.Equal(t, &scheduleID, preview.Fee.ScheduleID)
+}
+
+func TestWalletUC_Transfer_FrozenRecipient(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWalletRepo := mock.NewMockRepository(ctrl)
+	mockCurrencyUC := currencyMock.NewMockUseCase(ctrl)
+	walletUC := NewWalletUseCase(&config.Config{}, mockWalletRepo, nil, nil, nil, mockCurrencyUC, nil, nil, nil, nil, newTestLogger())
+
+	usd := &models.Currency{Code: "USD", Exponent: 2, Enabled: true}
+	mockCurrencyUC.EXPECT().Validate(gomock.Any(), "USD", gomock.Any()).Return(usd, nil).Times(2)
+	mockWalletRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(&models.Wallet{ID: 1, UserID: 7, Status: models.WalletStatusActive}, nil)
+	mockWalletRepo.EXPECT().GetByID(gomock.Any(), int64(2)).Return(&models.Wallet{ID: 2, UserID: 9, Status: models.WalletStatusFrozen}, nil)
+
+	_, err := walletUC.Transfer(userCtx(7), &dto.RequestTransfer{
+		FromWalletID: 1,
+		ToWalletID:   2,
+		FromCurrency: "USD",
+		ToCurrency:   "USD",
+		Amount:       decimal.NewFromInt(10),
+	})
+	require.ErrorIs(t, err, wallet.ErrWalletFrozen)
+}
+
+func TestWalletUC_ListWallet_OtherUser(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWalletRepo := mock.NewMockRepository(ctrl)
+	mockRbac := rbacMock.NewMockRBACServiceInterface(ctrl)
+	walletUC := NewWalletUseCase(&config.Config{}, mockWalletRepo, nil, nil, nil, nil, nil, nil, nil, mockRbac, newTestLogger())
+
+	pq := &utils.PaginationQuery{Size: 10, Page: 1}
+
+	mockRbac.EXPECT().HasPermission(8, "manage", "wallets", nil).Return(false, nil)
+	_, err := walletUC.ListWallet(userCtx(8), 7, pq)
+	require.ErrorIs(t, err, wallet.ErrWalletAccessDenied)
+
+	mockWalletRepo.EXPECT().FindAll(gomock.Any(), 7, pq).Return(&models.WalletList{}, nil)
+	_, err = walletUC.ListWallet(userCtx(7), 7, pq)
+	require.NoError(t, err)
+}
+
+func TestWalletUC_ListTransactions_Cursor(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWalletRepo := mock.NewMockRepository(ctrl)
+	walletUC := NewWalletUseCase(&config.Config{}, mockWalletRepo, nil, nil, nil, nil, nil, nil, nil, nil, newTestLogger())
+
+	start := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	rows := make([]*models.Transaction, 0, 5)
+	for i := 0; i < 5; i++ {
+		rows = append(rows, &models.Transaction{ID: int64(10 - i), WalletID: 1, CreatedAt: start.Add(-time.Duration(i) * time.Minute)})
+	}
+
+	mockWalletRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(&models.Wallet{ID: 1, UserID: 7}, nil).Times(2)
+
+	// first page, the extra row only signals there is more
+	mockWalletRepo.EXPECT().FindTransactions(gomock.Any(), &models.TransactionFilter{WalletID: 1, Limit: 4}).Return(rows[:4], nil)
+
+	page, err := walletUC.ListTransactions(userCtx(7), &models.TransactionFilter{WalletID: 1, Limit: 3})
+	require.NoError(t, err)
+	require.True(t, page.HasMore)
+	require.Len(t, page.Transactions, 3)
+	require.Equal(t, int64(8), page.Transactions[2].ID)
+
+	cursor, err := utils.DecodeCursor(page.NextCursor)
+	require.NoError(t, err)
+	require.Equal(t, int64(8), cursor.ID)
+	require.True(t, cursor.CreatedAt.Equal(rows[2].CreatedAt))
+
+	// second page continues strictly after the last row of the first
+	mockWalletRepo.EXPECT().FindTransactions(gomock.Any(), &models.TransactionFilter{
+		WalletID: 1, BeforeCreatedAt: &cursor.CreatedAt, BeforeID: cursor.ID, Limit: 4,
+	}).Return(rows[3:], nil)
+
+	page, err = walletUC.ListTransactions(userCtx(7), &models.TransactionFilter{
+		WalletID: 1, BeforeCreatedAt: &cursor.CreatedAt, BeforeID: cursor.ID, Limit: 3,
+	})
+	require.NoError(t, err)
+	require.False(t, page.HasMore)
+	require.Empty(t, page.NextCursor)
+	require.Len(t, page.Transactions, 2)
+	require.Equal(t, int64(7), page.Transactions[0].ID)
+}
+
+func TestWalletUC_ReverseTransfer(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWalletRepo := mock.NewMockRepository(ctrl)
+	mockCurrencyUC := currencyMock.NewMockUseCase(ctrl)
+	walletUC := NewWalletUseCase(&config.Config{}, mockWalletRepo, nil, nil, nil, mockCurrencyUC, nil, nil, nil, nil, newTestLogger())
+
+	original := &models.TransferEntry{
+		Transfer: models.Transfer{
+			FromWalletID:    1,
+			ToWalletID:      2,
+			FromCurrency:    "USD",
+			ToCurrency:      "IDR",
+			Amount:          decimal.NewFromInt(10),
+			ConvertedAmount: decimal.NewFromInt(160000),
+			Rate:            decimal.NewFromInt(16000),
+		},
+		EntryID:  11,
+		Reversed: decimal.NewFromInt(4),
+	}
+	mockWalletRepo.EXPECT().FindTransferEntry(gomock.Any(), "tr-1").Return(original, nil).Times(2)
+
+	// only 6 USD are left to reverse
+	_, err := walletUC.ReverseTransfer(userCtx(1), "tr-1", &dto.RequestReversal{Amount: decimal.NewFromInt(7), Reason: "dispute"})
+	require.ErrorIs(t, err, wallet.ErrReversalExceedsOriginal)
+
+	usd := &models.Currency{Code: "USD", Exponent: 2, Enabled: true}
+	idr := &models.Currency{Code: "IDR", Exponent: 2, Enabled: true}
+	mockCurrencyUC.EXPECT().Validate(gomock.Any(), "USD", gomock.Any()).Return(usd, nil)
+	mockCurrencyUC.EXPECT().Validate(gomock.Any(), "IDR", gomock.Any()).Return(idr, nil)
+	mockWalletRepo.EXPECT().ReverseTransferTx(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, rv *models.Reversal) (*models.Reversal, error) {
+		require.Equal(t, "tr-1", rv.OriginalRefID)
+		require.Equal(t, "2.5", rv.Amount.String())
+		require.Equal(t, "40000", rv.ConvertedAmount.String())
+		require.True(t, rv.FeeAmount.IsZero())
+		require.Equal(t, int64(1), *rv.ActorID)
+		return rv, nil
+	})
+
+	_, err = walletUC.ReverseTransfer(userCtx(1), "tr-1", &dto.RequestReversal{Amount: decimal.RequireFromString("2.5"), Reason: "dispute"})
+	require.NoError(t, err)
+}
+
+func TestWalletUC_EscrowParties(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWalletRepo := mock.NewMockRepository(ctrl)
+	walletUC := NewWalletUseCase(&config.Config{}, mockWalletRepo, nil, nil, nil, nil, nil, nil, nil, nil, newTestLogger())
+
+	held := &models.Escrow{ID: 5, BuyerWalletID: 1, SellerWalletID: 2, Status: models.EscrowStatusHeld}
+	mockWalletRepo.EXPECT().GetEscrow(gomock.Any(), int64(5)).Return(held, nil).AnyTimes()
+	mockWalletRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(&models.Wallet{ID: 1, UserID: 7}, nil).AnyTimes()
+	mockWalletRepo.EXPECT().GetByID(gomock.Any(), int64(2)).Return(&models.Wallet{ID: 2, UserID: 8}, nil).AnyTimes()
+
+	// only the buyer confirms and only the seller cancels
+	_, err := walletUC.ReleaseEscrow(userCtx(8), 5)
+	require.ErrorIs(t, err, wallet.ErrEscrowAccessDenied)
+	_, err = walletUC.CancelEscrow(userCtx(7), 5, &dto.RequestEscrowAction{Reason: "changed my mind"})
+	require.ErrorIs(t, err, wallet.ErrEscrowAccessDenied)
+
+	mockWalletRepo.EXPECT().UpdateEscrowTx(gomock.Any(), int64(5), models.EscrowStatusHeld, models.EscrowStatusReleased, gomock.Any(), gomock.Any()).
+		Return(&models.Escrow{ID: 5, Status: models.EscrowStatusReleased}, nil)
+	released, err := walletUC.ReleaseEscrow(userCtx(7), 5)
+	require.NoError(t, err)
+	require.Equal(t, models.EscrowStatusReleased, released.Status)
+
+	mockWalletRepo.EXPECT().UpdateEscrowTx(gomock.Any(), int64(5), models.EscrowStatusHeld, models.EscrowStatusDisputed, "not delivered", gomock.Any()).
+		Return(&models.Escrow{ID: 5, Status: models.EscrowStatusDisputed}, nil)
+	_, err = walletUC.DisputeEscrow(userCtx(7), 5, &dto.RequestEscrowAction{Reason: "not delivered"})
+	require.NoError(t, err)
+
+	// a held escrow has no dispute to resolve
+	_, err = walletUC.ResolveEscrow(userCtx(1), 5, &dto.RequestEscrowResolution{Resolution: "refund", Reason: "refund"})
+	require.ErrorIs(t, err, wallet.ErrEscrowTransition)
+}
+
+func TestWalletUC_PreviewP2P(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWalletRepo := mock.NewMockRepository(ctrl)
+	mockAuthRepo := authMock.NewMockRepository(ctrl)
+	mockCurrencyUC := currencyMock.NewMockUseCase(ctrl)
+	mockFeesUC := feesMock.NewMockUseCase(ctrl)
+	walletUC := NewWalletUseCase(&config.Config{}, mockWalletRepo, mockAuthRepo, nil, nil, mockCurrencyUC, nil, mockFeesUC, nil, nil, newTestLogger())
+
+	usd := &models.Currency{Code: "USD", Exponent: 2, Enabled: true}
+	sender := &models.Wallet{ID: 1, UserID: 7, Status: models.WalletStatusActive}
+	mockCurrencyUC.EXPECT().Validate(gomock.Any(), "USD", gomock.Any()).Return(usd, nil).Times(3)
+	mockWalletRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(sender, nil).Times(2)
+	mockAuthRepo.EXPECT().FindByEmail(gomock.Any(), "alice@example.com").Return(&models.User{ID: 9, Username: "alice"}, nil)
+	// a preview only looks the default wallet up, it never creates one
+	mockWalletRepo.EXPECT().FindDefaultWallet(gomock.Any(), int64(9), "USD").
+		Return(&models.Wallet{ID: 4, UserID: 9, Status: models.WalletStatusActive}, nil)
+	mockWalletRepo.EXPECT().GetByID(gomock.Any(), int64(4)).Return(&models.Wallet{ID: 4, UserID: 9, Status: models.WalletStatusActive}, nil)
+	mockFeesUC.EXPECT().Calculate(gomock.Any(), int64(7), "USD", "USD", gomock.Any()).Return(&models.Fee{Currency: "USD", Amount: decimal.NewFromInt(1)}, nil)
+
+	preview, err := walletUC.PreviewP2P(userCtx(7), &dto.RequestP2PTransfer{
+		FromWalletID: 1,
+		Recipient:    " Alice@Example.com ",
+		Currency:     "USD",
+		Amount:       decimal.NewFromInt(20),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "a***e", preview.Recipient)
+	require.Equal(t, "21", preview.TotalDebit.String())
+
+	// a recipient without a wallet yet is priced all the same
+	mockCurrencyUC.EXPECT().Validate(gomock.Any(), "USD", gomock.Any()).Return(usd, nil).Times(3)
+	mockWalletRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(sender, nil).Times(2)
+	mockAuthRepo.EXPECT().FindByUsername(gomock.Any(), "carol").Return(&models.UserWithRole{User: models.User{ID: 11, Username: "carol"}}, nil)
+	mockWalletRepo.EXPECT().FindDefaultWallet(gomock.Any(), int64(11), "USD").Return(nil, wallet.ErrWalletNotFound)
+	mockFeesUC.EXPECT().Calculate(gomock.Any(), int64(7), "USD", "USD", gomock.Any()).Return(&models.Fee{Currency: "USD", Amount: decimal.NewFromInt(1)}, nil)
+	preview, err = walletUC.PreviewP2P(userCtx(7), &dto.RequestP2PTransfer{
+		FromWalletID: 1,
+		Recipient:    "carol",
+		Currency:     "USD",
+		Amount:       decimal.NewFromInt(20),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "c***l", preview.Recipient)
+
+	// a recipient with a frozen wallet is refused
+	mockCurrencyUC.EXPECT().Validate(gomock.Any(), "USD", gomock.Any()).Return(usd, nil)
+	mockWalletRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(sender, nil)
+	mockAuthRepo.EXPECT().FindByUsername(gomock.Any(), "dave").Return(&models.UserWithRole{User: models.User{ID: 12, Username: "dave"}}, nil)
+	mockWalletRepo.EXPECT().FindDefaultWallet(gomock.Any(), int64(12), "USD").Return(nil, wallet.ErrRecipientFrozen)
+	_, err = walletUC.PreviewP2P(userCtx(7), &dto.RequestP2PTransfer{
+		FromWalletID: 1,
+		Recipient:    "dave",
+		Currency:     "USD",
+		Amount:       decimal.NewFromInt(20),
+	})
+	require.ErrorIs(t, err, wallet.ErrRecipientFrozen)
+
+	// sending to a wallet of one's own is a plain transfer
+	mockCurrencyUC.EXPECT().Validate(gomock.Any(), "USD", gomock.Any()).Return(usd, nil)
+	mockWalletRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(sender, nil)
+	mockAuthRepo.EXPECT().FindByUsername(gomock.Any(), "bob").Return(&models.UserWithRole{User: models.User{ID: 7, Username: "bob"}}, nil)
+	_, err = walletUC.PreviewP2P(userCtx(7), &dto.RequestP2PTransfer{
+		FromWalletID: 1,
+		Recipient:    "bob",
+		Currency:     "USD",
+		Amount:       decimal.NewFromInt(20),
+	})
+	require.Error(t, err)
+}
+
+func TestWalletUC_MovePocketFunds(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWalletRepo := mock.NewMockRepository(ctrl)
+	mockCurrencyUC := currencyMock.NewMockUseCase(ctrl)
+	walletUC := NewWalletUseCase(&config.Config{}, mockWalletRepo, nil, nil, nil, mockCurrencyUC, nil, nil, nil, nil, newTestLogger())
+
+	parent := &models.Wallet{ID: 1, UserID: 7, Status: models.WalletStatusActive}
+	pockets := []*models.Pocket{
+		{ID: 2, ParentWalletID: 1, Currency: "USD", Status: models.WalletStatusActive},
+		{ID: 3, ParentWalletID: 1, Currency: "EUR", Status: models.WalletStatusActive},
+	}
+	mockWalletRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(parent, nil).Times(3)
+	mockWalletRepo.EXPECT().FindPockets(gomock.Any(), int64(1)).Return(pockets, nil).Times(4)
+	mockCurrencyUC.EXPECT().Validate(gomock.Any(), "USD", gomock.Any()).Return(&models.Currency{Code: "USD", Exponent: 2, Enabled: true}, nil)
+	mockWalletRepo.EXPECT().MovePocketFundsTx(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, m *models.PocketMove) error {
+		require.Equal(t, "USD", m.Currency)
+		require.Equal(t, "save-1", m.RefID)
+		return nil
+	})
+
+	// the pocket currency is used for a move from the parent
+	_, err := walletUC.MovePocketFunds(userCtx(7), 1, &dto.RequestPocketMove{
+		FromWalletID: 1,
+		ToWalletID:   2,
+		Amount:       decimal.NewFromInt(10),
+		Reference:    "save-1",
+	})
+	require.NoError(t, err)
+
+	_, err = walletUC.MovePocketFunds(userCtx(7), 1, &dto.RequestPocketMove{
+		FromWalletID: 2,
+		ToWalletID:   3,
+		Amount:       decimal.NewFromInt(10),
+	})
+	require.Error(t, err)
+
+	// only the parent and its own pockets take part in a move
+	_, err = walletUC.MovePocketFunds(userCtx(7), 1, &dto.RequestPocketMove{
+		FromWalletID: 1,
+		ToWalletID:   9,
+		Amount:       decimal.NewFromInt(10),
+	})
+	require.ErrorIs(t, err, wallet.ErrPocketNotFound)
+}
+
+func TestWalletUC_BalancesAt(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWalletRepo := mock.NewMockRepository(ctrl)
+	walletUC := NewWalletUseCase(&config.Config{}, mockWalletRepo, nil, newTestConverter(), nil, nil, nil, nil, nil, nil, newTestLogger())
+
+	at := time.Date(2026, 6, 30, 23, 59, 0, 0, time.UTC)
+	_, err := walletUC.BalancesAt(userCtx(7), 1, time.Now().Add(time.Hour))
+	require.ErrorIs(t, err, wallet.ErrBalanceAtFuture)
+
+	// replayed from the midnight snapshot before at
+	snapshotAt := time.Date(2026, 6, 30, 0, 0, 0, 0, time.UTC)
+	balances := []*models.HistoricalBalance{{WalletID: 1, Currency: "USD", Amount: decimal.NewFromInt(120)}}
+	mockWalletRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(&models.Wallet{ID: 1, UserID: 7}, nil)
+	mockWalletRepo.EXPECT().LatestSnapshot(gomock.Any(), at).Return(&models.BalanceSnapshot{AsOf: snapshotAt}, nil)
+	mockWalletRepo.EXPECT().FindBalancesAt(gomock.Any(), int64(1), &snapshotAt, at).Return(balances, nil)
+
+	result, err := walletUC.BalancesAt(userCtx(7), 1, at)
+	require.NoError(t, err)
+	require.Equal(t, snapshotAt, *result.SnapshotAt)
+	require.Equal(t, balances, result.Balances)
+
+	// without a snapshot the whole ledger is replayed
+	mockWalletRepo.EXPECT().LatestSnapshot(gomock.Any(), at).Return(nil, nil)
+	mockWalletRepo.EXPECT().FindAllBalancesAt(gomock.Any(), (*time.Time)(nil), at).Return(balances, nil)
+
+	exported, err := walletUC.ExportBalancesAt(context.Background(), at)
+	require.NoError(t, err)
+	require.Len(t, exported, 1)
+}
+
+func TestWalletUC_ReviewAdjustment(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWalletRepo := mock.NewMockRepository(ctrl)
+	walletUC := NewWalletUseCase(&config.Config{}, mockWalletRepo, nil, newTestConverter(), nil, nil, nil, nil, nil, nil, newTestLogger())
+
+	pending := &models.Adjustment{ID: 4, WalletID: 1, Currency: "USD", Direction: models.AdjustmentCredit,
+		Amount: decimal.NewFromInt(25), Status: models.AdjustmentPending, RequestedBy: 7}
+	mockWalletRepo.EXPECT().GetAdjustment(gomock.Any(), int64(4)).Return(pending, nil).Times(4)
+
+	// the requester can never approve their own adjustment
+	_, err := walletUC.ApproveAdjustment(userCtx(7), 4, &dto.RequestAdjustmentReview{})
+	require.ErrorIs(t, err, wallet.ErrAdjustmentSelfReview)
+
+	// only the requester can cancel it
+	_, err = walletUC.CancelAdjustment(userCtx(9), 4)
+	require.ErrorIs(t, err, wallet.ErrAdjustmentAccessDenied)
+
+	// a rejection needs a note
+	_, err = walletUC.RejectAdjustment(userCtx(9), 4, &dto.RequestAdjustmentReview{Note: " "})
+	require.Error(t, err)
+
+	entryID := int64(30)
+	mockWalletRepo.EXPECT().UpdateAdjustmentTx(gomock.Any(), int64(4), models.AdjustmentApproved, int64(9), (*string)(nil)).
+		Return(&models.Adjustment{ID: 4, Status: models.AdjustmentApproved, RequestedBy: 7, EntryID: &entryID}, nil)
+	approved, err := walletUC.ApproveAdjustment(userCtx(9), 4, &dto.RequestAdjustmentReview{})
+	require.NoError(t, err)
+	require.Equal(t, entryID, *approved.EntryID)
+
+	mockWalletRepo.EXPECT().UpdateAdjustmentTx(gomock.Any(), int64(4), models.AdjustmentCancelled, int64(7), (*string)(nil)).
+		Return(&models.Adjustment{ID: 4, Status: models.AdjustmentCancelled}, nil)
+	cancelled, err := walletUC.CancelAdjustment(userCtx(7), 4)
+	require.NoError(t, err)
+	require.Equal(t, models.AdjustmentCancelled, cancelled.Status)
+}
+
+func TestWalletUC_ApproveRiskReview_SelfReview(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWalletRepo := mock.NewMockRepository(ctrl)
+	walletUC := NewWalletUseCase(&config.Config{}, mockWalletRepo, nil, nil, nil, nil, nil, nil, nil, nil, newTestLogger())
+
+	requestedBy := int64(5)
+	review := &models.RiskReview{ID: 11, Operation: models.RiskOperationTransfer, WalletID: 1, UserID: 7, RequestedBy: &requestedBy, Status: models.RiskReviewPending}
+	mockWalletRepo.EXPECT().GetRiskReview(gomock.Any(), int64(11)).Return(review, nil).Times(2)
+
+	// neither the wallet owner nor the admin who moved the funds may decide
+	_, err := walletUC.ApproveRiskReview(userCtx(7), 11, &dto.RequestRiskReview{})
+	require.ErrorIs(t, err, wallet.ErrRiskReviewSelfReview)
+
+	_, err = walletUC.RejectRiskReview(userCtx(5), 11, &dto.RequestRiskReview{Note: "fraud"})
+	require.ErrorIs(t, err, wallet.ErrRiskReviewSelfReview)
+}
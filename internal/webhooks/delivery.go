@@ -0,0 +1,12 @@
+package webhooks
+
+import "github.com/labstack/echo/v4"
+
+// Webhooks HTTP Handlers interface
+type Handlers interface {
+	CreateEndpoint() echo.HandlerFunc
+	ListEndpoints() echo.HandlerFunc
+	DeleteEndpoint() echo.HandlerFunc
+	ListDeliveries() echo.HandlerFunc
+	ReplayDelivery() echo.HandlerFunc
+}
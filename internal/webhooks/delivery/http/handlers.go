@@ -0,0 +1,177 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/opentracing/opentracing-go"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/dto"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/webhooks"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/httpErrors"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/logger"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/utils"
+)
+
+type webhooksHandlers struct {
+	webhooksUC webhooks.UseCase
+	logger     logger.Logger
+}
+
+func NewWebhooksHandlers(webhooksUC webhooks.UseCase, log logger.Logger) webhooks.Handlers {
+	return &webhooksHandlers{webhooksUC: webhooksUC, logger: log}
+}
+
+// CreateEndpoint godoc
+// @Summary Register webhook endpoint
+// @Description Register an https callback URL on a public host for wallet events, the signing secret is only returned here
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Param body body dto.RequestWebhookEndpoint true "endpoint"
+// @Success 201 {object} models.WebhookEndpoint
+// @Router /webhooks [post]
+func (h *webhooksHandlers) CreateEndpoint() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "webhooks.CreateEndpoint")
+		defer span.Finish()
+
+		endpointRequest := &dto.RequestWebhookEndpoint{}
+		if err := utils.ReadRequest(c, endpointRequest); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		endpoint, err := h.webhooksUC.CreateEndpoint(ctx, endpointRequest)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusCreated, endpoint)
+	}
+}
+
+// ListEndpoints godoc
+// @Summary List webhook endpoints
+// @Description List the webhook endpoints of the current user
+// @Tags Webhooks
+// @Produce json
+// @Success 200 {array} models.WebhookEndpoint
+// @Router /webhooks [get]
+func (h *webhooksHandlers) ListEndpoints() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "webhooks.ListEndpoints")
+		defer span.Finish()
+
+		endpoints, err := h.webhooksUC.ListEndpoints(ctx)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, endpoints)
+	}
+}
+
+// DeleteEndpoint godoc
+// @Summary Delete webhook endpoint
+// @Description Delete a webhook endpoint and its delivery log
+// @Tags Webhooks
+// @Param id path int true "endpoint_id"
+// @Success 204
+// @Router /webhooks/{id} [delete]
+func (h *webhooksHandlers) DeleteEndpoint() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "webhooks.DeleteEndpoint")
+		defer span.Finish()
+
+		endpointID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(httpErrors.NewBadRequestError(err.Error())))
+		}
+
+		if err = h.webhooksUC.DeleteEndpoint(ctx, endpointID); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// ListDeliveries godoc
+// @Summary List webhook deliveries
+// @Description Latest deliveries of an endpoint, newest first
+// @Tags Webhooks
+// @Produce json
+// @Param id path int true "endpoint_id"
+// @Param status query string false "pending, delivered or failed"
+// @Success 200 {array} models.WebhookDelivery
+// @Router /webhooks/{id}/deliveries [get]
+func (h *webhooksHandlers) ListDeliveries() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "webhooks.ListDeliveries")
+		defer span.Finish()
+
+		endpointID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(httpErrors.NewBadRequestError(err.Error())))
+		}
+
+		status := c.QueryParam("status")
+		switch status {
+		case "", models.WebhookDeliveryPending, models.WebhookDeliveryDelivered, models.WebhookDeliveryFailed:
+		default:
+			return c.JSON(httpErrors.ErrorResponse(httpErrors.NewBadRequestError("invalid status")))
+		}
+
+		deliveries, err := h.webhooksUC.ListDeliveries(ctx, endpointID, status)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, deliveries)
+	}
+}
+
+// ReplayDelivery godoc
+// @Summary Replay webhook delivery
+// @Description Queue a delivery to be sent again
+// @Tags Webhooks
+// @Produce json
+// @Param id path int true "endpoint_id"
+// @Param deliveryID path int true "delivery_id"
+// @Success 202 {object} models.WebhookDelivery
+// @Router /webhooks/{id}/deliveries/{deliveryID}/replay [post]
+func (h *webhooksHandlers) ReplayDelivery() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "webhooks.ReplayDelivery")
+		defer span.Finish()
+
+		endpointID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(httpErrors.NewBadRequestError(err.Error())))
+		}
+
+		deliveryID, err := strconv.ParseInt(c.Param("deliveryID"), 10, 64)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(httpErrors.NewBadRequestError(err.Error())))
+		}
+
+		delivery, err := h.webhooksUC.ReplayDelivery(ctx, endpointID, deliveryID)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusAccepted, delivery)
+	}
+}
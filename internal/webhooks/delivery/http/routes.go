@@ -0,0 +1,22 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/config"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/auth"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/middleware"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/webhooks"
+)
+
+// Map webhooks routes, endpoints are scoped to the authenticated user
+func MapWebhooksRoutes(webhooksGroup *echo.Group, h webhooks.Handlers, mw *middleware.MiddlewareManager, authUc auth.UseCase, cfg *config.Config) {
+	webhooksGroup.Use(mw.AuthJWTMiddleware(authUc, cfg))
+	webhooksGroup.Use(mw.AuthSessionMiddleware)
+
+	webhooksGroup.GET("", h.ListEndpoints())
+	webhooksGroup.POST("", h.CreateEndpoint())
+	webhooksGroup.DELETE("/:id", h.DeleteEndpoint())
+	webhooksGroup.GET("/:id/deliveries", h.ListDeliveries())
+	webhooksGroup.POST("/:id/deliveries/:deliveryID/replay", h.ReplayDelivery())
+}
@@ -0,0 +1,215 @@
+package dispatcher
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/config"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/webhooks"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/logger"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/utils"
+)
+
+const (
+	defaultBatchSize      = 50
+	defaultMaxAttempts    = 8
+	defaultPollInterval   = time.Second
+	defaultRetryBackoff   = 10 * time.Second
+	defaultLease          = time.Minute
+	defaultRequestTimeout = 10 * time.Second
+	dialTimeout           = 10 * time.Second
+	maxRetryBackoff       = 6 * time.Hour
+)
+
+// Body posted to webhook endpoints
+type webhookBody struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// Dispatcher sends queued webhook deliveries, signing each request and
+// retrying failures with exponential backoff until maxAttempts. Requests only
+// go to https URLs on public addresses and redirects are not followed.
+type Dispatcher struct {
+	webhooksRepo webhooks.Repository
+	client       *http.Client
+	batchSize    int
+	maxAttempts  int
+	pollInterval time.Duration
+	retryBackoff time.Duration
+	lease        time.Duration
+	logger       logger.Logger
+}
+
+// Dispatcher constructor, zero config values fall back to defaults
+func NewDispatcher(cfg *config.Config, webhooksRepo webhooks.Repository, log logger.Logger) *Dispatcher {
+	d := &Dispatcher{
+		webhooksRepo: webhooksRepo,
+		client:       newWebhookClient(time.Second * cfg.Webhooks.RequestTimeout),
+		batchSize:    cfg.Webhooks.BatchSize,
+		maxAttempts:  cfg.Webhooks.MaxAttempts,
+		pollInterval: time.Duration(cfg.Webhooks.PollIntervalSeconds) * time.Second,
+		retryBackoff: time.Duration(cfg.Webhooks.RetryBackoffSeconds) * time.Second,
+		lease:        time.Duration(cfg.Webhooks.LeaseSeconds) * time.Second,
+		logger:       log,
+	}
+
+	if d.client.Timeout <= 0 {
+		d.client.Timeout = defaultRequestTimeout
+	}
+	if d.batchSize <= 0 {
+		d.batchSize = defaultBatchSize
+	}
+	if d.maxAttempts <= 0 {
+		d.maxAttempts = defaultMaxAttempts
+	}
+	if d.pollInterval <= 0 {
+		d.pollInterval = defaultPollInterval
+	}
+	if d.retryBackoff <= 0 {
+		d.retryBackoff = defaultRetryBackoff
+	}
+	if d.lease <= 0 {
+		d.lease = defaultLease
+	}
+
+	return d
+}
+
+// HTTP client that only connects to public addresses, checked on every dial
+// after DNS resolution. There is no proxy, it would connect in its place.
+func newWebhookClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{Timeout: dialTimeout, Control: utils.PublicDialControl}
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: &http.Transport{DialContext: dialer.DialContext, TLSHandshakeTimeout: dialTimeout},
+		// a redirect could lead anywhere, a 3xx answer is a failed delivery
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// Poll for due deliveries until ctx is done
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		n, err := d.ProcessBatch(ctx)
+		if err != nil {
+			d.logger.Errorf("webhook dispatcher: %s", err)
+		}
+
+		if err == nil && n == d.batchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Send one batch of due deliveries, returns how many were claimed
+func (d *Dispatcher) ProcessBatch(ctx context.Context) (int, error) {
+	dispatches, err := d.webhooksRepo.ClaimDeliveries(ctx, d.batchSize, d.lease)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, dispatch := range dispatches {
+		if err := d.deliver(ctx, dispatch); err != nil {
+			return len(dispatches), err
+		}
+	}
+
+	return len(dispatches), nil
+}
+
+// Send one delivery and record the outcome, only repository errors are returned
+func (d *Dispatcher) deliver(ctx context.Context, dispatch *models.WebhookDispatch) error {
+	statusCode, sendErr := d.send(ctx, dispatch)
+	if sendErr == nil {
+		return d.webhooksRepo.MarkDelivered(ctx, dispatch.ID, *statusCode)
+	}
+
+	attempts := dispatch.Attempts + 1
+	if attempts >= d.maxAttempts {
+		d.logger.Warnf("webhook dispatcher: giving up on delivery %d to %s after %d attempts: %s",
+			dispatch.ID, dispatch.URL, attempts, sendErr)
+		return d.webhooksRepo.MarkFailed(ctx, dispatch.ID, statusCode, sendErr.Error(), nil)
+	}
+
+	retryAt := time.Now().Add(d.backoff(attempts))
+	return d.webhooksRepo.MarkFailed(ctx, dispatch.ID, statusCode, sendErr.Error(), &retryAt)
+}
+
+// POST the signed event, any 2xx response acknowledges it
+func (d *Dispatcher) send(ctx context.Context, dispatch *models.WebhookDispatch) (*int, error) {
+	body, err := json.Marshal(webhookBody{
+		ID:        dispatch.EventID,
+		Type:      dispatch.EventType,
+		CreatedAt: dispatch.CreatedAt,
+		Data:      json.RawMessage(dispatch.Payload),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, dispatch.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	// endpoints registered before https was required
+	if req.URL.Scheme != "https" {
+		return nil, utils.ErrWebhookTarget
+	}
+
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(utils.WebhookEventIDHeader, dispatch.EventID)
+	req.Header.Set(utils.WebhookTimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(utils.WebhookSignatureHeader, utils.WebhookSignature(dispatch.Secret, timestamp, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+
+	statusCode := resp.StatusCode
+	if statusCode < 200 || statusCode > 299 {
+		return &statusCode, fmt.Errorf("unexpected status %d", statusCode)
+	}
+
+	return &statusCode, nil
+}
+
+// Delay before the next attempt, doubling per attempt
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	delay := d.retryBackoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= maxRetryBackoff {
+			return maxRetryBackoff
+		}
+	}
+
+	return delay
+}
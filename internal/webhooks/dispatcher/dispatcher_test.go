@@ -0,0 +1,165 @@
+package dispatcher
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/config"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/webhooks/mock"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/logger"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/utils"
+)
+
+// Dispatcher posting to srv, a test server listens on loopback which the
+// default client refuses
+func newTestDispatcher(repo *mock.MockRepository, srv *httptest.Server) *Dispatcher {
+	cfg := &config.Config{
+		Logger:   config.Logger{Level: "error", Encoding: "console"},
+		Webhooks: config.Webhooks{MaxAttempts: 3, RetryBackoffSeconds: 1},
+	}
+	l := logger.NewApiLogger(cfg)
+	l.InitLogger()
+
+	d := NewDispatcher(cfg, repo, l)
+	if srv != nil {
+		d.client = srv.Client()
+	}
+	return d
+}
+
+func TestDispatcher_SignsDelivery(t *testing.T) {
+	t.Parallel()
+
+	const secret = "whsec_test_secret"
+
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		timestamp, err := strconv.ParseInt(r.Header.Get(utils.WebhookTimestampHeader), 10, 64)
+		require.NoError(t, err)
+		require.True(t, utils.VerifyWebhookSignature(secret, timestamp, body, r.Header.Get(utils.WebhookSignatureHeader)))
+		require.Equal(t, "evt-1", r.Header.Get(utils.WebhookEventIDHeader))
+		require.JSONEq(t, `{"wallet_id":1,"amount":"10"}`, string(mustData(t, body)))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockRepository(ctrl)
+	dispatch := &models.WebhookDispatch{
+		WebhookDelivery: models.WebhookDelivery{ID: 7, EventID: "evt-1", EventType: models.EventWalletDeposited, Payload: []byte(`{"wallet_id":1,"amount":"10"}`)},
+		URL:             srv.URL,
+		Secret:          secret,
+	}
+	repo.EXPECT().ClaimDeliveries(gomock.Any(), defaultBatchSize, defaultLease).Return([]*models.WebhookDispatch{dispatch}, nil)
+	repo.EXPECT().MarkDelivered(gomock.Any(), int64(7), http.StatusNoContent).Return(nil)
+
+	n, err := newTestDispatcher(repo, srv).ProcessBatch(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+}
+
+func TestDispatcher_RetriesThenGivesUp(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockRepository(ctrl)
+	first := &models.WebhookDispatch{WebhookDelivery: models.WebhookDelivery{ID: 1, Payload: []byte(`{}`)}, URL: srv.URL}
+	last := &models.WebhookDispatch{WebhookDelivery: models.WebhookDelivery{ID: 2, Payload: []byte(`{}`), Attempts: 2}, URL: srv.URL}
+
+	repo.EXPECT().ClaimDeliveries(gomock.Any(), gomock.Any(), gomock.Any()).Return([]*models.WebhookDispatch{first, last}, nil)
+	repo.EXPECT().MarkFailed(gomock.Any(), int64(1), gomock.Any(), "unexpected status 502", gomock.Not(gomock.Nil())).
+		DoAndReturn(func(_ context.Context, _ int64, code *int, _ string, retryAt *time.Time) error {
+			require.Equal(t, http.StatusBadGateway, *code)
+			require.WithinDuration(t, time.Now().Add(time.Second), *retryAt, time.Second)
+			return nil
+		})
+	repo.EXPECT().MarkFailed(gomock.Any(), int64(2), gomock.Any(), "unexpected status 502", gomock.Nil()).Return(nil)
+
+	_, err := newTestDispatcher(repo, srv).ProcessBatch(context.Background())
+	require.NoError(t, err)
+}
+
+func TestDispatcher_RefusesNonPublicTargets(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		url  string
+	}{
+		{"plain http", "http://example.com/hook"},
+		{"loopback", "https://127.0.0.1:8443/hook"},
+		{"metadata", "https://169.254.169.254/latest/meta-data"},
+		{"private", "https://10.0.0.5/hook"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockRepository(ctrl)
+			dispatch := &models.WebhookDispatch{WebhookDelivery: models.WebhookDelivery{ID: 3, Payload: []byte(`{}`)}, URL: tt.url}
+
+			repo.EXPECT().ClaimDeliveries(gomock.Any(), gomock.Any(), gomock.Any()).Return([]*models.WebhookDispatch{dispatch}, nil)
+			repo.EXPECT().MarkFailed(gomock.Any(), int64(3), gomock.Nil(), gomock.Any(), gomock.Not(gomock.Nil())).
+				DoAndReturn(func(_ context.Context, _ int64, _ *int, reason string, _ *time.Time) error {
+					require.Contains(t, reason, utils.ErrWebhookTarget.Error())
+					return nil
+				})
+
+			_, err := newTestDispatcher(repo, nil).ProcessBatch(context.Background())
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestFanoutPublisher_WalletIDs(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockRepository(ctrl)
+	fanout := NewFanoutPublisher(repo)
+
+	transfer, err := models.NewOutboxEvent(models.AggregateWallet, "1", models.EventWalletTransferred,
+		map[string]interface{}{"from_wallet_id": 1, "to_wallet_id": 2})
+	require.NoError(t, err)
+	repo.EXPECT().EnqueueDeliveries(gomock.Any(), transfer, []int64{1, 2}).Return(nil)
+	require.NoError(t, fanout.Publish(context.Background(), transfer))
+
+	// events merchants cannot subscribe to are skipped
+	status, err := models.NewOutboxEvent(models.AggregateWallet, "1", models.EventWalletStatusChanged, map[string]interface{}{"wallet_id": 1})
+	require.NoError(t, err)
+	require.NoError(t, fanout.Publish(context.Background(), status))
+}
+
+func mustData(t *testing.T, body []byte) []byte {
+	t.Helper()
+
+	var b webhookBody
+	require.NoError(t, json.Unmarshal(body, &b))
+	return b.Data
+}
@@ -0,0 +1,49 @@
+package dispatcher
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/outbox"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/webhooks"
+)
+
+// Wallet references carried by wallet event payloads
+type walletRefs struct {
+	WalletID     int64 `json:"wallet_id"`
+	FromWalletID int64 `json:"from_wallet_id"`
+	ToWalletID   int64 `json:"to_wallet_id"`
+}
+
+// Outbox publisher that queues webhook deliveries for the owners of the wallets in an event
+type fanoutPublisher struct {
+	webhooksRepo webhooks.Repository
+}
+
+// Fan-out publisher constructor, plug it into the outbox relay
+func NewFanoutPublisher(webhooksRepo webhooks.Repository) outbox.EventPublisher {
+	return &fanoutPublisher{webhooksRepo: webhooksRepo}
+}
+
+func (p *fanoutPublisher) Publish(ctx context.Context, event *models.OutboxEvent) error {
+	if !models.StringList(models.WebhookEventTypes).Contains(event.EventType) {
+		return nil
+	}
+
+	refs := walletRefs{}
+	if err := json.Unmarshal(event.Payload, &refs); err != nil {
+		return errors.Wrap(err, "webhooks.fanoutPublisher.Publish.json.Unmarshal")
+	}
+
+	walletIDs := make([]int64, 0, 2)
+	for _, id := range []int64{refs.WalletID, refs.FromWalletID, refs.ToWalletID} {
+		if id != 0 {
+			walletIDs = append(walletIDs, id)
+		}
+	}
+
+	return p.webhooksRepo.EnqueueDeliveries(ctx, event, walletIDs)
+}
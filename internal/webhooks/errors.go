@@ -0,0 +1,16 @@
+package webhooks
+
+import (
+	"net/http"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/httpErrors"
+)
+
+// Webhooks domain errors
+var (
+	ErrEndpointNotFound     = httpErrors.NewRestError(http.StatusNotFound, "Webhook endpoint not found", nil)
+	ErrEndpointAccessDenied = httpErrors.NewRestError(http.StatusForbidden, "Webhook endpoint belongs to another user", nil)
+	ErrDeliveryNotFound     = httpErrors.NewRestError(http.StatusNotFound, "Webhook delivery not found", nil)
+	ErrUnsupportedEvent     = httpErrors.NewRestError(http.StatusBadRequest, "Unsupported webhook event type", nil)
+	ErrEndpointURL          = httpErrors.NewRestError(http.StatusBadRequest, "Webhook URL must be https on a public host", nil)
+)
@@ -0,0 +1,27 @@
+//go:generate mockgen -source pg_repository.go -destination mock/pg_repository_mock.go -package mock
+package webhooks
+
+import (
+	"context"
+	"time"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+)
+
+// Webhooks repository interface
+type Repository interface {
+	CreateEndpoint(ctx context.Context, endpoint *models.WebhookEndpoint) (*models.WebhookEndpoint, error)
+	ListEndpoints(ctx context.Context, userID int) ([]*models.WebhookEndpoint, error)
+	GetEndpoint(ctx context.Context, endpointID int64) (*models.WebhookEndpoint, error)
+	DeleteEndpoint(ctx context.Context, endpointID int64) error
+
+	// Delivery log
+	EnqueueDeliveries(ctx context.Context, event *models.OutboxEvent, walletIDs []int64) error
+	ListDeliveries(ctx context.Context, endpointID int64, status string, limit int) ([]*models.WebhookDelivery, error)
+	ReplayDelivery(ctx context.Context, endpointID, deliveryID int64) (*models.WebhookDelivery, error)
+
+	// Dispatch
+	ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*models.WebhookDispatch, error)
+	MarkDelivered(ctx context.Context, deliveryID int64, statusCode int) error
+	MarkFailed(ctx context.Context, deliveryID int64, statusCode *int, reason string, retryAt *time.Time) error
+}
@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/webhooks"
+)
+
+// Webhooks Repository
+type webhooksRepo struct {
+	db *sqlx.DB
+}
+
+// Webhooks Repository constructor
+func NewWebhooksRepository(db *sqlx.DB) webhooks.Repository {
+	return &webhooksRepo{db: db}
+}
+
+// Create webhook endpoint
+func (r *webhooksRepo) CreateEndpoint(ctx context.Context, endpoint *models.WebhookEndpoint) (*models.WebhookEndpoint, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "webhooksRepo.CreateEndpoint")
+	defer span.Finish()
+
+	created := &models.WebhookEndpoint{}
+	if err := r.db.QueryRowxContext(ctx, createEndpointQuery,
+		endpoint.UserID, endpoint.URL, endpoint.Secret, endpoint.EventTypes,
+	).StructScan(created); err != nil {
+		return nil, errors.Wrap(err, "webhooksRepo.CreateEndpoint.StructScan")
+	}
+
+	return created, nil
+}
+
+// List endpoints of a user
+func (r *webhooksRepo) ListEndpoints(ctx context.Context, userID int) ([]*models.WebhookEndpoint, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "webhooksRepo.ListEndpoints")
+	defer span.Finish()
+
+	endpoints := make([]*models.WebhookEndpoint, 0)
+	if err := r.db.SelectContext(ctx, &endpoints, listEndpointsQuery, userID); err != nil {
+		return nil, errors.Wrap(err, "webhooksRepo.ListEndpoints.SelectContext")
+	}
+
+	return endpoints, nil
+}
+
+// Get endpoint by id
+func (r *webhooksRepo) GetEndpoint(ctx context.Context, endpointID int64) (*models.WebhookEndpoint, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "webhooksRepo.GetEndpoint")
+	defer span.Finish()
+
+	endpoint := &models.WebhookEndpoint{}
+	if err := r.db.GetContext(ctx, endpoint, getEndpointQuery, endpointID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, webhooks.ErrEndpointNotFound
+		}
+		return nil, errors.Wrap(err, "webhooksRepo.GetEndpoint.GetContext")
+	}
+
+	return endpoint, nil
+}
+
+// Delete endpoint together with its delivery log
+func (r *webhooksRepo) DeleteEndpoint(ctx context.Context, endpointID int64) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "webhooksRepo.DeleteEndpoint")
+	defer span.Finish()
+
+	result, err := r.db.ExecContext(ctx, deleteEndpointQuery, endpointID)
+	if err != nil {
+		return errors.Wrap(err, "webhooksRepo.DeleteEndpoint.ExecContext")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "webhooksRepo.DeleteEndpoint.RowsAffected")
+	}
+	if rowsAffected == 0 {
+		return webhooks.ErrEndpointNotFound
+	}
+
+	return nil
+}
+
+// Queue event for every endpoint subscribed by the owners of walletIDs
+func (r *webhooksRepo) EnqueueDeliveries(ctx context.Context, event *models.OutboxEvent, walletIDs []int64) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "webhooksRepo.EnqueueDeliveries")
+	defer span.Finish()
+
+	ids, err := json.Marshal(walletIDs)
+	if err != nil {
+		return errors.Wrap(err, "webhooksRepo.EnqueueDeliveries.json.Marshal")
+	}
+
+	if _, err := r.db.ExecContext(ctx, enqueueDeliveriesQuery, event.EventID, event.EventType, event.Payload, ids); err != nil {
+		return errors.Wrap(err, "webhooksRepo.EnqueueDeliveries.ExecContext")
+	}
+
+	return nil
+}
+
+// List the latest deliveries of an endpoint, optionally filtered by status
+func (r *webhooksRepo) ListDeliveries(ctx context.Context, endpointID int64, status string, limit int) ([]*models.WebhookDelivery, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "webhooksRepo.ListDeliveries")
+	defer span.Finish()
+
+	deliveries := make([]*models.WebhookDelivery, 0)
+	if err := r.db.SelectContext(ctx, &deliveries, listDeliveriesQuery, endpointID, status, limit); err != nil {
+		return nil, errors.Wrap(err, "webhooksRepo.ListDeliveries.SelectContext")
+	}
+
+	return deliveries, nil
+}
+
+// Reset a delivery to pending so it is sent again
+func (r *webhooksRepo) ReplayDelivery(ctx context.Context, endpointID, deliveryID int64) (*models.WebhookDelivery, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "webhooksRepo.ReplayDelivery")
+	defer span.Finish()
+
+	delivery := &models.WebhookDelivery{}
+	if err := r.db.QueryRowxContext(ctx, replayDeliveryQuery, deliveryID, endpointID).StructScan(delivery); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, webhooks.ErrDeliveryNotFound
+		}
+		return nil, errors.Wrap(err, "webhooksRepo.ReplayDelivery.StructScan")
+	}
+
+	return delivery, nil
+}
+
+// Claim up to limit due deliveries for lease
+func (r *webhooksRepo) ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*models.WebhookDispatch, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "webhooksRepo.ClaimDeliveries")
+	defer span.Finish()
+
+	dispatches := make([]*models.WebhookDispatch, 0, limit)
+	if err := r.db.SelectContext(ctx, &dispatches, claimDeliveriesQuery, limit, lease.Seconds()); err != nil {
+		return nil, errors.Wrap(err, "webhooksRepo.ClaimDeliveries.SelectContext")
+	}
+
+	return dispatches, nil
+}
+
+// Mark delivery acknowledged by the endpoint
+func (r *webhooksRepo) MarkDelivered(ctx context.Context, deliveryID int64, statusCode int) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "webhooksRepo.MarkDelivered")
+	defer span.Finish()
+
+	if _, err := r.db.ExecContext(ctx, markDeliveredQuery, deliveryID, statusCode); err != nil {
+		return errors.Wrap(err, "webhooksRepo.MarkDelivered.ExecContext")
+	}
+
+	return nil
+}
+
+// Record a failed attempt, retrying at retryAt or giving up when it is nil
+func (r *webhooksRepo) MarkFailed(ctx context.Context, deliveryID int64, statusCode *int, reason string, retryAt *time.Time) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "webhooksRepo.MarkFailed")
+	defer span.Finish()
+
+	if _, err := r.db.ExecContext(ctx, markFailedQuery, deliveryID, statusCode, reason, retryAt); err != nil {
+		return errors.Wrap(err, "webhooksRepo.MarkFailed.ExecContext")
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package repository
+
+const (
+	createEndpointQuery = `INSERT INTO webhook_endpoints (user_id, url, secret, event_types)
+						VALUES ($1, $2, $3, $4) RETURNING *`
+
+	listEndpointsQuery = `SELECT * FROM webhook_endpoints WHERE user_id = $1 ORDER BY id`
+
+	getEndpointQuery = `SELECT * FROM webhook_endpoints WHERE id = $1`
+
+	deleteEndpointQuery = `DELETE FROM webhook_endpoints WHERE id = $1`
+)
+
+const (
+	// one delivery per subscribed endpoint of the owners of the event wallets,
+	// $4 is a json array of wallet ids, replays of the same event are ignored
+	enqueueDeliveriesQuery = `INSERT INTO webhook_deliveries (endpoint_id, event_id, event_type, payload)
+						SELECT e.id, $1, $2, $3 FROM webhook_endpoints e
+						WHERE e.active AND e.event_types ? $2
+						AND e.user_id IN (
+							SELECT user_id FROM wallets
+							WHERE id IN (SELECT value::bigint FROM jsonb_array_elements_text($4::jsonb))
+						)
+						ON CONFLICT (endpoint_id, event_id) DO NOTHING`
+
+	listDeliveriesQuery = `SELECT * FROM webhook_deliveries
+						WHERE endpoint_id = $1 AND ($2 = '' OR status = $2)
+						ORDER BY created_at DESC, id DESC LIMIT $3`
+
+	replayDeliveryQuery = `UPDATE webhook_deliveries
+						SET status = 'pending', attempts = 0, next_attempt_at = now(), updated_at = now()
+						WHERE id = $1 AND endpoint_id = $2 RETURNING *`
+
+	// lease due deliveries so concurrent dispatchers skip them until the lease expires
+	claimDeliveriesQuery = `WITH due AS (
+							UPDATE webhook_deliveries SET next_attempt_at = now() + make_interval(secs => $2)
+							WHERE id IN (
+								SELECT id FROM webhook_deliveries
+								WHERE status = 'pending' AND next_attempt_at <= now()
+								ORDER BY next_attempt_at, id LIMIT $1
+								FOR UPDATE SKIP LOCKED
+							)
+							RETURNING *
+						)
+						SELECT due.*, e.url, e.secret FROM due
+						JOIN webhook_endpoints e ON e.id = due.endpoint_id
+						ORDER BY due.id`
+
+	markDeliveredQuery = `UPDATE webhook_deliveries
+						SET status = 'delivered', attempts = attempts + 1, last_status_code = $2,
+						last_error = NULL, delivered_at = now(), updated_at = now()
+						WHERE id = $1`
+
+	// a nil retry time gives up on the delivery
+	markFailedQuery = `UPDATE webhook_deliveries
+						SET status = CASE WHEN $4::timestamptz IS NULL THEN 'failed' ELSE 'pending' END,
+						attempts = attempts + 1, last_status_code = $2, last_error = $3,
+						next_attempt_at = COALESCE($4::timestamptz, next_attempt_at), updated_at = now()
+						WHERE id = $1`
+)
@@ -0,0 +1,18 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase_mock.go -package mock
+package webhooks
+
+import (
+	"context"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/dto"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+)
+
+// Webhooks UseCase interface, endpoints are scoped to the ctx user
+type UseCase interface {
+	CreateEndpoint(ctx context.Context, dto *dto.RequestWebhookEndpoint) (*models.WebhookEndpoint, error)
+	ListEndpoints(ctx context.Context) ([]*models.WebhookEndpoint, error)
+	DeleteEndpoint(ctx context.Context, endpointID int64) error
+	ListDeliveries(ctx context.Context, endpointID int64, status string) ([]*models.WebhookDelivery, error)
+	ReplayDelivery(ctx context.Context, endpointID, deliveryID int64) (*models.WebhookDelivery, error)
+}
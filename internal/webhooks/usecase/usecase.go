@@ -0,0 +1,142 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/dto"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/models"
+	"github.com/aditwar-man/go-microservice-boilerplate/internal/webhooks"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/logger"
+	"github.com/aditwar-man/go-microservice-boilerplate/pkg/utils"
+)
+
+const deliveryLogSize = 100
+
+// Webhooks UseCase
+type webhooksUC struct {
+	webhooksRepo webhooks.Repository
+	logger       logger.Logger
+}
+
+// Webhooks UseCase constructor
+func NewWebhooksUseCase(webhooksRepo webhooks.Repository, log logger.Logger) webhooks.UseCase {
+	return &webhooksUC{webhooksRepo: webhooksRepo, logger: log}
+}
+
+// Register an endpoint for the ctx user, a secret is generated when none is
+// given. The URL must be https and resolve to public addresses only.
+func (u *webhooksUC) CreateEndpoint(ctx context.Context, dto *dto.RequestWebhookEndpoint) (*models.WebhookEndpoint, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "webhooksUC.CreateEndpoint")
+	defer span.Finish()
+
+	user, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	eventTypes := models.StringList(dto.EventTypes)
+	for _, t := range eventTypes {
+		if !models.StringList(models.WebhookEventTypes).Contains(t) {
+			return nil, webhooks.ErrUnsupportedEvent
+		}
+	}
+
+	if err := utils.CheckWebhookURL(ctx, dto.URL); err != nil {
+		u.logger.Warnf("User %d webhook URL %s refused: %s", user.User.ID, dto.URL, err)
+		return nil, webhooks.ErrEndpointURL
+	}
+
+	secret := dto.Secret
+	if secret == "" {
+		if secret, err = utils.NewWebhookSecret(); err != nil {
+			return nil, errors.Wrap(err, "webhooksUC.CreateEndpoint.NewWebhookSecret")
+		}
+	}
+
+	return u.webhooksRepo.CreateEndpoint(ctx, &models.WebhookEndpoint{
+		UserID:     user.User.ID,
+		URL:        dto.URL,
+		Secret:     secret,
+		EventTypes: eventTypes,
+	})
+}
+
+// List the endpoints of the ctx user, secrets are not returned
+func (u *webhooksUC) ListEndpoints(ctx context.Context) ([]*models.WebhookEndpoint, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "webhooksUC.ListEndpoints")
+	defer span.Finish()
+
+	user, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoints, err := u.webhooksRepo.ListEndpoints(ctx, user.User.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, e := range endpoints {
+		e.Secret = ""
+	}
+
+	return endpoints, nil
+}
+
+// Delete an endpoint of the ctx user
+func (u *webhooksUC) DeleteEndpoint(ctx context.Context, endpointID int64) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "webhooksUC.DeleteEndpoint")
+	defer span.Finish()
+
+	if _, err := u.authorizeEndpoint(ctx, endpointID); err != nil {
+		return err
+	}
+
+	return u.webhooksRepo.DeleteEndpoint(ctx, endpointID)
+}
+
+// Latest deliveries of an endpoint, status filters by delivery state when set
+func (u *webhooksUC) ListDeliveries(ctx context.Context, endpointID int64, status string) ([]*models.WebhookDelivery, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "webhooksUC.ListDeliveries")
+	defer span.Finish()
+
+	if _, err := u.authorizeEndpoint(ctx, endpointID); err != nil {
+		return nil, err
+	}
+
+	return u.webhooksRepo.ListDeliveries(ctx, endpointID, status, deliveryLogSize)
+}
+
+// Send a delivery again, whatever its current state
+func (u *webhooksUC) ReplayDelivery(ctx context.Context, endpointID, deliveryID int64) (*models.WebhookDelivery, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "webhooksUC.ReplayDelivery")
+	defer span.Finish()
+
+	if _, err := u.authorizeEndpoint(ctx, endpointID); err != nil {
+		return nil, err
+	}
+
+	return u.webhooksRepo.ReplayDelivery(ctx, endpointID, deliveryID)
+}
+
+// Load an endpoint owned by the ctx user
+func (u *webhooksUC) authorizeEndpoint(ctx context.Context, endpointID int64) (*models.WebhookEndpoint, error) {
+	user, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint, err := u.webhooksRepo.GetEndpoint(ctx, endpointID)
+	if err != nil {
+		return nil, err
+	}
+
+	if endpoint.UserID != user.User.ID {
+		return nil, webhooks.ErrEndpointAccessDenied
+	}
+
+	return endpoint, nil
+}
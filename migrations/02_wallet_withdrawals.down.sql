@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS withdrawals CASCADE;
+
+ALTER TABLE wallet_balances DROP CONSTRAINT IF EXISTS chk_wallet_balances_held;
+ALTER TABLE wallet_balances DROP COLUMN IF EXISTS held;
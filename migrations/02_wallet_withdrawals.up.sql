@@ -0,0 +1,19 @@
+-- held amount reserved by pending withdrawals, available = amount - held
+ALTER TABLE wallet_balances ADD COLUMN IF NOT EXISTS held NUMERIC(36,18) NOT NULL DEFAULT 0;
+ALTER TABLE wallet_balances DROP CONSTRAINT IF EXISTS chk_wallet_balances_held;
+ALTER TABLE wallet_balances ADD CONSTRAINT chk_wallet_balances_held CHECK (held >= 0 AND held <= amount);
+
+-- withdrawals
+CREATE TABLE IF NOT EXISTS withdrawals (
+  id BIGSERIAL PRIMARY KEY,
+  wallet_id BIGINT NOT NULL REFERENCES wallets(id) ON DELETE CASCADE,
+  currency TEXT NOT NULL,
+  amount NUMERIC(36,18) NOT NULL CHECK (amount > 0),
+  status TEXT NOT NULL DEFAULT 'held',
+  ref_id TEXT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS ux_withdrawals_ref_id ON withdrawals(ref_id);
+CREATE INDEX IF NOT EXISTS idx_withdrawals_wallet_created ON withdrawals(wallet_id, created_at DESC);
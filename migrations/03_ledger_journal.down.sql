@@ -0,0 +1,11 @@
+DELETE FROM txs WHERE type = 'opening_balance';
+UPDATE txs SET amount = -amount WHERE type IN ('transfer_out', 'withdraw') AND amount < 0;
+
+DROP INDEX IF EXISTS idx_tx_entry_id;
+ALTER TABLE txs DROP COLUMN IF EXISTS entry_id;
+
+DROP TRIGGER IF EXISTS trg_postings_balanced ON postings;
+DROP FUNCTION IF EXISTS check_journal_entry_balanced();
+
+DROP TABLE IF EXISTS postings CASCADE;
+DROP TABLE IF EXISTS journal_entries CASCADE;
@@ -0,0 +1,97 @@
+-- journal entries, one per business event
+CREATE TABLE IF NOT EXISTS journal_entries (
+  id BIGSERIAL PRIMARY KEY,
+  type TEXT NOT NULL,
+  ref_id TEXT NOT NULL,
+  description TEXT NOT NULL DEFAULT '',
+  meta JSONB,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS ux_journal_entries_ref_id ON journal_entries(ref_id);
+
+-- postings, signed amounts (credit > 0) that sum to zero per entry and currency
+CREATE TABLE IF NOT EXISTS postings (
+  id BIGSERIAL PRIMARY KEY,
+  entry_id BIGINT NOT NULL REFERENCES journal_entries(id),
+  account TEXT NOT NULL,
+  wallet_id BIGINT REFERENCES wallets(id),
+  type TEXT NOT NULL,
+  currency TEXT NOT NULL,
+  amount NUMERIC(36,18) NOT NULL CHECK (amount <> 0),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_postings_entry_id ON postings(entry_id);
+CREATE INDEX IF NOT EXISTS idx_postings_wallet_currency ON postings(wallet_id, currency) WHERE wallet_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_postings_account_currency ON postings(account, currency);
+
+-- reject unbalanced entries at commit time
+CREATE OR REPLACE FUNCTION check_journal_entry_balanced()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM postings
+        WHERE entry_id = NEW.entry_id
+        GROUP BY currency
+        HAVING SUM(amount) <> 0
+    ) THEN
+        RAISE EXCEPTION 'journal entry % is not balanced', NEW.entry_id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_postings_balanced ON postings;
+CREATE CONSTRAINT TRIGGER trg_postings_balanced
+AFTER INSERT OR UPDATE ON postings
+DEFERRABLE INITIALLY DEFERRED
+FOR EACH ROW
+EXECUTE FUNCTION check_journal_entry_balanced();
+
+-- txs rows become the per wallet view of postings, signed like postings
+ALTER TABLE txs ADD COLUMN IF NOT EXISTS entry_id BIGINT REFERENCES journal_entries(id);
+CREATE INDEX IF NOT EXISTS idx_tx_entry_id ON txs(entry_id);
+
+UPDATE txs SET type = lower(type) WHERE type <> lower(type);
+UPDATE txs SET amount = -amount WHERE type IN ('transfer_out', 'withdraw') AND amount > 0;
+
+-- open the ledger with the current balances so postings reproduce wallet_balances
+INSERT INTO journal_entries (type, ref_id, description)
+SELECT 'opening_balance', 'opening-' || wallet_id || '-' || currency, 'Opening balance'
+FROM wallet_balances
+WHERE amount <> 0
+ON CONFLICT (ref_id) DO NOTHING;
+
+INSERT INTO postings (entry_id, account, wallet_id, type, currency, amount)
+SELECT je.id, 'wallet:' || wb.wallet_id, wb.wallet_id, 'opening_balance', wb.currency, wb.amount
+FROM wallet_balances wb
+JOIN journal_entries je ON je.ref_id = 'opening-' || wb.wallet_id || '-' || wb.currency
+WHERE wb.amount <> 0
+UNION ALL
+SELECT je.id, 'system:opening', NULL, 'opening_balance', wb.currency, -wb.amount
+FROM wallet_balances wb
+JOIN journal_entries je ON je.ref_id = 'opening-' || wb.wallet_id || '-' || wb.currency
+WHERE wb.amount <> 0;
+
+INSERT INTO txs (wallet_id, type, currency, amount, ref_id, entry_id)
+SELECT wb.wallet_id, 'opening_balance', wb.currency, wb.amount, je.ref_id, je.id
+FROM wallet_balances wb
+JOIN journal_entries je ON je.ref_id = 'opening-' || wb.wallet_id || '-' || wb.currency
+WHERE wb.amount <> 0
+ON CONFLICT (ref_id) DO NOTHING;
+
+-- wallets RBAC resource for already seeded databases
+INSERT INTO resources (name, description)
+SELECT 'wallets', 'Wallet management'
+WHERE EXISTS (SELECT 1 FROM roles)
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id, resource_id, context_id)
+SELECT r.id, p.id, res.id, NULL
+FROM roles r, permissions p, resources res
+WHERE r.name = 'administrator' AND p.name = 'manage' AND res.name = 'wallets'
+  AND NOT EXISTS (
+    SELECT 1 FROM role_permissions rp
+    WHERE rp.role_id = r.id AND rp.permission_id = p.id AND rp.resource_id = res.id AND rp.context_id IS NULL
+  );
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS fx_quotes CASCADE;
+DROP TABLE IF EXISTS fx_rates CASCADE;
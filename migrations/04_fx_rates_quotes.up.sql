@@ -0,0 +1,28 @@
+-- fx rates, the latest effective row per pair wins
+CREATE TABLE IF NOT EXISTS fx_rates (
+  id BIGSERIAL PRIMARY KEY,
+  base TEXT NOT NULL,
+  quote TEXT NOT NULL,
+  rate NUMERIC(36,18) NOT NULL CHECK (rate > 0),
+  effective_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_fx_rates_pair_effective ON fx_rates(base, quote, effective_at DESC);
+
+-- locked fx quotes, single use
+CREATE TABLE IF NOT EXISTS fx_quotes (
+  id UUID PRIMARY KEY,
+  user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  from_currency TEXT NOT NULL,
+  to_currency TEXT NOT NULL,
+  rate NUMERIC(36,18) NOT NULL,
+  amount NUMERIC(36,18) NOT NULL,
+  converted_amount NUMERIC(36,18) NOT NULL,
+  expires_at TIMESTAMPTZ NOT NULL,
+  used_at TIMESTAMPTZ,
+  used_ref_id TEXT,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_fx_quotes_user_id ON fx_quotes(user_id);
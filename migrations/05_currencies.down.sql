@@ -0,0 +1,26 @@
+-- back to minor units, for the currencies the up migration rescaled
+UPDATE wallet_balances wb
+SET amount = wb.amount * power(10::numeric, c.exponent), held = wb.held * power(10::numeric, c.exponent)
+FROM currencies c
+WHERE c.code = wb.currency AND c.code IN ('USD', 'EUR', 'JPY', 'IDR');
+
+UPDATE txs t SET amount = t.amount * power(10::numeric, c.exponent)
+FROM currencies c
+WHERE c.code = t.currency AND c.code IN ('USD', 'EUR', 'JPY', 'IDR');
+
+UPDATE withdrawals w SET amount = w.amount * power(10::numeric, c.exponent)
+FROM currencies c
+WHERE c.code = w.currency AND c.code IN ('USD', 'EUR', 'JPY', 'IDR');
+
+UPDATE postings p SET amount = p.amount * power(10::numeric, c.exponent)
+FROM currencies c
+WHERE c.code = p.currency AND c.code IN ('USD', 'EUR', 'JPY', 'IDR');
+
+UPDATE fx_quotes q
+SET amount = q.amount * power(10::numeric, f.exponent), converted_amount = q.converted_amount * power(10::numeric, t.exponent)
+FROM currencies f, currencies t
+WHERE f.code = q.from_currency AND t.code = q.to_currency AND f.code IN ('USD', 'EUR', 'JPY', 'IDR') AND t.code IN ('USD', 'EUR', 'JPY', 'IDR');
+
+ALTER TABLE wallet_balances DROP CONSTRAINT IF EXISTS fk_wallet_balances_currency;
+
+DROP TABLE IF EXISTS currencies;
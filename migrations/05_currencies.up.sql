@@ -0,0 +1,51 @@
+-- currency registry, exponent is the number of minor unit digits; amounts are stored in major units
+CREATE TABLE IF NOT EXISTS currencies (
+  code TEXT PRIMARY KEY,
+  name TEXT NOT NULL DEFAULT '',
+  exponent SMALLINT NOT NULL CHECK (exponent BETWEEN 0 AND 18),
+  enabled BOOLEAN NOT NULL DEFAULT TRUE,
+  rounding_mode TEXT NOT NULL DEFAULT 'half_even' CHECK (rounding_mode IN ('half_even', 'half_up', 'down')),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO currencies (code, name, exponent, rounding_mode) VALUES
+  ('USD', 'US Dollar', 2, 'half_even'),
+  ('EUR', 'Euro', 2, 'half_even'),
+  ('JPY', 'Japanese Yen', 0, 'half_even'),
+  ('IDR', 'Indonesian Rupiah', 2, 'half_even')
+ON CONFLICT (code) DO NOTHING;
+
+-- amounts were stored in minor units (cents) before the registry, rescale
+-- the currencies seeded above to major units. Balances keep held <= amount as both
+-- sides shrink by the same factor.
+UPDATE wallet_balances wb
+SET amount = wb.amount / power(10::numeric, c.exponent), held = wb.held / power(10::numeric, c.exponent)
+FROM currencies c
+WHERE c.code = wb.currency;
+
+UPDATE txs t SET amount = t.amount / power(10::numeric, c.exponent)
+FROM currencies c
+WHERE c.code = t.currency;
+
+UPDATE withdrawals w SET amount = w.amount / power(10::numeric, c.exponent)
+FROM currencies c
+WHERE c.code = w.currency;
+
+UPDATE postings p SET amount = p.amount / power(10::numeric, c.exponent)
+FROM currencies c
+WHERE c.code = p.currency;
+
+UPDATE fx_quotes q
+SET amount = q.amount / power(10::numeric, f.exponent), converted_amount = q.converted_amount / power(10::numeric, t.exponent)
+FROM currencies f, currencies t
+WHERE f.code = q.from_currency AND t.code = q.to_currency;
+
+-- keep currencies already held in wallets usable, at the widest precision.
+-- Their minor unit is unknown, so their amounts are left as stored and have
+-- to be rescaled by hand before the currency is given its real exponent.
+INSERT INTO currencies (code, exponent)
+SELECT DISTINCT currency, 18 FROM wallet_balances
+ON CONFLICT (code) DO NOTHING;
+
+ALTER TABLE wallet_balances
+  ADD CONSTRAINT fk_wallet_balances_currency FOREIGN KEY (currency) REFERENCES currencies(code);
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS wallet_status_events;
+
+ALTER TABLE wallets DROP CONSTRAINT IF EXISTS chk_wallets_status;
+ALTER TABLE wallets DROP COLUMN IF EXISTS status_changed_at;
+ALTER TABLE wallets DROP COLUMN IF EXISTS status_changed_by;
+ALTER TABLE wallets DROP COLUMN IF EXISTS status_reason;
+ALTER TABLE wallets DROP COLUMN IF EXISTS status;
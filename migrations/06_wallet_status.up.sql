@@ -0,0 +1,20 @@
+-- wallet lifecycle, only active wallets can send or receive
+ALTER TABLE wallets ADD COLUMN IF NOT EXISTS status TEXT NOT NULL DEFAULT 'active';
+ALTER TABLE wallets ADD COLUMN IF NOT EXISTS status_reason TEXT;
+ALTER TABLE wallets ADD COLUMN IF NOT EXISTS status_changed_by BIGINT REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE wallets ADD COLUMN IF NOT EXISTS status_changed_at TIMESTAMPTZ;
+ALTER TABLE wallets DROP CONSTRAINT IF EXISTS chk_wallets_status;
+ALTER TABLE wallets ADD CONSTRAINT chk_wallets_status CHECK (status IN ('active', 'frozen', 'closed', 'archived'));
+
+-- audit trail of state changes
+CREATE TABLE IF NOT EXISTS wallet_status_events (
+  id BIGSERIAL PRIMARY KEY,
+  wallet_id BIGINT NOT NULL REFERENCES wallets(id) ON DELETE CASCADE,
+  from_status TEXT NOT NULL,
+  to_status TEXT NOT NULL,
+  reason TEXT NOT NULL,
+  actor_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_wallet_status_events_wallet ON wallet_status_events(wallet_id, created_at DESC);
@@ -0,0 +1,33 @@
+-- velocity limit rules, no user_id/role_id means the currency default
+CREATE TABLE IF NOT EXISTS limit_rules (
+  id BIGSERIAL PRIMARY KEY,
+  currency TEXT NOT NULL REFERENCES currencies(code),
+  operation TEXT NOT NULL CHECK (operation IN ('deposit', 'transfer', 'withdrawal')),
+  period TEXT NOT NULL CHECK (period IN ('transaction', 'day', 'month')),
+  aggregate TEXT NOT NULL DEFAULT 'user' CHECK (aggregate IN ('wallet', 'user')),
+  max_amount NUMERIC(36,18) NOT NULL CHECK (max_amount >= 0),
+  user_id BIGINT REFERENCES users(id) ON DELETE CASCADE,
+  role_id BIGINT REFERENCES roles(id) ON DELETE CASCADE,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  CHECK (user_id IS NULL OR role_id IS NULL)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_limit_rules_scope ON limit_rules (
+  currency, operation, period, aggregate, COALESCE(user_id, 0), COALESCE(role_id, 0)
+);
+
+-- usage counted against rolling windows, written in the same transaction as the ledger entry
+CREATE TABLE IF NOT EXISTS limit_usage (
+  id BIGSERIAL PRIMARY KEY,
+  user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  wallet_id BIGINT NOT NULL REFERENCES wallets(id) ON DELETE CASCADE,
+  operation TEXT NOT NULL,
+  currency TEXT NOT NULL,
+  amount NUMERIC(36,18) NOT NULL CHECK (amount > 0),
+  ref_id TEXT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_limit_usage_user ON limit_usage(user_id, operation, currency, created_at);
+CREATE INDEX IF NOT EXISTS idx_limit_usage_wallet ON limit_usage(wallet_id, operation, currency, created_at);
+CREATE INDEX IF NOT EXISTS idx_limit_usage_ref ON limit_usage(ref_id);
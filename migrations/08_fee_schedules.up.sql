@@ -0,0 +1,19 @@
+-- fee schedules, no to_currency matches any target and no role_id is the default
+CREATE TABLE IF NOT EXISTS fee_schedules (
+  id BIGSERIAL PRIMARY KEY,
+  tx_type TEXT NOT NULL CHECK (tx_type IN ('transfer', 'fx')),
+  from_currency TEXT NOT NULL REFERENCES currencies(code),
+  to_currency TEXT REFERENCES currencies(code),
+  role_id BIGINT REFERENCES roles(id) ON DELETE CASCADE,
+  flat NUMERIC(36,18) NOT NULL DEFAULT 0 CHECK (flat >= 0),
+  percent NUMERIC(9,6) NOT NULL DEFAULT 0 CHECK (percent >= 0 AND percent <= 100),
+  tiers JSONB,
+  min_fee NUMERIC(36,18) CHECK (min_fee >= 0),
+  max_fee NUMERIC(36,18) CHECK (max_fee >= 0),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  CHECK (min_fee IS NULL OR max_fee IS NULL OR min_fee <= max_fee)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_fee_schedules_scope ON fee_schedules (
+  tx_type, from_currency, COALESCE(to_currency, ''), COALESCE(role_id, 0)
+);
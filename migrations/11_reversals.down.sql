@@ -0,0 +1,8 @@
+DELETE FROM role_permissions rp
+USING permissions p, resources res
+WHERE rp.permission_id = p.id AND rp.resource_id = res.id
+  AND p.name = 'approve' AND res.name = 'wallets' AND rp.context_id IS NULL;
+
+DROP TABLE IF EXISTS reversals;
+DROP INDEX IF EXISTS idx_journal_entries_reverses;
+ALTER TABLE journal_entries DROP COLUMN IF EXISTS reverses_entry_id;
@@ -0,0 +1,32 @@
+-- journal entries can compensate an earlier entry
+ALTER TABLE journal_entries ADD COLUMN IF NOT EXISTS reverses_entry_id BIGINT REFERENCES journal_entries(id);
+CREATE INDEX IF NOT EXISTS idx_journal_entries_reverses ON journal_entries(reverses_entry_id) WHERE reverses_entry_id IS NOT NULL;
+
+-- full or partial reversals of a transfer, amounts are what was returned to the
+-- sender (amount, fee_amount) and taken back from the recipient (converted_amount)
+CREATE TABLE IF NOT EXISTS reversals (
+  id BIGSERIAL PRIMARY KEY,
+  ref_id TEXT NOT NULL UNIQUE,
+  entry_id BIGINT NOT NULL REFERENCES journal_entries(id),
+  original_entry_id BIGINT NOT NULL REFERENCES journal_entries(id),
+  original_ref_id TEXT NOT NULL,
+  amount NUMERIC(36,18) NOT NULL CHECK (amount > 0),
+  converted_amount NUMERIC(36,18) NOT NULL CHECK (converted_amount > 0),
+  fee_amount NUMERIC(36,18) NOT NULL DEFAULT 0 CHECK (fee_amount >= 0),
+  rate NUMERIC(36,18) NOT NULL,
+  reason TEXT NOT NULL,
+  actor_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_reversals_original ON reversals(original_entry_id);
+
+-- approving reversals for already seeded databases
+INSERT INTO role_permissions (role_id, permission_id, resource_id, context_id)
+SELECT r.id, p.id, res.id, NULL
+FROM roles r, permissions p, resources res
+WHERE r.name = 'administrator' AND p.name = 'approve' AND res.name = 'wallets'
+  AND NOT EXISTS (
+    SELECT 1 FROM role_permissions rp
+    WHERE rp.role_id = r.id AND rp.permission_id = p.id AND rp.resource_id = res.id AND rp.context_id IS NULL
+  );
@@ -0,0 +1,43 @@
+-- standing orders, executed by the schedule runner as their owner
+CREATE TABLE IF NOT EXISTS scheduled_transfers (
+  id BIGSERIAL PRIMARY KEY,
+  user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  from_wallet_id BIGINT NOT NULL REFERENCES wallets(id) ON DELETE CASCADE,
+  to_wallet_id BIGINT NOT NULL REFERENCES wallets(id) ON DELETE CASCADE,
+  from_currency TEXT NOT NULL REFERENCES currencies(code),
+  to_currency TEXT NOT NULL REFERENCES currencies(code),
+  amount NUMERIC(36,18) NOT NULL CHECK (amount > 0),
+  description TEXT NOT NULL DEFAULT '',
+  recurrence TEXT NOT NULL CHECK (recurrence IN ('once', 'cron', 'rrule')),
+  rule TEXT NOT NULL DEFAULT '',
+  timezone TEXT NOT NULL DEFAULT 'UTC',
+  start_at TIMESTAMPTZ NOT NULL,
+  end_at TIMESTAMPTZ,
+  max_occurrences INT CHECK (max_occurrences > 0),
+  occurrences INT NOT NULL DEFAULT 0,
+  status TEXT NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'paused', 'completed', 'cancelled')),
+  next_run_at TIMESTAMPTZ,
+  last_run_at TIMESTAMPTZ,
+  -- retries of the current occurrence after transient errors
+  attempts INT NOT NULL DEFAULT 0,
+  last_error TEXT,
+  locked_until TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_scheduled_transfers_due ON scheduled_transfers(next_run_at) WHERE status = 'active';
+CREATE INDEX IF NOT EXISTS idx_scheduled_transfers_user ON scheduled_transfers(user_id, created_at DESC);
+
+-- one row per occurrence, ref_id is the transfer ref_id of the occurrence
+CREATE TABLE IF NOT EXISTS scheduled_transfer_runs (
+  id BIGSERIAL PRIMARY KEY,
+  schedule_id BIGINT NOT NULL REFERENCES scheduled_transfers(id) ON DELETE CASCADE,
+  occurrence INT NOT NULL,
+  scheduled_for TIMESTAMPTZ NOT NULL,
+  ref_id TEXT NOT NULL,
+  status TEXT NOT NULL CHECK (status IN ('succeeded', 'failed', 'skipped')),
+  error TEXT,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  UNIQUE (schedule_id, scheduled_for)
+);
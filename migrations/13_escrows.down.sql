@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS escrow_events;
+DROP TABLE IF EXISTS escrows;
@@ -0,0 +1,36 @@
+-- escrow between a buyer and a seller wallet, the held amount sits on the
+-- ledger account escrow:<id> until it is released to the seller or refunded
+CREATE TABLE IF NOT EXISTS escrows (
+  id BIGSERIAL PRIMARY KEY,
+  ref_id TEXT NOT NULL UNIQUE,
+  buyer_wallet_id BIGINT NOT NULL REFERENCES wallets(id),
+  seller_wallet_id BIGINT NOT NULL REFERENCES wallets(id),
+  currency TEXT NOT NULL,
+  amount NUMERIC(36,18) NOT NULL CHECK (amount > 0),
+  status TEXT NOT NULL DEFAULT 'held' CHECK (status IN ('held', 'released', 'refunded', 'disputed')),
+  description TEXT NOT NULL DEFAULT '',
+  release_at TIMESTAMPTZ,
+  dispute_reason TEXT,
+  created_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  CHECK (buyer_wallet_id <> seller_wallet_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_escrows_buyer ON escrows(buyer_wallet_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_escrows_seller ON escrows(seller_wallet_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_escrows_release_due ON escrows(release_at) WHERE status = 'held' AND release_at IS NOT NULL;
+
+-- every state change, entry_id links the journal entry that moved the funds
+CREATE TABLE IF NOT EXISTS escrow_events (
+  id BIGSERIAL PRIMARY KEY,
+  escrow_id BIGINT NOT NULL REFERENCES escrows(id) ON DELETE CASCADE,
+  from_status TEXT NOT NULL,
+  to_status TEXT NOT NULL,
+  reason TEXT NOT NULL,
+  actor_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+  entry_id BIGINT REFERENCES journal_entries(id),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_escrow_events_escrow ON escrow_events(escrow_id, created_at);
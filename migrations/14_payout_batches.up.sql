@@ -0,0 +1,39 @@
+-- batch payouts from an uploaded file, batch_id is chosen by the client and
+-- makes the upload idempotent
+CREATE TABLE IF NOT EXISTS payout_batches (
+  id BIGSERIAL PRIMARY KEY,
+  batch_id TEXT NOT NULL UNIQUE,
+  user_id BIGINT NOT NULL REFERENCES users(id),
+  from_wallet_id BIGINT NOT NULL REFERENCES wallets(id),
+  status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'processing', 'completed')),
+  total_rows INT NOT NULL,
+  succeeded INT NOT NULL DEFAULT 0,
+  failed INT NOT NULL DEFAULT 0,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  completed_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_payout_batches_user ON payout_batches(user_id, created_at DESC);
+
+-- one transfer per row, ref_id is the transfer ref_id of the row
+CREATE TABLE IF NOT EXISTS payout_rows (
+  id BIGSERIAL PRIMARY KEY,
+  batch_id BIGINT NOT NULL REFERENCES payout_batches(id) ON DELETE CASCADE,
+  row_number INT NOT NULL,
+  wallet_id BIGINT NOT NULL REFERENCES wallets(id),
+  user_id BIGINT,
+  currency TEXT NOT NULL REFERENCES currencies(code),
+  amount NUMERIC(36,18) NOT NULL CHECK (amount > 0),
+  reference TEXT NOT NULL,
+  ref_id TEXT NOT NULL UNIQUE,
+  status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'succeeded', 'failed')),
+  error TEXT,
+  attempts INT NOT NULL DEFAULT 0,
+  locked_until TIMESTAMPTZ,
+  processed_at TIMESTAMPTZ,
+  UNIQUE (batch_id, row_number),
+  UNIQUE (batch_id, reference)
+);
+
+CREATE INDEX IF NOT EXISTS idx_payout_rows_pending ON payout_rows(batch_id, row_number) WHERE status = 'pending';
@@ -0,0 +1,24 @@
+-- money requested by one user from another, paid by a transfer from the payer
+CREATE TABLE IF NOT EXISTS payment_requests (
+  id BIGSERIAL PRIMARY KEY,
+  requester_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  requester_wallet_id BIGINT NOT NULL REFERENCES wallets(id) ON DELETE CASCADE,
+  payer_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  currency TEXT NOT NULL REFERENCES currencies(code),
+  amount NUMERIC(36,18) NOT NULL CHECK (amount > 0),
+  memo TEXT NOT NULL DEFAULT '',
+  status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'paid', 'declined', 'expired', 'cancelled')),
+  expires_at TIMESTAMPTZ NOT NULL,
+  -- set when paid, transfer_ref is the ref_id of the transfer
+  paid_from_wallet_id BIGINT REFERENCES wallets(id) ON DELETE SET NULL,
+  transfer_ref TEXT UNIQUE,
+  decline_reason TEXT,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  resolved_at TIMESTAMPTZ,
+  CHECK (requester_id <> payer_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_payment_requests_payer ON payment_requests(payer_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_payment_requests_requester ON payment_requests(requester_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_payment_requests_expiry ON payment_requests(expires_at) WHERE status = 'pending';
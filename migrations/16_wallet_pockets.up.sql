@@ -0,0 +1,14 @@
+-- a pocket is a wallet kept under a parent wallet of the same user, it holds
+-- one currency and may carry a savings goal
+CREATE TABLE IF NOT EXISTS wallet_pockets (
+  wallet_id BIGINT PRIMARY KEY REFERENCES wallets(id) ON DELETE CASCADE,
+  parent_wallet_id BIGINT NOT NULL REFERENCES wallets(id) ON DELETE CASCADE,
+  currency TEXT NOT NULL REFERENCES currencies(code),
+  target_amount NUMERIC(36,18) CHECK (target_amount > 0),
+  target_date DATE,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  CHECK (wallet_id <> parent_wallet_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_wallet_pockets_parent ON wallet_pockets(parent_wallet_id, wallet_id);
@@ -0,0 +1,9 @@
+DELETE FROM role_permissions rp
+USING permissions p, resources res
+WHERE rp.permission_id = p.id AND rp.resource_id = res.id
+  AND p.name = 'read' AND res.name = 'statements' AND rp.context_id IS NULL;
+
+DELETE FROM resources WHERE name = 'statements';
+
+DROP INDEX IF EXISTS idx_tx_wallet_currency_created;
+DROP TABLE IF EXISTS statements;
@@ -0,0 +1,45 @@
+-- account statements of a wallet and currency over [period_from, period_to),
+-- rendered by the statement generator and stored in object storage
+CREATE TABLE IF NOT EXISTS statements (
+  id BIGSERIAL PRIMARY KEY,
+  user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  wallet_id BIGINT NOT NULL REFERENCES wallets(id) ON DELETE CASCADE,
+  currency TEXT NOT NULL REFERENCES currencies(code),
+  format TEXT NOT NULL CHECK (format IN ('csv', 'pdf')),
+  period_from TIMESTAMPTZ NOT NULL,
+  period_to TIMESTAMPTZ NOT NULL,
+  status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'completed', 'failed')),
+  -- set when completed
+  object_key TEXT,
+  opening_balance NUMERIC(36,18),
+  closing_balance NUMERIC(36,18),
+  tx_count INT NOT NULL DEFAULT 0,
+  error TEXT,
+  attempts INT NOT NULL DEFAULT 0,
+  locked_until TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  completed_at TIMESTAMPTZ,
+  CHECK (period_from < period_to)
+);
+
+CREATE INDEX IF NOT EXISTS idx_statements_user ON statements(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_statements_pending ON statements(id) WHERE status = 'pending';
+
+-- opening balances sum the ledger up to the period start
+CREATE INDEX IF NOT EXISTS idx_tx_wallet_currency_created ON txs(wallet_id, currency, created_at, id);
+
+-- reading other users' statements for already seeded databases
+INSERT INTO resources (name, description)
+SELECT 'statements', 'Account statements'
+WHERE EXISTS (SELECT 1 FROM roles)
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id, resource_id, context_id)
+SELECT r.id, p.id, res.id, NULL
+FROM roles r, permissions p, resources res
+WHERE r.name = 'administrator' AND p.name = 'read' AND res.name = 'statements'
+  AND NOT EXISTS (
+    SELECT 1 FROM role_permissions rp
+    WHERE rp.role_id = r.id AND rp.permission_id = p.id AND rp.resource_id = res.id AND rp.context_id IS NULL
+  );
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_tx_wallet_currency_posted;
+DROP TABLE IF EXISTS reconciliation_drifts;
+DROP TABLE IF EXISTS reconciliation_runs;
@@ -0,0 +1,38 @@
+-- reconciliation runs recompute balances from the posted txs rows and record
+-- every wallet_balances row that disagrees with its ledger sum
+CREATE TABLE IF NOT EXISTS reconciliation_runs (
+  id BIGSERIAL PRIMARY KEY,
+  trigger TEXT NOT NULL CHECK (trigger IN ('schedule', 'manual', 'cli')),
+  requested_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+  status TEXT NOT NULL DEFAULT 'running' CHECK (status IN ('running', 'completed', 'failed')),
+  wallets_checked INT NOT NULL DEFAULT 0,
+  drift_count INT NOT NULL DEFAULT 0,
+  error TEXT,
+  started_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  finished_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_reconciliation_runs_started ON reconciliation_runs(started_at DESC);
+
+-- difference is balance - ledger, a repair posts it against system:reconciliation
+CREATE TABLE IF NOT EXISTS reconciliation_drifts (
+  id BIGSERIAL PRIMARY KEY,
+  run_id BIGINT NOT NULL REFERENCES reconciliation_runs(id) ON DELETE CASCADE,
+  wallet_id BIGINT NOT NULL REFERENCES wallets(id) ON DELETE CASCADE,
+  currency TEXT NOT NULL,
+  balance NUMERIC(36,18) NOT NULL,
+  ledger NUMERIC(36,18) NOT NULL,
+  difference NUMERIC(36,18) NOT NULL CHECK (difference <> 0),
+  status TEXT NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'repaired', 'superseded')),
+  repair_entry_id BIGINT REFERENCES journal_entries(id),
+  repaired_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+  repair_reason TEXT,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  resolved_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_reconciliation_drifts_run ON reconciliation_drifts(run_id, wallet_id, currency);
+CREATE INDEX IF NOT EXISTS idx_reconciliation_drifts_open ON reconciliation_drifts(run_id) WHERE status = 'open';
+
+-- ledger sums per balance
+CREATE INDEX IF NOT EXISTS idx_tx_wallet_currency_posted ON txs(wallet_id, currency) INCLUDE (amount) WHERE entry_id IS NOT NULL;
@@ -0,0 +1,10 @@
+DROP TABLE IF EXISTS ledger_checkpoint_heads;
+DROP TABLE IF EXISTS ledger_checkpoints;
+DROP TRIGGER IF EXISTS trg_txs_chain ON txs;
+DROP FUNCTION IF EXISTS txs_chain_append();
+DROP INDEX IF EXISTS ux_tx_wallet_seq;
+DROP FUNCTION IF EXISTS txs_chain_hash(TEXT, txs);
+DROP TABLE IF EXISTS ledger_chain_heads;
+ALTER TABLE txs DROP COLUMN IF EXISTS hash;
+ALTER TABLE txs DROP COLUMN IF EXISTS prev_hash;
+ALTER TABLE txs DROP COLUMN IF EXISTS seq;
@@ -0,0 +1,118 @@
+-- every txs row is chained to the previous row of its wallet: seq numbers the
+-- rows of a wallet from 1 and hash covers the row content and prev_hash
+ALTER TABLE txs ADD COLUMN IF NOT EXISTS seq BIGINT;
+ALTER TABLE txs ADD COLUMN IF NOT EXISTS prev_hash TEXT;
+ALTER TABLE txs ADD COLUMN IF NOT EXISTS hash TEXT;
+
+-- last link of each wallet chain, locked while a row is appended
+CREATE TABLE IF NOT EXISTS ledger_chain_heads (
+  wallet_id BIGINT PRIMARY KEY REFERENCES wallets(id) ON DELETE CASCADE,
+  seq BIGINT NOT NULL DEFAULT 0,
+  hash TEXT NOT NULL DEFAULT '',
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- hex sha256 of the unit separator joined fields, models.ChainRow.ComputeHash
+-- must produce the same value
+CREATE OR REPLACE FUNCTION txs_chain_hash(prev_hash TEXT, t txs)
+RETURNS TEXT AS $$
+  SELECT encode(sha256(convert_to(concat_ws(chr(31),
+    prev_hash,
+    t.id::text,
+    t.wallet_id::text,
+    t.seq::text,
+    t.type,
+    t.currency,
+    t.amount::text,
+    COALESCE(t.ref_id, ''),
+    COALESCE(t.entry_id::text, ''),
+    COALESCE(t.meta::text, ''),
+    to_char(t.created_at AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS.US"Z"')
+  ), 'UTF8')), 'hex')
+$$ LANGUAGE SQL STABLE;
+
+-- chain the existing rows in the order they were written
+DO $$
+DECLARE
+  r txs%ROWTYPE;
+  cur_wallet BIGINT := NULL;
+  cur_seq BIGINT := 0;
+  cur_hash TEXT := '';
+BEGIN
+  FOR r IN SELECT * FROM txs ORDER BY wallet_id, created_at, id LOOP
+    IF cur_wallet IS DISTINCT FROM r.wallet_id THEN
+      IF cur_wallet IS NOT NULL THEN
+        INSERT INTO ledger_chain_heads (wallet_id, seq, hash) VALUES (cur_wallet, cur_seq, cur_hash)
+        ON CONFLICT (wallet_id) DO UPDATE SET seq = EXCLUDED.seq, hash = EXCLUDED.hash, updated_at = now();
+      END IF;
+      cur_wallet := r.wallet_id;
+      cur_seq := 0;
+      cur_hash := '';
+    END IF;
+
+    cur_seq := cur_seq + 1;
+    r.seq := cur_seq;
+    UPDATE txs SET seq = cur_seq, prev_hash = cur_hash, hash = txs_chain_hash(cur_hash, r) WHERE id = r.id
+    RETURNING hash INTO cur_hash;
+  END LOOP;
+
+  IF cur_wallet IS NOT NULL THEN
+    INSERT INTO ledger_chain_heads (wallet_id, seq, hash) VALUES (cur_wallet, cur_seq, cur_hash)
+    ON CONFLICT (wallet_id) DO UPDATE SET seq = EXCLUDED.seq, hash = EXCLUDED.hash, updated_at = now();
+  END IF;
+END $$;
+
+ALTER TABLE txs ALTER COLUMN seq SET NOT NULL;
+ALTER TABLE txs ALTER COLUMN prev_hash SET NOT NULL;
+ALTER TABLE txs ALTER COLUMN hash SET NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS ux_tx_wallet_seq ON txs(wallet_id, seq);
+
+-- append new rows to their wallet chain. The head is advanced before the row
+-- is written, so txs inserts must not use ON CONFLICT DO NOTHING.
+CREATE OR REPLACE FUNCTION txs_chain_append()
+RETURNS TRIGGER AS $$
+DECLARE
+  head ledger_chain_heads%ROWTYPE;
+BEGIN
+  INSERT INTO ledger_chain_heads (wallet_id) VALUES (NEW.wallet_id)
+  ON CONFLICT (wallet_id) DO NOTHING;
+
+  SELECT * INTO head FROM ledger_chain_heads WHERE wallet_id = NEW.wallet_id FOR UPDATE;
+
+  NEW.seq := head.seq + 1;
+  NEW.prev_hash := head.hash;
+  NEW.hash := txs_chain_hash(head.hash, NEW);
+
+  UPDATE ledger_chain_heads SET seq = NEW.seq, hash = NEW.hash, updated_at = now()
+  WHERE wallet_id = NEW.wallet_id;
+
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_txs_chain ON txs;
+CREATE TRIGGER trg_txs_chain
+BEFORE INSERT ON txs
+FOR EACH ROW
+EXECUTE FUNCTION txs_chain_append();
+
+-- signed snapshots of every chain head, exported for auditors
+CREATE TABLE IF NOT EXISTS ledger_checkpoints (
+  id BIGSERIAL PRIMARY KEY,
+  wallet_count INT NOT NULL,
+  row_count BIGINT NOT NULL,
+  root_hash TEXT NOT NULL,
+  key_id TEXT NOT NULL,
+  signature TEXT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_ledger_checkpoints_created ON ledger_checkpoints(created_at DESC);
+
+CREATE TABLE IF NOT EXISTS ledger_checkpoint_heads (
+  checkpoint_id BIGINT NOT NULL REFERENCES ledger_checkpoints(id) ON DELETE CASCADE,
+  wallet_id BIGINT NOT NULL,
+  seq BIGINT NOT NULL,
+  hash TEXT NOT NULL,
+  PRIMARY KEY (checkpoint_id, wallet_id)
+);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_tx_created;
+DROP TABLE IF EXISTS balance_snapshot_lines;
+DROP TABLE IF EXISTS balance_snapshots;
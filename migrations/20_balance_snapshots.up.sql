@@ -0,0 +1,19 @@
+-- one row per closed UTC day: the lines hold every wallet balance built from
+-- the posted txs rows created before as_of
+CREATE TABLE IF NOT EXISTS balance_snapshots (
+  as_of TIMESTAMPTZ PRIMARY KEY,
+  wallet_count INT NOT NULL,
+  line_count INT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS balance_snapshot_lines (
+  as_of TIMESTAMPTZ NOT NULL REFERENCES balance_snapshots(as_of) ON DELETE CASCADE,
+  wallet_id BIGINT NOT NULL REFERENCES wallets(id) ON DELETE CASCADE,
+  currency TEXT NOT NULL,
+  amount NUMERIC(36,18) NOT NULL,
+  PRIMARY KEY (as_of, wallet_id, currency)
+);
+
+-- replaying every wallet between a snapshot and a point in time
+CREATE INDEX IF NOT EXISTS idx_tx_created ON txs(created_at);
@@ -0,0 +1,27 @@
+-- manual balance corrections, proposed by one admin and approved or rejected
+-- by another; only approved adjustments post a journal entry
+CREATE TABLE IF NOT EXISTS balance_adjustments (
+  id BIGSERIAL PRIMARY KEY,
+  wallet_id BIGINT NOT NULL REFERENCES wallets(id) ON DELETE CASCADE,
+  currency TEXT NOT NULL,
+  direction TEXT NOT NULL CHECK (direction IN ('credit', 'debit')),
+  amount NUMERIC(36,18) NOT NULL CHECK (amount > 0),
+  reason TEXT NOT NULL,
+  attachments JSONB NOT NULL DEFAULT '[]',
+  status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'rejected', 'cancelled')),
+  requested_by BIGINT NOT NULL,
+  reviewed_by BIGINT,
+  review_note TEXT,
+  entry_id BIGINT REFERENCES journal_entries(id),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  reviewed_at TIMESTAMPTZ,
+  -- the requester may cancel but never review their own adjustment
+  CONSTRAINT balance_adjustments_no_self_review CHECK (
+    status NOT IN ('approved', 'rejected') OR reviewed_by IS DISTINCT FROM requested_by
+  ),
+  CONSTRAINT balance_adjustments_entry CHECK ((status = 'approved') = (entry_id IS NOT NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_balance_adjustments_status ON balance_adjustments(status, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_balance_adjustments_wallet ON balance_adjustments(wallet_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_balance_adjustments_requested_by ON balance_adjustments(requested_by, created_at DESC);
@@ -0,0 +1,21 @@
+-- held runs, rows and requests lose their pending decision
+UPDATE scheduled_transfer_runs SET status = 'failed' WHERE status = 'held';
+UPDATE payout_rows SET status = 'pending' WHERE status = 'held';
+UPDATE payment_requests SET status = 'pending', paid_from_wallet_id = NULL, transfer_ref = NULL WHERE status = 'held';
+
+DROP INDEX IF EXISTS idx_scheduled_transfer_runs_held;
+DROP INDEX IF EXISTS idx_payout_rows_held;
+DROP INDEX IF EXISTS idx_payment_requests_held;
+
+ALTER TABLE scheduled_transfer_runs DROP CONSTRAINT IF EXISTS scheduled_transfer_runs_status_check;
+ALTER TABLE scheduled_transfer_runs ADD CONSTRAINT scheduled_transfer_runs_status_check
+  CHECK (status IN ('succeeded', 'failed', 'skipped'));
+ALTER TABLE payout_rows DROP CONSTRAINT IF EXISTS payout_rows_status_check;
+ALTER TABLE payout_rows ADD CONSTRAINT payout_rows_status_check
+  CHECK (status IN ('pending', 'succeeded', 'failed'));
+ALTER TABLE payment_requests DROP CONSTRAINT IF EXISTS payment_requests_status_check;
+ALTER TABLE payment_requests ADD CONSTRAINT payment_requests_status_check
+  CHECK (status IN ('pending', 'paid', 'declined', 'expired', 'cancelled'));
+
+DROP TABLE IF EXISTS risk_reviews;
+DROP TABLE IF EXISTS risk_rules;
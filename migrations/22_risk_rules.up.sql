@@ -0,0 +1,65 @@
+-- declarative risk rules checked before deposits and transfers, no operation
+-- or currency means all of them
+CREATE TABLE IF NOT EXISTS risk_rules (
+  id BIGSERIAL PRIMARY KEY,
+  name TEXT NOT NULL,
+  kind TEXT NOT NULL CHECK (kind IN ('amount_threshold', 'velocity', 'account_age', 'structuring', 'blocked_counterparty')),
+  operation TEXT CHECK (operation IN ('deposit', 'transfer')),
+  currency TEXT REFERENCES currencies(code),
+  action TEXT NOT NULL CHECK (action IN ('review', 'deny')),
+  params JSONB NOT NULL DEFAULT '{}',
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- deposits and transfers held by a review rule until an admin approves or
+-- rejects them; a held transfer reserves amount plus fee on the sender balance
+CREATE TABLE IF NOT EXISTS risk_reviews (
+  id BIGSERIAL PRIMARY KEY,
+  operation TEXT NOT NULL CHECK (operation IN ('deposit', 'transfer')),
+  wallet_id BIGINT NOT NULL REFERENCES wallets(id) ON DELETE CASCADE,
+  user_id BIGINT NOT NULL,
+  currency TEXT NOT NULL,
+  amount NUMERIC(36,18) NOT NULL CHECK (amount > 0),
+  held NUMERIC(36,18) NOT NULL DEFAULT 0 CHECK (held >= 0),
+  to_wallet_id BIGINT REFERENCES wallets(id) ON DELETE CASCADE,
+  to_currency TEXT,
+  converted_amount NUMERIC(36,18),
+  rate NUMERIC(36,18),
+  quote_id TEXT,
+  fee NUMERIC(36,18) NOT NULL DEFAULT 0,
+  fee_schedule_id BIGINT,
+  fee_wallet_id BIGINT,
+  ref_id TEXT NOT NULL,
+  hits JSONB NOT NULL DEFAULT '[]',
+  status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'rejected')),
+  requested_by BIGINT,
+  reviewed_by BIGINT,
+  review_note TEXT,
+  entry_id BIGINT REFERENCES journal_entries(id),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  reviewed_at TIMESTAMPTZ,
+  CHECK (operation = 'deposit' OR to_wallet_id IS NOT NULL)
+);
+
+-- a retried operation finds its review instead of holding twice
+CREATE UNIQUE INDEX IF NOT EXISTS uq_risk_reviews_ref ON risk_reviews(operation, ref_id);
+CREATE INDEX IF NOT EXISTS idx_risk_reviews_status ON risk_reviews(status, created_at);
+CREATE INDEX IF NOT EXISTS idx_risk_reviews_wallet ON risk_reviews(wallet_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_risk_reviews_user ON risk_reviews(user_id, created_at DESC);
+
+-- a scheduled, payout or payment request transfer held for review waits in
+-- held until the review is decided
+ALTER TABLE scheduled_transfer_runs DROP CONSTRAINT IF EXISTS scheduled_transfer_runs_status_check;
+ALTER TABLE scheduled_transfer_runs ADD CONSTRAINT scheduled_transfer_runs_status_check
+  CHECK (status IN ('succeeded', 'failed', 'skipped', 'held'));
+CREATE INDEX IF NOT EXISTS idx_scheduled_transfer_runs_held ON scheduled_transfer_runs(ref_id) WHERE status = 'held';
+
+ALTER TABLE payout_rows DROP CONSTRAINT IF EXISTS payout_rows_status_check;
+ALTER TABLE payout_rows ADD CONSTRAINT payout_rows_status_check
+  CHECK (status IN ('pending', 'held', 'succeeded', 'failed'));
+CREATE INDEX IF NOT EXISTS idx_payout_rows_held ON payout_rows(ref_id) WHERE status = 'held';
+
+ALTER TABLE payment_requests DROP CONSTRAINT IF EXISTS payment_requests_status_check;
+ALTER TABLE payment_requests ADD CONSTRAINT payment_requests_status_check
+  CHECK (status IN ('pending', 'held', 'paid', 'declined', 'expired', 'cancelled'));
+CREATE INDEX IF NOT EXISTS idx_payment_requests_held ON payment_requests(transfer_ref) WHERE status = 'held';
@@ -125,6 +125,8 @@ func SeedRBACData(db *sql.DB) error {
 		{"settings", "System settings"},
 		{"audit_logs", "Audit log access"},
 		{"dashboard", "Dashboard access"},
+		{"wallets", "Wallet management"},
+		{"statements", "Account statements"},
 	}
 
 	resourceIDs := make(map[string]int)
@@ -194,13 +196,21 @@ func SeedRBACData(db *sql.DB) error {
 
 		// Guest - minimal access
 		{"guest", "read", "dashboard", "global"},
+
+		// Wallet administration, checked without context
+		{"administrator", "manage", "wallets", ""},
+		{"administrator", "approve", "wallets", ""},
+		{"administrator", "read", "statements", ""},
 	}
 
 	for _, rp := range rolePermissions {
 		roleID := roleIDs[rp.roleName]
 		permissionID := permissionIDs[rp.permissionName]
 		resourceID := resourceIDs[rp.resourceName]
-		contextID := contextIDs[rp.contextName]
+		var contextID *int
+		if id, ok := contextIDs[rp.contextName]; ok {
+			contextID = &id
+		}
 
 		_, err = db.Exec("INSERT INTO role_permissions (role_id, permission_id, resource_id, context_id) VALUES ($1, $2, $3, $4)",
 			roleID, permissionID, resourceID, contextID)
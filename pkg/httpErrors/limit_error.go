@@ -0,0 +1,51 @@
+package httpErrors
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const ErrLimitExceeded = "Limit exceeded"
+
+// Limit exceeded error, unlike RestError its details are part of the response
+// body so clients can tell which limit was hit and when it resets
+type LimitError struct {
+	ErrStatus int        `json:"status"`
+	ErrError  string     `json:"error"`
+	Limit     string     `json:"limit"`
+	Currency  string     `json:"currency"`
+	Max       string     `json:"max"`
+	Used      string     `json:"used"`
+	Requested string     `json:"requested"`
+	ResetsAt  *time.Time `json:"resets_at,omitempty"`
+}
+
+// New Limit Error, resetsAt is nil when the request can never fit the limit
+func NewLimitError(limit, currency, max, used, requested string, resetsAt *time.Time) RestErr {
+	return LimitError{
+		ErrStatus: http.StatusUnprocessableEntity,
+		ErrError:  ErrLimitExceeded,
+		Limit:     limit,
+		Currency:  currency,
+		Max:       max,
+		Used:      used,
+		Requested: requested,
+		ResetsAt:  resetsAt,
+	}
+}
+
+// Error  Error() interface method
+func (e LimitError) Error() string {
+	return fmt.Sprintf("status: %d - errors: %s - limit: %s", e.ErrStatus, e.ErrError, e.Limit)
+}
+
+// Error status
+func (e LimitError) Status() int {
+	return e.ErrStatus
+}
+
+// LimitError Causes
+func (e LimitError) Causes() interface{} {
+	return e.Limit
+}
@@ -0,0 +1,46 @@
+package httpErrors
+
+import (
+	"fmt"
+	"net/http"
+)
+
+const ErrInvalidRows = "Invalid rows"
+
+// Problem with one row of an uploaded file, rows are numbered from 1
+type RowError struct {
+	Row   int    `json:"row"`
+	Error string `json:"error"`
+}
+
+// Rejected upload, every invalid row is listed in the response body so the
+// file can be fixed in one pass
+type RowsError struct {
+	ErrStatus int        `json:"status"`
+	ErrError  string     `json:"error"`
+	Rows      []RowError `json:"rows"`
+}
+
+// New Rows Error
+func NewRowsError(rows []RowError) RestErr {
+	return RowsError{
+		ErrStatus: http.StatusUnprocessableEntity,
+		ErrError:  ErrInvalidRows,
+		Rows:      rows,
+	}
+}
+
+// Error  Error() interface method
+func (e RowsError) Error() string {
+	return fmt.Sprintf("status: %d - errors: %s - rows: %d", e.ErrStatus, e.ErrError, len(e.Rows))
+}
+
+// Error status
+func (e RowsError) Status() int {
+	return e.ErrStatus
+}
+
+// RowsError Causes
+func (e RowsError) Causes() interface{} {
+	return e.Rows
+}
@@ -1,6 +1,7 @@
 package metric
 
 import (
+	"errors"
 	"log"
 	"strconv"
 
@@ -81,3 +82,24 @@ func (metr *PrometheusMetrics) IncHits(status int, method, path string) {
 func (metr *PrometheusMetrics) ObserveResponseTime(status int, method, path string, observeTime float64) {
 	metr.Times.WithLabelValues(strconv.Itoa(status), method, path).Observe(observeTime)
 }
+
+// Gauge of the balance drifts found by the last reconciliation run. The API
+// and the workers share it, a second call returns the registered gauge.
+func CreateDriftGauge(name string) (prometheus.Gauge, error) {
+	gauge := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: name + "_ledger_drift_count",
+		Help: "Wallet balances that disagree with the ledger in the last reconciliation run",
+	})
+
+	if err := prometheus.Register(gauge); err != nil {
+		var registered prometheus.AlreadyRegisteredError
+		if errors.As(err, &registered) {
+			if existing, ok := registered.ExistingCollector.(prometheus.Gauge); ok {
+				return existing, nil
+			}
+		}
+		return nil, err
+	}
+
+	return gauge, nil
+}
@@ -0,0 +1,36 @@
+package pdf
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocument_WriteTo(t *testing.T) {
+	t.Parallel()
+
+	doc := New()
+	doc.Line("Statement (USD) for café \\ shop")
+	for i := 0; i < linesPerPage; i++ {
+		doc.Line("line " + strconv.Itoa(i))
+	}
+
+	buf := &bytes.Buffer{}
+	_, err := doc.WriteTo(buf)
+	require.NoError(t, err)
+
+	out := buf.String()
+	require.True(t, strings.HasPrefix(out, "%PDF-1.4\n"))
+	require.True(t, strings.HasSuffix(out, "%%EOF\n"))
+	require.Contains(t, out, "/Count 2")
+	require.Contains(t, out, `(Statement \(USD\) for caf? \\ shop) '`)
+
+	// startxref points at the xref table
+	tail := out[strings.LastIndex(out, "startxref\n")+len("startxref\n"):]
+	offset, err := strconv.Atoi(strings.TrimSpace(strings.TrimSuffix(tail, "%%EOF\n")))
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(out[offset:], "xref\n"))
+}
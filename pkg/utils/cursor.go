@@ -0,0 +1,75 @@
+package utils
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultCursorSize = 20
+	maxCursorSize     = 100
+)
+
+// Keyset cursor over rows ordered by (created_at DESC, id DESC)
+type Cursor struct {
+	CreatedAt time.Time
+	ID        int64
+}
+
+// Encode cursor into an opaque url safe string
+func (c *Cursor) Encode() string {
+	raw := strconv.FormatInt(c.CreatedAt.UnixNano(), 10) + ":" + strconv.FormatInt(c.ID, 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// Decode cursor string, empty string returns nil cursor
+func DecodeCursor(s string) (*Cursor, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errors.Wrap(err, "DecodeCursor.DecodeString")
+	}
+
+	parts := strings.SplitN(string(raw), ":", 2)
+	if len(parts) != 2 {
+		return nil, errors.New("invalid cursor")
+	}
+
+	nanos, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "DecodeCursor.ParseInt.created_at")
+	}
+	id, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "DecodeCursor.ParseInt.id")
+	}
+
+	return &Cursor{CreatedAt: time.Unix(0, nanos).UTC(), ID: id}, nil
+}
+
+// Get cursor page size from query param, defaults to 20 and is capped at 100
+func GetCursorSize(sizeQuery string) (int, error) {
+	if sizeQuery == "" {
+		return defaultCursorSize, nil
+	}
+
+	n, err := strconv.Atoi(sizeQuery)
+	if err != nil {
+		return 0, err
+	}
+	if n <= 0 {
+		return defaultCursorSize, nil
+	}
+	if n > maxCursorSize {
+		return maxCursorSize, nil
+	}
+
+	return n, nil
+}
@@ -0,0 +1,332 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// How far ahead Next looks for an occurrence before giving up
+const recurrenceHorizon = 10
+
+// Recurrence yields the occurrences of a repeating schedule
+type Recurrence interface {
+	// First occurrence strictly after t, zero time when there is none
+	Next(t time.Time) time.Time
+}
+
+// bitset of allowed values for one cron or rrule field
+type fieldSet uint64
+
+func (s fieldSet) has(v int) bool {
+	return s&(1<<uint(v)) != 0
+}
+
+// Cron recurrence with the standard five fields: minute hour day-of-month month day-of-week
+type cronRecurrence struct {
+	minute, hour, dom, month, dow fieldSet
+	// day-of-month and day-of-week are OR-ed when both are restricted
+	domAny, dowAny bool
+	loc            *time.Location
+}
+
+// Parse a five field cron expression evaluated in loc. Fields accept *, lists,
+// ranges and steps, day-of-week is 0-6 with 0 or 7 for Sunday.
+func ParseCron(expr string, loc *time.Location) (Recurrence, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron %q: expected 5 fields, got %d", expr, len(fields))
+	}
+
+	c := &cronRecurrence{loc: loc, domAny: fields[2] == "*", dowAny: fields[4] == "*"}
+	bounds := []struct {
+		set      *fieldSet
+		min, max int
+	}{
+		{&c.minute, 0, 59},
+		{&c.hour, 0, 23},
+		{&c.dom, 1, 31},
+		{&c.month, 1, 12},
+		{&c.dow, 0, 7},
+	}
+
+	for i, b := range bounds {
+		set, err := parseCronField(fields[i], b.min, b.max)
+		if err != nil {
+			return nil, fmt.Errorf("cron %q: %w", expr, err)
+		}
+		*b.set = set
+	}
+
+	if c.dow.has(7) {
+		c.dow |= 1
+	}
+
+	return c, nil
+}
+
+func parseCronField(field string, min, max int) (fieldSet, error) {
+	var set fieldSet
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			s, err := strconv.Atoi(part[i+1:])
+			if err != nil || s <= 0 {
+				return 0, fmt.Errorf("invalid step in %q", part)
+			}
+			rangePart, step = part[:i], s
+		}
+
+		lo, hi := min, max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if lo, err = strconv.Atoi(bounds[0]); err != nil {
+				return 0, fmt.Errorf("invalid range %q", part)
+			}
+			if hi, err = strconv.Atoi(bounds[1]); err != nil {
+				return 0, fmt.Errorf("invalid range %q", part)
+			}
+		default:
+			v, err := strconv.Atoi(rangePart)
+			if err != nil {
+				return 0, fmt.Errorf("invalid value %q", part)
+			}
+			lo, hi = v, v
+			if step > 1 {
+				hi = max
+			}
+		}
+
+		if lo < min || hi > max || lo > hi {
+			return 0, fmt.Errorf("%q out of range %d-%d", part, min, max)
+		}
+
+		for v := lo; v <= hi; v += step {
+			set |= 1 << uint(v)
+		}
+	}
+
+	return set, nil
+}
+
+func (c *cronRecurrence) Next(after time.Time) time.Time {
+	t := after.In(c.loc).Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(recurrenceHorizon, 0, 0)
+
+	for t.Before(limit) {
+		if !c.month.has(int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, c.loc)
+			continue
+		}
+		if !c.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, c.loc)
+			continue
+		}
+		if !c.hour.has(t.Hour()) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, c.loc)
+			continue
+		}
+		if !c.minute.has(t.Minute()) {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+
+	return time.Time{}
+}
+
+func (c *cronRecurrence) dayMatches(t time.Time) bool {
+	dom, dow := c.dom.has(t.Day()), c.dow.has(int(t.Weekday()))
+	switch {
+	case c.domAny && c.dowAny:
+		return true
+	case c.domAny:
+		return dow
+	case c.dowAny:
+		return dom
+	default:
+		return dom || dow
+	}
+}
+
+// RRULE frequencies
+const (
+	RRuleDaily   = "DAILY"
+	RRuleWeekly  = "WEEKLY"
+	RRuleMonthly = "MONTHLY"
+	RRuleYearly  = "YEARLY"
+)
+
+var rruleWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+// RFC 5545 recurrence rule subset: FREQ, INTERVAL, BYDAY (plain weekdays),
+// BYMONTHDAY (negative counts from the month end) and BYMONTH. Occurrences
+// take their time of day from start.
+type rruleRecurrence struct {
+	freq       string
+	interval   int
+	byDay      fieldSet
+	byMonthDay []int
+	byMonth    fieldSet
+	start      time.Time
+}
+
+// Parse an RRULE such as "FREQ=MONTHLY;BYMONTHDAY=1" anchored at start.
+// COUNT and UNTIL are rejected, schedules bound occurrences themselves.
+func ParseRRule(rule string, start time.Time) (Recurrence, error) {
+	r := &rruleRecurrence{interval: 1, start: start}
+
+	for _, part := range strings.Split(strings.TrimPrefix(strings.TrimSpace(rule), "RRULE:"), ";") {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("rrule %q: invalid part %q", rule, part)
+		}
+
+		key, value := strings.ToUpper(kv[0]), strings.ToUpper(kv[1])
+		switch key {
+		case "FREQ":
+			switch value {
+			case RRuleDaily, RRuleWeekly, RRuleMonthly, RRuleYearly:
+				r.freq = value
+			default:
+				return nil, fmt.Errorf("rrule %q: unsupported FREQ %s", rule, value)
+			}
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil || n <= 0 {
+				return nil, fmt.Errorf("rrule %q: invalid INTERVAL", rule)
+			}
+			r.interval = n
+		case "BYDAY":
+			for _, day := range strings.Split(value, ",") {
+				wd, ok := rruleWeekdays[day]
+				if !ok {
+					return nil, fmt.Errorf("rrule %q: unsupported BYDAY %s", rule, day)
+				}
+				r.byDay |= 1 << uint(wd)
+			}
+		case "BYMONTHDAY":
+			for _, day := range strings.Split(value, ",") {
+				n, err := strconv.Atoi(day)
+				if err != nil || n == 0 || n < -31 || n > 31 {
+					return nil, fmt.Errorf("rrule %q: invalid BYMONTHDAY %s", rule, day)
+				}
+				r.byMonthDay = append(r.byMonthDay, n)
+			}
+		case "BYMONTH":
+			for _, month := range strings.Split(value, ",") {
+				n, err := strconv.Atoi(month)
+				if err != nil || n < 1 || n > 12 {
+					return nil, fmt.Errorf("rrule %q: invalid BYMONTH %s", rule, month)
+				}
+				r.byMonth |= 1 << uint(n)
+			}
+		case "COUNT", "UNTIL":
+			return nil, fmt.Errorf("rrule %q: %s is not supported, use max occurrences or end date", rule, key)
+		default:
+			return nil, fmt.Errorf("rrule %q: unsupported part %s", rule, key)
+		}
+	}
+
+	if r.freq == "" {
+		return nil, fmt.Errorf("rrule %q: FREQ is required", rule)
+	}
+
+	// missing BY parts default to the start date, as in RFC 5545
+	switch r.freq {
+	case RRuleWeekly:
+		if r.byDay == 0 {
+			r.byDay = 1 << uint(start.Weekday())
+		}
+	case RRuleMonthly:
+		if r.byDay == 0 && len(r.byMonthDay) == 0 {
+			r.byMonthDay = []int{start.Day()}
+		}
+	case RRuleYearly:
+		if r.byMonth == 0 {
+			r.byMonth = 1 << uint(start.Month())
+		}
+		if r.byDay == 0 && len(r.byMonthDay) == 0 {
+			r.byMonthDay = []int{start.Day()}
+		}
+	}
+
+	return r, nil
+}
+
+func (r *rruleRecurrence) Next(after time.Time) time.Time {
+	loc := r.start.Location()
+	after = after.In(loc)
+	if after.Before(r.start) {
+		after = r.start.Add(-time.Nanosecond)
+	}
+
+	day := time.Date(after.Year(), after.Month(), after.Day(), 0, 0, 0, 0, loc)
+	limit := day.AddDate(recurrenceHorizon*r.interval, 0, 0)
+
+	for ; day.Before(limit); day = day.AddDate(0, 0, 1) {
+		if !r.inPeriod(day) || !r.dayMatches(day) {
+			continue
+		}
+
+		t := time.Date(day.Year(), day.Month(), day.Day(), r.start.Hour(), r.start.Minute(), r.start.Second(), 0, loc)
+		if t.After(after) {
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+// Day falls in a period selected by INTERVAL, counted from the start period
+func (r *rruleRecurrence) inPeriod(day time.Time) bool {
+	start := time.Date(r.start.Year(), r.start.Month(), r.start.Day(), 0, 0, 0, 0, day.Location())
+
+	var n int
+	switch r.freq {
+	case RRuleDaily:
+		n = int(day.Sub(start).Hours()+12) / 24
+	case RRuleWeekly:
+		// weeks start on Monday
+		monday := func(t time.Time) time.Time { return t.AddDate(0, 0, -(int(t.Weekday())+6)%7) }
+		n = int(monday(day).Sub(monday(start)).Hours()+12) / (24 * 7)
+	case RRuleMonthly:
+		n = (day.Year()-start.Year())*12 + int(day.Month()-start.Month())
+	case RRuleYearly:
+		n = day.Year() - start.Year()
+	}
+
+	return n%r.interval == 0
+}
+
+func (r *rruleRecurrence) dayMatches(day time.Time) bool {
+	if r.byMonth != 0 && !r.byMonth.has(int(day.Month())) {
+		return false
+	}
+	if r.byDay != 0 && !r.byDay.has(int(day.Weekday())) {
+		return false
+	}
+	if len(r.byMonthDay) == 0 {
+		return true
+	}
+
+	lastDay := time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, day.Location()).Day()
+	for _, d := range r.byMonthDay {
+		if d < 0 {
+			d = lastDay + d + 1
+		}
+		if d == day.Day() {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCron_Next(t *testing.T) {
+	t.Parallel()
+
+	at := func(s string) time.Time {
+		v, err := time.Parse(time.RFC3339, s)
+		require.NoError(t, err)
+		return v
+	}
+
+	// 09:00 on the 1st of every month
+	monthly, err := ParseCron("0 9 1 * *", time.UTC)
+	require.NoError(t, err)
+	require.Equal(t, at("2024-02-01T09:00:00Z"), monthly.Next(at("2024-01-01T09:00:00Z")))
+	require.Equal(t, at("2024-01-01T09:00:00Z"), monthly.Next(at("2023-12-31T23:59:00Z")))
+
+	// every 15 minutes on weekdays, Saturday jumps to Monday
+	weekdays, err := ParseCron("*/15 * * * 1-5", time.UTC)
+	require.NoError(t, err)
+	require.Equal(t, at("2024-01-08T00:00:00Z"), weekdays.Next(at("2024-01-06T10:07:00Z")))
+	require.Equal(t, at("2024-01-08T00:15:00Z"), weekdays.Next(at("2024-01-08T00:00:00Z")))
+
+	_, err = ParseCron("0 9 32 * *", time.UTC)
+	require.Error(t, err)
+	_, err = ParseCron("0 9 * *", time.UTC)
+	require.Error(t, err)
+}
+
+func TestParseRRule_Next(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 31, 8, 30, 0, 0, time.UTC)
+
+	// last day of every second month
+	lastDay, err := ParseRRule("FREQ=MONTHLY;INTERVAL=2;BYMONTHDAY=-1", start)
+	require.NoError(t, err)
+	require.Equal(t, start, lastDay.Next(start.Add(-time.Hour)))
+	require.Equal(t, time.Date(2024, 3, 31, 8, 30, 0, 0, time.UTC), lastDay.Next(start))
+
+	weekly, err := ParseRRule("FREQ=WEEKLY;BYDAY=MO,FR", start)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2024, 2, 2, 8, 30, 0, 0, time.UTC), weekly.Next(start))
+	require.Equal(t, time.Date(2024, 2, 5, 8, 30, 0, 0, time.UTC), weekly.Next(time.Date(2024, 2, 2, 9, 0, 0, 0, time.UTC)))
+
+	_, err = ParseRRule("FREQ=DAILY;COUNT=3", start)
+	require.Error(t, err)
+	_, err = ParseRRule("BYDAY=MO", start)
+	require.Error(t, err)
+}
@@ -13,6 +13,14 @@ func init() {
 	validate = validator.New()
 }
 
+// Register a custom validation tag, call it from an init function before any
+// struct is validated
+func RegisterValidation(tag string, fn validator.Func) {
+	if err := validate.RegisterValidation(tag, fn); err != nil {
+		panic(err)
+	}
+}
+
 // Validate struct fields
 func ValidateStruct(ctx context.Context, s interface{}) error {
 	return validate.StructCtx(ctx, s)
@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublicIP(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		ip     string
+		public bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:2800:220:1::1", true},
+		{"127.0.0.1", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"100.100.100.200", false},
+		{"0.0.0.0", false},
+		{"::1", false},
+		{"fd00:ec2::254", false},
+		{"fe80::1", false},
+		{"::ffff:127.0.0.1", false},
+	}
+
+	for _, tt := range tests {
+		require.Equal(t, tt.public, PublicIP(net.ParseIP(tt.ip)), tt.ip)
+	}
+}
+
+func TestCheckWebhookURL(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, CheckWebhookURL(context.Background(), "https://93.184.216.34/hook"))
+	require.ErrorIs(t, CheckWebhookURL(context.Background(), "http://93.184.216.34/hook"), ErrWebhookTarget)
+	require.ErrorIs(t, CheckWebhookURL(context.Background(), "https://169.254.169.254/latest"), ErrWebhookTarget)
+	require.ErrorIs(t, CheckWebhookURL(context.Background(), "https:///hook"), ErrWebhookTarget)
+}